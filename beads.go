@@ -184,6 +184,11 @@ func findDatabaseInTree() string {
 			// Check for config.json first (single source of truth)
 			if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil {
 				dbPath := cfg.DatabasePath(beadsDir)
+				// With the Postgres backend the issues live in a shared database,
+				// so no local file is expected at dbPath
+				if cfg.IsPostgres() {
+					return dbPath
+				}
 				if _, err := os.Stat(dbPath); err == nil {
 					return dbPath
 				}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/config"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var claimCmd = &cobra.Command{
+	Use:   "claim",
+	Short: "Atomically claim the next ready issue",
+	Long: `Claim the highest-ranked open issue with no blockers and no current claim.
+
+The issue moves to in_progress, assigned to the agent, under a lease. Two agents
+claiming at the same time never get the same issue. Closing the issue or
+reassigning it ends the lease. Keep the lease alive with 'beads heartbeat'; if
+it expires first, the issue returns to open and unassigned (see 'beads stale').
+
+With --json, prints {"issue": ..., "lease": ...}, or null when nothing is ready.
+
+Examples:
+  beads claim
+  beads claim --agent worker-3 --ttl 2h
+  beads claim --priority 0 --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		agent, _ := cmd.Flags().GetString("agent")
+		ttl := leaseTTLFlag(cmd)
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if agent == "" {
+			agent = actor
+		}
+		if ttl <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: --ttl must be positive\n")
+			os.Exit(1)
+		}
+		var priority *int
+		if cmd.Flags().Changed("priority") {
+			p, _ := cmd.Flags().GetInt("priority")
+			priority = &p
+		}
+
+		var claim *types.Claim
+		usedDaemon := false
+
+		// If daemon is running, use RPC
+		if daemonClient != nil {
+			resp, err := daemonClient.Claim(&rpc.ClaimArgs{Agent: agent, TTL: ttl.String(), Priority: priority})
+			if err != nil {
+				if isUnknownOperationError(err) {
+					if err := fallbackToDirectMode("daemon does not support claim RPC"); err != nil {
+						fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+						os.Exit(1)
+					}
+				} else {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			} else {
+				if err := json.Unmarshal(resp.Data, &claim); err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+					os.Exit(1)
+				}
+				usedDaemon = true
+			}
+		}
+
+		if !usedDaemon {
+			var err error
+			claim, err = store.ClaimReadyWork(context.Background(), types.WorkFilter{Priority: priority}, agent, ttl)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if claim != nil {
+				markDirtyAndScheduleFlush()
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(claim)
+			return
+		}
+
+		if claim == nil {
+			yellow := color.New(color.FgYellow).SprintFunc()
+			fmt.Printf("\n%s No ready work to claim\n\n", yellow("INFO:"))
+			return
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		issue := claim.Issue
+		fmt.Printf("%s Claimed [P%d] %s: %s\n", green("✓"), issue.Priority, issue.ID, issue.Title)
+		fmt.Printf("  Agent: %s\n", claim.Lease.Holder)
+		fmt.Printf("  Lease expires: %s\n", claim.Lease.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	},
+}
+
+func init() {
+	claimCmd.Flags().String("agent", "", "Agent claiming the work (default: actor)")
+	claimCmd.Flags().Duration("ttl", 0, "How long the claim lasts without a heartbeat, e.g. 30m (default: lease-ttl setting)")
+	claimCmd.Flags().IntP("priority", "p", 0, "Only claim issues with this priority")
+	claimCmd.Flags().Bool("json", false, "Output JSON format")
+	rootCmd.AddCommand(claimCmd)
+}
+
+// leaseTTLFlag returns the --ttl flag if given, else the lease-ttl setting
+func leaseTTLFlag(cmd *cobra.Command) time.Duration {
+	if cmd.Flags().Changed("ttl") {
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+		return ttl
+	}
+	ttl, err := config.LoadLeaseTTL()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid config: %v\n", err)
+		os.Exit(1)
+	}
+	return ttl
+}
@@ -29,15 +29,22 @@ var compactCmd = &cobra.Command{
 	Long: `Compact old closed issues using semantic summarization.
 
 Compaction reduces database size by summarizing closed issues that are no longer
-actively referenced. This is permanent graceful decay - original content is discarded.
+actively referenced. This is graceful decay - original content is discarded.
 
 Tiers:
   - Tier 1: Semantic compression (30 days closed, 70% reduction)
-  - Tier 2: Ultra compression (90 days closed, 95% reduction)
+  - Tier 2: Ultra compression of Tier 1 issues (90 days closed and 100 commits
+    since Tier 1, 95% reduction). Events and comments are archived into a local
+    snapshot; 'beads restore <id> --undo' reverses it.
+
+Summaries come from the backend set by the compact_model config: Claude by
+default (needs ANTHROPIC_API_KEY), openai:<model> for an OpenAI-compatible
+server at compact_api_url, or extractive to work offline without a model.
 
 Examples:
   beads compact --dry-run                  # Preview candidates
   beads compact --all                      # Compact all eligible issues
+  beads compact --all --tier 2             # Ultra-compact old Tier 1 issues
   beads compact --id beads-42                 # Compact specific issue
   beads compact --id beads-42 --force         # Force compact (bypass checks)
   beads compact --stats                    # Show statistics
@@ -89,9 +96,12 @@ Examples:
 		}
 
 		apiKey := os.Getenv("ANTHROPIC_API_KEY")
-		if apiKey == "" && !compactDryRun {
-			fmt.Fprintf(os.Stderr, "Error: ANTHROPIC_API_KEY environment variable not set\n")
-			os.Exit(1)
+		if !compactDryRun {
+			model, _ := sqliteStore.GetConfig(ctx, "compact_model")
+			if err := compact.CheckAPIKey(model, apiKey); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
 		}
 
 		config := &compact.Config{
@@ -119,7 +129,7 @@ func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *
 	start := time.Now()
 
 	if !compactForce {
-		eligible, reason, err := store.CheckEligibility(ctx, issueID, compactTier)
+		eligible, reason, err := compact.CheckEligibility(ctx, store, issueID, compactTier)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to check eligibility: %v\n", err)
 			os.Exit(1)
@@ -145,7 +155,7 @@ func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *
 				"tier":                compactTier,
 				"issue_id":            issueID,
 				"original_size":       originalSize,
-				"estimated_reduction": "70-80%",
+				"estimated_reduction": estimatedReduction(compactTier),
 			}
 			outputJSON(output)
 			return
@@ -154,30 +164,21 @@ func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *
 		fmt.Printf("DRY RUN - Tier %d compaction\n\n", compactTier)
 		fmt.Printf("Issue: %s\n", issueID)
 		fmt.Printf("Original size: %d bytes\n", originalSize)
-		fmt.Printf("Estimated reduction: 70-80%%\n")
+		fmt.Printf("Estimated reduction: %s\n", estimatedReduction(compactTier))
 		return
 	}
 
-	var compactErr error
-	if compactTier == 1 {
-		compactErr = compactor.CompactTier1(ctx, issueID)
-	} else {
-		fmt.Fprintf(os.Stderr, "Error: Tier 2 compaction not yet implemented\n")
-		os.Exit(1)
-	}
-
-	if compactErr != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", compactErr)
-		os.Exit(1)
+	tier := 1
+	if compactTier == 2 {
+		tier = 2
 	}
-
-	issue, err = store.GetIssue(ctx, issueID)
+	result, err := compactor.Compact(ctx, issueID, tier)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: failed to get updated issue: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	compactedSize := len(issue.Description)
+	compactedSize := result.CompactedSize
 	savingBytes := originalSize - compactedSize
 	elapsed := time.Since(start)
 
@@ -191,16 +192,29 @@ func runCompactSingle(ctx context.Context, compactor *compact.Compactor, store *
 			"saved_bytes":    savingBytes,
 			"reduction_pct":  float64(savingBytes) / float64(originalSize) * 100,
 			"elapsed_ms":     elapsed.Milliseconds(),
+			"staged":         result.Staged,
+			"missing_refs":   result.MissingRefs,
 		}
 		outputJSON(output)
 		return
 	}
 
-	fmt.Printf("✔ Compacted %s (Tier %d)\n", issueID, compactTier)
+	if result.Staged {
+		fmt.Printf("✔ Staged Tier %d summary for %s\n", compactTier, issueID)
+	} else {
+		fmt.Printf("✔ Compacted %s (Tier %d)\n", issueID, compactTier)
+	}
 	fmt.Printf("  %d → %d bytes (saved %d, %.1f%%)\n",
 		originalSize, compactedSize, savingBytes,
 		float64(savingBytes)/float64(originalSize)*100)
+	if len(result.MissingRefs) > 0 {
+		fmt.Printf("  Dropped references: %s\n", compact.FormatReferences(result.MissingRefs))
+	}
 	fmt.Printf("  Time: %v\n", elapsed)
+	if result.Staged {
+		fmt.Printf("\nReview it with 'beads compact review %s'\n", issueID)
+		return
+	}
 
 	// Schedule auto-flush to export changes
 	markDirtyAndScheduleFlush()
@@ -220,7 +234,7 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 			candidates = append(candidates, c.IssueID)
 		}
 	} else {
-		tier2, err := store.GetTier2Candidates(ctx)
+		tier2, err := compact.Tier2Candidates(ctx, store)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to get candidates: %v\n", err)
 			os.Exit(1)
@@ -259,7 +273,7 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 				"tier":                compactTier,
 				"candidate_count":     len(candidates),
 				"total_size_bytes":    totalSize,
-				"estimated_reduction": "70-80%",
+				"estimated_reduction": estimatedReduction(compactTier),
 			}
 			outputJSON(output)
 			return
@@ -268,7 +282,7 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 		fmt.Printf("DRY RUN - Tier %d compaction\n\n", compactTier)
 		fmt.Printf("Candidates: %d issues\n", len(candidates))
 		fmt.Printf("Total size: %d bytes\n", totalSize)
-		fmt.Printf("Estimated reduction: 70-80%%\n")
+		fmt.Printf("Estimated reduction: %s\n", estimatedReduction(compactTier))
 		return
 	}
 
@@ -276,7 +290,13 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 		fmt.Printf("Compacting %d issues (Tier %d)...\n\n", len(candidates), compactTier)
 	}
 
-	results, err := compactor.CompactTier1Batch(ctx, candidates)
+	var results []*compact.Result
+	var err error
+	if compactTier == 2 {
+		results, err = compactor.CompactTier2Batch(ctx, candidates)
+	} else {
+		results, err = compactor.CompactTier1Batch(ctx, candidates)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: batch compaction failed: %v\n", err)
 		os.Exit(1)
@@ -284,6 +304,8 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 
 	successCount := 0
 	failCount := 0
+	stagedCount := 0
+	flaggedCount := 0
 	totalSaved := 0
 	totalOriginal := 0
 
@@ -294,11 +316,18 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 
 		if result.Err != nil {
 			failCount++
-		} else {
-			successCount++
-			totalOriginal += result.OriginalSize
-			totalSaved += (result.OriginalSize - result.CompactedSize)
+			continue
+		}
+		if len(result.MissingRefs) > 0 {
+			flaggedCount++
+		}
+		if result.Staged {
+			stagedCount++
+			continue
 		}
+		successCount++
+		totalOriginal += result.OriginalSize
+		totalSaved += (result.OriginalSize - result.CompactedSize)
 	}
 
 	elapsed := time.Since(start)
@@ -309,6 +338,8 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 			"tier":          compactTier,
 			"total":         len(results),
 			"succeeded":     successCount,
+			"staged":        stagedCount,
+			"flagged":       flaggedCount,
 			"failed":        failCount,
 			"saved_bytes":   totalSaved,
 			"original_size": totalOriginal,
@@ -321,10 +352,19 @@ func runCompactAll(ctx context.Context, compactor *compact.Compactor, store *sql
 	fmt.Printf("\n\nCompleted in %v\n\n", elapsed)
 	fmt.Printf("Summary:\n")
 	fmt.Printf("  Succeeded: %d\n", successCount)
+	if stagedCount > 0 {
+		fmt.Printf("  Staged for review: %d\n", stagedCount)
+	}
 	fmt.Printf("  Failed: %d\n", failCount)
+	if flaggedCount > 0 {
+		fmt.Printf("  Dropped references: %d summaries (see issue comments)\n", flaggedCount)
+	}
 	if totalOriginal > 0 {
 		fmt.Printf("  Saved: %d bytes (%.1f%%)\n", totalSaved, float64(totalSaved)/float64(totalOriginal)*100)
 	}
+	if stagedCount > 0 {
+		fmt.Printf("\nReview staged summaries with 'beads compact review'\n")
+	}
 
 	// Schedule auto-flush to export changes
 	if successCount > 0 {
@@ -339,7 +379,7 @@ func runCompactStats(ctx context.Context, store *sqlite.SQLiteStorage) {
 		os.Exit(1)
 	}
 
-	tier2, err := store.GetTier2Candidates(ctx)
+	tier2, err := compact.Tier2Candidates(ctx, store)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to get Tier 2 candidates: %v\n", err)
 		os.Exit(1)
@@ -350,9 +390,16 @@ func runCompactStats(ctx context.Context, store *sqlite.SQLiteStorage) {
 		tier1Size += c.OriginalSize
 	}
 
+	// Tier 2 shrinks the Tier 1 summary, not the original text
 	tier2Size := 0
 	for _, c := range tier2 {
-		tier2Size += c.OriginalSize
+		tier2Size += c.EstimatedSize
+	}
+
+	auto, err := compact.LoadAutoStatus(ctx, store, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
 	if jsonOutput {
@@ -365,6 +412,7 @@ func runCompactStats(ctx context.Context, store *sqlite.SQLiteStorage) {
 				"candidates": len(tier2),
 				"total_size": tier2Size,
 			},
+			"auto_compact": auto,
 		}
 		outputJSON(output)
 		return
@@ -384,6 +432,36 @@ func runCompactStats(ctx context.Context, store *sqlite.SQLiteStorage) {
 	if tier2Size > 0 {
 		fmt.Printf("  Estimated savings: %d bytes (95%%)\n", tier2Size*95/100)
 	}
+
+	fmt.Println()
+	printAutoCompactStatus(auto)
+}
+
+// printAutoCompactStatus prints the daemon's background compaction settings,
+// budget and last run
+func printAutoCompactStatus(status *compact.AutoStatus) {
+	if status == nil {
+		return
+	}
+	if !status.Enabled {
+		fmt.Printf("Auto-compaction: disabled (beads config set auto_compact_enabled true)\n")
+		return
+	}
+	fmt.Printf("Auto-compaction: enabled (every %s when idle for %s, up to %d issues)\n",
+		status.Interval, status.IdleAfter, status.BatchSize)
+	fmt.Printf("  Calls today: %d/%d\n", status.CallsToday, status.MaxCallsPerDay)
+	fmt.Printf("  Runs: %d, %d issues compacted, %d bytes saved\n", status.Runs, status.Compacted, status.SavedBytes)
+	if run := status.LastRun; run != nil {
+		fmt.Printf("  Last run: %s at %s (%d Tier 1, %d Tier 2, %d staged, %d failed)\n",
+			run.ID, run.StartedAt.Local().Format("2006-01-02 15:04"), run.Tier1, run.Tier2, run.Staged, run.Failed)
+	}
+}
+
+func estimatedReduction(tier int) string {
+	if tier == 2 {
+		return "90-95%"
+	}
+	return "70-80%"
 }
 
 func progressBar(current, total int) string {
@@ -420,11 +498,8 @@ func runCompactRPC(_ context.Context) {
 		os.Exit(1)
 	}
 
+	// The daemon checks the key against its compact_model
 	apiKey := os.Getenv("ANTHROPIC_API_KEY")
-	if apiKey == "" && !compactDryRun {
-		fmt.Fprintf(os.Stderr, "Error: ANTHROPIC_API_KEY environment variable not set\n")
-		os.Exit(1)
-	}
 
 	args := map[string]interface{}{
 		"tier":       compactTier,
@@ -456,20 +531,24 @@ func runCompactRPC(_ context.Context) {
 	}
 
 	var result struct {
-		Success       bool   `json:"success"`
-		IssueID       string `json:"issue_id,omitempty"`
-		OriginalSize  int    `json:"original_size,omitempty"`
-		CompactedSize int    `json:"compacted_size,omitempty"`
-		Reduction     string `json:"reduction,omitempty"`
-		Duration      string `json:"duration,omitempty"`
-		DryRun        bool   `json:"dry_run,omitempty"`
+		Success       bool     `json:"success"`
+		IssueID       string   `json:"issue_id,omitempty"`
+		OriginalSize  int      `json:"original_size,omitempty"`
+		CompactedSize int      `json:"compacted_size,omitempty"`
+		Reduction     string   `json:"reduction,omitempty"`
+		Duration      string   `json:"duration,omitempty"`
+		DryRun        bool     `json:"dry_run,omitempty"`
+		Staged        bool     `json:"staged,omitempty"`
+		MissingRefs   []string `json:"missing_refs,omitempty"`
 		Results       []struct {
-			IssueID       string `json:"issue_id"`
-			Success       bool   `json:"success"`
-			Error         string `json:"error,omitempty"`
-			OriginalSize  int    `json:"original_size,omitempty"`
-			CompactedSize int    `json:"compacted_size,omitempty"`
-			Reduction     string `json:"reduction,omitempty"`
+			IssueID       string   `json:"issue_id"`
+			Success       bool     `json:"success"`
+			Error         string   `json:"error,omitempty"`
+			OriginalSize  int      `json:"original_size,omitempty"`
+			CompactedSize int      `json:"compacted_size,omitempty"`
+			Reduction     string   `json:"reduction,omitempty"`
+			Staged        bool     `json:"staged,omitempty"`
+			MissingRefs   []string `json:"missing_refs,omitempty"`
 		} `json:"results,omitempty"`
 	}
 
@@ -485,10 +564,17 @@ func runCompactRPC(_ context.Context) {
 			fmt.Printf("Original size: %d bytes\n", result.OriginalSize)
 			fmt.Printf("Estimated reduction: %s\n", result.Reduction)
 		} else {
-			fmt.Printf("Successfully compacted %s\n", result.IssueID)
+			if result.Staged {
+				fmt.Printf("Staged summary for %s (review with 'beads compact review %s')\n", result.IssueID, result.IssueID)
+			} else {
+				fmt.Printf("Successfully compacted %s\n", result.IssueID)
+			}
 			fmt.Printf("Original size: %d bytes\n", result.OriginalSize)
 			fmt.Printf("Compacted size: %d bytes\n", result.CompactedSize)
 			fmt.Printf("Reduction: %s\n", result.Reduction)
+			if len(result.MissingRefs) > 0 {
+				fmt.Printf("Dropped references: %s\n", compact.FormatReferences(result.MissingRefs))
+			}
 			fmt.Printf("Duration: %s\n", result.Duration)
 		}
 	} else if compactAll {
@@ -504,7 +590,14 @@ func runCompactRPC(_ context.Context) {
 			fmt.Printf("Compacted %d/%d issues in %s\n", successCount, len(result.Results), result.Duration)
 			for _, r := range result.Results {
 				if r.Success {
-					fmt.Printf("  ✔ %s: %d → %d bytes (%s)\n", r.IssueID, r.OriginalSize, r.CompactedSize, r.Reduction)
+					status := ""
+					if r.Staged {
+						status = ", staged for review"
+					}
+					if len(r.MissingRefs) > 0 {
+						status += fmt.Sprintf(", dropped %d references", len(r.MissingRefs))
+					}
+					fmt.Printf("  ✔ %s: %d → %d bytes (%s%s)\n", r.IssueID, r.OriginalSize, r.CompactedSize, r.Reduction, status)
 				} else {
 					fmt.Printf("  ✗ %s: %s\n", r.IssueID, r.Error)
 				}
@@ -543,6 +636,8 @@ func runCompactStatsRPC() {
 			Tier1MinAge      string `json:"tier1_min_age"`
 			Tier2MinAge      string `json:"tier2_min_age"`
 			EstimatedSavings string `json:"estimated_savings,omitempty"`
+
+			AutoCompact *compact.AutoStatus `json:"auto_compact,omitempty"`
 		} `json:"stats"`
 	}
 
@@ -560,6 +655,11 @@ func runCompactStatsRPC() {
 	fmt.Printf("Tier 2 (90+ days closed, Tier 1 compacted):\n")
 	fmt.Printf("  Candidates: %d\n", result.Stats.Tier2Candidates)
 	fmt.Printf("  Min age: %s\n", result.Stats.Tier2MinAge)
+
+	if result.Stats.AutoCompact != nil {
+		fmt.Println()
+		printAutoCompactStatus(result.Stats.AutoCompact)
+	}
 }
 
 func init() {
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/compact"
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compactReviewApprove bool
+	compactReviewReject  bool
+	compactReviewAll     bool
+)
+
+var compactReviewCmd = &cobra.Command{
+	Use:   "review [issue-id...]",
+	Short: "Review compaction summaries staged for approval",
+	Long: `Review compaction summaries staged for approval.
+
+With compact_mode set to pending_review, compaction (including the daemon's
+auto-compaction) stages each summary instead of replacing the issue text.
+Nothing changes until the summary is approved here.
+
+A rejected summary is discarded, and the issue is not summarized again until
+its content changes. A summary can't be approved if the issue changed after it
+was staged; reject it and compact the issue again.
+
+Examples:
+  beads compact review                       # List summaries awaiting review
+  beads compact review beads-42              # Show the original and the summary
+  beads compact review beads-42 --approve    # Apply the summary
+  beads compact review beads-42 --reject     # Discard the summary
+  beads compact review --all --approve       # Apply every staged summary
+`,
+	Run: func(_ *cobra.Command, args []string) {
+		ctx := context.Background()
+
+		if compactReviewApprove && compactReviewReject {
+			fmt.Fprintf(os.Stderr, "Error: cannot use --approve and --reject together\n")
+			os.Exit(1)
+		}
+		if compactReviewAll && len(args) > 0 {
+			fmt.Fprintf(os.Stderr, "Error: cannot use --all with issue IDs\n")
+			os.Exit(1)
+		}
+		if compactReviewAll && !compactReviewApprove && !compactReviewReject {
+			fmt.Fprintf(os.Stderr, "Error: --all requires --approve or --reject\n")
+			os.Exit(1)
+		}
+		if (compactReviewApprove || compactReviewReject) && !compactReviewAll && len(args) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: specify issue IDs or --all\n")
+			os.Exit(1)
+		}
+
+		if err := ensureDirectMode("compact review requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: compact review requires SQLite storage\n")
+			os.Exit(1)
+		}
+
+		var ids []string
+		if compactReviewAll {
+			pending, err := sqliteStore.ListPendingCompactions(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			for _, pc := range pending {
+				ids = append(ids, pc.IssueID)
+			}
+		}
+		for _, arg := range args {
+			id, err := utils.ResolvePartialID(ctx, sqliteStore, arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to resolve %s: %v\n", arg, err)
+				os.Exit(1)
+			}
+			ids = append(ids, id)
+		}
+
+		switch {
+		case compactReviewApprove || compactReviewReject:
+			runCompactReviewDecision(ctx, sqliteStore, ids, compactReviewApprove)
+		case len(ids) > 0:
+			runCompactReviewShow(ctx, sqliteStore, ids)
+		default:
+			runCompactReviewList(ctx, sqliteStore)
+		}
+	},
+}
+
+func runCompactReviewList(ctx context.Context, store *sqlite.SQLiteStorage) {
+	pending, err := store.ListPendingCompactions(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		if pending == nil {
+			pending = []*sqlite.PendingCompaction{}
+		}
+		outputJSON(pending)
+		return
+	}
+
+	if len(pending) == 0 {
+		fmt.Println("No compaction summaries awaiting review")
+		return
+	}
+
+	yellow := color.New(color.FgYellow).SprintFunc()
+	fmt.Printf("Compaction summaries awaiting review: %d\n\n", len(pending))
+	for _, pc := range pending {
+		fmt.Printf("%s  Tier %d  %d → %d bytes  staged %s\n",
+			pc.IssueID, pc.Tier, pc.OriginalSize, len(pc.Summary), pc.StagedAt.Local().Format("2006-01-02 15:04"))
+		if len(pc.MissingRefs) > 0 {
+			fmt.Printf("  %s %s\n", yellow("Dropped references:"), compact.FormatReferences(pc.MissingRefs))
+		}
+		fmt.Printf("  %s\n\n", firstLine(pc.Summary))
+	}
+	fmt.Println("Show one with 'beads compact review <id>', then --approve or --reject it")
+}
+
+func runCompactReviewShow(ctx context.Context, store *sqlite.SQLiteStorage, ids []string) {
+	var shown []*sqlite.PendingCompaction
+	for _, id := range ids {
+		pc, err := store.GetPendingCompaction(ctx, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if pc == nil {
+			fmt.Fprintf(os.Stderr, "Error: no compaction pending review for %s\n", id)
+			os.Exit(1)
+		}
+		shown = append(shown, pc)
+	}
+
+	if jsonOutput {
+		outputJSON(shown)
+		return
+	}
+
+	bold := color.New(color.Bold).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	for i, pc := range shown {
+		if i > 0 {
+			fmt.Println()
+		}
+		issue, err := store.GetIssue(ctx, pc.IssueID)
+		if err != nil || issue == nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to get issue %s: %v\n", pc.IssueID, err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("%s: %s\n", bold(pc.IssueID), issue.Title)
+		fmt.Printf("Tier %d summary, staged %s: %d → %d bytes\n",
+			pc.Tier, pc.StagedAt.Local().Format("2006-01-02 15:04"), pc.OriginalSize, len(pc.Summary))
+		if len(pc.MissingRefs) > 0 {
+			fmt.Printf("%s %s\n", yellow("Dropped references:"), strings.Join(pc.MissingRefs, ", "))
+		}
+
+		fmt.Printf("\n%s\n", bold("Original:"))
+		for _, field := range []struct{ label, text string }{
+			{"Description", issue.Description},
+			{"Design", issue.Design},
+			{"Acceptance Criteria", issue.AcceptanceCriteria},
+			{"Notes", issue.Notes},
+		} {
+			if field.text != "" {
+				fmt.Printf("  %s:\n%s\n", field.label, indent(field.text, "    "))
+			}
+		}
+
+		fmt.Printf("\n%s\n%s\n", bold("Summary:"), indent(pc.Summary, "    "))
+	}
+}
+
+func runCompactReviewDecision(ctx context.Context, store *sqlite.SQLiteStorage, ids []string, approve bool) {
+	if len(ids) == 0 {
+		if jsonOutput {
+			outputJSON([]interface{}{})
+			return
+		}
+		fmt.Println("No compaction summaries awaiting review")
+		return
+	}
+
+	type decision struct {
+		IssueID string `json:"issue_id"`
+		Tier    int    `json:"tier,omitempty"`
+		Success bool   `json:"success"`
+		Error   string `json:"error,omitempty"`
+	}
+	var results []decision
+	failed := 0
+
+	green := color.New(color.FgGreen).SprintFunc()
+	for _, id := range ids {
+		var pc *sqlite.PendingCompaction
+		var err error
+		if approve {
+			pc, err = compact.ApprovePending(ctx, store, id, actor)
+		} else {
+			pc, err = compact.RejectPending(ctx, store, id, actor)
+		}
+		if err != nil {
+			failed++
+			results = append(results, decision{IssueID: id, Error: err.Error()})
+			if !jsonOutput {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			continue
+		}
+		results = append(results, decision{IssueID: id, Tier: pc.Tier, Success: true})
+		if jsonOutput {
+			continue
+		}
+		if approve {
+			fmt.Printf("%s Compacted %s (Tier %d): %d → %d bytes\n", green("✓"), id, pc.Tier, pc.OriginalSize, len(pc.Summary))
+		} else {
+			fmt.Printf("%s Rejected Tier %d summary for %s\n", green("✓"), pc.Tier, id)
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(results)
+	}
+	if failed == len(ids) {
+		os.Exit(1)
+	}
+	markDirtyAndScheduleFlush()
+}
+
+// firstLine returns the first non-empty line of text
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func indent(text, prefix string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	compactReviewCmd.Flags().BoolVar(&compactReviewApprove, "approve", false, "Apply the staged summaries")
+	compactReviewCmd.Flags().BoolVar(&compactReviewReject, "reject", false, "Discard the staged summaries")
+	compactReviewCmd.Flags().BoolVar(&compactReviewAll, "all", false, "Act on every staged summary")
+
+	compactCmd.AddCommand(compactReviewCmd)
+}
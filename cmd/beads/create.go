@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/templates"
 	"github.com/shaneholloman/beads/internal/types"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +22,7 @@ var createCmd = &cobra.Command{
 	Args:  cobra.MinimumNArgs(0), // Changed to allow no args when using -f
 	Run: func(cmd *cobra.Command, args []string) {
 		file, _ := cmd.Flags().GetString("file")
+		templateName, _ := cmd.Flags().GetString("template")
 
 		// If file flag is provided, parse markdown and create multiple issues
 		if file != "" {
@@ -26,10 +30,22 @@ var createCmd = &cobra.Command{
 				fmt.Fprintf(os.Stderr, "Error: cannot specify both title and --file flag\n")
 				os.Exit(1)
 			}
+			if templateName != "" {
+				fmt.Fprintf(os.Stderr, "Error: cannot specify both --template and --file flags\n")
+				os.Exit(1)
+			}
 			createIssuesFromMarkdown(cmd, file)
 			return
 		}
 
+		var tmpl *templates.Template
+		if templateName != "" {
+			tmpl = loadCreateTemplate(cmd, templateName)
+		} else if cmd.Flags().Changed("var") {
+			fmt.Fprintf(os.Stderr, "Error: --var requires --template\n")
+			os.Exit(1)
+		}
+
 		// Original single-issue creation logic
 		// Get title from flag or positional argument
 		titleFlag, _ := cmd.Flags().GetString("title")
@@ -48,6 +64,8 @@ var createCmd = &cobra.Command{
 			title = args[0]
 		} else if titleFlag != "" {
 			title = titleFlag
+		} else if tmpl != nil && tmpl.Title != "" {
+			title = tmpl.Title
 		} else {
 			fmt.Fprintf(os.Stderr, "Error: title required (or use --file to create from markdown)\n")
 			os.Exit(1)
@@ -65,6 +83,48 @@ var createCmd = &cobra.Command{
 		deps, _ := cmd.Flags().GetStringSlice("deps")
 		forceCreate, _ := cmd.Flags().GetBool("force")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		var estimate *int
+		if cmd.Flags().Changed("estimate") {
+			minutes, _ := cmd.Flags().GetInt("estimate")
+			estimate = &minutes
+		}
+		dueFlag, _ := cmd.Flags().GetString("due")
+		due, err := parseDueFlag(dueFlag, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Template fields fill in whatever wasn't given on the command line
+		if tmpl != nil {
+			flags := cmd.Flags()
+			if !flags.Changed("description") {
+				description = tmpl.Description
+			}
+			if !flags.Changed("design") {
+				design = tmpl.Design
+			}
+			if !flags.Changed("acceptance") {
+				acceptance = tmpl.Acceptance
+			}
+			if !flags.Changed("priority") && tmpl.Priority != nil {
+				priority = *tmpl.Priority
+			}
+			if !flags.Changed("type") && tmpl.Type != "" {
+				issueType = tmpl.Type
+			}
+			if !flags.Changed("assignee") {
+				assignee = tmpl.Assignee
+			}
+			if !flags.Changed("estimate") {
+				estimate = tmpl.Estimate
+			}
+			for _, label := range tmpl.Labels {
+				if !slices.Contains(labels, label) {
+					labels = append(labels, label)
+				}
+			}
+		}
 
 		// Check for conflicting flags
 		if explicitID != "" && parentID != "" {
@@ -147,6 +207,8 @@ var createCmd = &cobra.Command{
 				Assignee:           assignee,
 				Labels:             labels,
 				Dependencies:       deps,
+				EstimatedMinutes:   estimate,
+				DueAt:              due,
 			}
 
 			resp, err := daemonClient.Create(createArgs)
@@ -155,20 +217,20 @@ var createCmd = &cobra.Command{
 				os.Exit(1)
 			}
 
-			if jsonOutput {
+			if jsonOutput && (tmpl == nil || len(tmpl.Children) == 0) {
 				fmt.Println(string(resp.Data))
-			} else {
-				var issue types.Issue
-				if err := json.Unmarshal(resp.Data, &issue); err != nil {
-					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
-					os.Exit(1)
-				}
-				green := color.New(color.FgGreen).SprintFunc()
-				fmt.Printf("%s Created issue: %s\n", green("✔"), issue.ID)
-				fmt.Printf("  Title: %s\n", issue.Title)
-				fmt.Printf("  Priority: P%d\n", issue.Priority)
-				fmt.Printf("  Status: %s\n", issue.Status)
+				return
 			}
+			var issue types.Issue
+			if err := json.Unmarshal(resp.Data, &issue); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+				os.Exit(1)
+			}
+			var children []*types.Issue
+			if tmpl != nil {
+				children = createTemplateChildren(issue.ID, tmpl.Children)
+			}
+			printCreated(&issue, children, jsonOutput)
 			return
 		}
 
@@ -184,6 +246,8 @@ var createCmd = &cobra.Command{
 			IssueType:          types.IssueType(issueType),
 			Assignee:           assignee,
 			ExternalRef:        externalRefPtr,
+			EstimatedMinutes:   estimate,
+			DueAt:              due,
 		}
 
 		ctx := context.Background()
@@ -242,19 +306,40 @@ var createCmd = &cobra.Command{
 			}
 		}
 
+		var children []*types.Issue
+		if tmpl != nil {
+			children = createTemplateChildren(issue.ID, tmpl.Children)
+		}
+
 		// Schedule auto-flush
 		markDirtyAndScheduleFlush()
 
-		if jsonOutput {
-			outputJSON(issue)
+		printCreated(issue, children, jsonOutput)
+	},
+}
+
+// printCreated reports a created issue and any template children. With
+// children, JSON output is an array with the issue first.
+func printCreated(issue *types.Issue, children []*types.Issue, jsonOutput bool) {
+	if jsonOutput {
+		if len(children) > 0 {
+			outputJSON(append([]*types.Issue{issue}, children...))
 		} else {
-			green := color.New(color.FgGreen).SprintFunc()
-			fmt.Printf("%s Created issue: %s\n", green("✔"), issue.ID)
-			fmt.Printf("  Title: %s\n", issue.Title)
-			fmt.Printf("  Priority: P%d\n", issue.Priority)
-			fmt.Printf("  Status: %s\n", issue.Status)
+			outputJSON(issue)
 		}
-	},
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Created issue: %s\n", green("✔"), issue.ID)
+	fmt.Printf("  Title: %s\n", issue.Title)
+	fmt.Printf("  Priority: P%d\n", issue.Priority)
+	fmt.Printf("  Status: %s\n", issue.Status)
+	if len(children) > 0 {
+		fmt.Printf("  Children:\n")
+		for _, child := range children {
+			fmt.Printf("    %s: %s [P%d, %s]\n", child.ID, child.Title, child.Priority, child.IssueType)
+		}
+	}
 }
 
 func init() {
@@ -266,11 +351,15 @@ func init() {
 	createCmd.Flags().IntP("priority", "p", 2, "Priority (0-4, 0=highest)")
 	createCmd.Flags().StringP("type", "t", "task", "Issue type (bug|feature|task|epic|chore)")
 	createCmd.Flags().StringP("assignee", "a", "", "Assignee")
+	createCmd.Flags().IntP("estimate", "e", 0, "Estimated time to complete, in minutes")
+	createCmd.Flags().String("due", "", "Due date (e.g., 2025-01-31, 3d, 2025-01-31T17:00:00Z)")
 	createCmd.Flags().StringSliceP("labels", "l", []string{}, "Labels (comma-separated)")
 	createCmd.Flags().String("id", "", "Explicit issue ID (e.g., 'beads-42' for partitioning)")
 	createCmd.Flags().String("parent", "", "Parent issue ID for hierarchical child (e.g., 'beads-a3f8e9')")
 	createCmd.Flags().String("external-ref", "", "External reference (e.g., 'gh-9', 'jira-ABC')")
 	createCmd.Flags().StringSlice("deps", []string{}, "Dependencies in format 'type:id' or 'id' (e.g., 'discovered-from:beads-20,blocks:beads-15' or 'beads-20')")
+	createCmd.Flags().String("template", "", "Prefill from .beads/templates/<name>.yaml (see 'beads template')")
+	createCmd.Flags().StringArray("var", nil, "Template variable as key=value (repeatable)")
 	createCmd.Flags().Bool("force", false, "Force creation even if prefix doesn't match database prefix")
 	createCmd.Flags().Bool("json", false, "Output JSON format")
 	rootCmd.AddCommand(createCmd)
@@ -4,8 +4,11 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -16,11 +19,15 @@ import (
 	"time"
 
 	"github.com/shaneholloman/beads"
+	"github.com/shaneholloman/beads/internal/config"
 	"github.com/shaneholloman/beads/internal/daemon"
+	"github.com/shaneholloman/beads/internal/dashboard"
 	"github.com/shaneholloman/beads/internal/rpc"
 	"github.com/shaneholloman/beads/internal/storage"
-	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/storage/factory"
+	"github.com/shaneholloman/beads/internal/storage/postgres"
 	"github.com/shaneholloman/beads/internal/types"
+	"github.com/shaneholloman/beads/internal/webhook"
 	"github.com/spf13/cobra"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
@@ -40,7 +47,10 @@ The daemon will:
 
 Use --stop to stop a running daemon.
 Use --status to check if daemon is running.
-Use --health to check daemon health and metrics.`,
+Use --health to check daemon health and metrics.
+
+Use --rpc-addr to also accept requests over HTTP from other machines or
+containers; clients set BEADS_DAEMON_URL and BEADS_DAEMON_TOKEN to use it.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		stop, _ := cmd.Flags().GetBool("stop")
 		status, _ := cmd.Flags().GetBool("status")
@@ -52,6 +62,12 @@ Use --health to check daemon health and metrics.`,
 		autoPush, _ := cmd.Flags().GetBool("auto-push")
 		logFile, _ := cmd.Flags().GetString("log")
 		global, _ := cmd.Flags().GetBool("global")
+		httpAddr, _ := cmd.Flags().GetString("http")
+		remoteRPC, err := remoteRPCConfig(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 
 		if interval <= 0 {
 			fmt.Fprintf(os.Stderr, "Error: interval must be positive (got %v)\n", interval)
@@ -131,6 +147,16 @@ Use --health to check daemon health and metrics.`,
 			os.Exit(1)
 		}
 
+		// The dashboard reads one workspace's database
+		if global && httpAddr != "" {
+			fmt.Fprintf(os.Stderr, "Error: --http is not supported with --global\n")
+			os.Exit(1)
+		}
+		if global && remoteRPC.Addr != "" {
+			fmt.Fprintf(os.Stderr, "Error: --rpc-addr is not supported with --global\n")
+			os.Exit(1)
+		}
+
 		// Validate we're in a git repo (skip for global daemon)
 		if !global && !isGitRepo() {
 			fmt.Fprintf(os.Stderr, "Error: not in a git repository\n")
@@ -168,8 +194,14 @@ Use --health to check daemon health and metrics.`,
 		if logFile != "" {
 			fmt.Printf("Logging to: %s\n", logFile)
 		}
+		if httpAddr != "" {
+			fmt.Printf("Dashboard: %s\n", dashboardURL(httpAddr))
+		}
+		if remoteRPC.Addr != "" {
+			fmt.Printf("Remote RPC: %s\n", remoteRPCURL(remoteRPC))
+		}
 
-		startDaemon(interval, autoCommit, autoPush, logFile, pidFile, global)
+		startDaemon(interval, autoCommit, autoPush, logFile, pidFile, global, httpAddr, remoteRPC)
 	},
 }
 
@@ -184,6 +216,11 @@ func init() {
 	daemonCmd.Flags().Bool("migrate-to-global", false, "Migrate from local to global daemon")
 	daemonCmd.Flags().String("log", "", "Log file path (default: .beads/daemon.log)")
 	daemonCmd.Flags().Bool("global", false, "Run as global daemon (socket at ~/.beads/beads.sock)")
+	daemonCmd.Flags().String("http", "", "Serve the read-only web dashboard on this address, e.g. localhost:8080")
+	daemonCmd.Flags().String("rpc-addr", "", "Also accept RPC requests over HTTP on this address, e.g. 0.0.0.0:7777")
+	daemonCmd.Flags().String("rpc-token-file", "", "File holding the bearer token for --rpc-addr (default: $BEADS_DAEMON_TOKEN)")
+	daemonCmd.Flags().String("tls-cert", "", "TLS certificate (PEM) for --rpc-addr")
+	daemonCmd.Flags().String("tls-key", "", "TLS private key (PEM) for --rpc-addr")
 	rootCmd.AddCommand(daemonCmd)
 }
 
@@ -459,6 +496,11 @@ func showDaemonMetrics(global bool) {
 	fmt.Printf("  Memory Sys: %d MB\n", metrics.MemorySysMB)
 	fmt.Printf("  Goroutines: %d\n\n", metrics.GoroutineCount)
 
+	if metrics.AutoCompact != nil {
+		printAutoCompactStatus(metrics.AutoCompact)
+		fmt.Println()
+	}
+
 	// Operation metrics
 	if len(metrics.Operations) > 0 {
 		fmt.Printf("Operation Metrics:\n")
@@ -590,7 +632,7 @@ func stopDaemon(pidFile string) {
 	fmt.Println("Daemon killed")
 }
 
-func startDaemon(interval time.Duration, autoCommit, autoPush bool, logFile, pidFile string, global bool) {
+func startDaemon(interval time.Duration, autoCommit, autoPush bool, logFile, pidFile string, global bool, httpAddr string, remoteRPC rpc.HTTPConfig) {
 	logPath, err := getLogFilePath(logFile, global)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -598,7 +640,7 @@ func startDaemon(interval time.Duration, autoCommit, autoPush bool, logFile, pid
 	}
 
 	if os.Getenv("BEADS_DAEMON_FOREGROUND") == "1" {
-		runDaemonLoop(interval, autoCommit, autoPush, logPath, pidFile, global)
+		runDaemonLoop(interval, autoCommit, autoPush, logPath, pidFile, global, httpAddr, remoteRPC)
 		return
 	}
 
@@ -623,9 +665,22 @@ func startDaemon(interval time.Duration, autoCommit, autoPush bool, logFile, pid
 	if global {
 		args = append(args, "--global")
 	}
+	if httpAddr != "" {
+		args = append(args, "--http", httpAddr)
+	}
+	if remoteRPC.Addr != "" {
+		args = append(args, "--rpc-addr", remoteRPC.Addr)
+		if remoteRPC.CertFile != "" {
+			args = append(args, "--tls-cert", remoteRPC.CertFile, "--tls-key", remoteRPC.KeyFile)
+		}
+	}
 
 	cmd := exec.Command(exe, args...) // #nosec G204 - beads daemon command from trusted binary
 	cmd.Env = append(os.Environ(), "BEADS_DAEMON_FOREGROUND=1")
+	if remoteRPC.Addr != "" {
+		// The token goes through the environment so it never shows up in ps
+		cmd.Env = append(cmd.Env, rpc.EnvDaemonToken+"="+remoteRPC.Token)
+	}
 	configureDaemonProcess(cmd)
 
 	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
@@ -953,6 +1008,11 @@ func startRPCServer(ctx context.Context, socketPath string, store storage.Storag
 	rpc.ServerVersion = Version
 
 	server := rpc.NewServer(socketPath, store, workspacePath, dbPath)
+	if ttl, err := config.LoadLeaseTTL(); err != nil {
+		log.log("Warning: %v, using %v", err, types.DefaultLeaseTTL)
+	} else {
+		server.SetLeaseTTL(ttl)
+	}
 	serverErrChan := make(chan error, 1)
 
 	go func() {
@@ -976,6 +1036,209 @@ func startRPCServer(ctx context.Context, socketPath string, store storage.Storag
 	return server, serverErrChan, nil
 }
 
+// startWebhooks delivers mutation events to the webhooks configured in config.yaml
+func startWebhooks(ctx context.Context, server *rpc.Server, log daemonLogger) {
+	hooks, err := config.LoadWebhooks()
+	if err != nil {
+		log.log("Warning: webhooks disabled: %v", err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+	events, unsubscribe := server.Subscribe()
+	go func() {
+		defer unsubscribe()
+		webhook.NewDispatcher(hooks, log.log).Run(ctx, events)
+	}()
+	log.log("Delivering events to %d webhook(s)", len(hooks))
+}
+
+// setRepoRegistry gives the RPC server the repository registry from
+// config.yaml, so ready work accounts for blockers in other repositories
+func setRepoRegistry(server *rpc.Server, log daemonLogger) {
+	repos, err := config.LoadRepos()
+	if err != nil {
+		log.log("Warning: repository registry not loaded: %v", err)
+		return
+	}
+	server.SetRepos(repos)
+	if len(repos) > 0 {
+		log.log("Loaded %d repository alias(es)", len(repos))
+	}
+}
+
+// startLeaseReaper periodically returns claimed issues whose lease expired
+// without a heartbeat to open, along with issues set in_progress without a
+// claim that have not been updated for the lease-ttl setting since the later of
+// their last update and the daemon start. Each such issue is logged at startup
+// before it can be released. Leases run for
+// that long from the last heartbeat, so checking at half that (at most every
+// minute) bounds how long abandoned work stays assigned.
+func startLeaseReaper(ctx context.Context, server *rpc.Server, log daemonLogger) {
+	ttl := server.LeaseTTL()
+	interval := ttl / 2
+	if interval > time.Minute {
+		interval = time.Minute
+	}
+	// Announce the in_progress work that will be released for lack of a
+	// heartbeat, before any of it is
+	if idle, err := server.UnleasedWork(ctx); err != nil {
+		log.log("Lease reaper failed: %v", err)
+	} else {
+		for _, issue := range idle {
+			log.log("%s is in_progress without a lease: it returns to open at %s unless it gets a heartbeat or update",
+				issue.ID, server.UnleasedDeadline(issue).Format(time.RFC3339))
+		}
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				released, err := server.ReapExpiredLeases(ctx)
+				if err != nil {
+					log.log("Lease reaper failed: %v", err)
+					continue
+				}
+				for _, lease := range released {
+					log.log("Released %s: lease held by %s expired at %s", lease.IssueID, lease.Holder, lease.ExpiresAt.Format(time.RFC3339))
+				}
+				idle, err := server.ReapUnleasedWork(ctx)
+				if err != nil {
+					log.log("Lease reaper failed: %v", err)
+					continue
+				}
+				for _, issue := range idle {
+					log.log("Released %s: in_progress without a heartbeat since %s", issue.ID, issue.UpdatedAt.Format(time.RFC3339))
+				}
+			}
+		}
+	}()
+}
+
+// autoCompactCheckInterval is how often the daemon checks whether a background
+// compaction run is due. The auto_compact_* settings decide whether it is.
+const autoCompactCheckInterval = time.Minute
+
+// startAutoCompactor periodically compacts old closed issues when
+// auto_compact_enabled is set. Runs happen only while the daemon is idle and
+// are limited by the auto_compact_* interval and daily budget settings.
+func startAutoCompactor(ctx context.Context, server *rpc.Server, log daemonLogger) {
+	go func() {
+		ticker := time.NewTicker(autoCompactCheckInterval)
+		defer ticker.Stop()
+		lastErr := ""
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run, err := server.AutoCompact(ctx)
+				if run != nil {
+					log.log("Auto-compaction run %s: %d Tier 1, %d Tier 2, %d staged, %d failed, %d calls, saved %d bytes",
+						run.ID, run.Tier1, run.Tier2, run.Staged, run.Failed, run.Calls, run.SavedBytes)
+					for _, e := range run.Errors {
+						log.log("  %s", e)
+					}
+				}
+				if err == nil {
+					lastErr = ""
+				} else if err.Error() != lastErr {
+					// Configuration errors repeat every check; log them once
+					log.log("Auto-compaction failed: %v", err)
+					lastErr = err.Error()
+				}
+			}
+		}
+	}()
+}
+
+// startDashboard serves the web dashboard over the daemon's store until ctx is
+// done. A dashboard that fails to start is logged; the daemon keeps running.
+func startDashboard(ctx context.Context, addr string, store storage.Storage, server *rpc.Server, workspacePath string, log daemonLogger) {
+	dash, err := dashboard.New(store, filepath.Base(workspacePath), server.MetricsSnapshot)
+	if err != nil {
+		log.log("Warning: dashboard disabled: %v", err)
+		return
+	}
+	go func() {
+		log.log("Serving dashboard at %s", dashboardURL(addr))
+		if err := dash.Serve(ctx, addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.log("Dashboard error: %v", err)
+		}
+	}()
+}
+
+// remoteRPCConfig reads the flags for the RPC-over-HTTP transport. The token
+// comes from --rpc-token-file or BEADS_DAEMON_TOKEN.
+func remoteRPCConfig(cmd *cobra.Command) (rpc.HTTPConfig, error) {
+	var cfg rpc.HTTPConfig
+	cfg.Addr, _ = cmd.Flags().GetString("rpc-addr")
+	tokenFile, _ := cmd.Flags().GetString("rpc-token-file")
+	certFile, _ := cmd.Flags().GetString("tls-cert")
+	keyFile, _ := cmd.Flags().GetString("tls-key")
+	if cfg.Addr == "" {
+		if tokenFile != "" || certFile != "" || keyFile != "" {
+			return cfg, fmt.Errorf("--rpc-token-file, --tls-cert and --tls-key require --rpc-addr")
+		}
+		return cfg, nil
+	}
+
+	cfg.Token = os.Getenv(rpc.EnvDaemonToken)
+	if tokenFile != "" {
+		data, err := os.ReadFile(tokenFile) // #nosec G304 - user-specified token file
+		if err != nil {
+			return cfg, fmt.Errorf("failed to read token file: %w", err)
+		}
+		cfg.Token = strings.TrimSpace(string(data))
+	}
+	// The forked daemon may run from another directory
+	for _, path := range []*string{&certFile, &keyFile} {
+		if *path != "" {
+			abs, err := filepath.Abs(*path)
+			if err != nil {
+				return cfg, fmt.Errorf("failed to resolve %s: %w", *path, err)
+			}
+			*path = abs
+		}
+	}
+	cfg.CertFile, cfg.KeyFile = certFile, keyFile
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid --rpc-addr setup: %w", err)
+	}
+	return cfg, nil
+}
+
+// remoteRPCURL is the BEADS_DAEMON_URL clients use to reach the transport
+func remoteRPCURL(cfg rpc.HTTPConfig) string {
+	url := dashboardURL(cfg.Addr)
+	if cfg.CertFile != "" {
+		url = "https" + strings.TrimPrefix(url, "http")
+	}
+	return strings.TrimSuffix(url, "/")
+}
+
+// startRemoteRPC serves the RPC protocol over HTTP until ctx is done
+func startRemoteRPC(ctx context.Context, cfg rpc.HTTPConfig, server *rpc.Server, log daemonLogger) {
+	if cfg.CertFile == "" {
+		if host, _, err := net.SplitHostPort(cfg.Addr); err == nil {
+			if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+				log.log("Warning: remote RPC on %s is not using TLS; the token is sent in the clear", cfg.Addr)
+			}
+		}
+	}
+	go func() {
+		log.log("Serving remote RPC at %s", remoteRPCURL(cfg))
+		if err := server.ListenHTTP(ctx, cfg); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.log("Remote RPC error: %v", err)
+		}
+	}()
+}
+
 func runGlobalDaemon(log daemonLogger) {
 	globalDir, err := getGlobalBeadsDir()
 	if err != nil {
@@ -1082,10 +1345,22 @@ func createExportFunc(ctx context.Context, store storage.Storage, autoCommit, au
 	}
 }
 
+// isSharedStore reports whether store is a shared Postgres database rather than
+// a per-clone SQLite file (see configfile.BackendPostgres)
+func isSharedStore(store storage.Storage) bool {
+	_, ok := store.(*postgres.PostgresStorage)
+	return ok
+}
+
 // createAutoImportFunc creates a function that pulls from git and imports JSONL
 // to database (no export). Used for file system change events.
 func createAutoImportFunc(ctx context.Context, store storage.Storage, log daemonLogger) func() {
 	return func() {
+		if isSharedStore(store) {
+			log.log("Skipping auto-import: shared Postgres database is the source of truth")
+			return
+		}
+
 		importCtx, importCancel := context.WithTimeout(ctx, 1*time.Minute)
 		defer importCancel()
 
@@ -1225,29 +1500,33 @@ func createSyncFunc(ctx context.Context, store storage.Storage, autoCommit, auto
 		}
 		log.log("Pulled from remote")
 
-		// Count issues before import for validation
-		beforeCount, err := countDBIssues(syncCtx, store)
-		if err != nil {
-			log.log("Failed to count issues before import: %v", err)
-			return
-		}
+		// A shared Postgres database is the source of truth for every clone,
+		// so JSONL is export-only and nothing is imported back
+		if !isSharedStore(store) {
+			// Count issues before import for validation
+			beforeCount, err := countDBIssues(syncCtx, store)
+			if err != nil {
+				log.log("Failed to count issues before import: %v", err)
+				return
+			}
 
-		if err := importToJSONLWithStore(syncCtx, store, jsonlPath); err != nil {
-			log.log("Import failed: %v", err)
-			return
-		}
-		log.log("Imported from JSONL")
+			if err := importToJSONLWithStore(syncCtx, store, jsonlPath); err != nil {
+				log.log("Import failed: %v", err)
+				return
+			}
+			log.log("Imported from JSONL")
 
-		// Validate import didn't cause data loss
-		afterCount, err := countDBIssues(syncCtx, store)
-		if err != nil {
-			log.log("Failed to count issues after import: %v", err)
-			return
-		}
+			// Validate import didn't cause data loss
+			afterCount, err := countDBIssues(syncCtx, store)
+			if err != nil {
+				log.log("Failed to count issues after import: %v", err)
+				return
+			}
 
-		if err := validatePostImport(beforeCount, afterCount); err != nil {
-			log.log("Post-import validation failed: %v", err)
-			return
+			if err := validatePostImport(beforeCount, afterCount); err != nil {
+				log.log("Post-import validation failed: %v", err)
+				return
+			}
 		}
 
 		if autoPush && autoCommit {
@@ -1267,6 +1546,10 @@ func runEventLoop(ctx context.Context, cancel context.CancelFunc, ticker *time.T
 	signal.Notify(sigChan, daemonSignals...)
 	defer signal.Stop(sigChan)
 
+	materializeRecurring(ctx, server, log)
+	recurTicker := time.NewTicker(recurInterval)
+	defer recurTicker.Stop()
+
 	for {
 		select {
 		case <-ticker.C:
@@ -1274,6 +1557,8 @@ func runEventLoop(ctx context.Context, cancel context.CancelFunc, ticker *time.T
 				return
 			}
 			doSync()
+		case <-recurTicker.C:
+			materializeRecurring(ctx, server, log)
 		case sig := <-sigChan:
 			if isReloadSignal(sig) {
 				log.log("Received reload signal, ignoring (daemon continues running)")
@@ -1302,7 +1587,7 @@ func runEventLoop(ctx context.Context, cancel context.CancelFunc, ticker *time.T
 	}
 }
 
-func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, pidFile string, global bool) {
+func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, pidFile string, global bool, httpAddr string, remoteRPC rpc.HTTPConfig) {
 	logF, log := setupDaemonLogger(logPath)
 	defer func() { _ = logF.Close() }()
 
@@ -1370,7 +1655,7 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 
 	log.log("Using database: %s", daemonDBPath)
 
-	store, err := sqlite.New(daemonDBPath)
+	store, err := factory.Open(daemonDBPath)
 	if err != nil {
 		log.log("Error: cannot open database: %v", err)
 		os.Exit(1)
@@ -1434,6 +1719,16 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 	if err != nil {
 		return
 	}
+	startWebhooks(ctx, server, log)
+	startLeaseReaper(ctx, server, log)
+	setRepoRegistry(server, log)
+	startAutoCompactor(ctx, server, log)
+	if httpAddr != "" {
+		startDashboard(ctx, httpAddr, store, server, workspacePath, log)
+	}
+	if remoteRPC.Addr != "" {
+		startRemoteRPC(ctx, remoteRPC, server, log)
+	}
 
 	// Register daemon in global registry
 	registry, err := daemon.NewRegistry()
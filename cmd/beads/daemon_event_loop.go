@@ -74,8 +74,17 @@ func runEventDrivenLoop(
 	healthTicker := time.NewTicker(60 * time.Second)
 	defer healthTicker.Stop()
 
+	// Recurring issues: create occurrences that fell due while stopped, then
+	// check every minute. Created issues emit mutations, which trigger export.
+	materializeRecurring(ctx, server, log)
+	recurTicker := time.NewTicker(recurInterval)
+	defer recurTicker.Stop()
+
 	for {
 		select {
+		case <-recurTicker.C:
+			materializeRecurring(ctx, server, log)
+
 		case <-healthTicker.C:
 			// Periodic health validation (not sync)
 			checkDaemonHealth(ctx, store, log)
@@ -120,6 +129,23 @@ func runEventDrivenLoop(
 	}
 }
 
+// recurInterval is how often the daemon checks for recurring issues that are
+// due. Rules have minute resolution.
+const recurInterval = time.Minute
+
+// materializeRecurring creates the occurrences of recurring issues that are
+// due. Occurrence IDs are deterministic, so daemons in several clones
+// creating the same occurrence produce one issue once their JSONL syncs.
+func materializeRecurring(ctx context.Context, server *rpc.Server, log daemonLogger) {
+	created, err := server.MaterializeRecurring(ctx)
+	for _, occ := range created {
+		log.log("Created %s from recurring issue %s (due %s)", occ.ID, occ.Template.ID, occ.At.Format(time.RFC3339))
+	}
+	if err != nil {
+		log.log("Recurring issues: %v", err)
+	}
+}
+
 // checkDaemonHealth performs periodic health validation.
 // Separate from sync operations - just validates state.
 func checkDaemonHealth(ctx context.Context, store storage.Storage, log daemonLogger) {
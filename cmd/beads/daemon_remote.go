@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/shaneholloman/beads/internal/rpc"
+)
+
+// connectRemoteDaemon connects to the daemon at BEADS_DAEMON_URL, exiting if
+// it can't. Remote daemons can't be auto-started or restarted on a version
+// mismatch, so both are errors rather than reasons to fall back.
+func connectRemoteDaemon() {
+	url := os.Getenv(rpc.EnvDaemonURL)
+	client, err := rpc.ConnectFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Hint: check %s and %s, or unset %s to use the local database\n",
+			rpc.EnvDaemonURL, rpc.EnvDaemonToken, rpc.EnvDaemonURL)
+		os.Exit(1)
+	}
+	health, err := client.Health()
+	if err != nil {
+		_ = client.Close()
+		fmt.Fprintf(os.Stderr, "Error: daemon at %s failed health check: %v\n", url, err)
+		os.Exit(1)
+	}
+	if !health.Compatible {
+		_ = client.Close()
+		fmt.Fprintf(os.Stderr, "Error: daemon at %s runs version %s, which is incompatible with this client (%s)\n",
+			url, health.Version, Version)
+		os.Exit(1)
+	}
+
+	// Set actor like the local path does: --actor, BEADS_ACTOR via config, USER
+	if actor == "" {
+		if user := os.Getenv("USER"); user != "" {
+			actor = user
+		} else {
+			actor = "unknown"
+		}
+	}
+
+	daemonClient = client
+	daemonStatus = DaemonStatus{
+		Mode:           cmdDaemon,
+		Connected:      true,
+		SocketPath:     url,
+		FallbackReason: FallbackNone,
+		Health:         health.Status,
+	}
+	if os.Getenv("BEADS_DEBUG") != "" {
+		fmt.Fprintf(os.Stderr, "Debug: connected to remote daemon at %s (health: %s)\n", url, health.Status)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/shaneholloman/beads/internal/config"
+	"github.com/shaneholloman/beads/internal/rpc"
 	"github.com/shaneholloman/beads/internal/storage"
 	"github.com/shaneholloman/beads/internal/storage/sqlite"
 	"github.com/shaneholloman/beads/internal/types"
@@ -853,3 +854,17 @@ func TestMutationToExportLatency(t *testing.T) {
 		}
 	})
 }
+
+func TestRemoteRPCURL(t *testing.T) {
+	for _, tc := range []struct {
+		cfg  rpc.HTTPConfig
+		want string
+	}{
+		{rpc.HTTPConfig{Addr: ":7777"}, "http://localhost:7777"},
+		{rpc.HTTPConfig{Addr: "10.0.0.5:7777", CertFile: "cert.pem"}, "https://10.0.0.5:7777"},
+	} {
+		if got := remoteRPCURL(tc.cfg); got != tc.want {
+			t.Errorf("remoteRPCURL(%+v) = %q, want %q", tc.cfg, got, tc.want)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/config"
+	"github.com/shaneholloman/beads/internal/federation"
 	"github.com/shaneholloman/beads/internal/rpc"
 	"github.com/shaneholloman/beads/internal/storage/sqlite"
 	"github.com/shaneholloman/beads/internal/types"
@@ -30,7 +32,7 @@ var depAddCmd = &cobra.Command{
 		ctx := context.Background()
 
 		// Resolve partial IDs first
-		var fromID, toID string
+		var fromID string
 		if daemonClient != nil {
 			resolveArgs := &rpc.ResolveIDArgs{ID: args[0]}
 			resp, err := daemonClient.ResolveID(resolveArgs)
@@ -39,14 +41,6 @@ var depAddCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			fromID = string(resp.Data)
-
-			resolveArgs = &rpc.ResolveIDArgs{ID: args[1]}
-			resp, err = daemonClient.ResolveID(resolveArgs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
-				os.Exit(1)
-			}
-			toID = string(resp.Data)
 		} else {
 			var err error
 			fromID, err = utils.ResolvePartialID(ctx, store, args[0])
@@ -54,12 +48,13 @@ var depAddCmd = &cobra.Command{
 				fmt.Fprintf(os.Stderr, "Error resolving issue ID %s: %v\n", args[0], err)
 				os.Exit(1)
 			}
+		}
 
-			toID, err = utils.ResolvePartialID(ctx, store, args[1])
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
-				os.Exit(1)
-			}
+		// A qualified ID (api:bd-a3f8) is an issue in another repository
+		toID, err := resolveDependsOnID(ctx, args[1], true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
+			os.Exit(1)
 		}
 
 		// If daemon is running, use RPC
@@ -151,7 +146,7 @@ var depRemoveCmd = &cobra.Command{
 		ctx := context.Background()
 
 		// Resolve partial IDs first
-		var fromID, toID string
+		var fromID string
 		if daemonClient != nil {
 			resolveArgs := &rpc.ResolveIDArgs{ID: args[0]}
 			resp, err := daemonClient.ResolveID(resolveArgs)
@@ -160,14 +155,6 @@ var depRemoveCmd = &cobra.Command{
 				os.Exit(1)
 			}
 			fromID = string(resp.Data)
-
-			resolveArgs = &rpc.ResolveIDArgs{ID: args[1]}
-			resp, err = daemonClient.ResolveID(resolveArgs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
-				os.Exit(1)
-			}
-			toID = string(resp.Data)
 		} else {
 			var err error
 			fromID, err = utils.ResolvePartialID(ctx, store, args[0])
@@ -175,12 +162,13 @@ var depRemoveCmd = &cobra.Command{
 				fmt.Fprintf(os.Stderr, "Error resolving issue ID %s: %v\n", args[0], err)
 				os.Exit(1)
 			}
+		}
 
-			toID, err = utils.ResolvePartialID(ctx, store, args[1])
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
-				os.Exit(1)
-			}
+		// A qualified ID (api:bd-a3f8) is an issue in another repository
+		toID, err := resolveDependsOnID(ctx, args[1], false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving dependency ID %s: %v\n", args[1], err)
+			os.Exit(1)
 		}
 
 		// If daemon is running, use RPC
@@ -234,6 +222,41 @@ var depRemoveCmd = &cobra.Command{
 	},
 }
 
+// resolveDependsOnID resolves the target of a dependency to a full issue ID.
+// A qualified ID such as api:a3f8 refers to an issue in a repository from the
+// registry in config.yaml and is resolved in that repository's database. When
+// mustExist is false, as when removing a dependency, a qualified ID that can't
+// be resolved is used as given.
+func resolveDependsOnID(ctx context.Context, id string, mustExist bool) (string, error) {
+	alias, localID, ok := types.ParseQualifiedID(id)
+	if !ok {
+		if daemonClient != nil {
+			resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: id})
+			if err != nil {
+				return "", err
+			}
+			return string(resp.Data), nil
+		}
+		return utils.ResolvePartialID(ctx, store, id)
+	}
+
+	repos, err := config.LoadRepos()
+	if err != nil {
+		return "", err
+	}
+	resolver := federation.NewResolver(repos)
+	defer func() { _ = resolver.Close() }()
+
+	fullID, err := resolver.ResolveID(ctx, id)
+	if err != nil && mustExist {
+		return "", err
+	}
+	if err != nil {
+		return types.QualifiedID(alias, localID), nil
+	}
+	return fullID, nil
+}
+
 var depTreeCmd = &cobra.Command{
 	Use:   "tree [issue-id]",
 	Short: "Show dependency tree",
@@ -5,11 +5,14 @@ import (
 	"os"
 
 	"github.com/shaneholloman/beads"
-	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/storage/factory"
 )
 
 // ensureDirectMode makes sure the CLI is operating in direct-storage mode.
 // If the daemon is active, it is cleanly disconnected and the shared store is opened.
+// A remote daemon (BEADS_DAEMON_URL) has no local database behind it, so that
+// is an error instead.
 func ensureDirectMode(reason string) error {
 	if daemonClient != nil {
 		if err := fallbackToDirectMode(reason); err != nil {
@@ -21,7 +24,12 @@ func ensureDirectMode(reason string) error {
 }
 
 // fallbackToDirectMode disables the daemon client and ensures a local store is ready.
+// It fails when the daemon is remote rather than switch to an unrelated local database.
 func fallbackToDirectMode(reason string) error {
+	if daemonClient != nil && daemonClient.IsRemote() {
+		return fmt.Errorf("%s, and the daemon at %s=%s has no local database to fall back to (unset %s to use a local database)",
+			reason, rpc.EnvDaemonURL, os.Getenv(rpc.EnvDaemonURL), rpc.EnvDaemonURL)
+	}
 	disableDaemonForFallback(reason)
 	return ensureStoreActive()
 }
@@ -65,7 +73,7 @@ func ensureStoreActive() error {
 		}
 	}
 
-	sqlStore, err := sqlite.New(dbPath)
+	sqlStore, err := factory.Open(dbPath)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
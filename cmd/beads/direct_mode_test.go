@@ -3,8 +3,11 @@ package main
 import (
 	"bytes"
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/shaneholloman/beads/internal/rpc"
@@ -144,3 +147,45 @@ func TestFallbackToDirectModeEnablesFlush(t *testing.T) {
 		t.Fatalf("expected JSONL export to contain neighbor issue ID %s", neighbor.ID)
 	}
 }
+
+func TestEnsureDirectModeRefusesRemoteDaemon(t *testing.T) {
+	origDaemonClient := daemonClient
+	origStore := store
+	origStoreActive := storeActive
+	defer func() {
+		daemonClient = origDaemonClient
+		storeMutex.Lock()
+		store = origStore
+		storeActive = origStoreActive
+		storeMutex.Unlock()
+	}()
+
+	// A daemon that answers every request with a healthy status
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success":true,"data":{"status":"healthy","compatible":true}}`))
+	}))
+	defer srv.Close()
+	t.Setenv(rpc.EnvDaemonURL, srv.URL)
+	client, err := rpc.ConnectURL(srv.URL, "", "")
+	if err != nil {
+		t.Fatalf("ConnectURL failed: %v", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	daemonClient = client
+	storeMutex.Lock()
+	store = nil
+	storeActive = false
+	storeMutex.Unlock()
+
+	err = ensureDirectMode("daemon does not support delete command")
+	if err == nil || !strings.Contains(err.Error(), "delete command") || !strings.Contains(err.Error(), rpc.EnvDaemonURL) {
+		t.Fatalf("expected an error naming the command and %s, got %v", rpc.EnvDaemonURL, err)
+	}
+	if daemonClient != client {
+		t.Error("remote daemon client was dropped")
+	}
+	if storeActive {
+		t.Error("opened a local store behind a remote daemon")
+	}
+}
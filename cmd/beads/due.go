@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/config"
+	"github.com/shaneholloman/beads/internal/query"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// parseDueFlag parses a --due value: a date (due at the end of that day,
+// local time), an RFC 3339 timestamp, or an offset from now such as 3d or
+// 12h. Offsets count forwards, since due dates are in the future. An empty
+// value means no due date.
+func parseDueFlag(value string, now time.Time) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if day, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		due := day.Add(24*time.Hour - time.Second)
+		return &due, nil
+	}
+	if !strings.HasPrefix(value, "+") && value != "now" {
+		if due, err := query.ParseTime("+"+value, now); err == nil {
+			return &due, nil
+		}
+	}
+	due, err := query.ParseTime(value, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid due date %q (expected e.g. 2025-01-31, 3d or 2025-01-31T17:00:00Z)", value)
+	}
+	return &due, nil
+}
+
+// formatDue describes an issue's due date for display, flagging it when the
+// issue is overdue
+func formatDue(issue *types.Issue, now time.Time) string {
+	if issue.DueAt == nil {
+		return ""
+	}
+	s := issue.DueAt.Local().Format("2006-01-02 15:04")
+	if issue.IsOverdue(now) {
+		s += " (overdue)"
+	}
+	return s
+}
+
+// notDoneFilter matches issues whose status is not in the done category
+func notDoneFilter() query.Expr {
+	done := query.Cond{Field: query.FieldStatus, Op: query.OpEq}
+	for _, status := range types.CurrentWorkflow().StatusesIn(types.CategoryDone) {
+		done.Values = append(done.Values, query.Value{Str: string(status)})
+	}
+	return query.Not{Expr: done}
+}
+
+// overdueFilter matches issues due before now that are not done
+func overdueFilter(now time.Time) query.Expr {
+	due := query.Cond{Field: query.FieldDue, Op: query.OpLt, Values: []query.Value{{Time: now}}}
+	return query.Combine(due, notDoneFilter())
+}
+
+// addSLABreaches fills in stats.SLABreaches from the sla config. Failures are
+// reported as warnings so the rest of the statistics still print.
+func addSLABreaches(ctx context.Context, stats *types.Statistics) {
+	policy, err := config.LoadSLA()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	if len(policy) == 0 {
+		return
+	}
+
+	var issues []*types.Issue
+	if daemonClient != nil {
+		var resp *rpc.Response
+		resp, err = daemonClient.List(&rpc.ListArgs{Filter: notDoneFilter().String()})
+		if err == nil {
+			err = json.Unmarshal(resp.Data, &issues)
+		}
+	} else {
+		issues, err = store.SearchIssues(ctx, "", types.IssueFilter{Expr: notDoneFilter()})
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to check SLAs: %v\n", err)
+		return
+	}
+	stats.SLABreaches = policy.Breaches(issues, time.Now())
+}
+
+// printSLABreaches lists the issues open longer than their SLA allows
+func printSLABreaches(stats *types.Statistics) {
+	if len(stats.SLABreaches) == 0 {
+		return
+	}
+	red := color.New(color.FgRed).SprintFunc()
+	fmt.Printf("\n%s Open longer than their SLA (%d):\n\n", red("SLA:"), len(stats.SLABreaches))
+	for _, b := range stats.SLABreaches {
+		fmt.Printf("[P%d] %s: %s (open %s, SLA %s)\n", b.Priority, b.IssueID, b.Title,
+			formatHours(b.OpenHours), formatHours(b.WindowHours))
+	}
+}
+
+// formatHours renders a span of hours as hours under two days, otherwise days
+func formatHours(hours float64) string {
+	if hours < 48 {
+		return fmt.Sprintf("%.0fh", hours)
+	}
+	return fmt.Sprintf("%.1fd", hours/24)
+}
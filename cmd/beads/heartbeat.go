@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/shaneholloman/beads/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var heartbeatCmd = &cobra.Command{
+	Use:   "heartbeat <id>",
+	Short: "Renew the lease on an issue you are working on",
+	Long: `Record that the agent is still working on an in_progress issue assigned to it,
+extending its lease to --ttl from now. Agents call this periodically, well within
+the TTL. An in_progress issue that was not claimed with 'beads claim' gets a
+lease on its first heartbeat.
+
+When a lease expires without a heartbeat, the daemon returns the issue to open
+and unassigned, recording an event that names the last holder. Without a
+daemon, 'beads stale --release' and 'beads claim' do the same.
+
+Examples:
+  beads heartbeat bd-a3f8
+  beads heartbeat bd-a3f8 --agent worker-3 --ttl 10m
+  beads heartbeat bd-a3f8 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		agent, _ := cmd.Flags().GetString("agent")
+		ttl := leaseTTLFlag(cmd)
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if agent == "" {
+			agent = actor
+		}
+		if ttl <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: --ttl must be positive\n")
+			os.Exit(1)
+		}
+		ctx := context.Background()
+
+		var lease *types.Lease
+		usedDaemon := false
+
+		// If daemon is running, use RPC
+		if daemonClient != nil {
+			resolveResp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: args[0]})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", args[0], err)
+				os.Exit(1)
+			}
+			var id string
+			if err := json.Unmarshal(resolveResp.Data, &id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+				os.Exit(1)
+			}
+
+			resp, err := daemonClient.Heartbeat(&rpc.HeartbeatArgs{ID: id, Agent: agent, TTL: ttl.String()})
+			if err != nil {
+				if isUnknownOperationError(err) {
+					if err := fallbackToDirectMode("daemon does not support heartbeat RPC"); err != nil {
+						fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+						os.Exit(1)
+					}
+				} else {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			} else {
+				if err := json.Unmarshal(resp.Data, &lease); err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+					os.Exit(1)
+				}
+				usedDaemon = true
+			}
+		}
+
+		if !usedDaemon {
+			id, err := utils.ResolvePartialID(ctx, store, args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			// Leases are not exported to JSONL, so there is nothing to flush
+			lease, err = store.Heartbeat(ctx, id, agent, ttl)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(lease)
+			return
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Heartbeat for %s from %s\n", green("✓"), lease.IssueID, lease.Holder)
+		fmt.Printf("  Lease expires: %s\n", lease.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	},
+}
+
+func init() {
+	heartbeatCmd.Flags().String("agent", "", "Agent holding the issue (default: actor)")
+	heartbeatCmd.Flags().Duration("ttl", 0, "How long the lease lasts from now, e.g. 30m (default: lease-ttl setting)")
+	heartbeatCmd.Flags().Bool("json", false, "Output JSON format")
+	rootCmd.AddCommand(heartbeatCmd)
+}
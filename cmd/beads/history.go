@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/history"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/shaneholloman/beads/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show the change history of an issue",
+	Long: `Show every recorded change to an issue, oldest first: field changes
+(status, priority, assignee, ...), unified diffs of description, design, notes
+and acceptance criteria edits, and labels and dependencies added or removed.
+
+Examples:
+  beads history bd-a3f8
+  beads history bd-a3f8 -n 5
+  beads history bd-a3f8 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, _ := cmd.Flags().GetInt("limit")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		ctx := context.Background()
+
+		var entries []*history.Entry
+		usedDaemon := false
+
+		// If daemon is running, use RPC
+		if daemonClient != nil {
+			resolveResp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: args[0]})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", args[0], err)
+				os.Exit(1)
+			}
+			var id string
+			if err := json.Unmarshal(resolveResp.Data, &id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+				os.Exit(1)
+			}
+
+			resp, err := daemonClient.History(&rpc.HistoryArgs{ID: id, Limit: limit})
+			if err != nil {
+				if isUnknownOperationError(err) {
+					if err := fallbackToDirectMode("daemon does not support history RPC"); err != nil {
+						fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+						os.Exit(1)
+					}
+				} else {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			} else {
+				if err := json.Unmarshal(resp.Data, &entries); err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+					os.Exit(1)
+				}
+				usedDaemon = true
+			}
+		}
+
+		if !usedDaemon {
+			id, err := utils.ResolvePartialID(ctx, store, args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			entries, err = history.IssueLog(ctx, store, id, limit)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if jsonOutput {
+			if entries == nil {
+				entries = []*history.Entry{}
+			}
+			outputJSON(entries)
+			return
+		}
+
+		if len(entries) == 0 {
+			fmt.Printf("No history recorded for %s\n", args[0])
+			return
+		}
+		for _, entry := range entries {
+			printHistoryEntry(entry)
+		}
+	},
+}
+
+// printHistoryEntry renders one history entry for the terminal
+func printHistoryEntry(entry *history.Entry) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	bold := color.New(color.Bold).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+
+	fmt.Printf("%s  %s  %s\n", cyan(entry.Time.Local().Format("2006-01-02 15:04:05")), bold(entry.EventType), entry.Actor)
+	if entry.Comment != "" {
+		fmt.Printf("    %s\n", entry.Comment)
+	}
+	for _, change := range entry.Changes {
+		switch {
+		case change.Field == history.FieldLabel || change.Field == history.FieldDependency:
+			if change.New != "" {
+				fmt.Printf("    %s %s %s\n", green("+"), change.Field, change.New)
+			} else {
+				fmt.Printf("    %s %s %s\n", red("-"), change.Field, change.Old)
+			}
+		case change.Diff != "":
+			fmt.Printf("    %s:\n", change.Field)
+			for _, line := range strings.Split(strings.TrimSuffix(change.Diff, "\n"), "\n") {
+				switch {
+				case strings.HasPrefix(line, "@@"):
+					fmt.Printf("      %s\n", cyan(line))
+				case strings.HasPrefix(line, "-"):
+					fmt.Printf("      %s\n", red(line))
+				case strings.HasPrefix(line, "+"):
+					fmt.Printf("      %s\n", green(line))
+				default:
+					fmt.Printf("      %s\n", line)
+				}
+			}
+		case strings.Contains(change.New, "\n"):
+			// Long text set on creation
+			fmt.Printf("    %s:\n", change.Field)
+			for _, line := range strings.Split(change.New, "\n") {
+				fmt.Printf("      %s\n", line)
+			}
+		case entry.EventType == types.EventCreated:
+			fmt.Printf("    %s: %s\n", change.Field, historyValue(change.New))
+		default:
+			fmt.Printf("    %s: %s → %s\n", change.Field, historyValue(change.Old), historyValue(change.New))
+		}
+	}
+	fmt.Println()
+}
+
+// historyValue quotes a field value for display, marking empty values
+func historyValue(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	if strings.ContainsAny(s, " \t") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+func init() {
+	historyCmd.Flags().IntP("limit", "n", 0, "Show only the most recent N entries")
+	historyCmd.Flags().Bool("json", false, "Output JSON format")
+	rootCmd.AddCommand(historyCmd)
+}
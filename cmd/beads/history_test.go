@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/history"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestPrintHistoryEntry(t *testing.T) {
+	oldNoColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = oldNoColor }()
+
+	entry := &history.Entry{
+		EventType: types.EventUpdated,
+		Actor:     "alice",
+		Time:      time.Date(2025, 1, 31, 15, 4, 5, 0, time.Local),
+		Changes: []history.Change{
+			{Field: "status", Old: "open", New: "in_progress"},
+			{Field: "assignee", New: "bob"},
+			{Field: "title", Old: "Login", New: "Login on Safari"},
+			{Field: "description", Diff: "@@ -1 +1 @@\n-old\n+new\n"},
+			{Field: history.FieldLabel, New: "frontend"},
+			{Field: history.FieldDependency, Old: "blocks bd-9"},
+		},
+	}
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	printHistoryEntry(entry)
+	w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	for _, want := range []string{
+		"2025-01-31 15:04:05  updated  alice",
+		"    status: open → in_progress",
+		"    assignee: (none) → bob",
+		`    title: Login → "Login on Safari"`,
+		"    description:\n      @@ -1 +1 @@\n      -old\n      +new\n",
+		"    + label frontend",
+		"    - dependency blocks bd-9",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
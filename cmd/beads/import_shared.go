@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"time"
 
 	"github.com/shaneholloman/beads/internal/importer"
 	"github.com/shaneholloman/beads/internal/storage"
@@ -78,6 +79,20 @@ func (fc *fieldComparator) equalPtrStr(existing *string, newVal interface{}) boo
 	return *existing == s
 }
 
+// equalTimePtr compares optional time fields
+func (fc *fieldComparator) equalTimePtr(existing *time.Time, newVal interface{}) bool {
+	switch t := newVal.(type) {
+	case nil:
+		return existing == nil
+	case time.Time:
+		return existing != nil && existing.Equal(t)
+	case *time.Time:
+		return (existing == nil && t == nil) || (existing != nil && t != nil && existing.Equal(*t))
+	default:
+		return false // Unknown type means changed
+	}
+}
+
 // equalStatus compares Status field
 func (fc *fieldComparator) equalStatus(existing types.Status, newVal interface{}) bool {
 	switch t := newVal.(type) {
@@ -134,6 +149,12 @@ func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue,
 		return !fc.equalStr(existing.Assignee, newVal)
 	case "external_ref":
 		return !fc.equalPtrStr(existing.ExternalRef, newVal)
+	case "recurrence":
+		return !fc.equalStr(existing.Recurrence, newVal)
+	case "due_at":
+		return !fc.equalTimePtr(existing.DueAt, newVal)
+	case "recurred_at":
+		return !fc.equalTimePtr(existing.RecurredAt, newVal)
 	default:
 		// Unknown field - treat as changed to be conservative
 		// This prevents skipping updates when new fields are added
@@ -11,7 +11,7 @@ import (
 	"github.com/shaneholloman/beads"
 	"github.com/shaneholloman/beads/internal/config"
 	"github.com/shaneholloman/beads/internal/configfile"
-	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/storage/factory"
 	"github.com/spf13/cobra"
 )
 
@@ -162,7 +162,8 @@ beads.db
 			os.Exit(1)
 		}
 
-		store, err := sqlite.New(initDBPath)
+		// Honors a pre-existing config.json selecting the Postgres backend
+		store, err := factory.Open(initDBPath)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create database: %v\n", err)
 			os.Exit(1)
@@ -213,6 +214,11 @@ beads.db
 		// Create config.json for explicit configuration
 		if useLocalBeads {
 			cfg := configfile.DefaultConfig(Version)
+			// Keep the storage backend of an existing config (e.g. a shared Postgres database)
+			if existing, err := configfile.Load(localBeadsDir); err == nil && existing != nil {
+				cfg.Backend = existing.Backend
+				cfg.Postgres = existing.Postgres
+			}
 			if err := cfg.Save(localBeadsDir); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to create config.json: %v\n", err)
 				// Non-fatal - continue anyway
@@ -221,7 +227,7 @@ beads.db
 
 		// Check if git has existing issues to import (fresh clone scenario)
 		issueCount, jsonlPath := checkGitForIssues()
-		if issueCount > 0 {
+		if issueCount > 0 && !isSharedStore(store) {
 			if !quiet {
 				green := color.New(color.FgGreen).SprintFunc()
 				fmt.Fprintf(os.Stderr, "\n%s Database initialized. Found %d issues in git, importing...\n", green("✔"), issueCount)
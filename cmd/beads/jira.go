@@ -0,0 +1,335 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/shaneholloman/beads/internal/jira"
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/shaneholloman/beads/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// jiraMappingFile is the default field mapping file, relative to .beads/
+const jiraMappingFile = "jira.yaml"
+
+var jiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Import issues from and export issues to Jira",
+	Long: `Import issues from and export issues to Jira.
+
+Issues are linked to Jira by external_ref "jira-<KEY>", so importing and
+exporting repeatedly updates the same issues. Jira issue types, priorities and
+statuses map onto beads types, priorities and statuses, and Jira parent and
+epic links become parent-child dependencies.
+
+Connection settings:
+  beads config set jira.url https://company.atlassian.net
+  beads config set jira.project ABC
+  beads config set jira.email me@company.com     # Jira Cloud; omit for a Server/DC token
+  beads config set jira.api_token TOKEN          # or JIRA_API_TOKEN
+
+The mapping can be customized in .beads/` + jiraMappingFile + ` (or --mapping):
+
+  types:                        # beads type: Jira issue types (first is used on export)
+    feature: [Story, Improvement]
+  statuses:
+    closed: [Done, "Won't Do"]
+  priorities:
+    0: [Blocker, Highest]
+  fields:                       # Jira fields for links and extra text fields
+    epic_link: customfield_10014
+    acceptance_criteria: customfield_10020
+
+jira.type_map.<type> and jira.status_map.<status> config settings choose the
+Jira value used on export.`,
+}
+
+var jiraImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import issues from Jira",
+	Long: `Import issues matching a JQL query (default: all issues in jira.project).
+
+New Jira issues are created with IDs derived from their key; issues already
+linked are updated when the Jira issue changed more recently than the local one.
+
+Examples:
+  beads jira import
+  beads jira import --jql 'project = ABC AND sprint in openSprints()'
+  beads jira import --file search.json     # Saved /rest/api/2/search response
+  beads jira import --dry-run`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		jql, _ := cmd.Flags().GetString("jql")
+		file, _ := cmd.Flags().GetString("file")
+		mappingPath, _ := cmd.Flags().GetString("mapping")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		ctx := context.Background()
+
+		if err := ensureDirectMode("jira import requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		mapping, err := loadJiraMapping(ctx, store, mappingPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		var jiraIssues []*jira.Issue
+		if file != "" {
+			jiraIssues, err = readJiraIssues(file)
+		} else {
+			jiraIssues, err = searchJira(ctx, store, jql)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		prefix, err := store.GetConfig(ctx, "issue_prefix")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to get issue prefix: %v\n", err)
+			os.Exit(1)
+		}
+		issues, err := jira.ToBeads(ctx, store, prefix, jiraIssues, mapping)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		result, err := importIssuesCore(ctx, dbPath, store, issues, ImportOptions{DryRun: dryRun, Strict: true})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Import failed: %v\n", err)
+			os.Exit(1)
+		}
+		if !dryRun {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		verb := "Imported"
+		if dryRun {
+			verb = "Would import"
+		}
+		fmt.Printf("%s %d Jira issues: %d created, %d updated, %d unchanged\n",
+			verb, len(jiraIssues), result.Created, result.Updated, result.Unchanged)
+	},
+}
+
+var jiraExportCmd = &cobra.Command{
+	Use:   "export [issue-id...]",
+	Short: "Export issues to Jira",
+	Long: `Create or update Jira issues for beads issues.
+
+With no arguments, every issue already linked to Jira is updated. Named issues
+that are not linked yet are created in jira.project and linked. Status changes
+are applied through the Jira workflow's transitions.
+
+Examples:
+  beads jira export
+  beads jira export bd-a3f8 bd-f14c
+  beads jira export bd-a3f8 --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		mappingPath, _ := cmd.Flags().GetString("mapping")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		ctx := context.Background()
+
+		if err := ensureDirectMode("jira export requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		mapping, err := loadJiraMapping(ctx, store, mappingPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		client, project, err := newJiraClient(ctx, store)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		issues, err := jiraExportIssues(ctx, store, args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(issues) == 0 {
+			fmt.Println("No issues linked to Jira (name issues to export them)")
+			return
+		}
+
+		result, err := jira.Export(ctx, client, store, issues, mapping, jira.ExportOptions{
+			Project: project,
+			Actor:   actor,
+			DryRun:  dryRun,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !dryRun && len(result.Created) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		verb := ""
+		if dryRun {
+			verb = "would be "
+		}
+		for _, id := range result.Created {
+			key := result.Keys[id]
+			if key == "" {
+				key = project
+			}
+			fmt.Printf("  %s → %s (%screated)\n", id, key, verb)
+		}
+		for _, id := range result.Updated {
+			fmt.Printf("  %s → %s (%supdated)\n", id, result.Keys[id], verb)
+		}
+		for _, id := range result.Transitioned {
+			fmt.Printf("  %s → %s (%stransitioned)\n", id, result.Keys[id], verb)
+		}
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+		fmt.Printf("Exported %d issues: %d created, %d updated, %d transitioned, %d unchanged\n",
+			len(issues), len(result.Created), len(result.Updated), len(result.Transitioned), result.Unchanged)
+	},
+}
+
+// newJiraClient builds a client from the jira.* config settings, returning the
+// configured project key
+func newJiraClient(ctx context.Context, s storage.Storage) (*jira.Client, string, error) {
+	cfg, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read config: %w", err)
+	}
+	if cfg["jira.url"] == "" {
+		return nil, "", fmt.Errorf("jira.url is not configured (beads config set jira.url https://company.atlassian.net)")
+	}
+	token := os.Getenv("JIRA_API_TOKEN")
+	if token == "" {
+		token = cfg["jira.api_token"]
+	}
+	return jira.NewClient(cfg["jira.url"], cfg["jira.email"], token), cfg["jira.project"], nil
+}
+
+// searchJira runs a JQL query, defaulting to the configured project
+func searchJira(ctx context.Context, s storage.Storage, jql string) ([]*jira.Issue, error) {
+	client, project, err := newJiraClient(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if jql == "" {
+		if project == "" {
+			return nil, fmt.Errorf("no JQL query given and jira.project is not configured")
+		}
+		jql = fmt.Sprintf("project = %q ORDER BY key", project)
+	}
+	return client.Search(ctx, jql)
+}
+
+// readJiraIssues reads issues from a saved search response or a JSON array of issues
+func readJiraIssues(path string) ([]*jira.Issue, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - user-specified file
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var search struct {
+		Issues []*jira.Issue `json:"issues"`
+	}
+	if err := json.Unmarshal(data, &search); err == nil && search.Issues != nil {
+		return search.Issues, nil
+	}
+	var issues []*jira.Issue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: expected a Jira search response or an array of issues", path)
+	}
+	return issues, nil
+}
+
+// loadJiraMapping loads the mapping file, if any, and applies config overrides
+func loadJiraMapping(ctx context.Context, s storage.Storage, path string) (*jira.Mapping, error) {
+	mapping := jira.DefaultMapping()
+	if path == "" && dbPath != "" {
+		candidate := filepath.Join(filepath.Dir(dbPath), jiraMappingFile)
+		if _, err := os.Stat(candidate); err == nil {
+			path = candidate
+		}
+	}
+	if path != "" {
+		var err error
+		if mapping, err = jira.LoadMapping(path); err != nil {
+			return nil, err
+		}
+	}
+	cfg, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := mapping.ApplyConfig(cfg); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// jiraExportIssues returns the named issues, or every issue linked to Jira
+func jiraExportIssues(ctx context.Context, s storage.Storage, ids []string) ([]*types.Issue, error) {
+	if len(ids) > 0 {
+		issues := make([]*types.Issue, 0, len(ids))
+		for _, id := range ids {
+			fullID, err := utils.ResolvePartialID(ctx, s, id)
+			if err != nil {
+				return nil, err
+			}
+			issue, err := s.GetIssue(ctx, fullID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s: %w", fullID, err)
+			}
+			if issue == nil {
+				return nil, fmt.Errorf("issue not found: %s", id)
+			}
+			issues = append(issues, issue)
+		}
+		return issues, nil
+	}
+
+	all, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+	var linked []*types.Issue
+	for _, issue := range all {
+		if issue.ExternalRef != nil {
+			if _, ok := jira.ParseRef(*issue.ExternalRef); ok {
+				linked = append(linked, issue)
+			}
+		}
+	}
+	sort.Slice(linked, func(i, j int) bool { return linked[i].ID < linked[j].ID })
+	return linked, nil
+}
+
+func init() {
+	jiraCmd.PersistentFlags().String("mapping", "", "Field mapping file (default: .beads/"+jiraMappingFile+" if present)")
+	jiraCmd.PersistentFlags().Bool("dry-run", false, "Preview changes without writing anything")
+	jiraCmd.PersistentFlags().Bool("json", false, "Output JSON format")
+	jiraImportCmd.Flags().String("jql", "", "JQL query selecting issues (default: all issues in jira.project)")
+	jiraImportCmd.Flags().String("file", "", "Import from a saved Jira search response instead of the API")
+	jiraCmd.AddCommand(jiraImportCmd)
+	jiraCmd.AddCommand(jiraExportCmd)
+	rootCmd.AddCommand(jiraCmd)
+}
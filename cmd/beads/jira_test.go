@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestNewJiraClientConfig(t *testing.T) {
+	ctx := context.Background()
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	t.Setenv("JIRA_API_TOKEN", "")
+
+	if _, _, err := newJiraClient(ctx, store); err == nil {
+		t.Error("expected error without jira.url")
+	}
+
+	for key, value := range map[string]string{
+		"jira.url":       "https://acme.atlassian.net",
+		"jira.project":   "ABC",
+		"jira.email":     "me@acme.com",
+		"jira.api_token": "from-config",
+	} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+	client, project, err := newJiraClient(ctx, store)
+	if err != nil {
+		t.Fatalf("newJiraClient failed: %v", err)
+	}
+	if project != "ABC" || client.BaseURL != "https://acme.atlassian.net" || client.Email != "me@acme.com" || client.Token != "from-config" {
+		t.Errorf("client = %+v, project = %q", client, project)
+	}
+
+	t.Setenv("JIRA_API_TOKEN", "from-env")
+	if client, _, _ = newJiraClient(ctx, store); client.Token != "from-env" {
+		t.Errorf("token = %q, want from-env", client.Token)
+	}
+}
+
+func TestReadJiraIssues(t *testing.T) {
+	dir := t.TempDir()
+	search := filepath.Join(dir, "search.json")
+	array := filepath.Join(dir, "array.json")
+	bad := filepath.Join(dir, "bad.json")
+	files := map[string]string{
+		search: `{"startAt":0,"total":2,"issues":[{"key":"ABC-1","fields":{"summary":"One"}},{"key":"ABC-2","fields":{}}]}`,
+		array:  `[{"key":"ABC-3","fields":{"summary":"Three"}}]`,
+		bad:    `{"key":`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	issues, err := readJiraIssues(search)
+	if err != nil || len(issues) != 2 || issues[0].String("summary") != "One" {
+		t.Errorf("search response: %v, %v", issues, err)
+	}
+	issues, err = readJiraIssues(array)
+	if err != nil || len(issues) != 1 || issues[0].Key != "ABC-3" {
+		t.Errorf("array: %v, %v", issues, err)
+	}
+	if _, err := readJiraIssues(bad); err == nil {
+		t.Error("expected error for malformed file")
+	}
+}
+
+func TestLoadJiraMappingConfig(t *testing.T) {
+	ctx := context.Background()
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "jira.yaml")
+	if err := os.WriteFile(path, []byte("types:\n  chore: [Maintenance]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetConfig(ctx, "jira.status_map.closed", "Closed"); err != nil {
+		t.Fatal(err)
+	}
+	mapping, err := loadJiraMapping(ctx, store, path)
+	if err != nil {
+		t.Fatalf("loadJiraMapping failed: %v", err)
+	}
+	if got := mapping.ExportType(types.TypeChore); got != "Maintenance" {
+		t.Errorf("ExportType(chore) = %q, want Maintenance", got)
+	}
+	if got := mapping.ExportStatus(types.StatusClosed); got != "Closed" {
+		t.Errorf("ExportStatus(closed) = %q, want Closed", got)
+	}
+}
+
+func TestJiraExportIssues(t *testing.T) {
+	ctx := context.Background()
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	jiraRef, githubRef := "jira-ABC-1", "gh-4"
+	for _, issue := range []*types.Issue{
+		{ID: "beads-1", Title: "Linked", ExternalRef: &jiraRef},
+		{ID: "beads-2", Title: "GitHub", ExternalRef: &githubRef},
+		{ID: "beads-3", Title: "Local"},
+	} {
+		issue.Status = types.StatusOpen
+		issue.Priority = 2
+		issue.IssueType = types.TypeTask
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	linked, err := jiraExportIssues(ctx, store, nil)
+	if err != nil {
+		t.Fatalf("jiraExportIssues failed: %v", err)
+	}
+	if len(linked) != 1 || linked[0].ID != "beads-1" {
+		t.Errorf("linked issues = %v, want [beads-1]", linked)
+	}
+	named, err := jiraExportIssues(ctx, store, []string{"beads-3"})
+	if err != nil || len(named) != 1 || named[0].ID != "beads-3" {
+		t.Errorf("named issues = %v, %v", named, err)
+	}
+	if _, err := jiraExportIssues(ctx, store, []string{"beads-99"}); err == nil {
+		t.Error("expected error for unknown issue")
+	}
+}
@@ -6,11 +6,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"text/template"
+	"time"
 
+	"github.com/shaneholloman/beads/internal/config"
+	"github.com/shaneholloman/beads/internal/history"
+	"github.com/shaneholloman/beads/internal/query"
 	"github.com/shaneholloman/beads/internal/rpc"
 	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/storage/memory"
 	"github.com/shaneholloman/beads/internal/types"
 	"github.com/spf13/cobra"
 )
@@ -34,8 +40,37 @@ func normalizeLabels(ss []string) []string {
 }
 
 var listCmd = &cobra.Command{
-	Use:   "list",
+	Use:   "list [filter]",
 	Short: "List issues",
+	Long: `List issues, optionally narrowed by flags and a filter expression.
+
+Filter syntax (terms are ANDed; quote the whole filter for your shell):
+  status:open              field equals value
+  type:(bug|task)          any of several values
+  priority<=1              comparisons on priority and created/updated/closed
+  updated>7d               updated within the last 7 days (units: m, h, d, w)
+  created<2025-01-31       created before a date (or RFC 3339 timestamp)
+  due<+3d, due<now         due within 3 days, past due (+ counts forwards)
+  assignee:none            unassigned (also label:none, closed:none, due:none)
+  -label:wontfix           negation (also NOT)
+  a OR b, (a b) OR c       alternatives and grouping
+  title:login, login       text contains (bare words: title, description or ID)
+
+Fields: ` + strings.Join(query.Fields(), ", ") + `
+
+Saved views are named filters in the "views" section of .beads/config.yaml:
+  views:
+    triage: "status:open priority<=1 assignee:none"
+
+--as-of replays the audit trail to list issues as they were at a past time
+(a date, RFC 3339 timestamp, relative age like 7d, or git revision). Relative
+filter times then count back from that moment.
+
+Examples:
+  beads list 'status:open priority<=1 label:backend -label:wontfix'
+  beads list 'type:(bug|task) updated>7d assignee:none'
+  beads list --view triage 'label:frontend'
+  beads list --as-of 2025-01-31 status:open`,
 	Run: func(cmd *cobra.Command, args []string) {
 		status, _ := cmd.Flags().GetString("status")
 		assignee, _ := cmd.Flags().GetString("assignee")
@@ -46,14 +81,36 @@ var listCmd = &cobra.Command{
 		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
 		titleSearch, _ := cmd.Flags().GetString("title")
 		idFilter, _ := cmd.Flags().GetString("id")
+		viewName, _ := cmd.Flags().GetString("view")
+		asOf, _ := cmd.Flags().GetString("as-of")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
 
+		now := time.Now()
+		if asOf != "" {
+			at, err := resolveTimeTravel(asOf, now)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --as-of: %v\n", err)
+				os.Exit(1)
+			}
+			now = at
+		}
+
+		expr, err := parseListFilter(viewName, args, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if overdue, _ := cmd.Flags().GetBool("overdue"); overdue {
+			expr = query.Combine(expr, overdueFilter(now))
+		}
+
 		// Normalize labels: trim, dedupe, remove empty
 		labels = normalizeLabels(labels)
 		labelsAny = normalizeLabels(labelsAny)
 
 		filter := types.IssueFilter{
 			Limit: limit,
+			Expr:  expr,
 		}
 		if status != "" && status != "all" {
 			s := types.Status(status)
@@ -87,6 +144,14 @@ var listCmd = &cobra.Command{
 			}
 		}
 
+		if asOf != "" {
+			// The daemon has no time-travel support; replay locally
+			if err := ensureDirectMode("daemon does not support --as-of"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			listArgs := &rpc.ListArgs{
@@ -112,6 +177,10 @@ var listCmd = &cobra.Command{
 			if len(filter.IDs) > 0 {
 				listArgs.IDs = filter.IDs
 			}
+			// Send the parsed form so relative times resolve here, not in the daemon
+			if expr != nil {
+				listArgs.Filter = expr.String()
+			}
 
 			resp, err := daemonClient.List(listArgs)
 			if err != nil {
@@ -146,14 +215,25 @@ var listCmd = &cobra.Command{
 
 		// Direct mode
 		ctx := context.Background()
-		issues, err := store.SearchIssues(ctx, "", filter)
+		source := storage.Storage(store)
+		if asOf != "" {
+			snapshot, err := loadSnapshotAt(ctx, store, now)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = snapshot.Close() }()
+			source = snapshot
+		}
+
+		issues, err := source.SearchIssues(ctx, "", filter)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
 		// If no issues found, check if git has issues and auto-import
-		if len(issues) == 0 {
+		if len(issues) == 0 && asOf == "" {
 			if checkAndAutoImport(ctx, store) {
 				// Re-run the query after import
 				issues, err = store.SearchIssues(ctx, "", filter)
@@ -166,7 +246,7 @@ var listCmd = &cobra.Command{
 
 		// Handle format flag
 		if formatStr != "" {
-			if err := outputFormattedList(ctx, store, issues, formatStr); err != nil {
+			if err := outputFormattedList(ctx, source, issues, formatStr); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
@@ -176,16 +256,19 @@ var listCmd = &cobra.Command{
 		if jsonOutput {
 			// Populate labels for JSON output
 			for _, issue := range issues {
-				issue.Labels, _ = store.GetLabels(ctx, issue.ID)
+				issue.Labels, _ = source.GetLabels(ctx, issue.ID)
 			}
 			outputJSON(issues)
 			return
 		}
 
+		if asOf != "" {
+			fmt.Printf("\nAs of %s:\n", now.Local().Format("2006-01-02 15:04:05 MST"))
+		}
 		fmt.Printf("\nFound %d issues:\n\n", len(issues))
 		for _, issue := range issues {
 			// Load labels for display
-			labels, _ := store.GetLabels(ctx, issue.ID)
+			labels, _ := source.GetLabels(ctx, issue.ID)
 
 			fmt.Printf("%s [P%d] [%s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Status)
 			fmt.Printf("  %s\n", issue.Title)
@@ -209,6 +292,9 @@ func init() {
 	listCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
 	listCmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
 	listCmd.Flags().String("id", "", "Filter by specific issue IDs (comma-separated, e.g., beads-1,beads-5,beads-10)")
+	listCmd.Flags().String("view", "", "Apply a saved view (named filter) from the views section of config.yaml")
+	listCmd.Flags().String("as-of", "", "List issues as they were at a past time or git revision (e.g. 2025-01-31, 7d, HEAD~5)")
+	listCmd.Flags().Bool("overdue", false, "Only issues past their due date that are not done")
 	listCmd.Flags().IntP("limit", "n", 0, "Limit results")
 	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
 	listCmd.Flags().Bool("all", false, "Show all issues (default behavior; flag provided for CLI familiarity)")
@@ -216,6 +302,53 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 }
 
+// parseListFilter parses the filter arguments and ANDs them with the named saved view, if any.
+// Relative times count back from now.
+func parseListFilter(viewName string, args []string, now time.Time) (query.Expr, error) {
+	var viewExpr query.Expr
+	if viewName != "" {
+		views, err := config.LoadViews()
+		if err != nil {
+			return nil, fmt.Errorf("invalid views in config.yaml: %w", err)
+		}
+		text, ok := views[strings.ToLower(viewName)]
+		if !ok {
+			if len(views) == 0 {
+				return nil, fmt.Errorf("unknown view %q (no views are defined in .beads/config.yaml)", viewName)
+			}
+			names := make([]string, 0, len(views))
+			for name := range views {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown view %q (saved views: %s)", viewName, strings.Join(names, ", "))
+		}
+		if viewExpr, err = query.ParseAt(text, now); err != nil {
+			return nil, fmt.Errorf("invalid view %q: %w", viewName, err)
+		}
+	}
+
+	argExpr, err := query.ParseAt(strings.Join(args, " "), now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	return query.Combine(viewExpr, argExpr), nil
+}
+
+// loadSnapshotAt replays the audit trail into an in-memory store holding the
+// issues, labels and dependencies as they were at the given time
+func loadSnapshotAt(ctx context.Context, store storage.Storage, at time.Time) (*memory.MemoryStorage, error) {
+	issues, err := history.IssuesAt(ctx, store, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct issues: %w", err)
+	}
+	snapshot := memory.New("")
+	if err := snapshot.LoadFromIssues(issues); err != nil {
+		return nil, fmt.Errorf("failed to load snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
 // outputDotFormat outputs issues in Graphviz DOT format
 func outputDotFormat(ctx context.Context, store storage.Storage, issues []*types.Issue) error {
 	fmt.Println("digraph dependencies {")
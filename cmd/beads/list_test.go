@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/shaneholloman/beads/internal/config"
 	"github.com/shaneholloman/beads/internal/storage/sqlite"
 	"github.com/shaneholloman/beads/internal/types"
 )
@@ -175,3 +176,52 @@ func TestListCommand(t *testing.T) {
 		}
 	})
 }
+
+func TestParseListFilter(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("Failed to create .beads: %v", err)
+	}
+	configYAML := "views:\n  triage: \"status:open OR status:blocked\"\n"
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(configYAML), 0600); err != nil {
+		t.Fatalf("Failed to write config.yaml: %v", err)
+	}
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("Failed to chdir: %v", err)
+	}
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize config: %v", err)
+	}
+
+	expr, err := parseListFilter("", nil, time.Now())
+	if err != nil || expr != nil {
+		t.Errorf("Expected no filter, got %v (err %v)", expr, err)
+	}
+
+	expr, err = parseListFilter("", []string{"priority<=1", "label:backend"}, time.Now())
+	if err != nil {
+		t.Fatalf("parseListFilter failed: %v", err)
+	}
+	if got := expr.String(); got != "priority<=1 label:backend" {
+		t.Errorf("Expected args to be joined, got %q", got)
+	}
+
+	// The view keeps its own grouping when ANDed with the arguments
+	expr, err = parseListFilter("Triage", []string{"label:backend"}, time.Now())
+	if err != nil {
+		t.Fatalf("parseListFilter failed: %v", err)
+	}
+	if got := expr.String(); got != "(status:open OR status:blocked) label:backend" {
+		t.Errorf("Unexpected combined filter %q", got)
+	}
+
+	if _, err := parseListFilter("missing", nil, time.Now()); err == nil || !strings.Contains(err.Error(), "saved views: triage") {
+		t.Errorf("Expected unknown view error listing views, got %v", err)
+	}
+	if _, err := parseListFilter("", []string{"colour:red"}, time.Now()); err == nil || !strings.Contains(err.Error(), "invalid filter") {
+		t.Errorf("Expected invalid filter error, got %v", err)
+	}
+}
@@ -13,8 +13,9 @@ import (
 	"github.com/shaneholloman/beads/internal/config"
 	"github.com/shaneholloman/beads/internal/rpc"
 	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/storage/factory"
 	"github.com/shaneholloman/beads/internal/storage/memory"
-	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
 	"github.com/spf13/cobra"
 )
 
@@ -84,6 +85,14 @@ var rootCmd = &cobra.Command{
 	Short: "beads - Dependency-aware issue tracker",
 	Long:  `Issues chained together like beads. A lightweight issue tracker with first-class dependency support.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Install custom workflow statuses from config.yaml before any storage is opened
+		if workflow, err := config.LoadWorkflow(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid workflow in config.yaml: %v\n", err)
+			os.Exit(1)
+		} else {
+			types.SetWorkflow(workflow)
+		}
+
 		// Apply viper configuration if flags weren't explicitly set
 		// Priority: flags > viper (config file + env vars) > defaults
 		// Do this BEFORE early-return so init/version/help respect config
@@ -112,7 +121,7 @@ var rootCmd = &cobra.Command{
 		}
 
 		// Skip database initialization for commands that don't need a database
-		noDbCommands := []string{"init", cmdDaemon, "help", "version", "quickstart", "doctor"}
+		noDbCommands := []string{"init", cmdDaemon, "help", "version", "quickstart", "doctor", "merge-driver"}
 		if slices.Contains(noDbCommands, cmd.Name()) {
 			return
 		}
@@ -152,6 +161,13 @@ var rootCmd = &cobra.Command{
 			return
 		}
 
+		// A remote daemon (BEADS_DAEMON_URL) serves its own database, so there
+		// is nothing to discover locally and no direct mode to fall back to
+		if !noDaemon && os.Getenv(rpc.EnvDaemonURL) != "" {
+			connectRemoteDaemon()
+			return
+		}
+
 		// Initialize database path
 		if dbPath == "" {
 			cwd, err := os.Getwd()
@@ -406,12 +422,17 @@ var rootCmd = &cobra.Command{
 
 		// Fall back to direct storage access
 		var err error
-		store, err = sqlite.New(dbPath)
+		store, err = factory.Open(dbPath)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 			os.Exit(1)
 		}
 
+		// A shared Postgres database is the source of truth; JSONL is export-only
+		if isSharedStore(store) {
+			autoImportEnabled = false
+		}
+
 		// Mark store as active for flush goroutine safety
 		storeMutex.Lock()
 		storeActive = true
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/shaneholloman/beads/internal/config"
+	"github.com/shaneholloman/beads/internal/mcp"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Run a Model Context Protocol server over stdio",
+	Long: `Run a Model Context Protocol (MCP) server on stdin/stdout so AI agents can
+use beads as tools, without the Python mcp-beads package.
+
+Tools: ready, claim, heartbeat, list, show, create, update, close, dep_add and
+comment_add. Each runs the daemon operation of the same name, with an input
+schema generated from its arguments. Requests go to the daemon when one is
+running (or BEADS_DAEMON_URL is set), otherwise straight to the database.
+
+Configure your MCP client to run it from the project directory, e.g.:
+
+  {"mcpServers": {"beads": {"command": "beads", "args": ["mcp"]}}}`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		var exec mcp.Executor
+		onMutation := func() {}
+		if daemonClient != nil {
+			exec = &daemonExecutor{}
+		} else {
+			// Run the daemon's handlers in-process against the local store
+			rpc.ServerVersion = Version
+			local := rpc.NewServer("", store, filepath.Dir(filepath.Dir(dbPath)), dbPath)
+			if repos, err := config.LoadRepos(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: repository registry not loaded: %v\n", err)
+			} else {
+				local.SetRepos(repos)
+			}
+			if ttl, err := config.LoadLeaseTTL(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v, using %v\n", err, types.DefaultLeaseTTL)
+			} else {
+				local.SetLeaseTTL(ttl)
+			}
+			exec = local
+			onMutation = markDirtyAndScheduleFlush
+		}
+
+		server := mcp.New(exec, Version, actor)
+		server.OnMutation = onMutation
+		if err := server.Serve(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// daemonExecutor sends MCP tool calls to the daemon. An MCP server idles
+// between calls for longer than the daemon keeps quiet socket connections
+// open, so it reconnects once when the connection has gone away.
+type daemonExecutor struct{}
+
+func (e *daemonExecutor) Execute(operation string, args interface{}) (*rpc.Response, error) {
+	resp, err := daemonClient.Execute(operation, args)
+	// A response means the daemon answered; only transport errors get a retry
+	if err == nil || resp != nil || daemonClient.IsRemote() {
+		return resp, err
+	}
+	client, connErr := rpc.TryConnect(daemonStatus.SocketPath)
+	if connErr != nil || client == nil {
+		return nil, err
+	}
+	if dbPath != "" {
+		absDBPath, _ := filepath.Abs(dbPath)
+		client.SetDatabasePath(absDBPath)
+	}
+	_ = daemonClient.Close()
+	daemonClient = client
+	return daemonClient.Execute(operation, args)
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/merge"
+	"github.com/spf13/cobra"
+)
+
+// mergeDriverAttributes routes the beads JSONL files through the merge driver
+const mergeDriverAttributes = ".beads/*.jsonl merge=beads"
+
+var mergeDriverCmd = &cobra.Command{
+	Use:   "merge-driver <base> <ours> <theirs>",
+	Short: "Git merge driver for beads JSONL files",
+	Long: `Three-way merge of .beads JSONL files, for use as a git merge driver.
+
+Issues are merged by ID and field by field:
+  - a field changed on one branch takes that branch's value
+  - a field changed on both branches takes the value with the later updated_at,
+    and the discarded value is recorded as a comment on the issue
+  - labels, dependencies and comments are the union of both branches, so a
+    removal on one branch does not carry over
+  - an issue deleted on one branch and modified on the other is kept, with a comment
+
+The result is written to <ours>, as git expects. Register the driver with:
+
+  beads merge-driver --install
+
+which is equivalent to:
+
+  git config merge.beads.name "beads JSONL merge driver"
+  git config merge.beads.driver "beads merge-driver %O %A %B"
+  echo '` + mergeDriverAttributes + `' >> .gitattributes`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if install, _ := cmd.Flags().GetBool("install"); install {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(3)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if install, _ := cmd.Flags().GetBool("install"); install {
+			if err := installMergeDriver(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("Installed beads merge driver for " + strings.Fields(mergeDriverAttributes)[0])
+			return
+		}
+
+		conflicts, err := runMergeDriver(args[0], args[1], args[2], time.Now())
+		if err != nil {
+			// A non-zero exit makes git report a conflict and keep our version
+			fmt.Fprintf(os.Stderr, "beads merge-driver: %v\n", err)
+			os.Exit(1)
+		}
+		for _, c := range conflicts {
+			fmt.Fprintf(os.Stderr, "beads merge-driver: %s: %s\n", c.IssueID, c.Message)
+		}
+	},
+}
+
+// runMergeDriver merges the base, ours and theirs files into oursPath
+func runMergeDriver(basePath, oursPath, theirsPath string, now time.Time) ([]merge.Conflict, error) {
+	var sides [3][]byte
+	for i, path := range []string{basePath, oursPath, theirsPath} {
+		data, err := os.ReadFile(path) // #nosec G304 - paths come from git
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		sides[i] = data
+	}
+	base, err := merge.ParseJSONL(sides[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base version: %w", err)
+	}
+	ours, err := merge.ParseJSONL(sides[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse our version: %w", err)
+	}
+	theirs, err := merge.ParseJSONL(sides[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse their version: %w", err)
+	}
+
+	result, err := merge.Merge(base, ours, theirs, now)
+	if err != nil {
+		return nil, err
+	}
+	data, err := merge.WriteJSONL(result.Issues)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write atomically so an interrupted merge leaves our version intact
+	tmp, err := os.CreateTemp(filepath.Dir(oursPath), filepath.Base(oursPath)+".tmp.*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return nil, fmt.Errorf("failed to write merged file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write merged file: %w", err)
+	}
+	if err := os.Rename(tmpPath, oursPath); err != nil {
+		return nil, fmt.Errorf("failed to replace %s: %w", oursPath, err)
+	}
+	return result.Conflicts, nil
+}
+
+// installMergeDriver registers the driver in the repository's git config and
+// routes the JSONL files through it in .gitattributes
+func installMergeDriver() error {
+	root := findGitRoot()
+	if root == "" {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	for _, kv := range [][2]string{
+		{"merge.beads.name", "beads JSONL merge driver"},
+		{"merge.beads.driver", "beads merge-driver %O %A %B"},
+	} {
+		cmd := exec.Command("git", "config", kv[0], kv[1]) // #nosec G204 - fixed arguments
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to set %s: %w\n%s", kv[0], err, out)
+		}
+	}
+
+	attrPath := filepath.Join(root, ".gitattributes")
+	existing, err := os.ReadFile(attrPath) // #nosec G304 - path within the repository
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .gitattributes: %w", err)
+	}
+	for _, line := range strings.Split(string(existing), "\n") {
+		if strings.TrimSpace(line) == mergeDriverAttributes {
+			return nil
+		}
+	}
+	content := string(existing)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += mergeDriverAttributes + "\n"
+	// #nosec G306 - .gitattributes is committed and world-readable
+	if err := os.WriteFile(attrPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to update .gitattributes: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	mergeDriverCmd.Flags().Bool("install", false, "Register the merge driver in git config and .gitattributes")
+	rootCmd.AddCommand(mergeDriverCmd)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/merge"
+)
+
+func TestRunMergeDriver(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	line := func(status string, priority int, updated string) string {
+		return fmt.Sprintf(`{"id":"bd-1","title":"Login","description":"","status":%q,"priority":%d,"issue_type":"bug","created_at":"2025-03-01T09:00:00Z","updated_at":%q}`+"\n",
+			status, priority, updated)
+	}
+	base := write("base", line("open", 2, "2025-03-01T09:00:00Z"))
+	ours := write("ours", line("in_progress", 2, "2025-03-01T10:00:00Z"))
+	theirs := write("theirs", line("open", 0, "2025-03-01T11:00:00Z")+
+		`{"id":"bd-2","title":"Added on their branch","description":"","status":"open","priority":2,"issue_type":"task","created_at":"2025-03-01T11:00:00Z","updated_at":"2025-03-01T11:00:00Z"}`+"\n")
+
+	conflicts, err := runMergeDriver(base, ours, theirs, time.Now())
+	if err != nil {
+		t.Fatalf("runMergeDriver failed: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("unexpected conflicts: %+v", conflicts)
+	}
+
+	data, err := os.ReadFile(ours)
+	if err != nil {
+		t.Fatalf("failed to read result: %v", err)
+	}
+	issues, err := merge.ParseJSONL(data)
+	if err != nil {
+		t.Fatalf("result is not valid JSONL: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Status != "in_progress" || issues[0].Priority != 0 || issues[1].ID != "bd-2" {
+		t.Errorf("unexpected merge result:\n%s", data)
+	}
+
+	// Unparseable input fails without touching our version
+	bad := write("bad", "<<<<<<< HEAD\n")
+	if _, err := runMergeDriver(base, ours, bad, time.Now()); err == nil {
+		t.Error("expected error for invalid JSONL")
+	}
+	if after, _ := os.ReadFile(ours); string(after) != string(data) {
+		t.Error("our version changed after a failed merge")
+	}
+}
+
+func TestInstallMergeDriver(t *testing.T) {
+	dir := t.TempDir()
+	initTestGitRepo(t, dir)
+	if err := os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.png binary"), 0600); err != nil {
+		t.Fatalf("failed to write .gitattributes: %v", err)
+	}
+	t.Chdir(dir)
+
+	// Installing twice must not duplicate the attributes line
+	for i := 0; i < 2; i++ {
+		if err := installMergeDriver(); err != nil {
+			t.Fatalf("installMergeDriver failed: %v", err)
+		}
+	}
+
+	attrs, err := os.ReadFile(filepath.Join(dir, ".gitattributes"))
+	if err != nil {
+		t.Fatalf("failed to read .gitattributes: %v", err)
+	}
+	if want := "*.png binary\n" + mergeDriverAttributes + "\n"; string(attrs) != want {
+		t.Errorf(".gitattributes = %q, want %q", attrs, want)
+	}
+
+	out, err := exec.Command("git", "config", "merge.beads.driver").Output()
+	if err != nil {
+		t.Fatalf("git config failed: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "beads merge-driver %O %A %B" {
+		t.Errorf("merge.beads.driver = %q", got)
+	}
+
+	// The attribute applies to the JSONL files
+	out, err = exec.Command("git", "check-attr", "merge", ".beads/issues.jsonl").Output()
+	if err != nil {
+		t.Fatalf("git check-attr failed: %v", err)
+	}
+	if !strings.Contains(string(out), "merge: beads") {
+		t.Errorf("check-attr = %q", out)
+	}
+}
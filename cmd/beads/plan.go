@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/plan"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/shaneholloman/beads/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan <epic-id>",
+	Short: "Estimate the schedule and critical path of an epic",
+	Long: `Schedule the issues under an epic from their estimates (--estimate on create
+and update) and the dependencies between them.
+
+An issue can start once the issues that block it are done, and a parent is done
+once all of its children are. The critical path is the longest chain of work
+through these dependencies: no number of workers finishes the epic sooner.
+Slack is how long an issue can slip without delaying the epic. The earliest
+finish assumes --workers people start the issues with the least slack first.
+
+Closed issues take no time, and issues without an estimate count as 0 and are
+listed so they can be estimated. Only issues under the epic are scheduled;
+blockers elsewhere are not.
+
+Examples:
+  beads plan bd-a3f8
+  beads plan bd-a3f8 --workers 3
+  beads plan bd-a3f8 --format mermaid
+  beads plan bd-a3f8 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		workers, _ := cmd.Flags().GetInt("workers")
+		formatStr, _ := cmd.Flags().GetString("format")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		if workers < 1 {
+			fmt.Fprintf(os.Stderr, "Error: --workers must be at least 1\n")
+			os.Exit(1)
+		}
+		if formatStr != "" && formatStr != "mermaid" {
+			fmt.Fprintf(os.Stderr, "Error: unknown format %q (supported: mermaid)\n", formatStr)
+			os.Exit(1)
+		}
+
+		if daemonClient != nil {
+			if err := ensureDirectMode("daemon does not support plan command"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+		} else if store == nil {
+			if err := ensureStoreActive(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		ctx := context.Background()
+		epicID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		p, err := plan.Build(ctx, store, epicID, workers)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if formatStr == "mermaid" {
+			outputMermaidGantt(p, time.Now().Truncate(time.Minute))
+			return
+		}
+
+		if jsonOutput {
+			outputJSON(p)
+			return
+		}
+
+		outputPlan(p)
+	},
+}
+
+// outputPlan prints a plan's summary and schedule
+func outputPlan(p *plan.Plan) {
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("\nPlan for %s: %s\n\n", cyan(p.EpicID), p.EpicTitle)
+
+	if len(p.Tasks) == 0 {
+		fmt.Printf("No issues under %s (add some with 'beads dep add <id> %s --type parent-child')\n\n", p.EpicID, p.EpicID)
+		return
+	}
+
+	done := 0
+	for _, task := range p.Tasks {
+		if task.Status.Category() == types.CategoryDone {
+			done++
+		}
+	}
+	fmt.Printf("  Issues: %d (%d done, %d unestimated)\n", len(p.Tasks), done, len(p.Unestimated))
+	fmt.Printf("  Effort: %s total, %s remaining\n", formatMinutes(p.TotalMinutes), formatMinutes(p.RemainingMinutes))
+	if len(p.CriticalPath) > 0 {
+		fmt.Printf("  Critical path: %s (%s)\n", formatMinutes(p.CriticalMinutes), strings.Join(p.CriticalPath, " → "))
+	} else {
+		fmt.Printf("  Critical path: %s\n", formatMinutes(p.CriticalMinutes))
+	}
+	workers := "worker"
+	if p.Workers != 1 {
+		workers = "workers"
+	}
+	fmt.Printf("  Earliest finish: %s with %d %s\n\n", formatMinutes(p.FinishMinutes), p.Workers, workers)
+
+	red := color.New(color.FgRed).SprintFunc()
+	fmt.Printf("  %-8s %-8s %-8s %s\n", "START", "FINISH", "SLACK", "ISSUE")
+	for _, task := range p.Tasks {
+		marker := " "
+		if task.Critical {
+			marker = red("*")
+		}
+		estimate := ""
+		if task.EstimatedMinutes == nil && task.Status.Category() != types.CategoryDone {
+			estimate = " (no estimate)"
+		}
+		fmt.Printf("  %-8s %-8s %-8s %s %s: %s [%s]%s\n",
+			formatMinutes(task.Start), formatMinutes(task.Finish), formatMinutes(task.Slack),
+			marker, task.ID, task.Title, task.Status, estimate)
+	}
+	fmt.Printf("\n  %s on the critical path; times are hours of work from now\n\n", red("*"))
+}
+
+// outputMermaidGantt outputs a plan's schedule as a Mermaid.js Gantt chart
+// starting at start, with one section per worker. Done issues are left out,
+// and issues without remaining work are shown as milestones.
+func outputMermaidGantt(p *plan.Plan, start time.Time) {
+	const dateFormat = "2006-01-02 15:04"
+	label := strings.NewReplacer(":", " -", "#", "", ";", ",", "\n", " ")
+
+	fmt.Println("gantt")
+	fmt.Printf("  title %s\n", label.Replace(fmt.Sprintf("Plan for %s: %s", p.EpicID, p.EpicTitle)))
+	fmt.Println("  dateFormat YYYY-MM-DD HH:mm")
+	fmt.Printf("  axisFormat %s\n", "%m-%d %H:%M")
+
+	sections := make([][]*plan.Task, p.Workers+1) // Index 0: milestones
+	for _, task := range p.Tasks {
+		if task.Status.Category() == types.CategoryDone {
+			continue
+		}
+		sections[task.Worker] = append(sections[task.Worker], task)
+	}
+
+	for worker, tasks := range sections {
+		if len(tasks) == 0 {
+			continue
+		}
+		if worker == 0 {
+			fmt.Println("  section Unestimated")
+		} else {
+			fmt.Printf("  section Worker %d\n", worker)
+		}
+		for _, task := range tasks {
+			var tags []string
+			if task.Critical {
+				tags = append(tags, "crit")
+			}
+			if task.Status == types.StatusInProgress {
+				tags = append(tags, "active")
+			}
+			if task.RemainingMinutes == 0 {
+				tags = append(tags, "milestone")
+			}
+			tags = append(tags, mermaidID(task.ID))
+			from := start.Add(time.Duration(task.Start) * time.Minute)
+			to := start.Add(time.Duration(task.Finish) * time.Minute)
+			fmt.Printf("  %s :%s, %s, %s\n", label.Replace(task.ID+" "+task.Title),
+				strings.Join(tags, ", "), from.Format(dateFormat), to.Format(dateFormat))
+		}
+	}
+}
+
+// mermaidID makes an issue ID usable as a Mermaid task ID
+func mermaidID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// formatMinutes formats minutes of work as hours and minutes, e.g. 2h30m
+func formatMinutes(m int) string {
+	if m < 60 {
+		return fmt.Sprintf("%dm", m)
+	}
+	if m%60 == 0 {
+		return fmt.Sprintf("%dh", m/60)
+	}
+	return fmt.Sprintf("%dh%dm", m/60, m%60)
+}
+
+func init() {
+	planCmd.Flags().IntP("workers", "w", 1, "Number of people or agents working in parallel")
+	planCmd.Flags().String("format", "", "Output format: 'mermaid' for a Mermaid.js Gantt chart")
+	planCmd.Flags().Bool("json", false, "Output JSON format")
+	rootCmd.AddCommand(planCmd)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/plan"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestFormatMinutes(t *testing.T) {
+	tests := map[int]string{0: "0m", 45: "45m", 60: "1h", 150: "2h30m", 1500: "25h"}
+	for minutes, want := range tests {
+		if got := formatMinutes(minutes); got != want {
+			t.Errorf("formatMinutes(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
+
+func TestOutputMermaidGantt(t *testing.T) {
+	p := &plan.Plan{
+		EpicID:    "beads-1",
+		EpicTitle: "Launch: v2",
+		Workers:   2,
+		Tasks: []*plan.Task{
+			{ID: "beads-2", Title: "Done", Status: types.StatusClosed},
+			{ID: "beads-3", Title: "API", Status: types.StatusInProgress, RemainingMinutes: 120, Finish: 120, Worker: 1, Critical: true},
+			{ID: "beads-4", Title: "Docs", Status: types.StatusOpen, RemainingMinutes: 30, Start: 15, Finish: 45, Worker: 2},
+			{ID: "beads-5", Title: "Polish", Status: types.StatusOpen},
+		},
+	}
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	outputMermaidGantt(p, time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC))
+	w.Close()
+	os.Stdout = old
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	output := buf.String()
+
+	for _, line := range []string{
+		"gantt",
+		"  title Plan for beads-1 - Launch - v2",
+		"  section Worker 1",
+		"  beads-3 API :crit, active, beads_3, 2025-01-02 09:00, 2025-01-02 11:00",
+		"  section Worker 2",
+		"  beads-4 Docs :beads_4, 2025-01-02 09:15, 2025-01-02 09:45",
+		"  section Unestimated",
+		"  beads-5 Polish :milestone, beads_5, 2025-01-02 09:00, 2025-01-02 09:00",
+	} {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("expected output to contain %q, got:\n%s", line, output)
+		}
+	}
+	if strings.Contains(output, "beads-2") {
+		t.Errorf("expected done issues to be left out, got:\n%s", output)
+	}
+}
@@ -5,8 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/shaneholloman/beads"
+	"github.com/shaneholloman/beads/internal/config"
+	"github.com/shaneholloman/beads/internal/federation"
 	"github.com/shaneholloman/beads/internal/rpc"
 	"github.com/shaneholloman/beads/internal/storage/sqlite"
 	"github.com/shaneholloman/beads/internal/types"
@@ -21,6 +26,7 @@ var readyCmd = &cobra.Command{
 		assignee, _ := cmd.Flags().GetString("assignee")
 		sortPolicy, _ := cmd.Flags().GetString("sort")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		allRepos, _ := cmd.Flags().GetBool("all-repos")
 
 		filter := types.WorkFilter{
 			// Leave Status empty to get both 'open' and 'in_progress' (beads-165)
@@ -38,10 +44,16 @@ var readyCmd = &cobra.Command{
 
 		// Validate sort policy
 		if !filter.SortPolicy.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: invalid sort policy '%s'. Valid values: hybrid, priority, oldest\n", sortPolicy)
+			fmt.Fprintf(os.Stderr, "Error: invalid sort policy '%s'. Valid values: hybrid, priority, oldest, deadline\n", sortPolicy)
 			os.Exit(1)
 		}
 
+		// Other repositories' databases are read directly, daemon or not
+		if allRepos {
+			runReadyAllRepos(filter, jsonOutput)
+			return
+		}
+
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			readyArgs := &rpc.ReadyArgs{
@@ -99,7 +111,7 @@ var readyCmd = &cobra.Command{
 
 		// Direct mode
 		ctx := context.Background()
-		issues, err := store.GetReadyWork(ctx, filter)
+		issues, err := getReadyWork(ctx, filter)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
@@ -109,7 +121,7 @@ var readyCmd = &cobra.Command{
 		if len(issues) == 0 {
 			if checkAndAutoImport(ctx, store) {
 				// Re-run the query after import
-				issues, err = store.GetReadyWork(ctx, filter)
+				issues, err = getReadyWork(ctx, filter)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 					os.Exit(1)
@@ -149,6 +161,93 @@ var readyCmd = &cobra.Command{
 	},
 }
 
+// getReadyWork returns the store's ready work without issues blocked by open
+// issues in other repositories (see 'repos' in config.yaml)
+func getReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	repos, err := config.LoadRepos()
+	if err != nil {
+		return nil, err
+	}
+	resolver := federation.NewResolver(repos)
+	defer func() { _ = resolver.Close() }()
+
+	issues, err := federation.GetReadyWork(ctx, store, filter, resolver)
+	for _, w := range resolver.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	return issues, err
+}
+
+// runReadyAllRepos shows the ready work of the current database, every
+// repository in the registry and every database found above the current
+// directory, merged into one list in sort policy order
+func runReadyAllRepos(filter types.WorkFilter, jsonOutput bool) {
+	ctx := context.Background()
+
+	registry, err := config.LoadRepos()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	var found []string
+	for _, db := range beads.FindAllDatabases() {
+		found = append(found, db.Path)
+	}
+	repos, warnings := federation.Repos(dbPath, registry, found)
+	for i := range repos {
+		// Each repository's references use its own aliases where it defines them
+		repos[i].Registry = registry
+		own, err := config.LoadReposFrom(filepath.Dir(repos[i].DBPath))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", repos[i].Alias, err))
+			continue
+		}
+		if len(own) > 0 {
+			merged := make(map[string]string, len(registry)+len(own))
+			for alias, path := range registry {
+				merged[alias] = path
+			}
+			for alias, path := range own {
+				merged[alias] = path
+			}
+			repos[i].Registry = merged
+		}
+	}
+
+	issues, readyWarnings := federation.ReadyAcrossRepos(ctx, repos, filter, time.Now())
+	for _, w := range append(warnings, readyWarnings...) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+
+	if jsonOutput {
+		if issues == nil {
+			issues = []*federation.ReadyIssue{}
+		}
+		outputJSON(issues)
+		return
+	}
+
+	if len(issues) == 0 {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("\n%s No ready work found in %d repositories\n\n", yellow("INFO:"), len(repos))
+		return
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("\n%s Ready work across %d repositories (%d issues with no blockers):\n\n", cyan("READY:"), len(repos), len(issues))
+
+	for i, issue := range issues {
+		fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, issue.QualifiedID, issue.Title)
+		if issue.EstimatedMinutes != nil {
+			fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
+		}
+		if issue.Assignee != "" {
+			fmt.Printf("   Assignee: %s\n", issue.Assignee)
+		}
+	}
+	fmt.Println()
+}
+
 var blockedCmd = &cobra.Command{
 	Use:   "blocked",
 	Short: "Show blocked issues",
@@ -223,6 +322,7 @@ var statsCmd = &cobra.Command{
 				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
 				os.Exit(1)
 			}
+			addSLABreaches(context.Background(), &stats)
 
 			if jsonOutput {
 				outputJSON(stats)
@@ -232,6 +332,7 @@ var statsCmd = &cobra.Command{
 			cyan := color.New(color.FgCyan).SprintFunc()
 			green := color.New(color.FgGreen).SprintFunc()
 			yellow := color.New(color.FgYellow).SprintFunc()
+			red := color.New(color.FgRed).SprintFunc()
 
 			fmt.Printf("\n%s Beads Statistics:\n\n", cyan("STATS:"))
 			fmt.Printf("Total Issues:      %d\n", stats.TotalIssues)
@@ -240,9 +341,13 @@ var statsCmd = &cobra.Command{
 			fmt.Printf("Closed:            %d\n", stats.ClosedIssues)
 			fmt.Printf("Blocked:           %d\n", stats.BlockedIssues)
 			fmt.Printf("Ready:             %s\n", green(fmt.Sprintf("%d", stats.ReadyIssues)))
+			if stats.OverdueIssues > 0 {
+				fmt.Printf("Overdue:           %s\n", red(fmt.Sprintf("%d", stats.OverdueIssues)))
+			}
 			if stats.AverageLeadTime > 0 {
 				fmt.Printf("Avg Lead Time:     %.1f hours\n", stats.AverageLeadTime)
 			}
+			printSLABreaches(&stats)
 			fmt.Println()
 			return
 		}
@@ -267,6 +372,8 @@ var statsCmd = &cobra.Command{
 			}
 		}
 
+		addSLABreaches(ctx, stats)
+
 		if jsonOutput {
 			outputJSON(stats)
 			return
@@ -275,6 +382,7 @@ var statsCmd = &cobra.Command{
 		cyan := color.New(color.FgCyan).SprintFunc()
 		green := color.New(color.FgGreen).SprintFunc()
 		yellow := color.New(color.FgYellow).SprintFunc()
+		red := color.New(color.FgRed).SprintFunc()
 
 		fmt.Printf("\n%s Beads Statistics:\n\n", cyan("STATS:"))
 		fmt.Printf("Total Issues:           %d\n", stats.TotalIssues)
@@ -286,9 +394,13 @@ var statsCmd = &cobra.Command{
 		if stats.EpicsEligibleForClosure > 0 {
 			fmt.Printf("Epics Ready to Close:   %s\n", green(fmt.Sprintf("%d", stats.EpicsEligibleForClosure)))
 		}
+		if stats.OverdueIssues > 0 {
+			fmt.Printf("Overdue:                %s\n", red(fmt.Sprintf("%d", stats.OverdueIssues)))
+		}
 		if stats.AverageLeadTime > 0 {
 			fmt.Printf("Avg Lead Time:          %.1f hours\n", stats.AverageLeadTime)
 		}
+		printSLABreaches(stats)
 		fmt.Println()
 	},
 }
@@ -297,8 +409,9 @@ func init() {
 	readyCmd.Flags().IntP("limit", "n", 10, "Maximum issues to show")
 	readyCmd.Flags().IntP("priority", "p", 0, "Filter by priority")
 	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
-	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest")
+	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest, deadline (approaching due dates first)")
 	readyCmd.Flags().Bool("json", false, "Output JSON format")
+	readyCmd.Flags().Bool("all-repos", false, "Merge ready work from every known repository (see 'repos' in config.yaml)")
 
 	statsCmd.Flags().Bool("json", false, "Output JSON format")
 	blockedCmd.Flags().Bool("json", false, "Output JSON format")
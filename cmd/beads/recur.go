@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/recur"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/shaneholloman/beads/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var recurCmd = &cobra.Command{
+	Use:   "recur",
+	Short: "Manage recurring issues",
+	Long: `A recurring issue is a template: on its schedule, a new open issue is created
+with its fields and labels, a dated title, and a discovered-from dependency
+on it. The recurring issue itself never shows up as ready work. Close it (or
+move it to any done status) to stop the schedule.
+
+Rules are cron expressions (minute hour day-of-month month day-of-week) or
+shorthands (@hourly, @daily, @weekly, @monthly, @yearly), evaluated in UTC
+unless prefixed with TZ=<zone>. Quote rules containing *.
+
+The daemon creates occurrences as they fall due, checking every minute;
+'beads recur run' does it by hand. A schedule starts when it is set, and
+only the latest missed occurrence is created. Occurrence IDs are derived from
+the recurring issue and the due time, so clones that each create the same
+occurrence end up with one issue once they sync.
+
+Examples:
+  beads recur set bd-12 "0 9 * * mon"                # Mondays at 09:00 UTC
+  beads recur set bd-12 "TZ=Europe/Berlin 0 9 1 * *" # 1st of the month, 09:00 Berlin
+  beads recur set bd-12 @daily
+  beads recur list
+  beads recur clear bd-12`,
+}
+
+var recurSetCmd = &cobra.Command{
+	Use:   "set <id> <rule>",
+	Short: "Make an issue recur on a schedule",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		rule := strings.Join(args[1:], " ")
+		if _, err := recur.Parse(rule); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		setRecurrence(cmd, args[0], rule)
+	},
+}
+
+var recurClearCmd = &cobra.Command{
+	Use:   "clear <id>",
+	Short: "Stop an issue recurring",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setRecurrence(cmd, args[0], "")
+	},
+}
+
+// setRecurrence sets or clears (with an empty rule) an issue's recurrence
+func setRecurrence(cmd *cobra.Command, id, rule string) {
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	ctx := context.Background()
+	var issue *types.Issue
+
+	if daemonClient != nil {
+		resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: id})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", id, err)
+			os.Exit(1)
+		}
+		if err := json.Unmarshal(resp.Data, &id); err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+			os.Exit(1)
+		}
+		resp, err = daemonClient.Update(&rpc.UpdateArgs{ID: id, Recurrence: &rule})
+		if err == nil {
+			issue = &types.Issue{}
+			err = json.Unmarshal(resp.Data, issue)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+			os.Exit(1)
+		}
+	} else {
+		var err error
+		id, err = utils.ResolvePartialID(ctx, store, id)
+		if err == nil {
+			err = store.UpdateIssue(ctx, id, recur.RuleUpdates(rule, time.Now()), actor)
+		}
+		if err == nil {
+			issue, err = store.GetIssue(ctx, id)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		markDirtyAndScheduleFlush()
+	}
+
+	if jsonOutput {
+		outputJSON(issue)
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	if rule == "" {
+		fmt.Printf("%s %s no longer recurs\n", green("✔"), id)
+		return
+	}
+	fmt.Printf("%s %s recurs: %s\n", green("✔"), id, rule)
+	if schedule, err := recur.Parse(rule); err == nil {
+		if next := schedule.Next(time.Now()); !next.IsZero() {
+			fmt.Printf("  Next: %s\n", next.Format("2006-01-02 15:04 MST"))
+		}
+	}
+}
+
+// RecurringIssue describes a recurring issue for 'beads recur list'
+type RecurringIssue struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	Status     string     `json:"status"`
+	Recurrence string     `json:"recurrence"`
+	Next       *time.Time `json:"next,omitempty"` // Unset when done or the rule is invalid
+	Error      string     `json:"error,omitempty"`
+}
+
+var recurListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring issues and when they are next due",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		ensureRecurStore()
+
+		issues, err := store.SearchIssues(context.Background(), "", types.IssueFilter{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		now := time.Now()
+		recurring := []*RecurringIssue{}
+		for _, issue := range issues {
+			if issue.Recurrence == "" {
+				continue
+			}
+			ri := &RecurringIssue{ID: issue.ID, Title: issue.Title, Status: string(issue.Status), Recurrence: issue.Recurrence}
+			schedule, err := recur.Parse(issue.Recurrence)
+			if err != nil {
+				ri.Error = err.Error()
+			} else if issue.Status.Category() != types.CategoryDone {
+				if next := schedule.Next(now); !next.IsZero() {
+					ri.Next = &next
+				}
+			}
+			recurring = append(recurring, ri)
+		}
+
+		if jsonOutput {
+			outputJSON(recurring)
+			return
+		}
+		if len(recurring) == 0 {
+			fmt.Println("No recurring issues")
+			return
+		}
+		cyan := color.New(color.FgCyan).SprintFunc()
+		for _, ri := range recurring {
+			fmt.Printf("%s %s [%s]\n", cyan(ri.ID), ri.Title, ri.Status)
+			fmt.Printf("  Rule: %s\n", ri.Recurrence)
+			switch {
+			case ri.Error != "":
+				fmt.Printf("  Error: %s\n", ri.Error)
+			case ri.Next != nil:
+				fmt.Printf("  Next: %s\n", ri.Next.Format("2006-01-02 15:04 MST"))
+			}
+		}
+	},
+}
+
+var recurRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Create recurring issues' occurrences that are due",
+	Long: `Create the occurrences of recurring issues that are due now. The daemon does
+this every minute; run it by hand without a daemon, or with --dry-run to see
+what is due.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		ensureRecurStore()
+
+		ctx := context.Background()
+		var occurrences []*recur.Occurrence
+		var err error
+		if dryRun {
+			occurrences, err = recur.Due(ctx, store, time.Now())
+		} else {
+			occurrences, err = recur.Materialize(ctx, store, time.Now(), actor)
+			if len(occurrences) > 0 {
+				markDirtyAndScheduleFlush()
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+
+		if jsonOutput {
+			if occurrences == nil {
+				occurrences = []*recur.Occurrence{}
+			}
+			outputJSON(occurrences)
+			return
+		}
+		if len(occurrences) == 0 {
+			fmt.Println("No recurring issues are due")
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		for _, occ := range occurrences {
+			if dryRun {
+				fmt.Printf("Would create %s from %s (due %s)\n", occ.ID, occ.Template.ID, occ.At.Format("2006-01-02 15:04 MST"))
+			} else {
+				fmt.Printf("%s Created %s: %s\n", green("✔"), occ.ID, occ.Issue.Title)
+			}
+		}
+	},
+}
+
+// ensureRecurStore opens the database directly; the daemon has no RPC for
+// listing or materializing recurring issues
+func ensureRecurStore() {
+	var err error
+	if daemonClient != nil {
+		err = ensureDirectMode("daemon does not support recur command")
+	} else if store == nil {
+		err = ensureStoreActive()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{recurSetCmd, recurClearCmd, recurListCmd, recurRunCmd} {
+		c.Flags().Bool("json", false, "Output JSON format")
+		recurCmd.AddCommand(c)
+	}
+	recurRunCmd.Flags().Bool("dry-run", false, "Show what is due without creating anything")
+	rootCmd.AddCommand(recurCmd)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestSetRecurrenceViaDaemon(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	socketPath := filepath.Join(tmpDir, ".beads", "beads.sock")
+	testStore := newTestStore(t, dbPath)
+
+	ctx := context.Background()
+	issue := &types.Issue{
+		Title:     "Weekly report",
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+	}
+	if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	server := rpc.NewServer(socketPath, testStore, tmpDir, dbPath)
+	go func() { _ = server.Start(ctx) }()
+	defer server.Stop()
+	select {
+	case <-server.WaitReady():
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not become ready")
+	}
+
+	client, err := rpc.TryConnect(socketPath)
+	if err != nil || client == nil {
+		t.Fatalf("failed to connect to daemon: %v", err)
+	}
+	defer client.Close()
+	client.SetDatabasePath(dbPath)
+
+	oldClient := daemonClient
+	daemonClient = client
+	defer func() { daemonClient = oldClient }()
+
+	setRecurrence(recurSetCmd, issue.ID, "@daily")
+
+	got, err := testStore.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Recurrence != "@daily" {
+		t.Errorf("Recurrence = %q, want %q", got.Recurrence, "@daily")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
 	"github.com/shaneholloman/beads/internal/types"
 	"github.com/spf13/cobra"
 )
@@ -26,12 +27,21 @@ When an issue is compacted, the git commit hash is saved. This command:
 4. Displays the full issue history (description, events, etc.)
 5. Returns to the current git state
 
-This is read-only and does not modify the database or git state.`,
+This is read-only and does not modify the database or git state.
+
+With --undo, a Tier 2 compaction is reversed instead: the issue's Tier 1 text,
+events and comments are put back from the snapshot saved in the local
+database when it was compacted. Git is not needed for this.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		issueID := args[0]
 		ctx := context.Background()
 
+		if restoreUndo {
+			runRestoreUndo(ctx, issueID)
+			return
+		}
+
 		// Check if we're in a git repository
 		if !isGitRepo() {
 			fmt.Fprintf(os.Stderr, "Error: not in a git repository\n")
@@ -108,13 +118,56 @@ This is read-only and does not modify the database or git state.`,
 
 		// Display the restored issue
 		displayRestoredIssue(historicalIssue, commitHash)
+
+		if issue.CompactionLevel >= 2 {
+			fmt.Printf("Hint: run 'beads restore %s --undo' to undo its Tier 2 compaction\n", issueID)
+		}
 	},
 }
 
+var restoreUndo bool
+
 func init() {
+	restoreCmd.Flags().BoolVar(&restoreUndo, "undo", false, "Undo the Tier 2 compaction from the local snapshot")
 	rootCmd.AddCommand(restoreCmd)
 }
 
+// runRestoreUndo reverses an issue's Tier 2 compaction from its snapshot
+func runRestoreUndo(ctx context.Context, issueID string) {
+	if err := ensureDirectMode("restore --undo requires direct database access"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: restore --undo requires SQLite storage\n")
+		os.Exit(1)
+	}
+
+	snap, err := sqliteStore.GetLatestSnapshot(ctx, issueID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if snap == nil {
+		fmt.Fprintf(os.Stderr, "Error: issue %s has no compaction snapshot to undo\n", issueID)
+		fmt.Fprintf(os.Stderr, "Hint: only Tier 2 compaction keeps a snapshot; run 'beads restore %s' to view Tier 1 history from git\n", issueID)
+		os.Exit(1)
+	}
+
+	if err := sqliteStore.RestoreSnapshot(ctx, issueID); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	markDirtyAndScheduleFlush()
+
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Restored %s to compaction level %d (%d events, %d comments)\n",
+		green("✓"), issueID, snap.CompactionLevel-1, len(snap.Archived.Events), len(snap.Archived.Comments))
+}
+
 // getCurrentGitHead returns the current HEAD reference (branch or commit)
 func getCurrentGitHead() (string, error) {
 	// Try to get symbolic ref (branch name) first
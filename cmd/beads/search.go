@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Full-text search across issue text and comments",
+	Long: `Search titles, descriptions, design notes, acceptance criteria, notes and
+comments. Results are ranked by relevance (title matches count most) and show a
+snippet with the matching terms highlighted.
+
+Query syntax:
+  login timeout        issues containing both words
+  "session token"      an exact phrase
+  login OR signin      either word
+  auth*                any word starting with "auth"
+
+Words match their variants, so "crash" also finds "crashes" and "crashing".
+
+Examples:
+  # Look for prior art before filing a new issue
+  beads search "rate limit"
+
+  # Only open bugs, as JSON
+  beads search flaky test --type bug --status open --json`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		query := strings.Join(args, " ")
+		status, _ := cmd.Flags().GetString("status")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		issueType, _ := cmd.Flags().GetString("type")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+		limit, _ := cmd.Flags().GetInt("limit")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+
+		if storage.ParseSearchQuery(query).Empty() {
+			fmt.Fprintf(os.Stderr, "Error: search query has no searchable terms\n")
+			os.Exit(1)
+		}
+
+		labels = normalizeLabels(labels)
+		labelsAny = normalizeLabels(labelsAny)
+
+		var results []*types.SearchResult
+		usedDaemon := false
+
+		// If daemon is running, use RPC
+		if daemonClient != nil {
+			searchArgs := &rpc.SearchArgs{
+				Query:     query,
+				Status:    status,
+				IssueType: issueType,
+				Assignee:  assignee,
+				Labels:    labels,
+				LabelsAny: labelsAny,
+				Limit:     limit,
+			}
+			if cmd.Flags().Changed("priority") {
+				priority, _ := cmd.Flags().GetInt("priority")
+				searchArgs.Priority = &priority
+			}
+
+			resp, err := daemonClient.Search(searchArgs)
+			if err != nil {
+				if isUnknownOperationError(err) {
+					if err := fallbackToDirectMode("daemon does not support search RPC"); err != nil {
+						fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+						os.Exit(1)
+					}
+				} else {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			} else {
+				if err := json.Unmarshal(resp.Data, &results); err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+					os.Exit(1)
+				}
+				usedDaemon = true
+			}
+		}
+
+		if !usedDaemon {
+			filter := types.IssueFilter{
+				Limit:     limit,
+				Labels:    labels,
+				LabelsAny: labelsAny,
+			}
+			if status != "" {
+				s := types.Status(status)
+				filter.Status = &s
+			}
+			// Use Changed() to properly handle P0 (priority=0)
+			if cmd.Flags().Changed("priority") {
+				priority, _ := cmd.Flags().GetInt("priority")
+				filter.Priority = &priority
+			}
+			if assignee != "" {
+				filter.Assignee = &assignee
+			}
+			if issueType != "" {
+				t := types.IssueType(issueType)
+				filter.IssueType = &t
+			}
+
+			var err error
+			results, err = store.SearchIssuesRanked(context.Background(), query, filter)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if jsonOutput {
+			if results == nil {
+				results = []*types.SearchResult{}
+			}
+			outputJSON(results)
+			return
+		}
+
+		if len(results) == 0 {
+			fmt.Printf("No issues match %q\n", query)
+			return
+		}
+
+		fmt.Printf("\nFound %d matching issues:\n\n", len(results))
+		for _, result := range results {
+			fmt.Printf("%s [P%d] [%s] %s\n", result.ID, result.Priority, result.IssueType, result.Status)
+			fmt.Printf("  %s\n", result.Title)
+			// A title match already shows in full above
+			if plain := strings.ReplaceAll(result.Snippet, storage.HighlightStart, ""); plain != "" && strings.ReplaceAll(plain, storage.HighlightEnd, "") != result.Title {
+				fmt.Printf("  %s\n", renderSnippet(result.Snippet))
+			}
+			fmt.Println()
+		}
+	},
+}
+
+// renderSnippet turns highlight markers into terminal emphasis, collapsing
+// newlines so each snippet stays on one line
+func renderSnippet(snippet string) string {
+	highlight := color.New(color.FgYellow, color.Bold).SprintFunc()
+	snippet = strings.Join(strings.Fields(snippet), " ")
+
+	var b strings.Builder
+	for {
+		start := strings.Index(snippet, storage.HighlightStart)
+		if start < 0 {
+			break
+		}
+		rest := snippet[start+len(storage.HighlightStart):]
+		end := strings.Index(rest, storage.HighlightEnd)
+		if end < 0 {
+			break
+		}
+		b.WriteString(snippet[:start])
+		b.WriteString(highlight(rest[:end]))
+		snippet = rest[end+len(storage.HighlightEnd):]
+	}
+	b.WriteString(snippet)
+	return b.String()
+}
+
+func init() {
+	searchCmd.Flags().StringP("status", "s", "", "Filter by status (open, in_progress, blocked, closed)")
+	searchCmd.Flags().IntP("priority", "p", 0, "Filter by priority (0-4: 0=critical, 1=high, 2=medium, 3=low, 4=backlog)")
+	searchCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	searchCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore)")
+	searchCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL)")
+	searchCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE)")
+	searchCmd.Flags().IntP("limit", "n", 20, "Maximum number of results")
+	searchCmd.Flags().Bool("json", false, "Output JSON format")
+	rootCmd.AddCommand(searchCmd)
+}
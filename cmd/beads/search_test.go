@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestRenderSnippet(t *testing.T) {
+	saved := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = saved }()
+
+	tests := []struct {
+		snippet string
+		want    string
+	}{
+		{"plain text", "plain text"},
+		{"the **login** page", "the login page"},
+		{"**a** and\n**b**", "a and b"},
+		{"unterminated **marker", "unterminated **marker"},
+	}
+	for _, tt := range tests {
+		if got := renderSnippet(tt.snippet); got != tt.want {
+			t.Errorf("renderSnippet(%q) = %q, want %q", tt.snippet, got, tt.want)
+		}
+	}
+}
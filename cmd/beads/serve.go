@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/shaneholloman/beads/internal/dashboard"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a read-only web dashboard",
+	Long: `Serve a read-only web dashboard and JSON API for this workspace until
+interrupted. The daemon can serve the same dashboard with 'beads daemon --http'.
+
+Pages:
+  /                  Stats, ready work, blocked issues and epic progress
+  /issues?q=FILTER   Issue list (same filter syntax as 'beads list')
+  /issues/<id>       Issue details, dependencies and comments
+
+JSON API:
+  /api/issues?q=FILTER, /api/issues/<id>, /api/ready?limit=N,
+  /api/blocked, /api/epics, /api/stats, /api/metrics (daemon only)
+
+Without q, lists leave out closed issues. There is no authentication: anyone
+who can reach the address can read every issue, so keep it on localhost or a
+trusted network.
+
+Examples:
+  beads serve
+  beads serve --addr :8080`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, _ := cmd.Flags().GetString("addr")
+
+		// The dashboard reads the database directly, alongside any running daemon
+		if err := ensureDirectMode("serve reads the database directly"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+			os.Exit(1)
+		}
+
+		name := filepath.Base(filepath.Dir(filepath.Dir(dbPath)))
+		dash, err := dashboard.New(store, name, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), daemonSignals...)
+		defer stop()
+
+		fmt.Printf("Serving dashboard at %s (Ctrl+C to stop)\n", dashboardURL(addr))
+		if err := dash.Serve(ctx, addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// dashboardURL turns a listen address into a URL to open, e.g. :8080 becomes
+// http://localhost:8080/
+func dashboardURL(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://" + addr + "/"
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port) + "/"
+}
+
+func init() {
+	serveCmd.Flags().String("addr", "localhost:8080", "Address to listen on")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+func TestDashboardURL(t *testing.T) {
+	for addr, want := range map[string]string{
+		":8080":          "http://localhost:8080/",
+		"0.0.0.0:80":     "http://localhost:80/",
+		"127.0.0.1:9000": "http://127.0.0.1:9000/",
+		"[::1]:8080":     "http://[::1]:8080/",
+		"example.com":    "http://example.com/",
+	} {
+		if got := dashboardURL(addr); got != want {
+			t.Errorf("dashboardURL(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
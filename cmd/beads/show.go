@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/shaneholloman/beads/internal/rpc"
@@ -21,8 +22,23 @@ var showCmd = &cobra.Command{
 	Args:  cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		atValue, _ := cmd.Flags().GetString("at")
 		ctx := context.Background()
 
+		var at time.Time
+		if atValue != "" {
+			var err error
+			if at, err = resolveTimeTravel(atValue, time.Now()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid --at: %v\n", err)
+				os.Exit(1)
+			}
+			// The daemon has no time-travel support; replay locally
+			if err := ensureDirectMode("daemon does not support --at"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		// Resolve partial IDs first
 		var resolvedIDs []string
 		if daemonClient != nil {
@@ -46,6 +62,11 @@ var showCmd = &cobra.Command{
 			}
 		}
 
+		if atValue != "" {
+			showIssuesAt(ctx, resolvedIDs, at, jsonOutput)
+			return
+		}
+
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			allDetails := []interface{}{}
@@ -116,6 +137,12 @@ var showCmd = &cobra.Command{
 					if issue.EstimatedMinutes != nil {
 						fmt.Printf("Estimated: %d minutes\n", *issue.EstimatedMinutes)
 					}
+					if issue.Recurrence != "" {
+						fmt.Printf("Recurs: %s\n", issue.Recurrence)
+					}
+					if issue.DueAt != nil {
+						fmt.Printf("Due: %s\n", formatDue(issue, time.Now()))
+					}
 					fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04"))
 					fmt.Printf("Updated: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04"))
 
@@ -238,6 +265,12 @@ var showCmd = &cobra.Command{
 			if issue.EstimatedMinutes != nil {
 				fmt.Printf("Estimated: %d minutes\n", *issue.EstimatedMinutes)
 			}
+			if issue.Recurrence != "" {
+				fmt.Printf("Recurs: %s\n", issue.Recurrence)
+			}
+			if issue.DueAt != nil {
+				fmt.Printf("Due: %s\n", formatDue(issue, time.Now()))
+			}
 			fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04"))
 			fmt.Printf("Updated: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04"))
 
@@ -366,6 +399,23 @@ var updateCmd = &cobra.Command{
 			externalRef, _ := cmd.Flags().GetString("external-ref")
 			updates["external_ref"] = externalRef
 		}
+		if cmd.Flags().Changed("estimate") {
+			estimate, _ := cmd.Flags().GetInt("estimate")
+			updates["estimated_minutes"] = estimate
+		}
+		if cmd.Flags().Changed("due") {
+			dueFlag, _ := cmd.Flags().GetString("due")
+			due, err := parseDueFlag(dueFlag, time.Now())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if due != nil {
+				updates["due_at"] = *due
+			} else {
+				updates["due_at"] = nil
+			}
+		}
 
 		if len(updates) == 0 {
 			fmt.Println("No updates specified")
@@ -426,6 +476,16 @@ var updateCmd = &cobra.Command{
 				if acceptanceCriteria, ok := updates["acceptance_criteria"].(string); ok {
 					updateArgs.AcceptanceCriteria = &acceptanceCriteria
 				}
+				if estimate, ok := updates["estimated_minutes"].(int); ok {
+					updateArgs.EstimatedMinutes = &estimate
+				}
+				if due, ok := updates["due_at"]; ok {
+					dueAt := ""
+					if t, ok := due.(time.Time); ok {
+						dueAt = t.Format(time.RFC3339Nano)
+					}
+					updateArgs.DueAt = &dueAt
+				}
 
 				resp, err := daemonClient.Update(updateArgs)
 				if err != nil {
@@ -771,6 +831,7 @@ var closeCmd = &cobra.Command{
 }
 
 func init() {
+	showCmd.Flags().String("at", "", "Show the issue as it was at a past time or git revision (e.g. 2025-01-31T15:04:05Z, 3d, HEAD~5)")
 	showCmd.Flags().Bool("json", false, "Output JSON format")
 	rootCmd.AddCommand(showCmd)
 
@@ -785,6 +846,8 @@ func init() {
 	updateCmd.Flags().String("acceptance-criteria", "", "DEPRECATED: use --acceptance")
 	_ = updateCmd.Flags().MarkHidden("acceptance-criteria")
 	updateCmd.Flags().String("external-ref", "", "External reference (e.g., 'gh-9', 'jira-ABC')")
+	updateCmd.Flags().IntP("estimate", "e", 0, "Estimated time to complete, in minutes")
+	updateCmd.Flags().String("due", "", "Due date (e.g., 2025-01-31, 3d); empty clears it")
 	updateCmd.Flags().Bool("json", false, "Output JSON format")
 	rootCmd.AddCommand(updateCmd)
 
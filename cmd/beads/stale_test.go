@@ -3,6 +3,8 @@ package main
 import (
 	"testing"
 	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
 )
 
 func TestFormatDuration(t *testing.T) {
@@ -58,25 +60,28 @@ func TestFormatDuration(t *testing.T) {
 	}
 }
 
-func TestStaleIssueInfo(t *testing.T) {
-	// Test that StaleIssueInfo struct can be created and serialized
-	info := &StaleIssueInfo{
-		IssueID:            "beads-42",
-		IssueTitle:         "Test Issue",
-		IssuePriority:      1,
-		ExecutorInstanceID: "exec-123",
-		ExecutorStatus:     "stopped",
-		ExecutorHostname:   "localhost",
-		ExecutorPID:        12345,
-		LastHeartbeat:      time.Now().Add(-10 * time.Minute),
-		ClaimedAt:          time.Now().Add(-30 * time.Minute),
-		ClaimedDuration:    "30 minutes",
+func TestNewStaleIssueInfo(t *testing.T) {
+	claimedAt := time.Now().Add(-90 * time.Minute)
+	issue := &types.Issue{ID: "beads-42", Title: "Test Issue", Priority: 1}
+	lease := &types.Lease{
+		IssueID:       "beads-42",
+		Holder:        "worker-1",
+		ClaimedAt:     claimedAt,
+		LastHeartbeat: claimedAt.Add(20 * time.Minute),
+		ExpiresAt:     claimedAt.Add(30 * time.Minute),
 	}
 
-	if info.IssueID != "beads-42" {
-		t.Errorf("Expected IssueID beads-42, got %s", info.IssueID)
+	info := newStaleIssueInfo(issue, lease)
+	if info.IssueID != "beads-42" || info.IssueTitle != "Test Issue" || info.IssuePriority != 1 {
+		t.Errorf("unexpected issue fields: %+v", info)
+	}
+	if info.Holder != "worker-1" {
+		t.Errorf("Expected holder worker-1, got %s", info.Holder)
+	}
+	if !info.LastHeartbeat.Equal(lease.LastHeartbeat) {
+		t.Errorf("Expected last heartbeat %v, got %v", lease.LastHeartbeat, info.LastHeartbeat)
 	}
-	if info.ExecutorStatus != "stopped" {
-		t.Errorf("Expected ExecutorStatus stopped, got %s", info.ExecutorStatus)
+	if info.ClaimedDuration != "30 minutes" {
+		t.Errorf("Expected claimed duration of 30 minutes, got %s", info.ClaimedDuration)
 	}
 }
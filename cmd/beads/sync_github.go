@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/github"
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var syncGitHubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Synchronize issues with GitHub Issues",
+	Long: `Two-way sync between beads and a GitHub repository's issues.
+
+Pull creates a beads issue for every GitHub issue not yet linked, and applies
+GitHub changes (title, body, state, labels, assignee, comments) made since the
+last sync. Push sends local title, description, status, label and comment
+changes to the linked GitHub issues. Issues are linked by external_ref "gh-<number>".
+When an issue changed on both sides, the more recently updated side wins.
+Pull and push keep separate sync points, so --no-push or --no-pull leaves the
+other direction's changes for the next sync.
+
+The repository and credentials come from flags, the environment or config:
+  --repo owner/repo        or github.org + github.repo (github.repo may be owner/repo)
+  GITHUB_TOKEN / GH_TOKEN  or github.token
+  --api-url                or github.url (for GitHub Enterprise: https://HOST/api/v3)
+
+GitHub labels mapped to issue types via github.label_map.<type> (default:
+bug → "bug", feature → "enhancement") set the issue type instead of becoming labels.
+
+Examples:
+  beads sync github --repo acme/widgets
+  beads sync github --dry-run
+  beads sync github --no-push        # Only pull from GitHub
+  beads sync github --create         # Also open GitHub issues for unlinked local issues`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		repoFlag, _ := cmd.Flags().GetString("repo")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		noPull, _ := cmd.Flags().GetBool("no-pull")
+		noPush, _ := cmd.Flags().GetBool("no-push")
+		createRemote, _ := cmd.Flags().GetBool("create")
+		ctx := context.Background()
+
+		if err := ensureDirectMode("sync github requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := newGitHubClient(ctx, store, repoFlag, apiURL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if client.Token == "" && !noPush {
+			fmt.Fprintf(os.Stderr, "Error: a GitHub token is required to push (set GITHUB_TOKEN or github.token, or use --no-push)\n")
+			os.Exit(1)
+		}
+		typeLabels, err := githubTypeLabels(ctx, store)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		syncer := github.NewSyncer(client, store, github.Options{
+			Actor:        actor,
+			NoPull:       noPull,
+			NoPush:       noPush,
+			DryRun:       dryRun,
+			CreateRemote: createRemote,
+			TypeLabels:   typeLabels,
+		})
+		result, err := syncer.Sync(ctx, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error syncing with %s: %v\n", client.FullName(), err)
+			os.Exit(1)
+		}
+		if !dryRun && (result.Pulled != (github.Stats{}) || result.Pushed.Created > 0) {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		prefix := "✔"
+		if dryRun {
+			prefix = "✔ [DRY RUN]"
+		}
+		fmt.Printf("%s Synced with %s\n", prefix, result.Repo)
+		if !noPull {
+			fmt.Printf("  Pulled: %s\n", formatGitHubStats(result.Pulled))
+		}
+		if !noPush {
+			fmt.Printf("  Pushed: %s\n", formatGitHubStats(result.Pushed))
+		}
+		for _, skipped := range result.Skipped {
+			fmt.Printf("  Conflict %s\n", skipped)
+		}
+	},
+}
+
+// newGitHubClient resolves the repository, token and API URL from flags, the
+// environment and config
+func newGitHubClient(ctx context.Context, s storage.Storage, repo, apiURL string) (*github.Client, error) {
+	if repo == "" {
+		name, err := s.GetConfig(ctx, "github.repo")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read github.repo: %w", err)
+		}
+		org, err := s.GetConfig(ctx, "github.org")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read github.org: %w", err)
+		}
+		repo = name
+		if org != "" && name != "" && !strings.Contains(name, "/") {
+			repo = org + "/" + name
+		}
+	}
+	if repo == "" {
+		return nil, fmt.Errorf("no GitHub repository configured (use --repo owner/repo or 'beads config set github.repo owner/repo')")
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+	if token == "" {
+		var err error
+		if token, err = s.GetConfig(ctx, "github.token"); err != nil {
+			return nil, fmt.Errorf("failed to read github.token: %w", err)
+		}
+	}
+
+	client, err := github.NewClient(repo, token)
+	if err != nil {
+		return nil, err
+	}
+	if apiURL == "" {
+		if apiURL, err = s.GetConfig(ctx, "github.url"); err != nil {
+			return nil, fmt.Errorf("failed to read github.url: %w", err)
+		}
+	}
+	if apiURL != "" {
+		client.BaseURL = apiURL
+	}
+	return client, nil
+}
+
+// githubTypeLabels returns the default type labels overridden by github.label_map.<type>
+func githubTypeLabels(ctx context.Context, s storage.Storage) (map[types.IssueType]string, error) {
+	all, err := s.GetAllConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	labels := make(map[types.IssueType]string, len(github.DefaultTypeLabels))
+	for t, label := range github.DefaultTypeLabels {
+		labels[t] = label
+	}
+	for key, value := range all {
+		name, ok := strings.CutPrefix(key, "github.label_map.")
+		if !ok {
+			continue
+		}
+		issueType := types.IssueType(name)
+		if !issueType.IsValid() {
+			return nil, fmt.Errorf("invalid issue type in %s", key)
+		}
+		if value == "" {
+			delete(labels, issueType)
+		} else {
+			labels[issueType] = value
+		}
+	}
+	return labels, nil
+}
+
+func formatGitHubStats(s github.Stats) string {
+	return fmt.Sprintf("%d created, %d updated, %d comments", s.Created, s.Updated, s.Comments)
+}
+
+func init() {
+	syncGitHubCmd.Flags().String("repo", "", "GitHub repository (owner/repo)")
+	syncGitHubCmd.Flags().String("api-url", "", "GitHub API URL (default: "+github.DefaultBaseURL+")")
+	syncGitHubCmd.Flags().Bool("dry-run", false, "Preview sync without making changes")
+	syncGitHubCmd.Flags().Bool("no-pull", false, "Skip applying GitHub changes locally")
+	syncGitHubCmd.Flags().Bool("no-push", false, "Skip sending local changes to GitHub")
+	syncGitHubCmd.Flags().Bool("create", false, "Open GitHub issues for local issues without an external_ref")
+	syncCmd.AddCommand(syncGitHubCmd)
+}
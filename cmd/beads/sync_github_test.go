@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/github"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestNewGitHubClientConfig(t *testing.T) {
+	ctx := context.Background()
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+
+	if _, err := newGitHubClient(ctx, store, "", ""); err == nil {
+		t.Error("expected error without a configured repository")
+	}
+
+	for key, value := range map[string]string{
+		"github.org":   "acme",
+		"github.repo":  "widgets",
+		"github.token": "from-config",
+		"github.url":   "https://ghe.example.com/api/v3",
+	} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+	client, err := newGitHubClient(ctx, store, "", "")
+	if err != nil {
+		t.Fatalf("newGitHubClient failed: %v", err)
+	}
+	if client.FullName() != "acme/widgets" || client.Token != "from-config" || client.BaseURL != "https://ghe.example.com/api/v3" {
+		t.Errorf("client from config = %+v", client)
+	}
+
+	// Flags and the environment take precedence
+	t.Setenv("GH_TOKEN", "from-env")
+	client, err = newGitHubClient(ctx, store, "other/repo", "http://127.0.0.1:9999")
+	if err != nil {
+		t.Fatalf("newGitHubClient failed: %v", err)
+	}
+	if client.FullName() != "other/repo" || client.Token != "from-env" || client.BaseURL != "http://127.0.0.1:9999" {
+		t.Errorf("client from flags = %+v", client)
+	}
+}
+
+func TestGitHubTypeLabels(t *testing.T) {
+	ctx := context.Background()
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	labels, err := githubTypeLabels(ctx, store)
+	if err != nil {
+		t.Fatalf("githubTypeLabels failed: %v", err)
+	}
+	if len(labels) != len(github.DefaultTypeLabels) || labels[types.TypeFeature] != "enhancement" {
+		t.Errorf("default labels = %v", labels)
+	}
+
+	if err := store.SetConfig(ctx, "github.label_map.feature", "feature-request"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetConfig(ctx, "github.label_map.bug", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetConfig(ctx, "github.label_map.epic", "epic"); err != nil {
+		t.Fatal(err)
+	}
+	labels, err = githubTypeLabels(ctx, store)
+	if err != nil {
+		t.Fatalf("githubTypeLabels failed: %v", err)
+	}
+	want := map[types.IssueType]string{types.TypeFeature: "feature-request", types.TypeEpic: "epic"}
+	if len(labels) != len(want) || labels[types.TypeFeature] != want[types.TypeFeature] || labels[types.TypeEpic] != want[types.TypeEpic] {
+		t.Errorf("configured labels = %v, want %v", labels, want)
+	}
+
+	if err := store.SetConfig(ctx, "github.label_map.story", "story"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := githubTypeLabels(ctx, store); err == nil {
+		t.Error("expected error for unknown issue type")
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/templates"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/spf13/cobra"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage issue templates",
+	Long: `Issue templates are YAML files in .beads/templates/<name>.yaml that prefill
+'beads create --template <name>': fields, labels, acceptance criteria and
+optionally child issues with dependencies between them.
+
+Example (.beads/templates/release-checklist.yaml):
+
+  summary: Steps for cutting a release
+  vars:
+    version:                  # No default: must be given with --var
+    branch: main
+  title: Release {{version}}
+  type: epic
+  priority: 1
+  labels: [release]
+  acceptance: v{{version}} is tagged and published
+  children:
+    - key: changelog
+      title: Update CHANGELOG for {{version}}
+    - key: tag
+      title: Tag v{{version}} on {{branch}}
+      depends_on: [changelog]
+
+  beads create --template release-checklist --var version=1.4
+
+Issue fields are title, description, design, acceptance, type, priority,
+assignee, labels and estimate (minutes). Flags given to create override the
+template, and --labels adds to its labels. Children become parent-child
+dependents of the new issue; depends_on lists sibling keys that block a child.`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List issue templates",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		all, err := templates.List(templatesBeadsDir())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(all)
+			return
+		}
+		if len(all) == 0 {
+			fmt.Printf("No templates found in %s\n", filepath.Join(templatesBeadsDir(), templates.Dir))
+			return
+		}
+		cyan := color.New(color.FgCyan).SprintFunc()
+		for _, t := range all {
+			fmt.Printf("%s", cyan(t.Name))
+			if t.Summary != "" {
+				fmt.Printf(" - %s", t.Summary)
+			}
+			fmt.Println()
+			if len(t.Vars) > 0 {
+				names := make([]string, 0, len(t.Vars))
+				for name, def := range t.Vars {
+					if def == "" {
+						name += " (required)"
+					} else {
+						name += "=" + def
+					}
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				fmt.Printf("  Vars: %s\n", strings.Join(names, ", "))
+			}
+			if len(t.Children) > 0 {
+				fmt.Printf("  Children: %d\n", len(t.Children))
+			}
+		}
+	},
+}
+
+// templatesBeadsDir returns the .beads directory holding templates: the one
+// with the database, or the nearest one above the working directory
+func templatesBeadsDir() string {
+	if dbPath != "" {
+		return filepath.Dir(dbPath)
+	}
+	return findBeadsDir()
+}
+
+// loadCreateTemplate loads and renders the template for 'beads create
+// --template', exiting on error
+func loadCreateTemplate(cmd *cobra.Command, name string) *templates.Template {
+	specs, _ := cmd.Flags().GetStringArray("var")
+	vars, err := templates.ParseVars(specs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	beadsDir := templatesBeadsDir()
+	if beadsDir == "" {
+		fmt.Fprintf(os.Stderr, "Error: no .beads directory found for templates\n")
+		os.Exit(1)
+	}
+	tmpl, err := templates.Load(beadsDir, name)
+	if err == nil {
+		tmpl, err = tmpl.Render(vars)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return tmpl
+}
+
+// createTemplateChildren creates a template's children under parentID. The
+// children are in dependency order, so sibling blockers exist before the
+// issues they block. Failures are reported and skipped.
+func createTemplateChildren(parentID string, children []templates.Child) []*types.Issue {
+	ctx := context.Background()
+	created := make([]*types.Issue, 0, len(children))
+	ids := make(map[string]string) // Child key -> issue ID
+
+	for _, child := range children {
+		issueType, priority := types.TypeTask, 2
+		if child.Type != "" {
+			issueType = types.IssueType(child.Type)
+		}
+		if child.Priority != nil {
+			priority = *child.Priority
+		}
+		deps := []string{string(types.DepParentChild) + ":" + parentID}
+		for _, key := range child.DependsOn {
+			if id, ok := ids[key]; ok {
+				deps = append(deps, string(types.DepBlocks)+":"+id)
+			} else {
+				fmt.Fprintf(os.Stderr, "Warning: %q was not created, so '%s' won't depend on it\n", key, child.Title)
+			}
+		}
+
+		var issue *types.Issue
+		if daemonClient != nil {
+			resp, err := daemonClient.Create(&rpc.CreateArgs{
+				Title:              child.Title,
+				Description:        child.Description,
+				IssueType:          string(issueType),
+				Priority:           priority,
+				Design:             child.Design,
+				AcceptanceCriteria: child.Acceptance,
+				Assignee:           child.Assignee,
+				Labels:             child.Labels,
+				Dependencies:       deps,
+				EstimatedMinutes:   child.Estimate,
+			})
+			if err == nil {
+				issue = &types.Issue{}
+				err = json.Unmarshal(resp.Data, issue)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating issue '%s': %v\n", child.Title, err)
+				continue
+			}
+		} else {
+			issue = &types.Issue{
+				Title:              child.Title,
+				Description:        child.Description,
+				Design:             child.Design,
+				AcceptanceCriteria: child.Acceptance,
+				Status:             types.StatusOpen,
+				Priority:           priority,
+				IssueType:          issueType,
+				Assignee:           child.Assignee,
+				EstimatedMinutes:   child.Estimate,
+			}
+			if err := store.CreateIssue(ctx, issue, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating issue '%s': %v\n", child.Title, err)
+				continue
+			}
+			for _, label := range child.Labels {
+				if err := store.AddLabel(ctx, issue.ID, label, actor); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to add label %s to %s: %v\n", label, issue.ID, err)
+				}
+			}
+			for _, spec := range deps {
+				depType, dependsOnID, _ := strings.Cut(spec, ":")
+				dep := &types.Dependency{IssueID: issue.ID, DependsOnID: dependsOnID, Type: types.DependencyType(depType)}
+				if err := store.AddDependency(ctx, dep, actor); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to add dependency %s -> %s: %v\n", issue.ID, dependsOnID, err)
+				}
+			}
+		}
+
+		if child.Key != "" {
+			ids[child.Key] = issue.ID
+		}
+		created = append(created, issue)
+	}
+	return created
+}
+
+func init() {
+	templateListCmd.Flags().Bool("json", false, "Output JSON format")
+	templateCmd.AddCommand(templateListCmd)
+	rootCmd.AddCommand(templateCmd)
+}
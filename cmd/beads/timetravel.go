@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/shaneholloman/beads/internal/history"
+	"github.com/shaneholloman/beads/internal/query"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// resolveTimeTravel turns a --at/--as-of value into a point in time. It accepts
+// the same times as filters (7d, 2025-01-31, RFC 3339) and otherwise resolves
+// the value as a git revision, using its commit time.
+func resolveTimeTravel(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty time")
+	}
+	if t, err := query.ParseTime(value, now); err == nil {
+		return t, nil
+	}
+
+	invalid := fmt.Errorf("%q is neither a time (e.g. 7d, 2025-01-31, 2025-01-31T15:04:05Z) nor a git revision", value)
+	if strings.HasPrefix(value, "-") {
+		return time.Time{}, invalid
+	}
+	cmd := exec.Command("git", "log", "-1", "--format=%cI", value, "--") // #nosec G204 - revision is passed as a single argument
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, invalid
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(out)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse commit time of %s: %w", value, err)
+	}
+	return t, nil
+}
+
+// showIssuesAt prints issues as they were at the given time, reconstructed from
+// the audit trail. Requires direct mode.
+func showIssuesAt(ctx context.Context, ids []string, at time.Time, jsonOutput bool) {
+	type IssueAtDetails struct {
+		*types.Issue
+		AsOf time.Time `json:"as_of"`
+	}
+	allDetails := []interface{}{}
+	shown := 0
+	for _, id := range ids {
+		issue, err := history.IssueAt(ctx, store, id, at)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reconstructing %s: %v\n", id, err)
+			continue
+		}
+		if issue == nil {
+			fmt.Fprintf(os.Stderr, "Issue %s did not exist at %s\n", id, at.Local().Format("2006-01-02 15:04:05"))
+			continue
+		}
+
+		if jsonOutput {
+			allDetails = append(allDetails, &IssueAtDetails{Issue: issue, AsOf: at})
+			continue
+		}
+
+		if shown > 0 {
+			fmt.Println("\n" + strings.Repeat("─", 60))
+		}
+		shown++
+
+		cyan := color.New(color.FgCyan).SprintFunc()
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("\n%s: %s\n", cyan(issue.ID), issue.Title)
+		fmt.Printf("%s\n", yellow("As of "+at.Local().Format("2006-01-02 15:04:05 MST")))
+		fmt.Printf("Status: %s\n", issue.Status)
+		fmt.Printf("Priority: P%d\n", issue.Priority)
+		fmt.Printf("Type: %s\n", issue.IssueType)
+		if issue.Assignee != "" {
+			fmt.Printf("Assignee: %s\n", issue.Assignee)
+		}
+		if issue.EstimatedMinutes != nil {
+			fmt.Printf("Estimated: %d minutes\n", *issue.EstimatedMinutes)
+		}
+		fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04"))
+		fmt.Printf("Updated: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04"))
+		if issue.ClosedAt != nil {
+			fmt.Printf("Closed: %s\n", issue.ClosedAt.Format("2006-01-02 15:04"))
+		}
+
+		if issue.Description != "" {
+			fmt.Printf("\nDescription:\n%s\n", issue.Description)
+		}
+		if issue.Design != "" {
+			fmt.Printf("\nDesign:\n%s\n", issue.Design)
+		}
+		if issue.Notes != "" {
+			fmt.Printf("\nNotes:\n%s\n", issue.Notes)
+		}
+		if issue.AcceptanceCriteria != "" {
+			fmt.Printf("\nAcceptance Criteria:\n%s\n", issue.AcceptanceCriteria)
+		}
+
+		if len(issue.Labels) > 0 {
+			fmt.Printf("\nLabels: %v\n", issue.Labels)
+		}
+
+		if len(issue.Dependencies) > 0 {
+			fmt.Printf("\nDepends on (%d):\n", len(issue.Dependencies))
+			for _, dep := range issue.Dependencies {
+				// Show the target as it was at the same moment
+				target, err := history.IssueAt(ctx, store, dep.DependsOnID, at)
+				if err != nil || target == nil {
+					fmt.Printf("  → %s (%s)\n", dep.DependsOnID, dep.Type)
+					continue
+				}
+				fmt.Printf("  → %s: %s [P%d] (%s)\n", target.ID, target.Title, target.Priority, dep.Type)
+			}
+		}
+
+		if len(issue.Comments) > 0 {
+			fmt.Printf("\nComments (%d):\n", len(issue.Comments))
+			for _, comment := range issue.Comments {
+				fmt.Printf("  [%s at %s]\n  %s\n\n", comment.Author, comment.CreatedAt.Format("2006-01-02 15:04"), comment.Text)
+			}
+		}
+
+		fmt.Println()
+	}
+
+	if jsonOutput && len(allDetails) > 0 {
+		outputJSON(allDetails)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveTimeTravel(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	got, err := resolveTimeTravel("2d", now)
+	if err != nil || !got.Equal(now.Add(-48*time.Hour)) {
+		t.Errorf("resolveTimeTravel(2d) = %v, %v", got, err)
+	}
+	got, err = resolveTimeTravel("2025-01-02T03:04:05Z", now)
+	if err != nil || !got.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("resolveTimeTravel(timestamp) = %v, %v", got, err)
+	}
+
+	// Git revisions resolve to their commit time
+	dir := t.TempDir()
+	initTestGitRepo(t, dir)
+	commit := exec.Command("git", "commit", "--allow-empty", "-m", "first")
+	commit.Dir = dir
+	commit.Env = append(os.Environ(), "GIT_COMMITTER_DATE=2024-05-06T07:08:09Z")
+	if out, err := commit.CombinedOutput(); err != nil {
+		t.Fatalf("git commit failed: %v\n%s", err, out)
+	}
+	t.Chdir(dir)
+
+	got, err = resolveTimeTravel("HEAD", now)
+	if err != nil || !got.Equal(time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)) {
+		t.Errorf("resolveTimeTravel(HEAD) = %v, %v", got, err)
+	}
+
+	for _, bad := range []string{"", "yesterday-ish", "-v"} {
+		if _, err := resolveTimeTravel(bad, now); err == nil {
+			t.Errorf("resolveTimeTravel(%q) succeeded, want error", bad)
+		} else if bad != "" && !strings.Contains(err.Error(), "nor a git revision") {
+			t.Errorf("resolveTimeTravel(%q) error = %v", bad, err)
+		}
+	}
+}
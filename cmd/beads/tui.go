@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shaneholloman/beads/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [filter]",
+	Short: "Browse and triage issues in a full-screen terminal UI",
+	Long: `Open a full-screen interface with the issue list on the left and the selected
+issue on the right: description, design, acceptance criteria, notes, labels,
+dependencies and comments.
+
+The list shows the issues matching the filter, which takes the same
+expressions as 'beads list' (default: -status:closed; press / to change it).
+Edits apply to the issue on the right:
+
+  o/i/b/c   set status to open, in_progress, blocked or closed
+  s         set any status, including custom workflow statuses
+  0-4       set priority
+  l/L       add/remove a label
+  t/T       browse what the issue depends on, or what depends on it
+
+Press ? for all keys and q to quit. Works through the daemon when one is
+running, or directly against the database.
+
+Examples:
+  beads tui
+  beads tui 'assignee:alice priority<=1'
+  beads tui 'label:ui OR label:frontend'`,
+	Run: func(cmd *cobra.Command, args []string) {
+		filter := tui.DefaultFilter
+		if len(args) > 0 {
+			filter = strings.Join(args, " ")
+		}
+
+		var backend tui.Backend
+		if daemonClient != nil {
+			backend = tui.NewRPCBackend(daemonClient)
+		} else {
+			backend = tui.NewStoreBackend(store, actor, markDirtyAndScheduleFlush)
+		}
+
+		if _, err := tea.NewProgram(tui.New(backend, filter), tea.WithAltScreen()).Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
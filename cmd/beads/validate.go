@@ -209,7 +209,8 @@ func validateOrphanedDeps(ctx context.Context, fix bool) checkResult {
 
 	for _, issue := range allIssues {
 		for _, dep := range issue.Dependencies {
-			if !existingIDs[dep.DependsOnID] {
+			// Dependencies on other repositories can't be checked here
+			if !existingIDs[dep.DependsOnID] && !types.IsQualifiedID(dep.DependsOnID) {
 				orphaned = append(orphaned, orphanedDep{
 					issueID:    issue.ID,
 					orphanedID: dep.DependsOnID,
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream live issue changes from the daemon",
+	Long: `Stream issue changes made through the daemon as they happen, one JSON
+object per line:
+
+  {"type":"close","issue_id":"bd-a3f8","actor":"alice","timestamp":"..."}
+
+Event types: create, update, close, delete, comment, dep_add, dep_remove.
+Dependency events include depends_on_id. Changes made with --no-daemon are not
+seen, since only the daemon publishes events.
+
+Examples:
+  beads watch
+  beads watch --type create --type close
+  beads watch --issue bd-a3f8 | jq -r .type`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		eventTypes, _ := cmd.Flags().GetStringSlice("type")
+		issues, _ := cmd.Flags().GetStringSlice("issue")
+
+		if daemonClient == nil {
+			fmt.Fprintf(os.Stderr, "Error: watch requires a running daemon (start one with 'beads daemon')\n")
+			os.Exit(1)
+		}
+
+		args := &rpc.SubscribeArgs{Types: eventTypes}
+		for _, issue := range issues {
+			resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: issue})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", issue, err)
+				os.Exit(1)
+			}
+			var id string
+			if err := json.Unmarshal(resp.Data, &id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+				os.Exit(1)
+			}
+			args.IssueIDs = append(args.IssueIDs, id)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		err := daemonClient.Subscribe(args, func(event rpc.MutationEvent) error {
+			return encoder.Encode(event)
+		})
+		if err != nil {
+			if isUnknownOperationError(err) {
+				fmt.Fprintf(os.Stderr, "Error: the running daemon does not support watch; restart it with 'beads daemon --stop' and 'beads daemon'\n")
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stderr, "Daemon closed the connection")
+	},
+}
+
+func init() {
+	watchCmd.Flags().StringSlice("type", nil, "Only show these event types (repeatable or comma-separated)")
+	watchCmd.Flags().StringSlice("issue", nil, "Only show events for these issues")
+	rootCmd.AddCommand(watchCmd)
+}
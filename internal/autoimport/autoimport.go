@@ -204,7 +204,9 @@ func checkForMergeConflicts(jsonlData []byte, jsonlPath string) error {
 				"  1. Resolve the merge conflict in your Git client, OR\n"+
 				"  2. Export from database to regenerate clean JSONL:\n"+
 				"     beads export -o %s\n\n"+
-				"After resolving, commit the fixed JSONL file.\n", jsonlPath, jsonlPath)
+				"After resolving, commit the fixed JSONL file.\n\n"+
+				"To merge future changes automatically, install the merge driver:\n"+
+				"  beads merge-driver --install\n", jsonlPath, jsonlPath)
 		}
 	}
 	return nil
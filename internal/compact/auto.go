@@ -0,0 +1,371 @@
+package compact
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// AutoActor is the actor recorded on events written by background compaction.
+const AutoActor = "daemon"
+
+const autoStateKey = "auto_compact_state"
+
+// Defaults for the auto_compact_* config keys
+const (
+	defaultAutoInterval  = 6 * time.Hour
+	defaultAutoIdle      = 10 * time.Minute
+	defaultAutoMaxCalls  = 100
+	defaultAutoBatchSize = 10
+)
+
+// AutoConfig controls background compaction by the daemon. It is read from
+// the auto_compact_* config keys.
+type AutoConfig struct {
+	Enabled        bool          // auto_compact_enabled
+	Interval       time.Duration // auto_compact_interval: minimum time between runs
+	IdleAfter      time.Duration // auto_compact_idle: no requests for this long before a run
+	MaxCallsPerDay int           // auto_compact_max_calls_per_day: summarizer calls per day
+	BatchSize      int           // auto_compact_batch_size: issues per run
+}
+
+// AutoRun describes one background compaction run.
+type AutoRun struct {
+	ID         string    `json:"id"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Tier1      int       `json:"tier1"`
+	Tier2      int       `json:"tier2"`
+	Staged     int       `json:"staged,omitempty"` // Summaries staged for review rather than applied
+	Failed     int       `json:"failed"`
+	Calls      int       `json:"calls"`
+	SavedBytes int       `json:"saved_bytes"`
+	IssueIDs   []string  `json:"issue_ids,omitempty"`
+	Errors     []string  `json:"errors,omitempty"`
+}
+
+// AutoState is the background compaction state kept in the database metadata,
+// so the daily budget survives daemon restarts.
+type AutoState struct {
+	Day        string   `json:"day"` // Local date CallsToday counts, YYYY-MM-DD
+	CallsToday int      `json:"calls_today"`
+	Runs       int      `json:"runs"`
+	Compacted  int      `json:"compacted"`
+	SavedBytes int      `json:"saved_bytes"`
+	LastRun    *AutoRun `json:"last_run,omitempty"`
+}
+
+// AutoStatus reports background compaction for stats and metrics.
+type AutoStatus struct {
+	Enabled        bool     `json:"enabled"`
+	Interval       string   `json:"interval"`
+	IdleAfter      string   `json:"idle_after"`
+	MaxCallsPerDay int      `json:"max_calls_per_day"`
+	BatchSize      int      `json:"batch_size"`
+	CallsToday     int      `json:"calls_today"`
+	Runs           int      `json:"runs"`
+	Compacted      int      `json:"compacted"`
+	SavedBytes     int      `json:"saved_bytes"`
+	LastRun        *AutoRun `json:"last_run,omitempty"`
+}
+
+// LoadAutoConfig reads the auto_compact_* config keys, applying defaults to
+// unset keys.
+func LoadAutoConfig(ctx context.Context, store *sqlite.SQLiteStorage) (*AutoConfig, error) {
+	cfg := &AutoConfig{
+		Interval:       defaultAutoInterval,
+		IdleAfter:      defaultAutoIdle,
+		MaxCallsPerDay: defaultAutoMaxCalls,
+		BatchSize:      defaultAutoBatchSize,
+	}
+
+	get := func(key string) (string, error) {
+		value, err := store.GetConfig(ctx, key)
+		if err != nil {
+			return "", fmt.Errorf("failed to get %s: %w", key, err)
+		}
+		return value, nil
+	}
+
+	enabled, err := get("auto_compact_enabled")
+	if err != nil {
+		return nil, err
+	}
+	if enabled != "" {
+		if cfg.Enabled, err = strconv.ParseBool(enabled); err != nil {
+			return nil, fmt.Errorf("invalid auto_compact_enabled %q: must be true or false", enabled)
+		}
+	}
+
+	for key, dst := range map[string]*time.Duration{
+		"auto_compact_interval": &cfg.Interval,
+		"auto_compact_idle":     &cfg.IdleAfter,
+	} {
+		value, err := get(key)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid %s %q: must be a duration such as 30m or 6h", key, value)
+		}
+		*dst = d
+	}
+
+	for key, dst := range map[string]*int{
+		"auto_compact_max_calls_per_day": &cfg.MaxCallsPerDay,
+		"auto_compact_batch_size":        &cfg.BatchSize,
+	} {
+		value, err := get(key)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid %s %q: must be a non-negative integer", key, value)
+		}
+		*dst = n
+	}
+
+	return cfg, nil
+}
+
+// LoadAutoStatus returns the background compaction config and state.
+func LoadAutoStatus(ctx context.Context, store *sqlite.SQLiteStorage, now time.Time) (*AutoStatus, error) {
+	cfg, err := LoadAutoConfig(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	state, err := loadAutoState(ctx, store, now)
+	if err != nil {
+		return nil, err
+	}
+	return &AutoStatus{
+		Enabled:        cfg.Enabled,
+		Interval:       cfg.Interval.String(),
+		IdleAfter:      cfg.IdleAfter.String(),
+		MaxCallsPerDay: cfg.MaxCallsPerDay,
+		BatchSize:      cfg.BatchSize,
+		CallsToday:     state.CallsToday,
+		Runs:           state.Runs,
+		Compacted:      state.Compacted,
+		SavedBytes:     state.SavedBytes,
+		LastRun:        state.LastRun,
+	}, nil
+}
+
+// RunAuto performs one background compaction run if it is due: compaction
+// must be enabled, the daemon idle for at least IdleAfter, the last run at
+// least Interval ago, and the daily call budget not spent. Tier 1 candidates
+// go first, then Tier 2, up to BatchSize issues. It returns nil if no run was
+// due.
+//
+// Each compacted issue gets an event naming the run, so 'beads history'
+// shows what the daemon did and 'beads restore' can recover it. With
+// compact_mode set to pending_review, summaries are only staged and counted
+// in Staged.
+func RunAuto(ctx context.Context, store *sqlite.SQLiteStorage, idleFor time.Duration, now time.Time) (*AutoRun, error) {
+	cfg, err := LoadAutoConfig(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled || idleFor < cfg.IdleAfter {
+		return nil, nil
+	}
+
+	state, err := loadAutoState(ctx, store, now)
+	if err != nil {
+		return nil, err
+	}
+	if state.LastRun != nil && now.Sub(state.LastRun.StartedAt) < cfg.Interval {
+		return nil, nil
+	}
+	limit := cfg.BatchSize
+	if remaining := cfg.MaxCallsPerDay - state.CallsToday; remaining < limit {
+		limit = remaining
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	tier1, tier2, err := autoCandidates(ctx, store, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(tier1) == 0 && len(tier2) == 0 {
+		return nil, nil
+	}
+
+	model, _ := store.GetConfig(ctx, "compact_model")
+	baseURL, _ := store.GetConfig(ctx, "compact_api_url")
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if err := CheckAPIKey(model, apiKey); err != nil {
+		return nil, err
+	}
+	summarizer, err := NewSummarizer(model, apiKey, baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create summarizer: %w", err)
+	}
+	counter := &countingSummarizer{Summarizer: summarizer}
+	// One issue at a time keeps background API usage gentle, and refused
+	// summaries are remembered so later runs move on to other issues
+	config := &Config{Concurrency: 1, RememberRejections: true}
+	if err := loadReviewConfig(ctx, store, config); err != nil {
+		return nil, err
+	}
+	compactor := NewWithSummarizer(store, counter, config)
+
+	run := &AutoRun{
+		ID:        now.UTC().Format("20060102T150405Z"),
+		StartedAt: now,
+	}
+
+	runErr := compactAutoCandidates(ctx, store, compactor, run, tier1, tier2)
+
+	// Calls made before a failure still count against today's budget, and
+	// the failed run still waits out the interval before the next one
+	run.Calls = int(counter.calls.Load())
+	run.FinishedAt = time.Now()
+	state.CallsToday += run.Calls
+	state.LastRun = run
+	if runErr != nil {
+		run.Errors = append(run.Errors, runErr.Error())
+		_ = saveAutoState(ctx, store, state) // The run's error is the one to report
+		return nil, runErr
+	}
+
+	state.Runs++
+	state.Compacted += run.Tier1 + run.Tier2
+	state.SavedBytes += run.SavedBytes
+	if err := saveAutoState(ctx, store, state); err != nil {
+		return run, err
+	}
+	return run, nil
+}
+
+// compactAutoCandidates compacts the Tier 1 and then the Tier 2 candidates,
+// tallying the results in run. Per-issue failures are counted in run; the
+// returned error means the run itself could not continue.
+func compactAutoCandidates(ctx context.Context, store *sqlite.SQLiteStorage, compactor *Compactor, run *AutoRun, tier1, tier2 []string) error {
+	for i, ids := range [][]string{tier1, tier2} {
+		tier := i + 1
+		if len(ids) == 0 {
+			continue
+		}
+		results, err := compactor.compactBatch(ctx, ids, tier)
+		if err != nil {
+			return fmt.Errorf("tier %d compaction failed: %w", tier, err)
+		}
+		for _, r := range results {
+			if r.Err != nil {
+				run.Failed++
+				run.Errors = append(run.Errors, fmt.Sprintf("%s: %v", r.IssueID, r.Err))
+				continue
+			}
+			if r.Staged {
+				// Nothing changed yet; 'beads compact review' applies it
+				run.Staged++
+				continue
+			}
+			if tier == 1 {
+				run.Tier1++
+			} else {
+				run.Tier2++
+			}
+			run.SavedBytes += r.OriginalSize - r.CompactedSize
+			run.IssueIDs = append(run.IssueIDs, r.IssueID)
+
+			comment := fmt.Sprintf("Auto-compaction run %s: Tier %d, %d → %d bytes", run.ID, tier, r.OriginalSize, r.CompactedSize)
+			if err := store.AddComment(ctx, r.IssueID, AutoActor, comment); err != nil {
+				return fmt.Errorf("failed to record compaction of %s: %w", r.IssueID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// autoCandidates picks up to limit issues, Tier 1 candidates first
+func autoCandidates(ctx context.Context, store *sqlite.SQLiteStorage, limit int) (tier1, tier2 []string, err error) {
+	candidates, err := store.GetTier1Candidates(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tier1 candidates: %w", err)
+	}
+	for _, c := range candidates {
+		if len(tier1) == limit {
+			return tier1, nil, nil
+		}
+		tier1 = append(tier1, c.IssueID)
+	}
+
+	candidates, err = Tier2Candidates(ctx, store)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tier2 candidates: %w", err)
+	}
+	for _, c := range candidates {
+		if len(tier1)+len(tier2) == limit {
+			break
+		}
+		tier2 = append(tier2, c.IssueID)
+	}
+	return tier1, tier2, nil
+}
+
+// loadAutoState reads the saved state, starting a new day's budget when the
+// local date has changed
+func loadAutoState(ctx context.Context, store *sqlite.SQLiteStorage, now time.Time) (*AutoState, error) {
+	var state AutoState
+	raw, err := store.GetMetadata(ctx, autoStateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", autoStateKey, err)
+	}
+	if raw != "" {
+		if err := json.Unmarshal([]byte(raw), &state); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", autoStateKey, err)
+		}
+	}
+	if today := now.Local().Format("2006-01-02"); state.Day != today {
+		state.Day = today
+		state.CallsToday = 0
+	}
+	return &state, nil
+}
+
+func saveAutoState(ctx context.Context, store *sqlite.SQLiteStorage, state *AutoState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", autoStateKey, err)
+	}
+	if err := store.SetMetadata(ctx, autoStateKey, string(data)); err != nil {
+		return fmt.Errorf("failed to save %s: %w", autoStateKey, err)
+	}
+	return nil
+}
+
+// countingSummarizer counts the calls made to a summarizer, for the daily budget
+type countingSummarizer struct {
+	Summarizer
+	calls atomic.Int64
+}
+
+func (c *countingSummarizer) SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error) {
+	c.calls.Add(1)
+	return c.Summarizer.SummarizeTier1(ctx, issue)
+}
+
+func (c *countingSummarizer) SummarizeTier2(ctx context.Context, issue *types.Issue) (string, error) {
+	c.calls.Add(1)
+	return c.Summarizer.SummarizeTier2(ctx, issue)
+}
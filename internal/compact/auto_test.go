@@ -0,0 +1,218 @@
+package compact
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestLoadAutoConfig(t *testing.T) {
+	store := setupTestStorage(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	cfg, err := LoadAutoConfig(ctx, store)
+	if err != nil {
+		t.Fatalf("LoadAutoConfig failed: %v", err)
+	}
+	if cfg.Enabled || cfg.Interval != defaultAutoInterval || cfg.IdleAfter != defaultAutoIdle ||
+		cfg.MaxCallsPerDay != defaultAutoMaxCalls || cfg.BatchSize != defaultAutoBatchSize {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+
+	for key, value := range map[string]string{
+		"auto_compact_enabled":           "true",
+		"auto_compact_interval":          "1h",
+		"auto_compact_idle":              "0s",
+		"auto_compact_max_calls_per_day": "20",
+		"auto_compact_batch_size":        "3",
+	} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("failed to set config: %v", err)
+		}
+	}
+	cfg, err = LoadAutoConfig(ctx, store)
+	if err != nil {
+		t.Fatalf("LoadAutoConfig failed: %v", err)
+	}
+	want := AutoConfig{Enabled: true, Interval: time.Hour, IdleAfter: 0, MaxCallsPerDay: 20, BatchSize: 3}
+	if *cfg != want {
+		t.Errorf("got %+v, want %+v", *cfg, want)
+	}
+
+	if err := store.SetConfig(ctx, "auto_compact_interval", "often"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	if _, err := LoadAutoConfig(ctx, store); err == nil || !strings.Contains(err.Error(), "auto_compact_interval") {
+		t.Errorf("expected an invalid interval error, got %v", err)
+	}
+}
+
+func TestRunAuto(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	store := setupTestStorage(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	for _, id := range []string{"beads-auto-1", "beads-auto-2", "beads-auto-3"} {
+		createClosedIssue(t, store, id)
+	}
+	for key, value := range map[string]string{
+		"compact_model":                  "extractive",
+		"auto_compact_idle":              "5m",
+		"auto_compact_interval":          "1h",
+		"auto_compact_max_calls_per_day": "3",
+		"auto_compact_batch_size":        "2",
+	} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("failed to set config: %v", err)
+		}
+	}
+
+	now := time.Now()
+	run, err := RunAuto(ctx, store, time.Hour, now)
+	if err != nil || run != nil {
+		t.Fatalf("expected no run while disabled, got %+v, %v", run, err)
+	}
+
+	if err := store.SetConfig(ctx, "auto_compact_enabled", "true"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	if run, err := RunAuto(ctx, store, time.Minute, now); err != nil || run != nil {
+		t.Fatalf("expected no run while the daemon is busy, got %+v, %v", run, err)
+	}
+
+	run, err = RunAuto(ctx, store, time.Hour, now)
+	if err != nil {
+		t.Fatalf("RunAuto failed: %v", err)
+	}
+	if run == nil || run.Tier1 != 2 || run.Calls != 2 || len(run.IssueIDs) != 2 {
+		t.Fatalf("expected a batch of 2 Tier 1 compactions, got %+v", run)
+	}
+
+	// The run is recorded on each issue it compacted
+	events, err := store.GetEvents(ctx, run.IssueIDs[0], 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	found := false
+	for _, e := range events {
+		if e.EventType == types.EventCommented && e.Actor == AutoActor && e.Comment != nil &&
+			strings.Contains(*e.Comment, "Auto-compaction run "+run.ID) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an auto-compaction event on %s", run.IssueIDs[0])
+	}
+
+	// Too soon for another run
+	if run, err := RunAuto(ctx, store, time.Hour, now.Add(30*time.Minute)); err != nil || run != nil {
+		t.Fatalf("expected no run within the interval, got %+v, %v", run, err)
+	}
+
+	// One call left in today's budget
+	run, err = RunAuto(ctx, store, time.Hour, now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("RunAuto failed: %v", err)
+	}
+	if run == nil || run.Tier1 != 1 {
+		t.Fatalf("expected the budget to allow one more compaction, got %+v", run)
+	}
+
+	status, err := LoadAutoStatus(ctx, store, now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("LoadAutoStatus failed: %v", err)
+	}
+	if !status.Enabled || status.CallsToday != 3 || status.Runs != 2 || status.Compacted != 3 || status.LastRun.ID != run.ID {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	// The budget resets the next day
+	status, err = LoadAutoStatus(ctx, store, now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("LoadAutoStatus failed: %v", err)
+	}
+	if status.CallsToday != 0 || status.Runs != 2 {
+		t.Errorf("expected a fresh daily budget, got %+v", status)
+	}
+}
+
+func TestRunAutoRemembersRejections(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	store := setupTestStorage(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	// The extractive summary of a one-line description is longer than it
+	createClosedIssue(t, store, "beads-auto-1")
+	short := map[string]interface{}{
+		"description":         "Done.",
+		"design":              "",
+		"notes":               "",
+		"acceptance_criteria": "",
+	}
+	if err := store.UpdateIssue(ctx, "beads-auto-1", short, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	for key, value := range map[string]string{
+		"compact_model":         "extractive",
+		"auto_compact_enabled":  "true",
+		"auto_compact_idle":     "0s",
+		"auto_compact_interval": "1h",
+	} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("failed to set config: %v", err)
+		}
+	}
+
+	now := time.Now()
+	run, err := RunAuto(ctx, store, time.Hour, now)
+	if err != nil {
+		t.Fatalf("RunAuto failed: %v", err)
+	}
+	if run == nil || run.Failed != 1 || run.Calls != 1 {
+		t.Fatalf("expected one refused summary, got %+v", run)
+	}
+	comments, err := store.GetIssueComments(ctx, "beads-auto-1")
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+
+	// The refused issue is not retried while it is unchanged
+	run, err = RunAuto(ctx, store, time.Hour, now.Add(2*time.Hour))
+	if err != nil || run != nil {
+		t.Fatalf("expected no run with only a rejected candidate, got %+v, %v", run, err)
+	}
+	again, err := store.GetIssueComments(ctx, "beads-auto-1")
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(again) != len(comments) {
+		t.Errorf("expected no new comments, had %d, now %d", len(comments), len(again))
+	}
+	status, err := LoadAutoStatus(ctx, store, now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("LoadAutoStatus failed: %v", err)
+	}
+	if status.CallsToday != 1 {
+		t.Errorf("expected 1 call charged today, got %d", status.CallsToday)
+	}
+
+	// Changing the issue makes it a candidate again
+	if err := store.UpdateIssue(ctx, "beads-auto-1", map[string]interface{}{"description": "Done. " + strings.Repeat("Explained at length. ", 40)}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	run, err = RunAuto(ctx, store, time.Hour, now.Add(4*time.Hour))
+	if err != nil {
+		t.Fatalf("RunAuto failed: %v", err)
+	}
+	if run == nil || run.Tier1 != 1 {
+		t.Fatalf("expected the changed issue to be compacted, got %+v", run)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
 )
 
 const (
@@ -18,16 +19,26 @@ type Config struct {
 	APIKey      string
 	Concurrency int
 	DryRun      bool
+	Model       string // Summarizer selection (see ParseModel); defaults to the compact_model config
+	BaseURL     string // OpenAI-compatible endpoint; defaults to the compact_api_url config
+	Verify      string // Reference check (VerifyFlag, VerifyReject, VerifyOff); defaults to the compact_verify config
+	Mode        string // ModeApply or ModePendingReview; defaults to the compact_mode config
+	// RememberRejections records summaries the compactor refuses (too long, or
+	// dropping references under VerifyReject) so the issue is skipped until its
+	// content changes. Background runs set it to avoid retrying the same issues.
+	RememberRejections bool
 }
 
 // Compactor handles issue compaction using AI summarization.
 type Compactor struct {
-	store  *sqlite.SQLiteStorage
-	haiku  *HaikuClient
-	config *Config
+	store      *sqlite.SQLiteStorage
+	summarizer Summarizer
+	config     *Config
 }
 
-// New creates a new Compactor instance with the given configuration.
+// New creates a new Compactor instance with the given configuration, using
+// the summarizer selected by config.Model or the compact_model config key.
+// Without an Anthropic API key, the Anthropic summarizer falls back to dry-run.
 func New(store *sqlite.SQLiteStorage, apiKey string, config *Config) (*Compactor, error) {
 	if config == nil {
 		config = &Config{
@@ -41,24 +52,52 @@ func New(store *sqlite.SQLiteStorage, apiKey string, config *Config) (*Compactor
 		config.APIKey = apiKey
 	}
 
-	var haikuClient *HaikuClient
+	ctx := context.Background()
+	if config.Model == "" {
+		config.Model, _ = store.GetConfig(ctx, "compact_model")
+	}
+	if config.BaseURL == "" {
+		config.BaseURL, _ = store.GetConfig(ctx, "compact_api_url")
+	}
+	if err := loadReviewConfig(ctx, store, config); err != nil {
+		return nil, err
+	}
+
+	var summarizer Summarizer
 	var err error
 	if !config.DryRun {
-		haikuClient, err = NewHaikuClient(config.APIKey)
+		summarizer, err = NewSummarizer(config.Model, config.APIKey, config.BaseURL)
 		if err != nil {
 			if errors.Is(err, ErrAPIKeyRequired) {
 				config.DryRun = true
 			} else {
-				return nil, fmt.Errorf("failed to create Haiku client: %w", err)
+				return nil, fmt.Errorf("failed to create summarizer: %w", err)
 			}
 		}
+	} else if _, _, err := ParseModel(config.Model); err != nil {
+		return nil, err
 	}
 
+	return NewWithSummarizer(store, summarizer, config), nil
+}
+
+// NewWithSummarizer creates a Compactor that uses the given summarizer.
+// Unset or invalid Verify and Mode fall back to VerifyFlag and ModeApply.
+func NewWithSummarizer(store *sqlite.SQLiteStorage, summarizer Summarizer, config *Config) *Compactor {
+	if config == nil {
+		config = &Config{}
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultConcurrency
+	}
+	if err := validateReviewConfig(config); err != nil {
+		config.Verify, config.Mode = VerifyFlag, ModeApply
+	}
 	return &Compactor{
-		store:  store,
-		haiku:  haikuClient,
-		config: config,
-	}, nil
+		store:      store,
+		summarizer: summarizer,
+		config:     config,
+	}
 }
 
 // Result holds the outcome of a compaction operation.
@@ -66,93 +105,121 @@ type Result struct {
 	IssueID       string
 	OriginalSize  int
 	CompactedSize int
+	Staged        bool     // Summary saved for review instead of applied (ModePendingReview)
+	MissingRefs   []string // References in the original the summary dropped
 	Err           error
 }
 
+// PendingReview reports whether summaries are staged for review rather than
+// applied.
+func (c *Compactor) PendingReview() bool {
+	return c.config.Mode == ModePendingReview
+}
+
 // CompactTier1 performs tier-1 compaction on a single issue using AI summarization.
 func (c *Compactor) CompactTier1(ctx context.Context, issueID string) error {
+	_, err := c.Compact(ctx, issueID, 1)
+	return err
+}
+
+// CompactTier2 performs tier-2 compaction on a single issue: its Tier 1
+// summary is condensed further, and its events and comments are archived
+// into a snapshot and pruned. RestoreSnapshot undoes it.
+func (c *Compactor) CompactTier2(ctx context.Context, issueID string) error {
+	_, err := c.Compact(ctx, issueID, 2)
+	return err
+}
+
+// Compact compacts a single issue at the given tier. The result reports
+// whether the summary was staged for review and which references it dropped.
+func (c *Compactor) Compact(ctx context.Context, issueID string, tier int) (*Result, error) {
 	if ctx.Err() != nil {
-		return ctx.Err()
+		return nil, ctx.Err()
 	}
 
-	eligible, reason, err := c.store.CheckEligibility(ctx, issueID, 1)
+	eligible, reason, err := CheckEligibility(ctx, c.store, issueID, tier)
 	if err != nil {
-		return fmt.Errorf("failed to verify eligibility: %w", err)
+		return nil, fmt.Errorf("failed to verify eligibility: %w", err)
 	}
 
 	if !eligible {
 		if reason != "" {
-			return fmt.Errorf("issue %s is not eligible for Tier 1 compaction: %s", issueID, reason)
+			return nil, fmt.Errorf("issue %s is not eligible for Tier %d compaction: %s", issueID, tier, reason)
 		}
-		return fmt.Errorf("issue %s is not eligible for Tier 1 compaction", issueID)
-	}
-
-	issue, err := c.store.GetIssue(ctx, issueID)
-	if err != nil {
-		return fmt.Errorf("failed to get issue: %w", err)
+		return nil, fmt.Errorf("issue %s is not eligible for Tier %d compaction", issueID, tier)
 	}
 
-	originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
-
 	if c.config.DryRun {
-		return fmt.Errorf("dry-run: would compact %s (original size: %d bytes)", issueID, originalSize)
+		issue, err := c.store.GetIssue(ctx, issueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+		originalSize := len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
+		return nil, fmt.Errorf("dry-run: would compact %s (original size: %d bytes)", issueID, originalSize)
 	}
 
-	summary, err := c.haiku.SummarizeTier1(ctx, issue)
-	if err != nil {
-		return fmt.Errorf("failed to summarize with Haiku: %w", err)
+	result := &Result{IssueID: issueID}
+	if err := c.compactWithResult(ctx, issueID, tier, result); err != nil {
+		return nil, err
 	}
+	return result, nil
+}
 
-	compactedSize := len(summary)
-
-	if compactedSize >= originalSize {
-		warningMsg := fmt.Sprintf("Tier 1 compaction skipped: summary (%d bytes) not shorter than original (%d bytes)", compactedSize, originalSize)
-		if err := c.store.AddComment(ctx, issueID, "compactor", warningMsg); err != nil {
-			return fmt.Errorf("failed to record warning: %w", err)
-		}
-		return fmt.Errorf("compaction would increase size (%d → %d bytes), keeping original", originalSize, compactedSize)
+func (c *Compactor) compactTier2(ctx context.Context, issueID string, result *Result) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
 
-	updates := map[string]interface{}{
-		"description":         summary,
-		"design":              "",
-		"notes":               "",
-		"acceptance_criteria": "",
+	issue, err := c.store.GetIssue(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
 	}
 
-	if err := c.store.UpdateIssue(ctx, issueID, updates, "compactor"); err != nil {
-		return fmt.Errorf("failed to update issue: %w", err)
-	}
+	result.OriginalSize = len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
 
-	commitHash := GetCurrentCommitHash()
-	if err := c.store.ApplyCompaction(ctx, issueID, 1, originalSize, compactedSize, commitHash); err != nil {
-		return fmt.Errorf("failed to set compaction level: %w", err)
+	summary, err := c.summarizer.SummarizeTier2(ctx, issue)
+	if err != nil {
+		return fmt.Errorf("failed to summarize: %w", err)
 	}
 
-	savingBytes := originalSize - compactedSize
-	eventData := fmt.Sprintf("Tier 1 compaction: %d → %d bytes (saved %d)", originalSize, compactedSize, savingBytes)
-	if err := c.store.AddComment(ctx, issueID, "compactor", eventData); err != nil {
-		return fmt.Errorf("failed to record event: %w", err)
-	}
+	result.CompactedSize = len(summary)
 
-	if err := c.store.MarkIssueDirty(ctx, issueID); err != nil {
-		return fmt.Errorf("failed to mark dirty: %w", err)
+	// Events and comments are pruned even when the text barely shrinks, so
+	// only a longer summary is refused
+	if result.CompactedSize > result.OriginalSize {
+		if err := c.rememberRejection(ctx, issue, 2, summary, result); err != nil {
+			return err
+		}
+		return fmt.Errorf("compaction would increase size (%d → %d bytes), keeping original", result.OriginalSize, result.CompactedSize)
 	}
 
-	return nil
+	return c.verifyAndApply(ctx, issue, 2, summary, result)
 }
 
 // CompactTier1Batch performs tier-1 compaction on multiple issues in a single batch.
 func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([]*Result, error) {
+	return c.compactBatch(ctx, issueIDs, 1)
+}
+
+// CompactTier2Batch performs tier-2 compaction on multiple issues in a single batch.
+func (c *Compactor) CompactTier2Batch(ctx context.Context, issueIDs []string) ([]*Result, error) {
+	return c.compactBatch(ctx, issueIDs, 2)
+}
+
+func (c *Compactor) compactBatch(ctx context.Context, issueIDs []string, tier int) ([]*Result, error) {
 	if len(issueIDs) == 0 {
 		return nil, nil
 	}
 
+	eligibility, err := NewEligibility(ctx, c.store, tier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify eligibility: %w", err)
+	}
 	eligibleIDs := make([]string, 0, len(issueIDs))
 	results := make([]*Result, 0, len(issueIDs))
 
 	for _, id := range issueIDs {
-		eligible, reason, err := c.store.CheckEligibility(ctx, id, 1)
+		eligible, reason, err := eligibility.Check(ctx, id)
 		if err != nil {
 			results = append(results, &Result{
 				IssueID: id,
@@ -163,7 +230,7 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 		if !eligible {
 			results = append(results, &Result{
 				IssueID: id,
-				Err:     fmt.Errorf("not eligible for Tier 1 compaction: %s", reason),
+				Err:     fmt.Errorf("not eligible for Tier %d compaction: %s", tier, reason),
 			})
 		} else {
 			eligibleIDs = append(eligibleIDs, id)
@@ -205,7 +272,7 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 			for issueID := range workCh {
 				result := &Result{IssueID: issueID}
 
-				if err := c.compactSingleWithResult(ctx, issueID, result); err != nil {
+				if err := c.compactWithResult(ctx, issueID, tier, result); err != nil {
 					result.Err = err
 				}
 
@@ -231,6 +298,13 @@ func (c *Compactor) CompactTier1Batch(ctx context.Context, issueIDs []string) ([
 	return results, nil
 }
 
+func (c *Compactor) compactWithResult(ctx context.Context, issueID string, tier int, result *Result) error {
+	if tier == 2 {
+		return c.compactTier2(ctx, issueID, result)
+	}
+	return c.compactSingleWithResult(ctx, issueID, result)
+}
+
 func (c *Compactor) compactSingleWithResult(ctx context.Context, issueID string, result *Result) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
@@ -243,9 +317,9 @@ func (c *Compactor) compactSingleWithResult(ctx context.Context, issueID string,
 
 	result.OriginalSize = len(issue.Description) + len(issue.Design) + len(issue.Notes) + len(issue.AcceptanceCriteria)
 
-	summary, err := c.haiku.SummarizeTier1(ctx, issue)
+	summary, err := c.summarizer.SummarizeTier1(ctx, issue)
 	if err != nil {
-		return fmt.Errorf("failed to summarize with Haiku: %w", err)
+		return fmt.Errorf("failed to summarize: %w", err)
 	}
 
 	result.CompactedSize = len(summary)
@@ -255,9 +329,93 @@ func (c *Compactor) compactSingleWithResult(ctx context.Context, issueID string,
 		if err := c.store.AddComment(ctx, issueID, "compactor", warningMsg); err != nil {
 			return fmt.Errorf("failed to record warning: %w", err)
 		}
+		if err := c.rememberRejection(ctx, issue, 1, summary, result); err != nil {
+			return err
+		}
 		return fmt.Errorf("compaction would increase size (%d → %d bytes), keeping original", result.OriginalSize, result.CompactedSize)
 	}
 
+	return c.verifyAndApply(ctx, issue, 1, summary, result)
+}
+
+// verifyAndApply checks that summary keeps the references in the issue
+// text, then applies it or stages it for review.
+func (c *Compactor) verifyAndApply(ctx context.Context, issue *types.Issue, tier int, summary string, result *Result) error {
+	if c.config.Verify != VerifyOff {
+		result.MissingRefs = MissingReferences(compactedText(issue), summary, IssuePrefix(issue.ID), tier)
+	}
+	if len(result.MissingRefs) > 0 && c.config.Verify == VerifyReject {
+		refs := FormatReferences(result.MissingRefs)
+		warningMsg := fmt.Sprintf("Tier %d compaction skipped: summary dropped references (%s)", tier, refs)
+		if err := c.store.AddComment(ctx, issue.ID, "compactor", warningMsg); err != nil {
+			return fmt.Errorf("failed to record warning: %w", err)
+		}
+		if err := c.rememberRejection(ctx, issue, tier, summary, result); err != nil {
+			return err
+		}
+		return fmt.Errorf("summary dropped references (%s), keeping original", refs)
+	}
+
+	if c.config.Mode == ModePendingReview {
+		err := c.store.StagePendingCompaction(ctx, &sqlite.PendingCompaction{
+			IssueID:      issue.ID,
+			Tier:         tier,
+			Summary:      summary,
+			OriginalSize: result.OriginalSize,
+			ContentHash:  contentHash(issue),
+			MissingRefs:  result.MissingRefs,
+		})
+		if err != nil {
+			return err
+		}
+		result.Staged = true
+		return nil
+	}
+
+	return applySummary(ctx, c.store, issue.ID, tier, summary, result.OriginalSize, result.MissingRefs)
+}
+
+// rememberRejection records a refused summary against the issue's current
+// content when RememberRejections is set
+func (c *Compactor) rememberRejection(ctx context.Context, issue *types.Issue, tier int, summary string, result *Result) error {
+	if !c.config.RememberRejections {
+		return nil
+	}
+	return c.store.RecordRejectedCompaction(ctx, &sqlite.PendingCompaction{
+		IssueID:      issue.ID,
+		Tier:         tier,
+		Summary:      summary,
+		OriginalSize: result.OriginalSize,
+		ContentHash:  contentHash(issue),
+		MissingRefs:  result.MissingRefs,
+	})
+}
+
+// applySummary replaces the issue text with summary and records the
+// compaction, noting any references the summary dropped.
+func applySummary(ctx context.Context, store *sqlite.SQLiteStorage, issueID string, tier int, summary string, originalSize int, missing []string) error {
+	compactedSize := len(summary)
+	var dropped string
+	if len(missing) > 0 {
+		dropped = fmt.Sprintf("; dropped references: %s", FormatReferences(missing))
+	}
+
+	if tier == 2 {
+		if err := store.ApplyTier2Compaction(ctx, issueID, summary, GetCurrentCommitHash()); err != nil {
+			return fmt.Errorf("failed to apply compaction: %w", err)
+		}
+		// The compaction event itself is kept by the Tier 2 prune, so only
+		// dropped references need a note
+		if dropped == "" {
+			return nil
+		}
+		eventData := fmt.Sprintf("Tier 2 compaction: %d → %d bytes%s", originalSize, compactedSize, dropped)
+		if err := store.AddComment(ctx, issueID, "compactor", eventData); err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+		return nil
+	}
+
 	updates := map[string]interface{}{
 		"description":         summary,
 		"design":              "",
@@ -265,22 +423,22 @@ func (c *Compactor) compactSingleWithResult(ctx context.Context, issueID string,
 		"acceptance_criteria": "",
 	}
 
-	if err := c.store.UpdateIssue(ctx, issueID, updates, "compactor"); err != nil {
+	if err := store.UpdateIssue(ctx, issueID, updates, "compactor"); err != nil {
 		return fmt.Errorf("failed to update issue: %w", err)
 	}
 
 	commitHash := GetCurrentCommitHash()
-	if err := c.store.ApplyCompaction(ctx, issueID, 1, result.OriginalSize, result.CompactedSize, commitHash); err != nil {
+	if err := store.ApplyCompaction(ctx, issueID, 1, originalSize, compactedSize, commitHash); err != nil {
 		return fmt.Errorf("failed to set compaction level: %w", err)
 	}
 
-	savingBytes := result.OriginalSize - result.CompactedSize
-	eventData := fmt.Sprintf("Tier 1 compaction: %d → %d bytes (saved %d)", result.OriginalSize, result.CompactedSize, savingBytes)
-	if err := c.store.AddComment(ctx, issueID, "compactor", eventData); err != nil {
+	savingBytes := originalSize - compactedSize
+	eventData := fmt.Sprintf("Tier 1 compaction: %d → %d bytes (saved %d)%s", originalSize, compactedSize, savingBytes, dropped)
+	if err := store.AddComment(ctx, issueID, "compactor", eventData); err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
 
-	if err := c.store.MarkIssueDirty(ctx, issueID); err != nil {
+	if err := store.MarkIssueDirty(ctx, issueID); err != nil {
 		return fmt.Errorf("failed to mark dirty: %w", err)
 	}
 
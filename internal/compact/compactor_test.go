@@ -3,6 +3,7 @@ package compact
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -386,6 +387,212 @@ func TestCompactTier1Batch_WithAPI(t *testing.T) {
 	}
 }
 
+func TestCompactTier1_Extractive(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	store := setupTestStorage(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "compact_model", "extractive"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	issue := createClosedIssue(t, store, "beads-extractive")
+
+	c, err := New(store, "", nil)
+	if err != nil {
+		t.Fatalf("failed to create compactor: %v", err)
+	}
+	if c.config.DryRun {
+		t.Fatal("extractive compaction should not need an API key")
+	}
+
+	results, err := c.CompactTier1Batch(ctx, []string{issue.ID})
+	if err != nil {
+		t.Fatalf("failed to compact: %v", err)
+	}
+	if len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("unexpected results: %+v", results[0])
+	}
+
+	afterIssue, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if !strings.HasPrefix(afterIssue.Description, "**Summary:** Implemented a comprehensive authentication system") {
+		t.Errorf("unexpected summary: %q", afterIssue.Description)
+	}
+	if afterIssue.CompactionLevel != 1 || afterIssue.Design != "" || afterIssue.Notes != "" {
+		t.Errorf("expected tier 1 compaction with cleared fields, got level %d", afterIssue.CompactionLevel)
+	}
+}
+
+func TestCompactTier2_Extractive(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	store := setupTestStorage(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	for key, value := range map[string]string{
+		"compact_model":         "extractive",
+		"compact_tier2_days":    "0",
+		"compact_tier2_commits": "0",
+	} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("failed to set config: %v", err)
+		}
+	}
+	issue := createClosedIssue(t, store, "beads-tier2")
+
+	c, err := New(store, "", nil)
+	if err != nil {
+		t.Fatalf("failed to create compactor: %v", err)
+	}
+
+	if err := c.CompactTier2(ctx, issue.ID); err == nil || !strings.Contains(err.Error(), "not eligible") {
+		t.Fatalf("expected tier 2 to require tier 1, got %v", err)
+	}
+	if err := c.CompactTier1(ctx, issue.ID); err != nil {
+		t.Fatalf("failed to compact tier 1: %v", err)
+	}
+	tier1, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+
+	candidates, err := Tier2Candidates(ctx, store)
+	if err != nil {
+		t.Fatalf("Tier2Candidates failed: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].IssueID != issue.ID {
+		t.Fatalf("expected %s as the only tier 2 candidate, got %+v", issue.ID, candidates)
+	}
+
+	results, err := c.CompactTier2Batch(ctx, []string{issue.ID})
+	if err != nil {
+		t.Fatalf("failed to compact: %v", err)
+	}
+	if len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("unexpected results: %+v", results[0])
+	}
+
+	afterIssue, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if afterIssue.CompactionLevel != 2 {
+		t.Errorf("expected compaction level 2, got %d", afterIssue.CompactionLevel)
+	}
+	if len(afterIssue.Description) >= len(tier1.Description) || strings.Contains(afterIssue.Description, "**") {
+		t.Errorf("expected a shorter plain summary, got %q", afterIssue.Description)
+	}
+
+	snap, err := store.GetLatestSnapshot(ctx, issue.ID)
+	if err != nil || snap == nil {
+		t.Fatalf("expected a snapshot, got %v, %v", snap, err)
+	}
+	if snap.Content.Description != tier1.Description {
+		t.Errorf("snapshot should hold the tier 1 text, got %q", snap.Content.Description)
+	}
+}
+
+func TestTier2Candidates_CommitThreshold(t *testing.T) {
+	store := setupTestStorage(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "compact_tier2_days", "0"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	issue := createClosedIssue(t, store, "beads-commits")
+	head := GetCurrentCommitHash()
+	if head == "" {
+		t.Skip("not in a git repository")
+	}
+	if err := store.ApplyCompaction(ctx, issue.ID, 1, 1000, 100, head); err != nil {
+		t.Fatalf("ApplyCompaction failed: %v", err)
+	}
+
+	// Compacted at HEAD: no commits since, so not yet eligible
+	candidates, err := Tier2Candidates(ctx, store)
+	if err != nil {
+		t.Fatalf("Tier2Candidates failed: %v", err)
+	}
+	if len(candidates) != 0 {
+		t.Errorf("expected no candidates before enough commits, got %d", len(candidates))
+	}
+	eligible, reason, err := CheckEligibility(ctx, store, issue.ID, 2)
+	if err != nil || eligible || !strings.Contains(reason, "commits") {
+		t.Errorf("expected ineligible for commits, got %v %q %v", eligible, reason, err)
+	}
+
+	if err := store.SetConfig(ctx, "compact_tier2_commits", "0"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	candidates, err = Tier2Candidates(ctx, store)
+	if err != nil {
+		t.Fatalf("Tier2Candidates failed: %v", err)
+	}
+	if len(candidates) != 1 {
+		t.Errorf("expected the commit threshold to be disabled, got %d candidates", len(candidates))
+	}
+}
+
+func TestTier2Batch_CountsCommitsOnce(t *testing.T) {
+	store := setupTestStorage(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "compact_tier2_days", "0"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	calls := 0
+	commitsSince = func(string) (int, bool) {
+		calls++
+		return 0, true
+	}
+	defer func() { commitsSince = CommitsSince }()
+
+	var ids []string
+	for _, id := range []string{"beads-a", "beads-b", "beads-c"} {
+		issue := createClosedIssue(t, store, id)
+		if err := store.ApplyCompaction(ctx, issue.ID, 1, 1000, 100, "abc123"); err != nil {
+			t.Fatalf("ApplyCompaction failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	c, err := New(store, "", &Config{DryRun: true, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("failed to create compactor: %v", err)
+	}
+	results, err := c.CompactTier2Batch(ctx, ids)
+	if err != nil {
+		t.Fatalf("CompactTier2Batch failed: %v", err)
+	}
+	for _, r := range results {
+		if r.Err == nil || !strings.Contains(r.Err.Error(), "commits") {
+			t.Errorf("%s: expected ineligible for commits, got %v", r.IssueID, r.Err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("counted commits %d times for one shared Tier 1 commit, want 1", calls)
+	}
+}
+
+func TestNew_UnknownModel(t *testing.T) {
+	store := setupTestStorage(t)
+	defer store.Close()
+
+	if _, err := New(store, "", &Config{Model: "gemini:flash"}); err == nil {
+		t.Error("expected an unknown compact_model to be rejected")
+	}
+	if _, err := New(store, "", &Config{Model: "gemini:flash", DryRun: true}); err == nil {
+		t.Error("expected an unknown compact_model to be rejected in dry-run too")
+	}
+}
+
 func TestMockAPI_CompactTier1(t *testing.T) {
 	store := setupTestStorage(t)
 	defer store.Close()
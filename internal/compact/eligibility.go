@@ -0,0 +1,143 @@
+package compact
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+)
+
+const defaultTier2Commits = 100
+
+// commitsSince counts commits after a compaction commit; tests replace it
+var commitsSince = CommitsSince
+
+// Tier2Candidates returns the issues eligible for Tier 2 compaction. On top
+// of the storage criteria (closed for compact_tier2_days, Tier 1 compacted, no
+// open dependents), at least compact_tier2_commits commits must have landed
+// since the Tier 1 compaction. The commit criterion is skipped when the
+// commit is unknown to git and disabled by setting it to 0. Commits are
+// counted once per distinct Tier 1 commit.
+func Tier2Candidates(ctx context.Context, store *sqlite.SQLiteStorage) ([]*sqlite.CompactionCandidate, error) {
+	candidates, err := store.GetTier2Candidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	minCommits, err := tier2Commits(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	if minCommits == 0 {
+		return candidates, nil
+	}
+
+	enough := make(map[string]bool)
+	eligible := candidates[:0]
+	for _, c := range candidates {
+		ok, counted := enough[c.CompactedAtCommit]
+		if !counted {
+			n, known := commitsSince(c.CompactedAtCommit)
+			ok = !known || n >= minCommits
+			enough[c.CompactedAtCommit] = ok
+		}
+		if ok {
+			eligible = append(eligible, c)
+		}
+	}
+	return eligible, nil
+}
+
+// CheckEligibility checks if an issue is eligible for compaction at the given
+// tier, including the Tier 2 commit criterion. Returns (eligible, reason, error).
+// To check many issues, use NewEligibility.
+func CheckEligibility(ctx context.Context, store *sqlite.SQLiteStorage, issueID string, tier int) (bool, string, error) {
+	eligible, reason, err := store.CheckEligibility(ctx, issueID, tier)
+	if err != nil || !eligible || tier != 2 {
+		return eligible, reason, err
+	}
+
+	minCommits, err := tier2Commits(ctx, store)
+	if err != nil {
+		return false, "", err
+	}
+	if minCommits == 0 {
+		return true, "", nil
+	}
+	issue, err := store.GetIssue(ctx, issueID)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to get issue: %w", err)
+	}
+	if issue.CompactedAtCommit != nil {
+		if n, ok := commitsSince(*issue.CompactedAtCommit); ok && n < minCommits {
+			return false, fewerCommitsReason(minCommits), nil
+		}
+	}
+	return true, "", nil
+}
+
+// Eligibility checks many issues against one tier, computing the Tier 2
+// candidates (and so running git) once rather than per issue
+type Eligibility struct {
+	store      *sqlite.SQLiteStorage
+	tier       int
+	tier2      map[string]bool
+	minCommits int
+}
+
+// NewEligibility prepares eligibility checks at the given tier
+func NewEligibility(ctx context.Context, store *sqlite.SQLiteStorage, tier int) (*Eligibility, error) {
+	e := &Eligibility{store: store, tier: tier}
+	if tier != 2 {
+		return e, nil
+	}
+	candidates, err := Tier2Candidates(ctx, store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tier2 candidates: %w", err)
+	}
+	if e.minCommits, err = tier2Commits(ctx, store); err != nil {
+		return nil, err
+	}
+	e.tier2 = make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		e.tier2[c.IssueID] = true
+	}
+	return e, nil
+}
+
+// Check reports whether issueID is eligible, like CheckEligibility
+func (e *Eligibility) Check(ctx context.Context, issueID string) (bool, string, error) {
+	if e.tier != 2 {
+		return e.store.CheckEligibility(ctx, issueID, e.tier)
+	}
+	if e.tier2[issueID] {
+		return true, "", nil
+	}
+	// Not a candidate: report the storage criterion it fails, or else the
+	// commit criterion
+	eligible, reason, err := e.store.CheckEligibility(ctx, issueID, e.tier)
+	if err != nil || !eligible {
+		return false, reason, err
+	}
+	return false, fewerCommitsReason(e.minCommits), nil
+}
+
+func fewerCommitsReason(minCommits int) string {
+	return fmt.Sprintf("fewer than %d commits since Tier 1 compaction", minCommits)
+}
+
+func tier2Commits(ctx context.Context, store *sqlite.SQLiteStorage) (int, error) {
+	value, err := store.GetConfig(ctx, "compact_tier2_commits")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get compact_tier2_commits: %w", err)
+	}
+	if value == "" {
+		return defaultTier2Commits, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid compact_tier2_commits %q: must be a non-negative integer", value)
+	}
+	return n, nil
+}
@@ -0,0 +1,215 @@
+package compact
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+const (
+	extractiveSummarySentences = 2
+	extractiveDecisions        = 3
+	extractiveMaxSentence      = 200 // Runes
+)
+
+// ExtractiveSummarizer summarizes an issue without a language model by
+// keeping the leading sentences of its fields, in the same Summary / Key
+// Decisions / Resolution layout the model prompts ask for. It needs no
+// network or API key and always produces the same summary for the same
+// issue, at the cost of summaries that are cruder than a model's. Issue IDs,
+// file paths and code identifiers the leading sentences miss are listed
+// after them, so reference checks pass.
+type ExtractiveSummarizer struct{}
+
+// NewExtractiveSummarizer creates an extractive summarizer.
+func NewExtractiveSummarizer() *ExtractiveSummarizer {
+	return &ExtractiveSummarizer{}
+}
+
+// SummarizeTier1 creates a structured summary of an issue (Summary, Key Decisions, Resolution).
+func (e *ExtractiveSummarizer) SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	summary := leadingSentences(issue.Description, extractiveSummarySentences)
+	if len(summary) == 0 {
+		summary = []string{issue.Title}
+	}
+	var sb strings.Builder
+	sb.WriteString("**Summary:** " + strings.Join(summary, " "))
+
+	// The first sentence of each design paragraph or bullet
+	var decisions []string
+	for _, block := range textBlocks(issue.Design) {
+		if len(decisions) == extractiveDecisions {
+			break
+		}
+		if s := leadingSentences(block, 1); len(s) > 0 {
+			decisions = append(decisions, s[0])
+		}
+	}
+	if len(decisions) > 0 {
+		sb.WriteString("\n\n**Key Decisions:**")
+		for _, d := range decisions {
+			sb.WriteString("\n- " + d)
+		}
+	}
+
+	resolution := leadingSentences(issue.Notes, 1)
+	if len(resolution) == 0 {
+		resolution = leadingSentences(issue.AcceptanceCriteria, 1)
+	}
+	if len(resolution) > 0 {
+		sb.WriteString("\n\n**Resolution:** " + resolution[0])
+	}
+
+	if missing := MissingReferences(compactedText(issue), sb.String(), IssuePrefix(issue.ID), 1); len(missing) > 0 {
+		sb.WriteString("\n\n**References:** " + strings.Join(missing, ", "))
+	}
+	return sb.String(), nil
+}
+
+// SummarizeTier2 reduces an issue to the first sentence of its summary
+// followed by its resolution. Tier 1 labels such as "**Summary:**" are dropped.
+func (e *ExtractiveSummarizer) SummarizeTier2(ctx context.Context, issue *types.Issue) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	var summary, resolution string
+	for _, block := range textBlocks(issue.Description) {
+		switch {
+		case strings.HasPrefix(block, tier1ReferencesLabel):
+			continue
+		case strings.HasPrefix(block, tier1ResolutionLabel):
+			if s := leadingSentences(strings.TrimPrefix(block, tier1ResolutionLabel), 1); len(s) > 0 {
+				resolution = s[0]
+			}
+		case summary == "" && !strings.HasPrefix(block, "**Key Decisions:**"):
+			if s := leadingSentences(strings.TrimPrefix(block, tier1SummaryLabel), 1); len(s) > 0 {
+				summary = s[0]
+			}
+		}
+	}
+	if summary == "" {
+		summary = issue.Title
+	}
+	if resolution != "" && resolution != summary {
+		summary += " " + resolution
+	}
+
+	if missing := MissingReferences(issue.Description, summary, IssuePrefix(issue.ID), 2); len(missing) > 0 {
+		summary += " References: " + strings.Join(missing, ", ") + "."
+	}
+	return summary, nil
+}
+
+const (
+	tier1SummaryLabel    = "**Summary:**"
+	tier1ResolutionLabel = "**Resolution:**"
+	tier1ReferencesLabel = "**References:**"
+)
+
+// textBlocks splits markdown into paragraphs and list items, dropping
+// headings, code blocks, and list and quote markers
+func textBlocks(text string) []string {
+	var blocks []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			blocks = append(blocks, strings.Join(current, " "))
+			current = nil
+		}
+	}
+
+	inCode := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			flush()
+			continue
+		}
+		if inCode {
+			continue
+		}
+		if line == "" {
+			flush()
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			flush() // Headings only label what follows
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimLeft(line, ">"))
+		if item, ok := trimListMarker(line); ok {
+			flush()
+			line = item
+		}
+		if line != "" {
+			current = append(current, line)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// trimListMarker removes a leading "- ", "* " or "1. " list marker
+func trimListMarker(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, marker) {
+			return line[len(marker):], true
+		}
+	}
+	digits := strings.TrimLeftFunc(line, unicode.IsDigit)
+	if len(digits) < len(line) && (strings.HasPrefix(digits, ". ") || strings.HasPrefix(digits, ") ")) {
+		return digits[2:], true
+	}
+	return line, false
+}
+
+// leadingSentences returns up to n sentences from the start of text, each
+// shortened to extractiveMaxSentence runes
+func leadingSentences(text string, n int) []string {
+	var sentences []string
+	for _, block := range textBlocks(text) {
+		rest := block
+		for rest != "" && len(sentences) < n {
+			end := sentenceEnd(rest)
+			sentences = append(sentences, truncateSentence(strings.TrimSpace(rest[:end])))
+			rest = strings.TrimSpace(rest[end:])
+		}
+		if len(sentences) == n {
+			break
+		}
+	}
+	return sentences
+}
+
+// sentenceEnd returns the index just past the first sentence in s: after a
+// '.', '!' or '?' followed by a space, or the end of s
+func sentenceEnd(s string) int {
+	for i := 0; i < len(s)-1; i++ {
+		if (s[i] == '.' || s[i] == '!' || s[i] == '?') && s[i+1] == ' ' {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+func truncateSentence(s string) string {
+	if utf8.RuneCountInString(s) <= extractiveMaxSentence {
+		return s
+	}
+	runes := []rune(s)[:extractiveMaxSentence]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > extractiveMaxSentence/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:") + "…"
+}
@@ -0,0 +1,70 @@
+package compact
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestExtractiveSummarizer(t *testing.T) {
+	issue := &types.Issue{
+		Title: "Add rate limiting",
+		Description: `## Problem
+Login can be brute forced. Attackers tried 10k passwords an hour! We need limits.
+
+More context nobody needs later.`,
+		Design: "- Use a token bucket per IP. Buckets live in Redis.\n- Return 429 with Retry-After.\n\n```go\nfunc limit() {}\n```\n- Log rejected attempts.\n- Alert on spikes.",
+		Notes:  "Shipped in v1.4. Follow-up in bd-12.",
+	}
+
+	summary, err := NewExtractiveSummarizer().SummarizeTier1(context.Background(), issue)
+	if err != nil {
+		t.Fatalf("SummarizeTier1 failed: %v", err)
+	}
+	want := `**Summary:** Login can be brute forced. Attackers tried 10k passwords an hour!
+
+**Key Decisions:**
+- Use a token bucket per IP.
+- Return 429 with Retry-After.
+- Log rejected attempts.
+
+**Resolution:** Shipped in v1.4.`
+	if summary != want {
+		t.Errorf("unexpected summary:\n%s\n\nwant:\n%s", summary, want)
+	}
+
+	again, _ := NewExtractiveSummarizer().SummarizeTier1(context.Background(), issue)
+	if again != summary {
+		t.Error("extractive summaries should be deterministic")
+	}
+
+	// Tier 2 condenses the Tier 1 summary to its first sentence and resolution
+	tier2, err := NewExtractiveSummarizer().SummarizeTier2(context.Background(), &types.Issue{Title: issue.Title, Description: summary})
+	if err != nil {
+		t.Fatalf("SummarizeTier2 failed: %v", err)
+	}
+	if tier2 != "Login can be brute forced. Shipped in v1.4." {
+		t.Errorf("unexpected tier 2 summary %q", tier2)
+	}
+}
+
+func TestExtractiveSummarizer_SparseIssue(t *testing.T) {
+	issue := &types.Issue{Title: "Bump deps", AcceptanceCriteria: "CI is green"}
+	summary, err := NewExtractiveSummarizer().SummarizeTier1(context.Background(), issue)
+	if err != nil {
+		t.Fatalf("SummarizeTier1 failed: %v", err)
+	}
+	if summary != "**Summary:** Bump deps\n\n**Resolution:** CI is green" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+}
+
+func TestLeadingSentences_Truncates(t *testing.T) {
+	long := strings.Repeat("word ", 100) + "end."
+	got := leadingSentences(long, 1)
+	if len(got) != 1 || !strings.HasSuffix(got[0], "…") || len([]rune(got[0])) > extractiveMaxSentence+1 {
+		t.Errorf("expected one truncated sentence, got %q", got)
+	}
+}
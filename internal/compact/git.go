@@ -3,6 +3,7 @@ package compact
 import (
 	"bytes"
 	"os/exec"
+	"strconv"
 	"strings"
 )
 
@@ -19,3 +20,25 @@ func GetCurrentCommitHash() string {
 
 	return strings.TrimSpace(out.String())
 }
+
+// CommitsSince returns the number of commits on HEAD after commit. ok is false
+// when commit is empty or git cannot answer (not a repository, or the commit
+// is unknown, e.g. after a shallow clone).
+func CommitsSince(commit string) (count int, ok bool) {
+	if commit == "" {
+		return 0, false
+	}
+	cmd := exec.Command("git", "rev-list", "--count", commit+"..HEAD")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return 0, false
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(out.String()))
+	if err != nil {
+		return 0, false
+	}
+	return count, true
+}
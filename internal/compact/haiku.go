@@ -1,4 +1,5 @@
-// Package compact provides AI-powered issue compaction using Claude Haiku.
+// Package compact provides issue compaction: summarizing old closed issues
+// with a pluggable Summarizer (Claude by default).
 package compact
 
 import (
@@ -25,11 +26,13 @@ const (
 // ErrAPIKeyRequired is returned when an API key is needed but not provided.
 var ErrAPIKeyRequired = errors.New("API key required")
 
-// HaikuClient wraps the Anthropic API for issue summarization.
+// HaikuClient wraps the Anthropic API for issue summarization. It is the
+// default Summarizer.
 type HaikuClient struct {
 	client         anthropic.Client
 	model          anthropic.Model
 	tier1Template  *template.Template
+	tier2Template  *template.Template
 	maxRetries     int
 	initialBackoff time.Duration
 }
@@ -46,15 +49,16 @@ func NewHaikuClient(apiKey string) (*HaikuClient, error) {
 
 	client := anthropic.NewClient(option.WithAPIKey(apiKey))
 
-	tier1Tmpl, err := template.New("tier1").Parse(tier1PromptTemplate)
+	tier1Tmpl, tier2Tmpl, err := parsePromptTemplates()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse tier1 template: %w", err)
+		return nil, err
 	}
 
 	return &HaikuClient{
 		client:         client,
 		model:          defaultModel,
 		tier1Template:  tier1Tmpl,
+		tier2Template:  tier2Tmpl,
 		maxRetries:     maxRetries,
 		initialBackoff: initialBackoff,
 	}, nil
@@ -70,6 +74,16 @@ func (h *HaikuClient) SummarizeTier1(ctx context.Context, issue *types.Issue) (s
 	return h.callWithRetry(ctx, prompt)
 }
 
+// SummarizeTier2 condenses an already Tier 1 compacted issue to one or two sentences.
+func (h *HaikuClient) SummarizeTier2(ctx context.Context, issue *types.Issue) (string, error) {
+	prompt, err := renderPrompt(h.tier2Template, issue)
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+
+	return h.callWithRetry(ctx, prompt)
+}
+
 func (h *HaikuClient) callWithRetry(ctx context.Context, prompt string) (string, error) {
 	var lastErr error
 	params := anthropic.MessageNewParams{
@@ -143,39 +157,8 @@ func isRetryable(err error) bool {
 	return false
 }
 
-type tier1Data struct {
-	Title              string
-	Description        string
-	Design             string
-	AcceptanceCriteria string
-	Notes              string
-}
-
 func (h *HaikuClient) renderTier1Prompt(issue *types.Issue) (string, error) {
-	var buf []byte
-	w := &bytesWriter{buf: buf}
-
-	data := tier1Data{
-		Title:              issue.Title,
-		Description:        issue.Description,
-		Design:             issue.Design,
-		AcceptanceCriteria: issue.AcceptanceCriteria,
-		Notes:              issue.Notes,
-	}
-
-	if err := h.tier1Template.Execute(w, data); err != nil {
-		return "", err
-	}
-	return string(w.buf), nil
-}
-
-type bytesWriter struct {
-	buf []byte
-}
-
-func (w *bytesWriter) Write(p []byte) (n int, err error) {
-	w.buf = append(w.buf, p...)
-	return len(p), nil
+	return renderPrompt(h.tier1Template, issue)
 }
 
 const tier1PromptTemplate = `You are summarizing a closed software issue for long-term storage. Your goal is to COMPRESS the content - the output MUST be significantly shorter than the input while preserving key technical decisions and outcomes.
@@ -198,6 +181,7 @@ const tier1PromptTemplate = `You are summarizing a closed software issue for lon
 {{end}}
 
 IMPORTANT: Your summary must be shorter than the original. Be concise and eliminate redundancy.
+Keep every issue ID, file path and code identifier you mention exactly as written in the original, and mention those that matter for understanding the outcome.
 
 Provide a summary in this exact format:
 
@@ -206,3 +190,16 @@ Provide a summary in this exact format:
 **Key Decisions:** [Brief bullet points of only the most important technical choices]
 
 **Resolution:** [One sentence on final outcome and lasting impact]`
+
+const tier2PromptTemplate = `You are condensing an already summarized, long-closed software issue for archival. Reduce it to the bare minimum someone searching the history would need.
+
+**Title:** {{.Title}}
+
+**Summary:**
+{{.Description}}
+
+{{if .Notes}}**Notes:**
+{{.Notes}}
+{{end}}
+
+Reply with one or two plain sentences stating what was done and the outcome. Keep any issue IDs exactly as written. No headings, no bullet points, no preamble.`
@@ -0,0 +1,194 @@
+package compact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+const defaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIClient summarizes issues with an OpenAI-compatible chat completions
+// API. Besides OpenAI itself, this covers local servers such as Ollama
+// (http://localhost:11434/v1), llama.cpp and vLLM, so compaction can run
+// without sending issues to a third party.
+type OpenAIClient struct {
+	httpClient     *http.Client
+	baseURL        string
+	apiKey         string
+	model          string
+	tier1Template  *template.Template
+	tier2Template  *template.Template
+	maxRetries     int
+	initialBackoff time.Duration
+}
+
+// NewOpenAIClient creates a client for model at baseURL (OpenAI's API when
+// empty). The API key comes from OPENAI_API_KEY and may be unset for local
+// servers that don't check it.
+func NewOpenAIClient(model, baseURL string) (*OpenAIClient, error) {
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	tier1Tmpl, tier2Tmpl, err := parsePromptTemplates()
+	if err != nil {
+		return nil, err
+	}
+	return &OpenAIClient{
+		httpClient:     &http.Client{Timeout: 2 * time.Minute},
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		apiKey:         os.Getenv("OPENAI_API_KEY"),
+		model:          model,
+		tier1Template:  tier1Tmpl,
+		tier2Template:  tier2Tmpl,
+		maxRetries:     maxRetries,
+		initialBackoff: initialBackoff,
+	}, nil
+}
+
+// SummarizeTier1 creates a structured summary of an issue (Summary, Key Decisions, Resolution).
+func (o *OpenAIClient) SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error) {
+	prompt, err := renderPrompt(o.tier1Template, issue)
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+	return o.callWithRetry(ctx, prompt)
+}
+
+// SummarizeTier2 condenses an already Tier 1 compacted issue to one or two sentences.
+func (o *OpenAIClient) SummarizeTier2(ctx context.Context, issue *types.Issue) (string, error) {
+	prompt, err := renderPrompt(o.tier2Template, issue)
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt: %w", err)
+	}
+	return o.callWithRetry(ctx, prompt)
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model     string        `json:"model"`
+	Messages  []chatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// statusError is an unsuccessful HTTP response from the API
+type statusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("API returned %d: %s", e.StatusCode, e.Body)
+}
+
+func (o *OpenAIClient) callWithRetry(ctx context.Context, prompt string) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model:     o.model,
+		Messages:  []chatMessage{{Role: "user", Content: prompt}},
+		MaxTokens: 1024,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= o.maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := o.initialBackoff * time.Duration(math.Pow(2, float64(attempt-1)))
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		text, err := o.call(ctx, body)
+		if err == nil {
+			return text, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if !isRetryableHTTP(err) {
+			return "", fmt.Errorf("non-retryable error: %w", err)
+		}
+	}
+
+	return "", fmt.Errorf("failed after %d retries: %w", o.maxRetries+1, lastErr)
+}
+
+func (o *OpenAIClient) call(ctx context.Context, body []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", &statusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return "", fmt.Errorf("unexpected response format: %w", err)
+	}
+	if len(parsed.Choices) == 0 || parsed.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("unexpected response format: no content")
+	}
+	return strings.TrimSpace(parsed.Choices[0].Message.Content), nil
+}
+
+func isRetryableHTTP(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	return false
+}
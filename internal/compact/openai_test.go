@@ -0,0 +1,109 @@
+package compact
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestOpenAIClient_SummarizeTier1(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "test-key")
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req.Model != "llama3.1" || len(req.Messages) != 1 || !strings.Contains(req.Messages[0].Content, "Fix login") {
+			t.Errorf("unexpected request: %+v", req)
+		}
+
+		// Fail once to exercise the retry
+		if calls.Add(1) == 1 {
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"**Summary:** Fixed login.\n"}}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewOpenAIClient("llama3.1", server.URL+"/v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.initialBackoff = time.Millisecond
+
+	summary, err := client.SummarizeTier1(context.Background(), &types.Issue{Title: "Fix login", Description: "Login fails"})
+	if err != nil {
+		t.Fatalf("SummarizeTier1 failed: %v", err)
+	}
+	if summary != "**Summary:** Fixed login." {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls (one retry), got %d", calls.Load())
+	}
+}
+
+func TestOpenAIClient_NonRetryableError(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "" {
+			t.Error("expected no Authorization header without OPENAI_API_KEY")
+		}
+		http.Error(w, `{"error":"model not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewOpenAIClient("missing", server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.initialBackoff = time.Millisecond
+
+	_, err = client.SummarizeTier1(context.Background(), &types.Issue{Title: "x"})
+	if err == nil || !strings.Contains(err.Error(), "model not found") {
+		t.Fatalf("expected the API error to be reported, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected no retries for a 404, got %d calls", calls.Load())
+	}
+}
+
+func TestIsRetryableHTTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"context canceled", context.Canceled, false},
+		{"rate limited", &statusError{StatusCode: 429}, true},
+		{"server error", &statusError{StatusCode: 502}, true},
+		{"bad request", &statusError{StatusCode: 400}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableHTTP(tt.err); got != tt.expected {
+				t.Errorf("isRetryableHTTP(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
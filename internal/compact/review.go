@@ -0,0 +1,65 @@
+package compact
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+)
+
+// ApprovePending applies a summary staged for review. It refuses if the
+// issue changed since the summary was staged, since the summary would no
+// longer describe it.
+func ApprovePending(ctx context.Context, store *sqlite.SQLiteStorage, issueID, actor string) (*sqlite.PendingCompaction, error) {
+	pc, err := store.GetPendingCompaction(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if pc == nil {
+		return nil, fmt.Errorf("no compaction pending review for %s", issueID)
+	}
+
+	issue, err := store.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+	if contentHash(issue) != pc.ContentHash {
+		return nil, fmt.Errorf("%s changed after its summary was staged; reject it and compact again", issueID)
+	}
+	if issue.CompactionLevel != pc.Tier-1 {
+		return nil, fmt.Errorf("%s is at compaction level %d, not %d as when its Tier %d summary was staged", issueID, issue.CompactionLevel, pc.Tier-1, pc.Tier)
+	}
+
+	if err := applySummary(ctx, store, issueID, pc.Tier, pc.Summary, pc.OriginalSize, pc.MissingRefs); err != nil {
+		return nil, err
+	}
+	if err := store.DeletePendingCompaction(ctx, issueID); err != nil {
+		return nil, err
+	}
+	if err := store.AddComment(ctx, issueID, actor, fmt.Sprintf("Approved Tier %d compaction summary", pc.Tier)); err != nil {
+		return nil, fmt.Errorf("failed to record approval: %w", err)
+	}
+	return pc, nil
+}
+
+// RejectPending discards a summary staged for review. The issue isn't
+// summarized again until its content changes.
+func RejectPending(ctx context.Context, store *sqlite.SQLiteStorage, issueID, actor string) (*sqlite.PendingCompaction, error) {
+	pc, err := store.GetPendingCompaction(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if pc == nil {
+		return nil, fmt.Errorf("no compaction pending review for %s", issueID)
+	}
+	if err := store.RejectPendingCompaction(ctx, issueID); err != nil {
+		return nil, err
+	}
+	if err := store.AddComment(ctx, issueID, actor, fmt.Sprintf("Rejected Tier %d compaction summary", pc.Tier)); err != nil {
+		return nil, fmt.Errorf("failed to record rejection: %w", err)
+	}
+	return pc, nil
+}
@@ -0,0 +1,81 @@
+package compact
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPendingReview(t *testing.T) {
+	store := setupTestStorage(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	approved := createClosedIssue(t, store, "beads-review-1")
+	rejected := createClosedIssue(t, store, "beads-review-2")
+	changed := createClosedIssue(t, store, "beads-review-3")
+
+	summarizer := &stubSummarizer{summary: "**Summary:** Added JWT authentication."}
+	c := NewWithSummarizer(store, summarizer, &Config{Mode: ModePendingReview})
+	results, err := c.CompactTier1Batch(ctx, []string{approved.ID, rejected.ID, changed.ID})
+	if err != nil {
+		t.Fatalf("CompactTier1Batch failed: %v", err)
+	}
+	for _, r := range results {
+		if r.Err != nil || !r.Staged {
+			t.Fatalf("expected %s to be staged, got %+v", r.IssueID, r)
+		}
+	}
+
+	// Staging leaves the issues alone and takes them out of the candidates
+	issue, _ := store.GetIssue(ctx, approved.ID)
+	if issue.CompactionLevel != 0 || issue.Description != approved.Description {
+		t.Fatalf("staging should not change the issue")
+	}
+	candidates, err := store.GetTier1Candidates(ctx)
+	if err != nil {
+		t.Fatalf("GetTier1Candidates failed: %v", err)
+	}
+	if len(candidates) != 0 {
+		t.Errorf("expected staged issues to be excluded, got %d candidates", len(candidates))
+	}
+	if _, err := c.Compact(ctx, approved.ID, 1); err == nil || !strings.Contains(err.Error(), "pending review") {
+		t.Errorf("expected a pending review error, got %v", err)
+	}
+
+	if _, err := ApprovePending(ctx, store, approved.ID, "alice"); err != nil {
+		t.Fatalf("ApprovePending failed: %v", err)
+	}
+	issue, _ = store.GetIssue(ctx, approved.ID)
+	if issue.CompactionLevel != 1 || issue.Description != summarizer.summary {
+		t.Errorf("expected the summary to be applied, got level %d: %q", issue.CompactionLevel, issue.Description)
+	}
+	if !hasEventContaining(t, store, approved.ID, "Approved Tier 1") {
+		t.Errorf("expected an approval event")
+	}
+
+	if _, err := RejectPending(ctx, store, rejected.ID, "alice"); err != nil {
+		t.Fatalf("RejectPending failed: %v", err)
+	}
+	if pc, _ := store.GetPendingCompaction(ctx, rejected.ID); pc != nil {
+		t.Errorf("expected the rejected summary to be gone")
+	}
+	// Not summarized again until it changes
+	if eligible, _, _ := CheckEligibility(ctx, store, rejected.ID, 1); eligible {
+		t.Errorf("expected a rejected issue to stay ineligible")
+	}
+	if err := store.UpdateIssue(ctx, rejected.ID, map[string]interface{}{"notes": "More context."}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if eligible, reason, _ := CheckEligibility(ctx, store, rejected.ID, 1); !eligible {
+		t.Errorf("expected a changed issue to be eligible again: %s", reason)
+	}
+
+	// A summary staged before the issue changed can't be approved
+	if err := store.UpdateIssue(ctx, changed.ID, map[string]interface{}{"notes": "Follow-up."}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if _, err := ApprovePending(ctx, store, changed.ID, "alice"); err == nil || !strings.Contains(err.Error(), "changed") {
+		t.Errorf("expected a changed issue error, got %v", err)
+	}
+}
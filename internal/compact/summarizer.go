@@ -0,0 +1,127 @@
+package compact
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// Summarizer condenses a closed issue's text for compaction.
+type Summarizer interface {
+	// SummarizeTier1 creates a structured summary of an issue (Summary, Key Decisions, Resolution).
+	SummarizeTier1(ctx context.Context, issue *types.Issue) (string, error)
+	// SummarizeTier2 condenses an already Tier 1 compacted issue to one or two sentences.
+	SummarizeTier2(ctx context.Context, issue *types.Issue) (string, error)
+}
+
+// Summarizer backends, selected by the compact_model config key.
+const (
+	BackendAnthropic  = "anthropic"
+	BackendOpenAI     = "openai"
+	BackendExtractive = "extractive"
+)
+
+// ParseModel splits a compact_model value into its backend and model name:
+//
+//	claude-3-5-haiku-20241022   Anthropic (any claude-* model)
+//	anthropic:<model>           Anthropic, explicitly
+//	openai:<model>              An OpenAI-compatible chat completions API,
+//	                            such as a local Ollama, llama.cpp or vLLM server
+//	extractive                  Picks sentences from the issue itself; no network
+//
+// An empty value selects the default Anthropic model.
+func ParseModel(value string) (backend, model string, err error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return BackendAnthropic, defaultModel, nil
+	case value == BackendExtractive:
+		return BackendExtractive, "", nil
+	case strings.HasPrefix(value, "claude"):
+		return BackendAnthropic, value, nil
+	}
+	backend, model, ok := strings.Cut(value, ":")
+	if !ok || model == "" || (backend != BackendAnthropic && backend != BackendOpenAI) {
+		return "", "", fmt.Errorf("unknown compact_model %q (expected claude-*, anthropic:<model>, openai:<model> or extractive)", value)
+	}
+	return backend, model, nil
+}
+
+// NewSummarizer creates the summarizer a compact_model value selects. apiKey
+// is used by the Anthropic backend (ANTHROPIC_API_KEY takes precedence);
+// baseURL is the OpenAI-compatible endpoint, defaulting to OpenAI's.
+func NewSummarizer(modelConfig, apiKey, baseURL string) (Summarizer, error) {
+	backend, model, err := ParseModel(modelConfig)
+	if err != nil {
+		return nil, err
+	}
+	switch backend {
+	case BackendOpenAI:
+		return NewOpenAIClient(model, baseURL)
+	case BackendExtractive:
+		return NewExtractiveSummarizer(), nil
+	default:
+		h, err := NewHaikuClient(apiKey)
+		if err != nil {
+			return nil, err
+		}
+		h.model = anthropic.Model(model)
+		return h, nil
+	}
+}
+
+// CheckAPIKey returns ErrAPIKeyRequired when the backend a compact_model
+// value selects needs an Anthropic API key and neither apiKey nor
+// ANTHROPIC_API_KEY provides one.
+func CheckAPIKey(modelConfig, apiKey string) error {
+	backend, _, err := ParseModel(modelConfig)
+	if err != nil {
+		return err
+	}
+	if backend == BackendAnthropic && apiKey == "" && os.Getenv("ANTHROPIC_API_KEY") == "" {
+		return fmt.Errorf("%w: set ANTHROPIC_API_KEY, or set compact_model to openai:<model> or extractive", ErrAPIKeyRequired)
+	}
+	return nil
+}
+
+type promptData struct {
+	Title              string
+	Description        string
+	Design             string
+	AcceptanceCriteria string
+	Notes              string
+}
+
+// parsePromptTemplates parses the Tier 1 and Tier 2 prompt templates.
+func parsePromptTemplates() (tier1, tier2 *template.Template, err error) {
+	tier1, err = template.New("tier1").Parse(tier1PromptTemplate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse tier1 template: %w", err)
+	}
+	tier2, err = template.New("tier2").Parse(tier2PromptTemplate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse tier2 template: %w", err)
+	}
+	return tier1, tier2, nil
+}
+
+// renderPrompt renders a Tier 1 or Tier 2 prompt template for an issue.
+func renderPrompt(tmpl *template.Template, issue *types.Issue) (string, error) {
+	var sb strings.Builder
+	data := promptData{
+		Title:              issue.Title,
+		Description:        issue.Description,
+		Design:             issue.Design,
+		AcceptanceCriteria: issue.AcceptanceCriteria,
+		Notes:              issue.Notes,
+	}
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
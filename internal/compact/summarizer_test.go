@@ -0,0 +1,93 @@
+package compact
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseModel(t *testing.T) {
+	tests := []struct {
+		value       string
+		wantBackend string
+		wantModel   string
+		wantErr     bool
+	}{
+		{"", BackendAnthropic, defaultModel, false},
+		{"claude-3-5-haiku-20241022", BackendAnthropic, "claude-3-5-haiku-20241022", false},
+		{"anthropic:claude-sonnet-4-0", BackendAnthropic, "claude-sonnet-4-0", false},
+		{"openai:llama3.1:8b", BackendOpenAI, "llama3.1:8b", false},
+		{"extractive", BackendExtractive, "", false},
+		{"openai:", "", "", true},
+		{"gpt-4o", "", "", true},
+		{"gemini:flash", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			backend, model, err := ParseModel(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseModel(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if backend != tt.wantBackend || model != tt.wantModel {
+				t.Errorf("ParseModel(%q) = (%q, %q), want (%q, %q)", tt.value, backend, model, tt.wantBackend, tt.wantModel)
+			}
+		})
+	}
+}
+
+func TestNewSummarizer_SelectsBackend(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	if s, err := NewSummarizer("extractive", "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, ok := s.(*ExtractiveSummarizer); !ok {
+		t.Errorf("expected *ExtractiveSummarizer, got %T", s)
+	}
+
+	s, err := NewSummarizer("openai:llama3.1", "", "http://localhost:11434/v1/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	o, ok := s.(*OpenAIClient)
+	if !ok {
+		t.Fatalf("expected *OpenAIClient, got %T", s)
+	}
+	if o.model != "llama3.1" || o.baseURL != "http://localhost:11434/v1" {
+		t.Errorf("unexpected OpenAI client settings: model %q, base URL %q", o.model, o.baseURL)
+	}
+
+	if _, err := NewSummarizer("claude-3-5-haiku-20241022", "", ""); !errors.Is(err, ErrAPIKeyRequired) {
+		t.Errorf("expected ErrAPIKeyRequired for Anthropic without a key, got %v", err)
+	}
+	s, err = NewSummarizer("anthropic:claude-sonnet-4-0", "test-key", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h, ok := s.(*HaikuClient); !ok || h.model != "claude-sonnet-4-0" {
+		t.Errorf("expected HaikuClient for claude-sonnet-4-0, got %T", s)
+	}
+}
+
+func TestCheckAPIKey(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	if err := CheckAPIKey("", ""); !errors.Is(err, ErrAPIKeyRequired) {
+		t.Errorf("expected ErrAPIKeyRequired for the default model, got %v", err)
+	}
+	if err := CheckAPIKey("", "key"); err != nil {
+		t.Errorf("unexpected error with an explicit key: %v", err)
+	}
+	for _, model := range []string{"extractive", "openai:llama3.1"} {
+		if err := CheckAPIKey(model, ""); err != nil {
+			t.Errorf("%s should not need an Anthropic key, got %v", model, err)
+		}
+	}
+	if err := CheckAPIKey("bogus", "key"); err == nil {
+		t.Error("expected an unknown compact_model to be rejected")
+	}
+
+	t.Setenv("ANTHROPIC_API_KEY", "from-env")
+	if err := CheckAPIKey("", ""); err != nil {
+		t.Errorf("expected ANTHROPIC_API_KEY to satisfy the check, got %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package compact
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// Reference checks, set by the compact_verify config key
+const (
+	VerifyFlag   = "flag"   // Apply the summary and note the references it dropped (default)
+	VerifyReject = "reject" // Keep the original when the summary drops references
+	VerifyOff    = "off"    // Don't check references
+)
+
+// Compaction modes, set by the compact_mode config key
+const (
+	ModeApply         = "apply"          // Replace the issue text right away (default)
+	ModePendingReview = "pending_review" // Stage summaries for 'beads compact review'
+)
+
+// maxListedRefs caps how many dropped references comments and errors name
+const maxListedRefs = 10
+
+// codeExtensions are the file extensions that mark a bare word like
+// "compact.go" as a file name
+var codeExtensions = map[string]bool{
+	"go": true, "mod": true, "sum": true, "py": true, "js": true, "jsx": true, "ts": true, "tsx": true,
+	"rs": true, "java": true, "kt": true, "rb": true, "c": true, "h": true, "cc": true, "cpp": true,
+	"hpp": true, "cs": true, "swift": true, "sh": true, "sql": true, "proto": true, "md": true,
+	"json": true, "jsonl": true, "yaml": true, "yml": true, "toml": true, "html": true, "css": true,
+	"txt": true, "db": true, "lock": true,
+}
+
+var (
+	urlRe      = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+`)
+	pathTokRe  = regexp.MustCompile(`[\w@~./-]+`)
+	backtickRe = regexp.MustCompile("`([^`\n]+)`")
+	callRe     = regexp.MustCompile(`\b([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*)\(\)`)
+	camelRe    = regexp.MustCompile(`\b(?:[a-z]+[A-Z]|[A-Z][a-z0-9]+[A-Z])\w*\b`)
+	snakeRe    = regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9]*(?:_[A-Za-z0-9]+)+\b`)
+)
+
+// References returns the issue IDs, file paths and code identifiers
+// mentioned in text: IDs first, then paths, then identifiers, each in order
+// of first appearance. Issue IDs are those with the given prefix. Fenced code
+// blocks are skipped, since no summary is expected to keep them.
+func References(text, prefix string) []string {
+	text = stripFencedCode(text)
+
+	var refs []string
+	seen := make(map[string]bool)
+	add := func(ref string) {
+		if ref != "" && !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, id := range issueIDs(text, prefix) {
+		add(id)
+	}
+
+	for _, tok := range pathTokRe.FindAllString(urlRe.ReplaceAllString(text, " "), -1) {
+		tok = strings.TrimRight(tok, ".-")
+		if isPath(tok) {
+			add(tok)
+		}
+	}
+
+	for _, m := range backtickRe.FindAllStringSubmatch(text, -1) {
+		// Only single tokens; spans with spaces are usually commands or prose
+		if span := strings.TrimSpace(m[1]); !strings.ContainsAny(span, " \t") {
+			add(span)
+		}
+	}
+	for _, m := range callRe.FindAllStringSubmatch(text, -1) {
+		add(m[1])
+	}
+	for _, re := range []*regexp.Regexp{camelRe, snakeRe} {
+		for _, m := range re.FindAllString(text, -1) {
+			add(m)
+		}
+	}
+	return refs
+}
+
+// MissingReferences returns the references in original that summary
+// doesn't mention. Tier 2 summaries are a sentence or two, so only issue IDs
+// are checked for them.
+func MissingReferences(original, summary, prefix string, tier int) []string {
+	var refs []string
+	if tier >= 2 {
+		refs = issueIDs(stripFencedCode(original), prefix)
+	} else {
+		refs = References(original, prefix)
+	}
+
+	var missing []string
+	for _, ref := range refs {
+		if !strings.Contains(summary, ref) {
+			missing = append(missing, ref)
+		}
+	}
+	return missing
+}
+
+// FormatReferences lists refs for a comment or error, naming at most ten.
+func FormatReferences(refs []string) string {
+	if len(refs) <= maxListedRefs {
+		return strings.Join(refs, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(refs[:maxListedRefs], ", "), len(refs)-maxListedRefs)
+}
+
+// IssuePrefix returns the prefix of an issue ID, such as "bd" for "bd-a3f8"
+// or "bd-a3f8.1".
+func IssuePrefix(issueID string) string {
+	if i := strings.LastIndex(issueID, "-"); i > 0 {
+		return issueID[:i]
+	}
+	return issueID
+}
+
+// issueIDs finds hash (bd-a3f8) and sequential (bd-42) IDs with the given
+// prefix, including hierarchical children (bd-a3f8.1)
+func issueIDs(text, prefix string) []string {
+	if prefix == "" {
+		return nil
+	}
+	re := regexp.MustCompile(`\b` + regexp.QuoteMeta(prefix) + `-(?:[0-9a-f]{4,8}|\d+)(?:\.\d+)*\b`)
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range re.FindAllString(text, -1) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// isPath reports whether tok looks like a file path: a name with a known
+// extension, or a slash-separated path that is explicitly relative or
+// absolute, ends in a file name, or has at least two separators. Pairs like
+// "and/or" or "client/server" don't count.
+func isPath(tok string) bool {
+	if !strings.ContainsAny(tok, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		return false
+	}
+	base := tok
+	if i := strings.LastIndex(tok, "/"); i >= 0 {
+		base = tok[i+1:]
+	}
+	hasExt := false
+	if i := strings.LastIndex(base, "."); i > 0 {
+		hasExt = codeExtensions[strings.ToLower(base[i+1:])]
+	}
+	if !strings.Contains(tok, "/") {
+		return hasExt
+	}
+	if strings.HasPrefix(tok, "//") {
+		return false
+	}
+	if hasExt || strings.HasPrefix(tok, "./") || strings.HasPrefix(tok, "../") || strings.HasPrefix(tok, "~/") {
+		return true
+	}
+	inner := strings.Trim(tok, "/")
+	return strings.Count(inner, "/") >= 2 || (strings.HasPrefix(tok, "/") && strings.Contains(inner, "/"))
+}
+
+// stripFencedCode drops ``` fenced code blocks
+func stripFencedCode(text string) string {
+	if !strings.Contains(text, "```") {
+		return text
+	}
+	var sb strings.Builder
+	inCode := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+		if !inCode {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// compactedText is the issue text compaction replaces
+func compactedText(issue *types.Issue) string {
+	return strings.Join([]string{issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes}, "\n\n")
+}
+
+// contentHash is the issue's stored content hash, computed for issues
+// written before content hashes existed
+func contentHash(issue *types.Issue) string {
+	if issue.ContentHash != "" {
+		return issue.ContentHash
+	}
+	return issue.ComputeContentHash()
+}
+
+// loadReviewConfig fills in config.Verify and config.Mode from the
+// compact_verify and compact_mode config keys when unset, and validates them.
+func loadReviewConfig(ctx context.Context, store *sqlite.SQLiteStorage, config *Config) error {
+	if config.Verify == "" {
+		value, err := store.GetConfig(ctx, "compact_verify")
+		if err != nil {
+			return fmt.Errorf("failed to get compact_verify: %w", err)
+		}
+		config.Verify = value
+	}
+	if config.Mode == "" {
+		value, err := store.GetConfig(ctx, "compact_mode")
+		if err != nil {
+			return fmt.Errorf("failed to get compact_mode: %w", err)
+		}
+		config.Mode = value
+	}
+	return validateReviewConfig(config)
+}
+
+func validateReviewConfig(config *Config) error {
+	switch config.Verify {
+	case "":
+		config.Verify = VerifyFlag
+	case VerifyFlag, VerifyReject, VerifyOff:
+	default:
+		return fmt.Errorf("invalid compact_verify %q: must be %s, %s or %s", config.Verify, VerifyFlag, VerifyReject, VerifyOff)
+	}
+	switch config.Mode {
+	case "":
+		config.Mode = ModeApply
+	case ModeApply, ModePendingReview:
+	default:
+		return fmt.Errorf("invalid compact_mode %q: must be %s or %s", config.Mode, ModeApply, ModePendingReview)
+	}
+	return nil
+}
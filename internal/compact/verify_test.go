@@ -0,0 +1,137 @@
+package compact
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestReferences(t *testing.T) {
+	text := "Fixed the race in `flushToJSONL` (see bd-a3f8 and bd-12.1) by moving the lock into\n" +
+		"internal/storage/sqlite/dirty.go and calling markDirtyAndScheduleFlush() from main.go.\n" +
+		"Config: auto_flush_debounce, ANTHROPIC_API_KEY. Docs at https://example.com/docs/a/b.md.\n" +
+		"Reads and/or writes from client/server are fine; lib/ changed too.\n" +
+		"```go\nfunc ignoredInCodeBlock() {}\n```\n"
+
+	got := References(text, "bd")
+	want := []string{
+		"bd-a3f8", "bd-12.1",
+		"internal/storage/sqlite/dirty.go", "main.go",
+		"flushToJSONL", "markDirtyAndScheduleFlush", "auto_flush_debounce", "ANTHROPIC_API_KEY",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("References() =\n  %q\nwant\n  %q", got, want)
+	}
+}
+
+func TestMissingReferences(t *testing.T) {
+	original := "Blocked on bd-a3f8. The fix lives in cmd/beads/sync.go, in exportToJSONL."
+	summary := "**Summary:** Fixed export after bd-a3f8 landed."
+
+	if got, want := MissingReferences(original, summary, "bd", 1), []string{"cmd/beads/sync.go", "exportToJSONL"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tier 1: got %q, want %q", got, want)
+	}
+	// Tier 2 only checks issue IDs
+	if got := MissingReferences(original, summary, "bd", 2); len(got) != 0 {
+		t.Errorf("tier 2: expected no missing IDs, got %q", got)
+	}
+	if got := MissingReferences(original, "Fixed export.", "bd", 2); !reflect.DeepEqual(got, []string{"bd-a3f8"}) {
+		t.Errorf("tier 2: got %q", got)
+	}
+}
+
+func TestCompactTier1_VerifyModes(t *testing.T) {
+	ctx := context.Background()
+	const missingRef = "flushToJSONL"
+
+	for _, verify := range []string{VerifyFlag, VerifyReject, VerifyOff} {
+		t.Run(verify, func(t *testing.T) {
+			store := setupTestStorage(t)
+			defer store.Close()
+
+			issue := createClosedIssue(t, store, "beads-verify")
+			if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{
+				"notes": issue.Notes + "\nThe fix is in " + missingRef + ".",
+			}, "test"); err != nil {
+				t.Fatalf("failed to update issue: %v", err)
+			}
+
+			summarizer := &stubSummarizer{summary: "**Summary:** Added JWT authentication."}
+			c := NewWithSummarizer(store, summarizer, &Config{Verify: verify})
+			result, err := c.Compact(ctx, issue.ID, 1)
+
+			after, _ := store.GetIssue(ctx, issue.ID)
+			switch verify {
+			case VerifyReject:
+				if err == nil || !strings.Contains(err.Error(), missingRef) {
+					t.Fatalf("expected a dropped reference error, got %v", err)
+				}
+				if after.CompactionLevel != 0 {
+					t.Errorf("expected the original to be kept")
+				}
+			case VerifyFlag:
+				if err != nil {
+					t.Fatalf("Compact failed: %v", err)
+				}
+				if !containsString(result.MissingRefs, missingRef) || after.CompactionLevel != 1 {
+					t.Errorf("expected a flagged compaction, got %+v at level %d", result, after.CompactionLevel)
+				}
+				if !hasEventContaining(t, store, issue.ID, "dropped references:", missingRef) {
+					t.Errorf("expected the compaction event to name %s", missingRef)
+				}
+			case VerifyOff:
+				if err != nil || len(result.MissingRefs) != 0 || after.CompactionLevel != 1 {
+					t.Errorf("expected an unchecked compaction, got %+v, %v", result, err)
+				}
+			}
+		})
+	}
+}
+
+// stubSummarizer returns a fixed summary for both tiers
+type stubSummarizer struct {
+	summary string
+}
+
+func (s *stubSummarizer) SummarizeTier1(context.Context, *types.Issue) (string, error) {
+	return s.summary, nil
+}
+
+func (s *stubSummarizer) SummarizeTier2(context.Context, *types.Issue) (string, error) {
+	return s.summary, nil
+}
+
+// hasEventContaining reports whether an event on the issue mentions all of texts
+func hasEventContaining(t *testing.T, store *sqlite.SQLiteStorage, issueID string, texts ...string) bool {
+	t.Helper()
+	events, err := store.GetEvents(context.Background(), issueID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	for _, e := range events {
+		if e.Comment == nil {
+			continue
+		}
+		found := true
+		for _, text := range texts {
+			found = found && strings.Contains(*e.Comment, text)
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
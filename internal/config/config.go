@@ -15,86 +15,91 @@ var v *viper.Viper
 // Initialize sets up the viper configuration singleton
 // Should be called once at application startup
 func Initialize() error {
-	v = viper.New()
+	nv, err := load()
+	if err != nil {
+		return err
+	}
+	v = nv
+	return nil
+}
 
-	// Set config file name and type
-	v.SetConfigName("config")
-	v.SetConfigType("yaml")
+// load builds a viper instance from config files, environment variables and defaults
+func load() (*viper.Viper, error) {
+	cfg := viper.New()
 
-	// Add config search paths (in order of precedence)
+	// Find the config file (first match wins):
 	// 1. Walk up from CWD to find project .beads/ directory
 	//    This allows commands to work from subdirectories
+	// 2. User config directory (~/.config/beads/)
+	// 3. Home directory (~/.beads/)
+	// Candidates are full paths rather than viper search paths: .beads/ also holds
+	// config.json (database metadata), which viper's search would find before config.yaml.
+	var candidates []string
 	cwd, err := os.Getwd()
 	if err == nil {
-		// Walk up parent directories to find .beads/config.yaml
+		// Walk up parent directories to find the nearest .beads directory
 		for dir := cwd; dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
 			beadsDir := filepath.Join(dir, ".beads")
-			configPath := filepath.Join(beadsDir, "config.yaml")
-			if _, err := os.Stat(configPath); err == nil {
-				// Found .beads/config.yaml - add this path
-				v.AddConfigPath(beadsDir)
-				break
-			}
-			// Also check if .beads directory exists (even without config.yaml)
 			if info, err := os.Stat(beadsDir); err == nil && info.IsDir() {
-				v.AddConfigPath(beadsDir)
+				candidates = append(candidates, filepath.Join(beadsDir, "config.yaml"))
 				break
 			}
 		}
-
-		// Also add CWD/.beads for backward compatibility
-		v.AddConfigPath(filepath.Join(cwd, ".beads"))
 	}
-
-	// 2. User config directory (~/.config/beads/)
 	if configDir, err := os.UserConfigDir(); err == nil {
-		v.AddConfigPath(filepath.Join(configDir, "beads"))
+		candidates = append(candidates, filepath.Join(configDir, "beads", "config.yaml"))
 	}
-
-	// 3. Home directory (~/.beads/)
 	if homeDir, err := os.UserHomeDir(); err == nil {
-		v.AddConfigPath(filepath.Join(homeDir, ".beads"))
+		candidates = append(candidates, filepath.Join(homeDir, ".beads", "config.yaml"))
 	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			cfg.SetConfigFile(path)
+			break
+		}
+	}
+	cfg.SetConfigType("yaml")
 
 	// Automatic environment variable binding
 	// Environment variables take precedence over config file
 	// E.g., BEADS_JSON, BEADS_NO_DAEMON, BEADS_ACTOR, BEADS_DB
-	v.SetEnvPrefix("BEADS")
+	cfg.SetEnvPrefix("BEADS")
 
 	// Replace hyphens and dots with underscores for env var mapping
 	// This allows BEADS_NO_DAEMON to map to "no-daemon" config key
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
-	v.AutomaticEnv()
+	cfg.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	cfg.AutomaticEnv()
 
 	// Set defaults for all flags
-	v.SetDefault("json", false)
-	v.SetDefault("no-daemon", false)
-	v.SetDefault("no-auto-flush", false)
-	v.SetDefault("no-auto-import", false)
-	v.SetDefault("no-db", false)
-	v.SetDefault("db", "")
-	v.SetDefault("actor", "")
-	v.SetDefault("issue-prefix", "")
+	cfg.SetDefault("json", false)
+	cfg.SetDefault("no-daemon", false)
+	cfg.SetDefault("no-auto-flush", false)
+	cfg.SetDefault("no-auto-import", false)
+	cfg.SetDefault("no-db", false)
+	cfg.SetDefault("db", "")
+	cfg.SetDefault("actor", "")
+	cfg.SetDefault("issue-prefix", "")
 
 	// Additional environment variables (not prefixed with BEADS_)
 	// These are bound explicitly for backward compatibility
-	_ = v.BindEnv("flush-debounce", "BEADS_FLUSH_DEBOUNCE")
-	_ = v.BindEnv("auto-start-daemon", "BEADS_AUTO_START_DAEMON")
+	_ = cfg.BindEnv("flush-debounce", "BEADS_FLUSH_DEBOUNCE")
+	_ = cfg.BindEnv("auto-start-daemon", "BEADS_AUTO_START_DAEMON")
 
 	// Set defaults for additional settings
-	v.SetDefault("flush-debounce", "30s")
-	v.SetDefault("auto-start-daemon", true)
-
-	// Read config file if it exists (don't error if not found)
-	if err := v.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			// Config file found but another error occurred
-			return fmt.Errorf("error reading config file: %w", err)
+	cfg.SetDefault("flush-debounce", "30s")
+	cfg.SetDefault("auto-start-daemon", true)
+	cfg.SetDefault("lease-ttl", "30m")
+	cfg.SetDefault("sla.p0", "24h")
+	cfg.SetDefault("sla.p1", "7d")
+
+	// Read config file if one was found (no config file is ok, we'll use defaults)
+	if cfg.ConfigFileUsed() != "" {
+		if err := cfg.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("error reading config file: %w", err)
 		}
-		// Config file not found - this is ok, we'll use defaults
 	}
 
-	return nil
+	return cfg, nil
 }
 
 // GetString retrieves a string configuration value
@@ -152,6 +152,41 @@ flush-debounce: 15s
 	}
 }
 
+func TestConfigFileIgnoresDatabaseMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+
+	// .beads/config.json holds database metadata and must not shadow config.yaml
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.json"), []byte(`{"database": "beads.db"}`), 0600); err != nil {
+		t.Fatalf("failed to write config.json: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte("actor: yamluser\n"), 0600); err != nil {
+		t.Fatalf("failed to write config.yaml: %v", err)
+	}
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	defer os.Chdir(origDir)
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+	if got := GetString("actor"); got != "yamluser" {
+		t.Errorf("GetString(actor) = %q, want \"yamluser\"", got)
+	}
+	if got := GetString("database"); got != "" {
+		t.Errorf("config.json was read: database = %q", got)
+	}
+}
+
 func TestConfigPrecedence(t *testing.T) {
 	// Create a temporary directory for config file
 	tmpDir := t.TempDir()
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoadLeaseTTL returns the "lease-ttl" setting: how long a claim on an issue
+// lasts without a heartbeat (see 'beads claim' and 'beads heartbeat'). The
+// daemon's lease reaper also checks for expired leases at this granularity.
+//
+// Uses the initialized configuration if available, otherwise reads the config files
+// and environment directly without installing the singleton.
+func LoadLeaseTTL() (time.Duration, error) {
+	cfg := v
+	if cfg == nil {
+		loaded, err := load()
+		if err != nil {
+			return 0, err
+		}
+		cfg = loaded
+	}
+
+	raw := cfg.GetString("lease-ttl")
+	ttl, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("lease-ttl: %w", err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("lease-ttl must be positive, got %s", raw)
+	}
+	return ttl, nil
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadLeaseTTL(t *testing.T) {
+	initConfigFromYAML(t, "json: false\n")
+	ttl, err := LoadLeaseTTL()
+	if err != nil {
+		t.Fatalf("LoadLeaseTTL() returned error: %v", err)
+	}
+	if ttl != 30*time.Minute {
+		t.Errorf("expected default lease-ttl of 30m, got %v", ttl)
+	}
+
+	initConfigFromYAML(t, "lease-ttl: 5m\n")
+	if ttl, err := LoadLeaseTTL(); err != nil || ttl != 5*time.Minute {
+		t.Errorf("expected lease-ttl of 5m, got %v (err %v)", ttl, err)
+	}
+
+	t.Setenv("BEADS_LEASE_TTL", "90s")
+	if ttl, err := LoadLeaseTTL(); err != nil || ttl != 90*time.Second {
+		t.Errorf("expected BEADS_LEASE_TTL to override config, got %v (err %v)", ttl, err)
+	}
+
+	t.Setenv("BEADS_LEASE_TTL", "soon")
+	if _, err := LoadLeaseTTL(); err == nil {
+		t.Error("expected an invalid lease-ttl to be rejected")
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/spf13/viper"
+)
+
+// LoadRepos returns the repository registry from the "repos" section of
+// config.yaml: aliases for other beads repositories, used in cross-repository
+// references such as "api:bd-a3f8" and by `beads ready --all-repos`.
+//
+// Example:
+//
+//	repos:
+//	  api: ../api
+//	  web: ~/src/web
+//
+// Each path is a repository root, its .beads directory, or a database file.
+// Relative paths are resolved against the repository holding config.yaml, and a
+// leading ~ is expanded. Aliases are lowercase letters, digits, '-' and '_'.
+// Returns an empty map if no repos are configured.
+//
+// Uses the initialized configuration if available, otherwise reads the config files
+// directly without installing the singleton.
+func LoadRepos() (map[string]string, error) {
+	cfg := v
+	if cfg == nil {
+		loaded, err := load()
+		if err != nil {
+			return nil, err
+		}
+		cfg = loaded
+	}
+	return reposFrom(cfg)
+}
+
+// LoadReposFrom returns the repository registry in beadsDir/config.yaml, so a
+// repository's own cross-repository references can be resolved from elsewhere.
+// Returns an empty map if the file doesn't exist.
+func LoadReposFrom(beadsDir string) (map[string]string, error) {
+	path := filepath.Join(beadsDir, "config.yaml")
+	if _, err := os.Stat(path); err != nil {
+		return map[string]string{}, nil
+	}
+	cfg := viper.New()
+	cfg.SetConfigFile(path)
+	cfg.SetConfigType("yaml")
+	if err := cfg.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+	return reposFrom(cfg)
+}
+
+func reposFrom(cfg *viper.Viper) (map[string]string, error) {
+	// Relative paths are relative to the repository root, the parent of .beads
+	base := ""
+	if file := cfg.ConfigFileUsed(); file != "" {
+		base = filepath.Dir(file)
+		if filepath.Base(base) == ".beads" {
+			base = filepath.Dir(base)
+		}
+	}
+
+	repos := make(map[string]string)
+	for alias, path := range cfg.GetStringMapString("repos") {
+		if !types.IsValidRepoAlias(alias) {
+			return nil, fmt.Errorf("repos.%s: invalid alias (use lowercase letters, digits, '-' and '_')", alias)
+		}
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return nil, fmt.Errorf("repos.%s: path is required", alias)
+		}
+		if path == "~" || strings.HasPrefix(path, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("repos.%s: %w", alias, err)
+			}
+			path = filepath.Join(home, path[1:])
+		}
+		if !filepath.IsAbs(path) && base != "" {
+			path = filepath.Join(base, path)
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("repos.%s: %w", alias, err)
+		}
+		repos[alias] = abs
+	}
+	return repos, nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadRepos(t *testing.T) {
+	initConfigFromYAML(t, `
+repos:
+  api: ../api
+  Web: /srv/web
+  home: ~/src/docs
+`)
+
+	repos, err := LoadRepos()
+	if err != nil {
+		t.Fatalf("LoadRepos() returned error: %v", err)
+	}
+	if len(repos) != 3 {
+		t.Fatalf("expected 3 repos, got %v", repos)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	// Relative to the repository root, not the .beads directory
+	if want := filepath.Join(filepath.Dir(cwd), "api"); repos["api"] != want {
+		t.Errorf("api = %q, want %q", repos["api"], want)
+	}
+	if repos["web"] != "/srv/web" {
+		t.Errorf("expected alias to be lowercased with an absolute path, got %v", repos)
+	}
+	home, _ := os.UserHomeDir()
+	if want := filepath.Join(home, "src", "docs"); repos["home"] != want {
+		t.Errorf("home = %q, want %q", repos["home"], want)
+	}
+}
+
+func TestLoadReposNone(t *testing.T) {
+	initConfigFromYAML(t, "json: false\n")
+
+	repos, err := LoadRepos()
+	if err != nil {
+		t.Fatalf("LoadRepos() returned error: %v", err)
+	}
+	if len(repos) != 0 {
+		t.Errorf("expected no repos, got %v", repos)
+	}
+}
+
+func TestLoadReposInvalidAlias(t *testing.T) {
+	initConfigFromYAML(t, `
+repos:
+  "my api": ../api
+`)
+
+	_, err := LoadRepos()
+	if err == nil || !strings.Contains(err.Error(), "repos.my api") {
+		t.Errorf("expected error naming the alias, got %v", err)
+	}
+}
+
+func TestLoadReposFrom(t *testing.T) {
+	repoDir := t.TempDir()
+	beadsDir := filepath.Join(repoDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte("repos:\n  lib: ./vendor/lib\n"), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	repos, err := LoadReposFrom(beadsDir)
+	if err != nil {
+		t.Fatalf("LoadReposFrom() returned error: %v", err)
+	}
+	if want := filepath.Join(repoDir, "vendor", "lib"); repos["lib"] != want {
+		t.Errorf("lib = %q, want %q", repos["lib"], want)
+	}
+
+	repos, err = LoadReposFrom(t.TempDir())
+	if err != nil || len(repos) != 0 {
+		t.Errorf("expected no repos without a config file, got %v, %v", repos, err)
+	}
+}
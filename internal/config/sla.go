@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// LoadSLA returns the "sla" settings: how long an issue of each priority may
+// stay open before 'beads stats' reports it as breaching its SLA. Windows are
+// Go durations or whole days and weeks (24h, 3d, 2w); "none" or 0 disables a
+// priority. P0 defaults to 24h and P1 to 7d; other priorities have no SLA.
+//
+//	sla:
+//	  p0: 4h
+//	  p1: 3d
+//	  p2: 4w
+//
+// Uses the initialized configuration if available, otherwise reads the config files
+// and environment directly without installing the singleton.
+func LoadSLA() (types.SLAPolicy, error) {
+	cfg := v
+	if cfg == nil {
+		loaded, err := load()
+		if err != nil {
+			return nil, err
+		}
+		cfg = loaded
+	}
+
+	policy := make(types.SLAPolicy)
+	for priority := 0; priority <= 4; priority++ {
+		key := fmt.Sprintf("sla.p%d", priority)
+		raw := strings.TrimSpace(cfg.GetString(key))
+		if raw == "" || raw == "none" || raw == "0" {
+			continue
+		}
+		window, err := parseSLAWindow(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		policy[priority] = window
+	}
+	return policy, nil
+}
+
+func parseSLAWindow(s string) (time.Duration, error) {
+	units := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if unit, ok := units[s[len(s)-1]]; ok {
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n > 0 {
+			return time.Duration(n) * unit, nil
+		}
+	}
+	window, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window %q (expected e.g. 4h, 3d or 2w)", s)
+	}
+	if window <= 0 {
+		return 0, fmt.Errorf("window must be positive, got %s", s)
+	}
+	return window, nil
+}
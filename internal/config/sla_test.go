@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadSLA(t *testing.T) {
+	initConfigFromYAML(t, "json: false\n")
+	policy, err := LoadSLA()
+	if err != nil {
+		t.Fatalf("LoadSLA() returned error: %v", err)
+	}
+	if len(policy) != 2 || policy[0] != 24*time.Hour || policy[1] != 7*24*time.Hour {
+		t.Errorf("expected default SLA of p0=24h p1=7d, got %v", policy)
+	}
+
+	initConfigFromYAML(t, "sla:\n  p0: 4h\n  p1: none\n  p2: 2w\n")
+	policy, err = LoadSLA()
+	if err != nil {
+		t.Fatalf("LoadSLA() returned error: %v", err)
+	}
+	if policy[0] != 4*time.Hour {
+		t.Errorf("expected p0 window of 4h, got %v", policy[0])
+	}
+	if _, ok := policy[1]; ok {
+		t.Errorf("expected p1: none to disable the P1 SLA, got %v", policy[1])
+	}
+	if policy[2] != 14*24*time.Hour {
+		t.Errorf("expected p2 window of 2w, got %v", policy[2])
+	}
+
+	for _, bad := range []string{"soon", "-3d", "-1h"} {
+		initConfigFromYAML(t, "sla:\n  p0: \""+bad+"\"\n")
+		if _, err := LoadSLA(); err == nil {
+			t.Errorf("expected sla.p0 %q to be rejected", bad)
+		}
+	}
+}
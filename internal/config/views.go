@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/shaneholloman/beads/internal/query"
+)
+
+// LoadViews returns the saved list filters from the "views" section of config.yaml.
+//
+// Example:
+//
+//	views:
+//	  triage: "status:open priority<=1 assignee:none"
+//	  stale: "-status:closed updated<30d"
+//
+// Each view is a filter expression (see query.Parse) used by `beads list --view <name>`.
+// View names are case-insensitive. Returns an empty map if no views are configured.
+//
+// Uses the initialized configuration if available, otherwise reads the config files
+// directly without installing the singleton.
+func LoadViews() (map[string]string, error) {
+	cfg := v
+	if cfg == nil {
+		loaded, err := load()
+		if err != nil {
+			return nil, err
+		}
+		cfg = loaded
+	}
+
+	views := cfg.GetStringMapString("views")
+	for name, filter := range views {
+		if _, err := query.Parse(filter); err != nil {
+			return nil, fmt.Errorf("views.%s: %w", name, err)
+		}
+	}
+	return views, nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadViews(t *testing.T) {
+	initConfigFromYAML(t, `
+views:
+  triage: "status:open priority<=1 assignee:none"
+  Stale: "-status:closed updated<30d"
+`)
+
+	views, err := LoadViews()
+	if err != nil {
+		t.Fatalf("LoadViews() returned error: %v", err)
+	}
+	if len(views) != 2 {
+		t.Fatalf("expected 2 views, got %v", views)
+	}
+	if views["triage"] != "status:open priority<=1 assignee:none" {
+		t.Errorf("unexpected triage view %q", views["triage"])
+	}
+	if _, ok := views["stale"]; !ok {
+		t.Errorf("expected view names to be lowercased, got %v", views)
+	}
+}
+
+func TestLoadViewsNone(t *testing.T) {
+	initConfigFromYAML(t, "json: false\n")
+
+	views, err := LoadViews()
+	if err != nil {
+		t.Fatalf("LoadViews() returned error: %v", err)
+	}
+	if len(views) != 0 {
+		t.Errorf("expected no views, got %v", views)
+	}
+}
+
+func TestLoadViewsInvalid(t *testing.T) {
+	initConfigFromYAML(t, `
+views:
+  broken: "colour:red"
+`)
+
+	_, err := LoadViews()
+	if err == nil {
+		t.Fatal("expected error for invalid view")
+	}
+	if !strings.Contains(err.Error(), "views.broken") {
+		t.Errorf("expected error to name the view, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/shaneholloman/beads/internal/webhook"
+)
+
+// LoadWebhooks returns the endpoints from the "webhooks" section of config.yaml.
+//
+// Example:
+//
+//	webhooks:
+//	  - url: https://bots.example.com/beads
+//	    events: [create, close]
+//	    secret: s3cret
+//	  - url: http://localhost:9000/dashboard
+//
+// The daemon posts each matching mutation event to every endpoint as JSON.
+// Omitting events sends all event types. With a secret, requests carry an
+// X-Beads-Signature-256 HMAC of the body. Returns nil if no webhooks are configured.
+//
+// Uses the initialized configuration if available, otherwise reads the config files
+// directly without installing the singleton.
+func LoadWebhooks() ([]webhook.Hook, error) {
+	cfg := v
+	if cfg == nil {
+		loaded, err := load()
+		if err != nil {
+			return nil, err
+		}
+		cfg = loaded
+	}
+
+	var hooks []webhook.Hook
+	if err := cfg.UnmarshalKey("webhooks", &hooks); err != nil {
+		return nil, fmt.Errorf("webhooks: %w", err)
+	}
+	for i, hook := range hooks {
+		if err := hook.Validate(); err != nil {
+			return nil, fmt.Errorf("webhooks[%d]: %w", i, err)
+		}
+	}
+	return hooks, nil
+}
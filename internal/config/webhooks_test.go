@@ -0,0 +1,55 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadWebhooks(t *testing.T) {
+	initConfigFromYAML(t, `
+webhooks:
+  - url: https://bots.example.com/beads
+    events: [create, close]
+    secret: s3cret
+  - url: http://localhost:9000/dashboard
+`)
+
+	hooks, err := LoadWebhooks()
+	if err != nil {
+		t.Fatalf("LoadWebhooks() returned error: %v", err)
+	}
+	if len(hooks) != 2 {
+		t.Fatalf("expected 2 webhooks, got %+v", hooks)
+	}
+	if hooks[0].URL != "https://bots.example.com/beads" || hooks[0].Secret != "s3cret" || len(hooks[0].Events) != 2 {
+		t.Errorf("unexpected first webhook %+v", hooks[0])
+	}
+	if !hooks[1].Wants("dep_add") || hooks[0].Wants("update") {
+		t.Errorf("unexpected event filters: %+v", hooks)
+	}
+}
+
+func TestLoadWebhooksNone(t *testing.T) {
+	initConfigFromYAML(t, "json: false\n")
+
+	hooks, err := LoadWebhooks()
+	if err != nil {
+		t.Fatalf("LoadWebhooks() returned error: %v", err)
+	}
+	if len(hooks) != 0 {
+		t.Errorf("expected no webhooks, got %+v", hooks)
+	}
+}
+
+func TestLoadWebhooksInvalid(t *testing.T) {
+	for yaml, want := range map[string]string{
+		"webhooks:\n  - url: ftp://example.com\n":                        "invalid webhook URL",
+		"webhooks:\n  - url: https://example.com\n    events: [moved]\n": "unknown event type",
+	} {
+		initConfigFromYAML(t, yaml)
+		_, err := LoadWebhooks()
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadWebhooks() error = %v, want %q", err, want)
+		}
+	}
+}
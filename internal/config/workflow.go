@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// LoadWorkflow builds the issue workflow from the "workflow" section of config.yaml.
+//
+// Example:
+//
+//	workflow:
+//	  statuses:
+//	    in_review: active
+//	    deferred: blocked
+//	  transitions:
+//	    closed: [open]
+//	    in_review: [in_progress, closed]
+//
+// Statuses map a custom status name to a category (active, blocked, or done).
+// Transitions restrict which statuses an issue may move to; statuses without
+// a rule may move anywhere. Returns the default workflow if no section is configured.
+//
+// Uses the initialized configuration if available, otherwise reads the config files
+// directly without installing the singleton.
+func LoadWorkflow() (*types.Workflow, error) {
+	cfg := v
+	if cfg == nil {
+		loaded, err := load()
+		if err != nil {
+			return nil, err
+		}
+		cfg = loaded
+	}
+
+	wf := types.DefaultWorkflow()
+
+	statuses := cfg.GetStringMapString("workflow.statuses")
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Deterministic error reporting
+	for _, name := range names {
+		if err := wf.AddStatus(types.Status(name), types.StatusCategory(statuses[name])); err != nil {
+			return nil, fmt.Errorf("workflow.statuses: %w", err)
+		}
+	}
+
+	transitions := cfg.GetStringMapStringSlice("workflow.transitions")
+	froms := make([]string, 0, len(transitions))
+	for from := range transitions {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+	for _, from := range froms {
+		targets := make([]types.Status, 0, len(transitions[from]))
+		for _, to := range transitions[from] {
+			targets = append(targets, types.Status(to))
+		}
+		if err := wf.SetTransitions(types.Status(from), targets); err != nil {
+			return nil, fmt.Errorf("workflow.transitions: %w", err)
+		}
+	}
+
+	return wf, nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// initConfigFromYAML writes content to .beads/config.yaml in a temp dir and initializes viper from it
+func initConfigFromYAML(t *testing.T, content string) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(origDir) })
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+}
+
+func TestLoadWorkflowDefault(t *testing.T) {
+	initConfigFromYAML(t, "json: false\n")
+
+	wf, err := LoadWorkflow()
+	if err != nil {
+		t.Fatalf("LoadWorkflow() returned error: %v", err)
+	}
+	if len(wf.CustomStatuses()) != 0 {
+		t.Errorf("expected no custom statuses, got %v", wf.CustomStatuses())
+	}
+}
+
+func TestLoadWorkflowCustomStatuses(t *testing.T) {
+	initConfigFromYAML(t, `
+workflow:
+  statuses:
+    in_review: active
+    deferred: blocked
+  transitions:
+    closed: [open]
+    in_review: [in_progress, closed]
+`)
+
+	wf, err := LoadWorkflow()
+	if err != nil {
+		t.Fatalf("LoadWorkflow() returned error: %v", err)
+	}
+
+	if c, ok := wf.Category("in_review"); !ok || c != types.CategoryActive {
+		t.Errorf("in_review category = %q (found=%v), want active", c, ok)
+	}
+	if c, ok := wf.Category("deferred"); !ok || c != types.CategoryBlocked {
+		t.Errorf("deferred category = %q (found=%v), want blocked", c, ok)
+	}
+	if err := wf.CanTransition(types.StatusClosed, "in_review"); err == nil {
+		t.Error("closed -> in_review should be rejected")
+	}
+	if err := wf.CanTransition("in_review", types.StatusClosed); err != nil {
+		t.Errorf("in_review -> closed should be allowed: %v", err)
+	}
+}
+
+func TestLoadWorkflowInvalid(t *testing.T) {
+	initConfigFromYAML(t, `
+workflow:
+  statuses:
+    in_review: sideways
+`)
+
+	if _, err := LoadWorkflow(); err == nil {
+		t.Error("expected error for invalid category")
+	}
+}
@@ -5,14 +5,37 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/shaneholloman/beads/internal/storage"
 )
 
 const ConfigFileName = "config.json"
 
+// Storage backends selectable via the "backend" field
+const (
+	BackendSQLite   = "sqlite"
+	BackendPostgres = "postgres"
+)
+
+// PostgresPasswordEnv is the environment variable holding the Postgres password.
+// The password is never stored in config.json since .beads/ is usually committed.
+const PostgresPasswordEnv = "BEADS_POSTGRES_PASSWORD"
+
 type Config struct {
-	Database    string `json:"database"`
-	Version     string `json:"version"`
-	JSONLExport string `json:"jsonl_export,omitempty"`
+	Database    string          `json:"database"`
+	Version     string          `json:"version"`
+	JSONLExport string          `json:"jsonl_export,omitempty"`
+	Backend     string          `json:"backend,omitempty"`
+	Postgres    *PostgresConfig `json:"postgres,omitempty"`
+}
+
+// PostgresConfig describes a shared Postgres database used instead of the local SQLite file
+type PostgresConfig struct {
+	Host     string `json:"host,omitempty"`
+	Port     int    `json:"port,omitempty"`
+	Database string `json:"database,omitempty"`
+	User     string `json:"user,omitempty"`
+	SSLMode  string `json:"sslmode,omitempty"`
 }
 
 func DefaultConfig(version string) *Config {
@@ -71,3 +94,37 @@ func (c *Config) JSONLPath(beadsDir string) string {
 	}
 	return filepath.Join(beadsDir, c.JSONLExport)
 }
+
+// IsPostgres reports whether the config selects the Postgres backend
+func (c *Config) IsPostgres() bool {
+	return c.Backend == BackendPostgres
+}
+
+// StorageConfig returns the storage configuration for this beads directory.
+// Path is always the local database path so the daemon and clients agree on
+// which database they serve, even when the issues live in Postgres.
+func (c *Config) StorageConfig(beadsDir string) (storage.Config, error) {
+	cfg := storage.Config{
+		Backend: c.Backend,
+		Path:    c.DatabasePath(beadsDir),
+	}
+
+	switch c.Backend {
+	case "", BackendSQLite:
+		cfg.Backend = BackendSQLite
+	case BackendPostgres:
+		if c.Postgres == nil {
+			return storage.Config{}, fmt.Errorf("backend %q requires a \"postgres\" section in %s", c.Backend, ConfigFileName)
+		}
+		cfg.Host = c.Postgres.Host
+		cfg.Port = c.Postgres.Port
+		cfg.Database = c.Postgres.Database
+		cfg.User = c.Postgres.User
+		cfg.SSLMode = c.Postgres.SSLMode
+		cfg.Password = os.Getenv(PostgresPasswordEnv)
+	default:
+		return storage.Config{}, fmt.Errorf("unknown storage backend %q (must be %s or %s)", c.Backend, BackendSQLite, BackendPostgres)
+	}
+
+	return cfg, nil
+}
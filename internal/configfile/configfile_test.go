@@ -126,3 +126,90 @@ func TestConfigPath(t *testing.T) {
 		t.Errorf("ConfigPath() = %q, want %q", got, want)
 	}
 }
+
+func TestStorageConfigDefaultsToSQLite(t *testing.T) {
+	beadsDir := t.TempDir()
+	cfg := DefaultConfig("0.17.5")
+
+	sc, err := cfg.StorageConfig(beadsDir)
+	if err != nil {
+		t.Fatalf("StorageConfig() failed: %v", err)
+	}
+	if sc.Backend != BackendSQLite {
+		t.Errorf("Backend = %q, want %q", sc.Backend, BackendSQLite)
+	}
+	if want := filepath.Join(beadsDir, "beads.db"); sc.Path != want {
+		t.Errorf("Path = %q, want %q", sc.Path, want)
+	}
+	if cfg.IsPostgres() {
+		t.Error("IsPostgres() = true for default config")
+	}
+}
+
+func TestStorageConfigPostgres(t *testing.T) {
+	t.Setenv(PostgresPasswordEnv, "s3cret")
+	beadsDir := t.TempDir()
+
+	cfg := DefaultConfig("0.17.5")
+	cfg.Backend = BackendPostgres
+	cfg.Postgres = &PostgresConfig{
+		Host:     "db.internal",
+		Port:     5433,
+		Database: "tracker",
+		User:     "beads",
+		SSLMode:  "require",
+	}
+
+	sc, err := cfg.StorageConfig(beadsDir)
+	if err != nil {
+		t.Fatalf("StorageConfig() failed: %v", err)
+	}
+	if !cfg.IsPostgres() {
+		t.Error("IsPostgres() = false, want true")
+	}
+	if sc.Backend != BackendPostgres || sc.Host != "db.internal" || sc.Port != 5433 ||
+		sc.Database != "tracker" || sc.User != "beads" || sc.SSLMode != "require" {
+		t.Errorf("unexpected storage config: %+v", sc)
+	}
+	if sc.Password != "s3cret" {
+		t.Errorf("Password = %q, want value from %s", sc.Password, PostgresPasswordEnv)
+	}
+	if want := filepath.Join(beadsDir, "beads.db"); sc.Path != want {
+		t.Errorf("Path = %q, want %q", sc.Path, want)
+	}
+}
+
+func TestStorageConfigErrors(t *testing.T) {
+	cfg := DefaultConfig("0.17.5")
+	cfg.Backend = BackendPostgres
+	if _, err := cfg.StorageConfig(t.TempDir()); err == nil {
+		t.Error("expected error for postgres backend without postgres section")
+	}
+
+	cfg.Backend = "mysql"
+	if _, err := cfg.StorageConfig(t.TempDir()); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+}
+
+func TestPostgresConfigRoundtrip(t *testing.T) {
+	beadsDir := t.TempDir()
+
+	cfg := DefaultConfig("0.17.5")
+	cfg.Backend = BackendPostgres
+	cfg.Postgres = &PostgresConfig{Host: "db.internal", Database: "tracker", User: "beads"}
+	if err := cfg.Save(beadsDir); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	loaded, err := Load(beadsDir)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !loaded.IsPostgres() {
+		t.Fatalf("Backend = %q, want %q", loaded.Backend, BackendPostgres)
+	}
+	if loaded.Postgres == nil || *loaded.Postgres != *cfg.Postgres {
+		t.Errorf("Postgres = %+v, want %+v", loaded.Postgres, cfg.Postgres)
+	}
+}
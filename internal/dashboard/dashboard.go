@@ -0,0 +1,391 @@
+// Package dashboard serves a read-only web dashboard and JSON API for a beads
+// database. Pages are rendered on the server from embedded templates and need
+// no external assets.
+package dashboard
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/query"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// DefaultFilter is the issue list filter when none is given: everything not closed
+const DefaultFilter = "-status:closed"
+
+// dashboardLimit caps the ready and blocked lists on the dashboard page
+const dashboardLimit = 20
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// Server serves the dashboard. It only reads from the store.
+type Server struct {
+	store   storage.Storage
+	name    string
+	metrics func() rpc.MetricsSnapshot
+	pages   map[string]*template.Template
+	mux     *http.ServeMux
+}
+
+// New returns a dashboard for store, titled with the workspace name. metrics
+// reports daemon metrics when the daemon serves the dashboard, and is nil
+// otherwise.
+func New(store storage.Storage, name string, metrics func() rpc.MetricsSnapshot) (*Server, error) {
+	s := &Server{
+		store:   store,
+		name:    name,
+		metrics: metrics,
+		pages:   make(map[string]*template.Template),
+		mux:     http.NewServeMux(),
+	}
+
+	for _, page := range []string{"index", "issues", "issue"} {
+		tmpl, err := template.New("layout.html").Funcs(templateFuncs).ParseFS(templateFS, "templates/layout.html", "templates/"+page+".html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", page, err)
+		}
+		s.pages[page] = tmpl
+	}
+
+	s.mux.HandleFunc("GET /{$}", s.handleIndex)
+	s.mux.HandleFunc("GET /issues", s.handleIssues)
+	s.mux.HandleFunc("GET /issues/{id}", s.handleIssue)
+	s.mux.HandleFunc("GET /api/issues", s.handleAPIIssues)
+	s.mux.HandleFunc("GET /api/issues/{id}", s.handleAPIIssue)
+	s.mux.HandleFunc("GET /api/ready", s.handleAPIReady)
+	s.mux.HandleFunc("GET /api/blocked", s.handleAPIBlocked)
+	s.mux.HandleFunc("GET /api/epics", s.handleAPIEpics)
+	s.mux.HandleFunc("GET /api/stats", s.handleAPIStats)
+	s.mux.HandleFunc("GET /api/metrics", s.handleAPIMetrics)
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Serve runs the dashboard on addr until ctx is done
+func (s *Server) Serve(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// IssueDetails is an issue with its labels, dependencies and comments
+type IssueDetails struct {
+	*types.Issue
+	Labels       []string         `json:"labels,omitempty"`
+	Dependencies []*types.Issue   `json:"dependencies,omitempty"`
+	Dependents   []*types.Issue   `json:"dependents,omitempty"`
+	Comments     []*types.Comment `json:"comments,omitempty"`
+}
+
+// Overview is everything on the dashboard page
+type Overview struct {
+	Stats   *types.Statistics     `json:"stats"`
+	Ready   []*types.Issue        `json:"ready"`
+	Blocked []*types.BlockedIssue `json:"blocked"`
+	Epics   []*types.EpicStatus   `json:"epics"`
+	Metrics *rpc.MetricsSnapshot  `json:"metrics,omitempty"`
+}
+
+func (s *Server) overview(ctx context.Context) (*Overview, error) {
+	stats, err := s.store.GetStatistics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statistics: %w", err)
+	}
+	ready, err := s.store.GetReadyWork(ctx, types.WorkFilter{Limit: dashboardLimit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ready work: %w", err)
+	}
+	blocked, err := s.store.GetBlockedIssues(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked issues: %w", err)
+	}
+	if len(blocked) > dashboardLimit {
+		blocked = blocked[:dashboardLimit]
+	}
+	epics, err := s.store.GetEpicsEligibleForClosure(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epics: %w", err)
+	}
+
+	o := &Overview{Stats: stats, Ready: ready, Blocked: blocked}
+	for _, epic := range epics {
+		if epic.Epic.Status.Category() != types.CategoryDone {
+			o.Epics = append(o.Epics, epic)
+		}
+	}
+	if s.metrics != nil {
+		m := s.metrics()
+		o.Metrics = &m
+	}
+	return o, nil
+}
+
+func (s *Server) listIssues(ctx context.Context, filter string) ([]*types.Issue, error) {
+	expr, err := query.Parse(filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	return s.store.SearchIssues(ctx, "", types.IssueFilter{Expr: expr})
+}
+
+// issueDetails returns nil if there is no such issue
+func (s *Server) issueDetails(ctx context.Context, id string) (*IssueDetails, error) {
+	issue, err := s.store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", id, err)
+	}
+	if issue == nil {
+		return nil, nil
+	}
+
+	details := &IssueDetails{Issue: issue}
+	if details.Labels, err = s.store.GetLabels(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	if details.Dependencies, err = s.store.GetDependencies(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	if details.Dependents, err = s.store.GetDependents(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get dependents: %w", err)
+	}
+	if details.Comments, err = s.store.GetIssueComments(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	return details, nil
+}
+
+// filterParam returns the q parameter, or DefaultFilter if it is absent.
+// An empty q lists every issue.
+func filterParam(r *http.Request) string {
+	if values, ok := r.URL.Query()["q"]; ok {
+		return strings.TrimSpace(values[0])
+	}
+	return DefaultFilter
+}
+
+// HTML pages
+
+type pageData struct {
+	Name   string
+	Title  string
+	Now    time.Time
+	Filter string
+	Error  string
+	Data   interface{}
+}
+
+func (s *Server) render(w http.ResponseWriter, page string, status int, data pageData) {
+	data.Name = s.name
+	data.Now = time.Now()
+	var buf strings.Builder
+	if err := s.pages[page].Execute(&buf, data); err != nil {
+		http.Error(w, fmt.Sprintf("failed to render page: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, buf.String())
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	o, err := s.overview(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.render(w, "index", http.StatusOK, pageData{Title: "Dashboard", Data: o})
+}
+
+func (s *Server) handleIssues(w http.ResponseWriter, r *http.Request) {
+	filter := filterParam(r)
+	issues, err := s.listIssues(r.Context(), filter)
+	if err != nil {
+		s.render(w, "issues", http.StatusBadRequest, pageData{Title: "Issues", Filter: filter, Error: err.Error()})
+		return
+	}
+	s.render(w, "issues", http.StatusOK, pageData{Title: "Issues", Filter: filter, Data: issues})
+}
+
+func (s *Server) handleIssue(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	details, err := s.issueDetails(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if details == nil {
+		http.Error(w, fmt.Sprintf("issue %s not found", id), http.StatusNotFound)
+		return
+	}
+	s.render(w, "issue", http.StatusOK, pageData{Title: details.ID, Data: details})
+}
+
+// JSON API
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func (s *Server) handleAPIIssues(w http.ResponseWriter, r *http.Request) {
+	issues, err := s.listIssues(r.Context(), filterParam(r))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	if issues == nil {
+		issues = []*types.Issue{}
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+func (s *Server) handleAPIIssue(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	details, err := s.issueDetails(r.Context(), id)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if details == nil {
+		writeJSONError(w, http.StatusNotFound, fmt.Errorf("issue %s not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, details)
+}
+
+func (s *Server) handleAPIReady(w http.ResponseWriter, r *http.Request) {
+	filter := types.WorkFilter{}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", limit))
+			return
+		}
+		filter.Limit = n
+	}
+	issues, err := s.store.GetReadyWork(r.Context(), filter)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("failed to get ready work: %w", err))
+		return
+	}
+	if issues == nil {
+		issues = []*types.Issue{}
+	}
+	writeJSON(w, http.StatusOK, issues)
+}
+
+func (s *Server) handleAPIBlocked(w http.ResponseWriter, r *http.Request) {
+	blocked, err := s.store.GetBlockedIssues(r.Context())
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("failed to get blocked issues: %w", err))
+		return
+	}
+	if blocked == nil {
+		blocked = []*types.BlockedIssue{}
+	}
+	writeJSON(w, http.StatusOK, blocked)
+}
+
+func (s *Server) handleAPIEpics(w http.ResponseWriter, r *http.Request) {
+	epics, err := s.store.GetEpicsEligibleForClosure(r.Context())
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("failed to get epics: %w", err))
+		return
+	}
+	if epics == nil {
+		epics = []*types.EpicStatus{}
+	}
+	writeJSON(w, http.StatusOK, epics)
+}
+
+func (s *Server) handleAPIStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.store.GetStatistics(r.Context())
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Errorf("failed to get statistics: %w", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (s *Server) handleAPIMetrics(w http.ResponseWriter, _ *http.Request) {
+	if s.metrics == nil {
+		writeJSONError(w, http.StatusNotFound, fmt.Errorf("metrics are only available when the daemon serves the dashboard"))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.metrics())
+}
+
+// Template helpers
+
+var templateFuncs = template.FuncMap{
+	"percent": func(done, total int) int {
+		if total == 0 {
+			return 0
+		}
+		return done * 100 / total
+	},
+	"ago": func(t time.Time) string {
+		d := time.Since(t)
+		switch {
+		case d < time.Minute:
+			return "just now"
+		case d < time.Hour:
+			return fmt.Sprintf("%dm ago", int(d.Minutes()))
+		case d < 24*time.Hour:
+			return fmt.Sprintf("%dh ago", int(d.Hours()))
+		default:
+			return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+		}
+	},
+	"statusClass": func(status types.Status) string {
+		switch status.Category() {
+		case types.CategoryDone:
+			return "done"
+		case types.CategoryBlocked:
+			return "blocked"
+		}
+		if status == types.StatusInProgress {
+			return "active"
+		}
+		return "open"
+	},
+	"join": strings.Join,
+}
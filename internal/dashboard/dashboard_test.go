@@ -0,0 +1,216 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+type testIssues struct {
+	epic, ready, blocked, closed *types.Issue
+}
+
+func newTestServer(t *testing.T, metrics func() rpc.MetricsSnapshot) (*httptest.Server, testIssues) {
+	t.Helper()
+	ctx := context.Background()
+	store, err := sqlite.New(t.TempDir() + "/test.db")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+
+	issues := testIssues{
+		epic:    &types.Issue{Title: "Launch", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic},
+		ready:   &types.Issue{Title: "Write <docs>", Description: "Cover the API", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask},
+		blocked: &types.Issue{Title: "Ship it", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		closed:  &types.Issue{Title: "Done already", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	for _, issue := range []*types.Issue{issues.epic, issues.ready, issues.blocked, issues.closed} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: issues.ready.ID, DependsOnID: issues.epic.ID, Type: types.DepParentChild},
+		{IssueID: issues.closed.ID, DependsOnID: issues.epic.ID, Type: types.DepParentChild},
+		{IssueID: issues.blocked.ID, DependsOnID: issues.ready.ID, Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, issues.closed.ID, "Done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, issues.ready.ID, "alice", "Started the outline"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	dash, err := New(store, "myproject", metrics)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	srv := httptest.NewServer(dash)
+	t.Cleanup(srv.Close)
+	return srv, issues
+}
+
+func get(t *testing.T, srv *httptest.Server, path string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func getJSON(t *testing.T, srv *httptest.Server, path string, v interface{}) int {
+	t.Helper()
+	status, body := get(t, srv, path)
+	if err := json.Unmarshal([]byte(body), v); err != nil {
+		t.Fatalf("GET %s returned invalid JSON: %v\n%s", path, err, body)
+	}
+	return status
+}
+
+func issueIDs(issues []*types.Issue) map[string]bool {
+	ids := make(map[string]bool)
+	for _, issue := range issues {
+		ids[issue.ID] = true
+	}
+	return ids
+}
+
+func TestAPI(t *testing.T) {
+	srv, issues := newTestServer(t, nil)
+
+	var list []*types.Issue
+	if status := getJSON(t, srv, "/api/issues", &list); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if ids := issueIDs(list); len(ids) != 3 || ids[issues.closed.ID] {
+		t.Errorf("expected the 3 issues that are not closed, got %v", ids)
+	}
+	if getJSON(t, srv, "/api/issues?q=", &list); len(list) != 4 {
+		t.Errorf("expected an empty filter to list all 4 issues, got %d", len(list))
+	}
+	if getJSON(t, srv, "/api/issues?q="+url.QueryEscape("priority<=0"), &list); len(list) != 1 || list[0].ID != issues.ready.ID {
+		t.Errorf("expected only %s at P0, got %v", issues.ready.ID, issueIDs(list))
+	}
+	var apiErr map[string]string
+	if status := getJSON(t, srv, "/api/issues?q="+url.QueryEscape("priority<<"), &apiErr); status != http.StatusBadRequest || !strings.Contains(apiErr["error"], "invalid filter") {
+		t.Errorf("expected a 400 for a bad filter, got %d %v", status, apiErr)
+	}
+
+	var details IssueDetails
+	getJSON(t, srv, "/api/issues/"+issues.ready.ID, &details)
+	if details.Issue == nil || details.Title != "Write <docs>" || len(details.Comments) != 1 || len(details.Dependents) != 1 {
+		t.Errorf("unexpected issue details: %+v", details)
+	}
+	if status := getJSON(t, srv, "/api/issues/test-missing", &apiErr); status != http.StatusNotFound {
+		t.Errorf("expected a 404 for a missing issue, got %d", status)
+	}
+
+	getJSON(t, srv, "/api/ready", &list)
+	if ids := issueIDs(list); !ids[issues.ready.ID] || ids[issues.blocked.ID] {
+		t.Errorf("expected %s ready and %s not, got %v", issues.ready.ID, issues.blocked.ID, ids)
+	}
+	if getJSON(t, srv, "/api/ready?limit=1", &list); len(list) != 1 {
+		t.Errorf("expected limit=1 to return 1 issue, got %d", len(list))
+	}
+	if status := getJSON(t, srv, "/api/ready?limit=x", &apiErr); status != http.StatusBadRequest {
+		t.Errorf("expected a 400 for a bad limit, got %d", status)
+	}
+
+	var blocked []*types.BlockedIssue
+	getJSON(t, srv, "/api/blocked", &blocked)
+	if len(blocked) != 1 || blocked[0].ID != issues.blocked.ID || blocked[0].BlockedBy[0] != issues.ready.ID {
+		t.Errorf("expected %s blocked by %s, got %+v", issues.blocked.ID, issues.ready.ID, blocked)
+	}
+
+	var epics []*types.EpicStatus
+	getJSON(t, srv, "/api/epics", &epics)
+	if len(epics) != 1 || epics[0].TotalChildren != 2 || epics[0].ClosedChildren != 1 {
+		t.Errorf("expected the epic with 1 of 2 children closed, got %+v", epics)
+	}
+
+	var stats types.Statistics
+	getJSON(t, srv, "/api/stats", &stats)
+	if stats.TotalIssues != 4 || stats.ClosedIssues != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	if status := getJSON(t, srv, "/api/metrics", &apiErr); status != http.StatusNotFound {
+		t.Errorf("expected no metrics outside the daemon, got %d", status)
+	}
+
+	// Read-only: other methods are rejected
+	resp, err := http.Post(srv.URL+"/api/issues", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected POST to be rejected, got %d", resp.StatusCode)
+	}
+}
+
+func TestPages(t *testing.T) {
+	srv, issues := newTestServer(t, func() rpc.MetricsSnapshot {
+		return rpc.MetricsSnapshot{UptimeSeconds: 90, ActiveConns: 2, Operations: []rpc.OperationMetrics{{Operation: "list", TotalCount: 7}}}
+	})
+
+	status, body := get(t, srv, "/")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	for _, want := range []string{
+		"beads · myproject",
+		"Ready to work", "/issues/" + issues.ready.ID, "Write &lt;docs&gt;",
+		"Blocked (1)", "Ship it",
+		"Launch", "1/2", "width: 50%",
+		"Daemon", "2 active", "list 7",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected dashboard to contain %q", want)
+		}
+	}
+
+	_, body = get(t, srv, "/issues?q="+url.QueryEscape("type:epic"))
+	if !strings.Contains(body, "1 issue<") || !strings.Contains(body, `value="type:epic"`) || strings.Contains(body, "Ship it") {
+		t.Errorf("unexpected filtered issue list:\n%s", body)
+	}
+	if status, body := get(t, srv, "/issues?q="+url.QueryEscape("priority<<")); status != http.StatusBadRequest || !strings.Contains(body, "invalid filter") {
+		t.Errorf("expected a bad filter to be reported, got %d", status)
+	}
+
+	_, body = get(t, srv, "/issues/"+issues.ready.ID)
+	for _, want := range []string{"Cover the API", "Blocks (1)", "Started the outline", "alice"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected issue page to contain %q", want)
+		}
+	}
+	if status, _ := get(t, srv, "/issues/test-missing"); status != http.StatusNotFound {
+		t.Errorf("expected a 404 for a missing issue, got %d", status)
+	}
+	if status, _ := get(t, srv, "/nope"); status != http.StatusNotFound {
+		t.Errorf("expected a 404 for an unknown page, got %d", status)
+	}
+}
@@ -0,0 +1,243 @@
+// Package federation resolves cross-repository issue references such as
+// "api:bd-a3f8" through the repository registry in config.yaml, and merges
+// ready work across beads databases.
+package federation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/shaneholloman/beads/internal/configfile"
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/shaneholloman/beads/internal/utils"
+)
+
+// FindDatabase returns the SQLite database for a registry path: a repository
+// root, its .beads directory, or the database file itself. The lookup order
+// matches the CLI's: config.json, then beads.db, then any other *.db file.
+func FindDatabase(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+
+	beadsDir := path
+	if filepath.Base(path) != ".beads" {
+		beadsDir = filepath.Join(path, ".beads")
+	}
+
+	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil {
+		if cfg.IsPostgres() {
+			return "", fmt.Errorf("%s uses Postgres storage; cross-repository references need SQLite", beadsDir)
+		}
+		if dbPath := cfg.DatabasePath(beadsDir); fileExists(dbPath) {
+			return dbPath, nil
+		}
+	}
+
+	if dbPath := filepath.Join(beadsDir, "beads.db"); fileExists(dbPath) {
+		return dbPath, nil
+	}
+	matches, _ := filepath.Glob(filepath.Join(beadsDir, "*.db"))
+	sort.Strings(matches)
+	if len(matches) > 0 {
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("no beads database in %s", beadsDir)
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// StoreCache keeps other repositories' databases open, read-only, across
+// Resolvers, so a long-running process such as the daemon opens each once.
+// It is safe for concurrent use.
+type StoreCache struct {
+	mu     sync.Mutex
+	stores map[string]*sqlite.SQLiteStorage // By database path
+}
+
+// NewStoreCache returns an empty StoreCache
+func NewStoreCache() *StoreCache {
+	return &StoreCache{stores: make(map[string]*sqlite.SQLiteStorage)}
+}
+
+// Open returns the database at dbPath, opening it on first use. Failures
+// aren't cached, so a repository that appears later is picked up.
+func (c *StoreCache) Open(dbPath string) (*sqlite.SQLiteStorage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if store, ok := c.stores[dbPath]; ok {
+		return store, nil
+	}
+	store, err := sqlite.NewReadOnly(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	c.stores[dbPath] = store
+	return store, nil
+}
+
+// Close closes the cached databases
+func (c *StoreCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var firstErr error
+	for path, store := range c.stores {
+		if err := store.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(c.stores, path)
+	}
+	return firstErr
+}
+
+// Resolver looks up issues in the repositories of a registry (alias → path,
+// see config.LoadRepos). Databases are opened read-only, without migrations,
+// on first use and kept open until Close, or in a StoreCache that outlives
+// the Resolver. A Resolver is not safe for concurrent use.
+type Resolver struct {
+	repos  map[string]string
+	cache  *StoreCache
+	stores map[string]*sqlite.SQLiteStorage
+	failed map[string]error
+	warned map[string]bool
+
+	// Warnings describes references that couldn't be resolved while checking
+	// blockers; such references are treated as still blocking.
+	Warnings []string
+}
+
+// NewResolver returns a Resolver for the given registry
+func NewResolver(repos map[string]string) *Resolver {
+	return NewCachedResolver(repos, nil)
+}
+
+// NewCachedResolver returns a Resolver for the given registry that opens
+// databases through cache, which keeps them open after the Resolver is closed
+func NewCachedResolver(repos map[string]string, cache *StoreCache) *Resolver {
+	return &Resolver{
+		repos:  repos,
+		cache:  cache,
+		stores: make(map[string]*sqlite.SQLiteStorage),
+		failed: make(map[string]error),
+		warned: make(map[string]bool),
+	}
+}
+
+// Close closes the databases the resolver opened, except cached ones
+func (r *Resolver) Close() error {
+	var firstErr error
+	for alias, store := range r.stores {
+		if r.cache == nil {
+			if err := store.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		delete(r.stores, alias)
+	}
+	return firstErr
+}
+
+// Store returns the database of the repository with the given alias
+func (r *Resolver) Store(alias string) (*sqlite.SQLiteStorage, error) {
+	if store, ok := r.stores[alias]; ok {
+		return store, nil
+	}
+	if err, ok := r.failed[alias]; ok {
+		return nil, err
+	}
+
+	store, err := r.open(alias)
+	if err != nil {
+		r.failed[alias] = err
+		return nil, err
+	}
+	r.stores[alias] = store
+	return store, nil
+}
+
+func (r *Resolver) open(alias string) (*sqlite.SQLiteStorage, error) {
+	path, ok := r.repos[alias]
+	if !ok {
+		return nil, fmt.Errorf("unknown repository alias %q (add it under repos: in .beads/config.yaml)", alias)
+	}
+	dbPath, err := FindDatabase(path)
+	if err != nil {
+		return nil, fmt.Errorf("repository %s: %w", alias, err)
+	}
+	var store *sqlite.SQLiteStorage
+	if r.cache != nil {
+		store, err = r.cache.Open(dbPath)
+	} else {
+		store, err = sqlite.NewReadOnly(dbPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository %s: %w", alias, err)
+	}
+	return store, nil
+}
+
+// Lookup returns the issue a qualified ID refers to
+func (r *Resolver) Lookup(ctx context.Context, qualifiedID string) (*types.Issue, error) {
+	alias, id, ok := types.ParseQualifiedID(qualifiedID)
+	if !ok {
+		return nil, fmt.Errorf("invalid cross-repository reference %q (expected <repo>:<issue-id>)", qualifiedID)
+	}
+	store, err := r.Store(alias)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", qualifiedID, err)
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s not found in repository %s", id, alias)
+	}
+	return issue, nil
+}
+
+// ResolveID expands a qualified ID with a partial issue ID, such as
+// "api:a3f8", to the full qualified ID of the issue in that repository.
+func (r *Resolver) ResolveID(ctx context.Context, qualifiedID string) (string, error) {
+	alias, id, ok := types.ParseQualifiedID(qualifiedID)
+	if !ok {
+		return "", fmt.Errorf("invalid cross-repository reference %q (expected <repo>:<issue-id>)", qualifiedID)
+	}
+	store, err := r.Store(alias)
+	if err != nil {
+		return "", err
+	}
+	fullID, err := utils.ResolvePartialID(ctx, store, id)
+	if err != nil {
+		return "", fmt.Errorf("repository %s: %w", alias, err)
+	}
+	return types.QualifiedID(alias, fullID), nil
+}
+
+// Blocking reports whether the issue a qualified ID refers to still blocks
+// its dependents, that is, isn't done. A reference that can't be resolved
+// counts as blocking and adds a warning, so unreachable work is never
+// reported ready.
+func (r *Resolver) Blocking(ctx context.Context, qualifiedID string) bool {
+	issue, err := r.Lookup(ctx, qualifiedID)
+	if err != nil {
+		if !r.warned[qualifiedID] {
+			r.warned[qualifiedID] = true
+			r.Warnings = append(r.Warnings, fmt.Sprintf("treating %s as open: %v", qualifiedID, err))
+		}
+		return true
+	}
+	return issue.Status.Category() != types.CategoryDone
+}
@@ -0,0 +1,252 @@
+package federation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// newRepo creates a repository with a .beads/beads.db using the given prefix
+func newRepo(t *testing.T, prefix string) (string, *sqlite.SQLiteStorage) {
+	t.Helper()
+	root := filepath.Join(t.TempDir(), prefix)
+	beadsDir := filepath.Join(root, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	store, err := sqlite.New(filepath.Join(beadsDir, "beads.db"))
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(context.Background(), "issue_prefix", prefix); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+	return root, store
+}
+
+func createIssue(t *testing.T, store *sqlite.SQLiteStorage, id string, priority int, created time.Time) *types.Issue {
+	t.Helper()
+	issue := &types.Issue{
+		ID:        id,
+		Title:     "Issue " + id,
+		Status:    types.StatusOpen,
+		Priority:  priority,
+		IssueType: types.TypeTask,
+		CreatedAt: created,
+	}
+	if err := store.CreateIssue(context.Background(), issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	return issue
+}
+
+func readyIDs(issues []*types.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+func TestFindDatabase(t *testing.T) {
+	root, _ := newRepo(t, "api")
+	want := filepath.Join(root, ".beads", "beads.db")
+
+	for _, path := range []string{root, filepath.Join(root, ".beads"), want} {
+		got, err := FindDatabase(path)
+		if err != nil || got != want {
+			t.Errorf("FindDatabase(%s) = %q, %v; want %q", path, got, err, want)
+		}
+	}
+	if _, err := FindDatabase(t.TempDir()); err == nil || !strings.Contains(err.Error(), "no beads database") {
+		t.Errorf("expected an error for a directory without a database, got %v", err)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	apiRoot, api := newRepo(t, "api")
+	createIssue(t, api, "api-a3f8", 1, time.Now())
+
+	r := NewResolver(map[string]string{"api": apiRoot})
+	defer func() { _ = r.Close() }()
+
+	id, err := r.ResolveID(ctx, "api:a3f8")
+	if err != nil || id != "api:api-a3f8" {
+		t.Errorf("ResolveID = %q, %v", id, err)
+	}
+	if _, err := r.Lookup(ctx, "api:api-ffff"); err == nil || !strings.Contains(err.Error(), "not found in repository api") {
+		t.Errorf("expected a missing issue error, got %v", err)
+	}
+	if _, err := r.Lookup(ctx, "web:web-1"); err == nil || !strings.Contains(err.Error(), `unknown repository alias "web"`) {
+		t.Errorf("expected an unknown alias error, got %v", err)
+	}
+
+	if !r.Blocking(ctx, "api:api-a3f8") {
+		t.Error("expected an open issue to block")
+	}
+	if !r.Blocking(ctx, "web:web-1") || len(r.Warnings) != 1 {
+		t.Errorf("expected an unresolvable reference to block with a warning, got %v", r.Warnings)
+	}
+}
+
+func TestResolverStoreCache(t *testing.T) {
+	ctx := context.Background()
+	apiRoot, api := newRepo(t, "api")
+	createIssue(t, api, "api-a3f8", 1, time.Now())
+	registry := map[string]string{"api": apiRoot}
+
+	cache := NewStoreCache()
+	defer func() { _ = cache.Close() }()
+	first := NewCachedResolver(registry, cache)
+	store, err := first.Store("api")
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	_ = first.Close()
+
+	// A later resolver reuses the database, still open
+	second := NewCachedResolver(registry, cache)
+	defer func() { _ = second.Close() }()
+	if again, err := second.Store("api"); err != nil || again != store {
+		t.Fatalf("expected the cached database, got %p, %v", again, err)
+	}
+	if _, err := second.Lookup(ctx, "api:api-a3f8"); err != nil {
+		t.Errorf("Lookup after the first resolver closed failed: %v", err)
+	}
+
+	// Other repositories are opened read-only
+	issue := &types.Issue{Title: "Sneaky", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err == nil {
+		t.Error("expected writes to another repository's database to fail")
+	}
+}
+
+func TestGetReadyWorkExternalBlockers(t *testing.T) {
+	ctx := context.Background()
+	apiRoot, api := newRepo(t, "api")
+	_, web := newRepo(t, "web")
+
+	now := time.Now()
+	createIssue(t, api, "api-a3f8", 1, now)
+	createIssue(t, web, "web-1", 0, now.Add(-3*time.Hour))
+	createIssue(t, web, "web-2", 1, now.Add(-2*time.Hour))
+	createIssue(t, web, "web-3", 2, now.Add(-time.Hour))
+
+	for _, dep := range []*types.Dependency{
+		{IssueID: "web-1", DependsOnID: "api:api-a3f8", Type: types.DepBlocks},
+		{IssueID: "web-3", DependsOnID: "web-1", Type: types.DepParentChild},
+	} {
+		if err := web.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	registry := map[string]string{"api": apiRoot}
+	filter := types.WorkFilter{SortPolicy: types.SortPolicyPriority, Limit: 1}
+
+	r := NewResolver(registry)
+	issues, err := GetReadyWork(ctx, web, filter, r)
+	_ = r.Close()
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	// web-1 is blocked by the open API issue and web-3 is its child; the
+	// limit applies after they are dropped
+	if got := readyIDs(issues); len(got) != 1 || got[0] != "web-2" {
+		t.Errorf("expected [web-2], got %v", got)
+	}
+
+	if err := api.CloseIssue(ctx, "api-a3f8", "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	r = NewResolver(registry)
+	issues, err = GetReadyWork(ctx, web, types.WorkFilter{SortPolicy: types.SortPolicyPriority}, r)
+	_ = r.Close()
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if got := readyIDs(issues); len(got) != 3 || got[0] != "web-1" {
+		t.Errorf("expected web-1 to be ready once the API issue closed, got %v", got)
+	}
+}
+
+func TestReadyAcrossRepos(t *testing.T) {
+	ctx := context.Background()
+	apiRoot, api := newRepo(t, "api")
+	webRoot, web := newRepo(t, "web")
+
+	now := time.Now()
+	createIssue(t, api, "api-1", 2, now.Add(-2*time.Hour))
+	createIssue(t, api, "api-2", 0, now.Add(-time.Hour))
+	createIssue(t, web, "web-1", 1, now.Add(-3*time.Hour))
+	createIssue(t, web, "web-2", 3, now.Add(-4*time.Hour))
+	if err := web.AddDependency(ctx, &types.Dependency{IssueID: "web-1", DependsOnID: "api:api-2", Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	registry := map[string]string{"api": apiRoot}
+	repos, warnings := Repos(filepath.Join(webRoot, ".beads", "beads.db"), registry,
+		[]string{filepath.Join(apiRoot, ".beads", "beads.db")})
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+	if len(repos) != 2 || repos[0].Alias != "web" || repos[1].Alias != "api" {
+		t.Fatalf("expected the current repository then api, each once, got %+v", repos)
+	}
+
+	for i := range repos {
+		repos[i].Registry = registry
+	}
+
+	filter := types.WorkFilter{SortPolicy: types.SortPolicyPriority, Limit: 2}
+	issues, warnings := ReadyAcrossRepos(ctx, repos, filter, now)
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+	// web-1 waits on api-2, so the top two are api-2 (P0) and api-1 (P2)
+	if len(issues) != 2 || issues[0].QualifiedID != "api:api-2" || issues[1].QualifiedID != "api:api-1" {
+		t.Fatalf("unexpected merged ready work: %+v", issues)
+	}
+	if issues[0].Repo != "api" || issues[0].DBPath != repos[1].DBPath {
+		t.Errorf("expected issues to be tagged with their repository, got %+v", issues[0])
+	}
+
+	// Unknown registry entries are reported, not fatal
+	_, warnings = Repos("", map[string]string{"gone": filepath.Join(t.TempDir(), "gone")}, nil)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "skipping repository gone") {
+		t.Errorf("expected a warning for a missing repository, got %v", warnings)
+	}
+}
+
+func TestSortReadyHybrid(t *testing.T) {
+	now := time.Now()
+	issue := func(repo, id string, priority int, age time.Duration) *ReadyIssue {
+		return &ReadyIssue{
+			Issue: &types.Issue{ID: id, Priority: priority, CreatedAt: now.Add(-age)},
+			Repo:  repo,
+		}
+	}
+	issues := []*ReadyIssue{
+		issue("api", "api-old", 0, 10*24*time.Hour),
+		issue("web", "web-new-p2", 2, time.Hour),
+		issue("api", "api-new-p1", 1, 2*time.Hour),
+		issue("web", "web-older", 0, 5*24*time.Hour),
+	}
+
+	SortReady(issues, types.SortPolicyHybrid, now)
+
+	want := []string{"api-new-p1", "web-new-p2", "api-old", "web-older"}
+	for i, id := range want {
+		if issues[i].ID != id {
+			t.Fatalf("position %d: got %s, want %s", i, issues[i].ID, id)
+		}
+	}
+}
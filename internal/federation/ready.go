@@ -0,0 +1,316 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// hybridRecentWindow matches the hybrid sort policy's '-48 hours' in SQL
+const hybridRecentWindow = 48 * time.Hour
+
+// GetReadyWork returns store.GetReadyWork(filter) without the issues blocked
+// by an open issue in another repository. Like local blockers, an external
+// blocker also holds back the issue's parent-child descendants. Stores
+// without cross-repository dependencies are queried as usual.
+func GetReadyWork(ctx context.Context, store storage.Storage, filter types.WorkFilter, r *Resolver) ([]*types.Issue, error) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return store.GetReadyWork(ctx, filter)
+	}
+	external, err := sqliteStore.GetExternalBlockers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(external) == 0 {
+		return store.GetReadyWork(ctx, filter)
+	}
+
+	blocked := make(map[string]bool)
+	for issueID, refs := range external {
+		for _, ref := range refs {
+			if r.Blocking(ctx, ref) {
+				blocked[issueID] = true
+				break
+			}
+		}
+	}
+	if len(blocked) == 0 {
+		return store.GetReadyWork(ctx, filter)
+	}
+	if err := addDescendants(ctx, sqliteStore, blocked); err != nil {
+		return nil, err
+	}
+
+	// Blocked issues are dropped after the query, so apply the limit afterwards
+	limit := filter.Limit
+	filter.Limit = 0
+	issues, err := store.GetReadyWork(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	ready := issues[:0]
+	for _, issue := range issues {
+		if !blocked[issue.ID] {
+			ready = append(ready, issue)
+		}
+	}
+	if limit > 0 && len(ready) > limit {
+		ready = ready[:limit]
+	}
+	return ready, nil
+}
+
+// addDescendants adds the parent-child descendants of the blocked issues
+func addDescendants(ctx context.Context, store *sqlite.SQLiteStorage, blocked map[string]bool) error {
+	allDeps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	children := make(map[string][]string)
+	for _, deps := range allDeps {
+		for _, dep := range deps {
+			if dep.Type == types.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], dep.IssueID)
+			}
+		}
+	}
+
+	queue := make([]string, 0, len(blocked))
+	for id := range blocked {
+		queue = append(queue, id)
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if !blocked[child] {
+				blocked[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return nil
+}
+
+// Repo is a beads database taking part in federated ready work
+type Repo struct {
+	Alias  string `json:"alias"`
+	DBPath string `json:"db_path"`
+
+	// Registry resolves the repository's own cross-repository references
+	Registry map[string]string `json:"-"`
+}
+
+// ReadyIssue is ready work tagged with the repository it came from
+type ReadyIssue struct {
+	*types.Issue
+	Repo        string `json:"repo"`
+	QualifiedID string `json:"qualified_id"`
+	DBPath      string `json:"db_path"`
+}
+
+// Repos lists the databases that take part in federated ready work: the
+// current database, each registry entry, then the extra databases (such as
+// those beads.FindAllDatabases discovers), each database once. Registry
+// entries keep their alias; other databases are named after their repository
+// directory. Registry entries without a database are reported as warnings.
+func Repos(currentDB string, registry map[string]string, extra []string) ([]Repo, []string) {
+	var repos []Repo
+	var warnings []string
+	seenPaths := make(map[string]bool)
+	usedAliases := make(map[string]bool)
+
+	// Registry aliases win, whichever order the databases are listed in
+	aliasByPath := make(map[string]string)
+	aliases := make([]string, 0, len(registry))
+	for alias := range registry {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	var registryPaths []string
+	for _, alias := range aliases {
+		dbPath, err := FindDatabase(registry[alias])
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("skipping repository %s: %v", alias, err))
+			continue
+		}
+		key := canonicalPath(dbPath)
+		if _, dup := aliasByPath[key]; dup {
+			continue
+		}
+		aliasByPath[key] = alias
+		usedAliases[alias] = true
+		registryPaths = append(registryPaths, dbPath)
+	}
+
+	add := func(dbPath string) {
+		if dbPath == "" {
+			return
+		}
+		key := canonicalPath(dbPath)
+		if seenPaths[key] {
+			return
+		}
+		seenPaths[key] = true
+
+		alias, ok := aliasByPath[key]
+		if !ok {
+			alias = uniqueAlias(repoName(dbPath), usedAliases)
+			usedAliases[alias] = true
+		}
+		repos = append(repos, Repo{Alias: alias, DBPath: dbPath})
+	}
+
+	add(currentDB)
+	for _, dbPath := range registryPaths {
+		add(dbPath)
+	}
+	for _, dbPath := range extra {
+		add(dbPath)
+	}
+	return repos, warnings
+}
+
+func canonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return path
+}
+
+var nonAliasRe = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// repoName names a database after its repository directory, the parent of .beads
+func repoName(dbPath string) string {
+	dir := filepath.Dir(canonicalPath(dbPath))
+	if filepath.Base(dir) == ".beads" {
+		dir = filepath.Dir(dir)
+	}
+	name := strings.Trim(nonAliasRe.ReplaceAllString(strings.ToLower(filepath.Base(dir)), "-"), "-_")
+	if name == "" {
+		name = "repo"
+	}
+	return name
+}
+
+func uniqueAlias(name string, used map[string]bool) string {
+	alias := name
+	for n := 2; used[alias]; n++ {
+		alias = fmt.Sprintf("%s-%d", name, n)
+	}
+	return alias
+}
+
+// ReadyAcrossRepos merges the ready work of every repo into one list ordered
+// by filter.SortPolicy, so the most important task across all of them comes
+// first, and applies filter.Limit to the merged list. Each repository's
+// cross-repository blockers are resolved with its Registry. A repository that
+// can't be read is skipped with a warning.
+func ReadyAcrossRepos(ctx context.Context, repos []Repo, filter types.WorkFilter, now time.Time) ([]*ReadyIssue, []string) {
+	var all []*ReadyIssue
+	var warnings []string
+
+	for _, repo := range repos {
+		issues, repoWarnings, err := readyInRepo(ctx, repo, filter)
+		for _, w := range repoWarnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", repo.Alias, w))
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("skipping repository %s: %v", repo.Alias, err))
+			continue
+		}
+		for _, issue := range issues {
+			all = append(all, &ReadyIssue{
+				Issue:       issue,
+				Repo:        repo.Alias,
+				QualifiedID: types.QualifiedID(repo.Alias, issue.ID),
+				DBPath:      repo.DBPath,
+			})
+		}
+	}
+
+	SortReady(all, filter.SortPolicy, now)
+	if filter.Limit > 0 && len(all) > filter.Limit {
+		all = all[:filter.Limit]
+	}
+	return all, warnings
+}
+
+func readyInRepo(ctx context.Context, repo Repo, filter types.WorkFilter) ([]*types.Issue, []string, error) {
+	store, err := sqlite.NewReadOnly(repo.DBPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	resolver := NewResolver(repo.Registry)
+	defer func() { _ = resolver.Close() }()
+
+	// Each repository returns at most filter.Limit issues in the same order as
+	// the merge, so the merged top issues are among them
+	issues, err := GetReadyWork(ctx, store, filter, resolver)
+	return issues, resolver.Warnings, err
+}
+
+// SortReady orders merged ready work the way the storage backends order a
+// single database's, for the given sort policy. Ties go to the repository
+// alias, then the issue ID, so the order is stable.
+func SortReady(issues []*ReadyIssue, policy types.SortPolicy, now time.Time) {
+	recent := func(i *ReadyIssue) bool {
+		return !i.CreatedAt.Before(now.Add(-hybridRecentWindow))
+	}
+
+	less := func(a, b *ReadyIssue) (bool, bool) {
+		switch policy {
+		case types.SortPolicyPriority:
+			if a.Priority != b.Priority {
+				return a.Priority < b.Priority, true
+			}
+		case types.SortPolicyOldest:
+		case types.SortPolicyDeadline:
+			if pa, pb := a.Priority-a.DeadlineWeight(now), b.Priority-b.DeadlineWeight(now); pa != pb {
+				return pa < pb, true
+			}
+			if (a.DueAt == nil) != (b.DueAt == nil) {
+				return a.DueAt != nil, true
+			}
+			if a.DueAt != nil && !a.DueAt.Equal(*b.DueAt) {
+				return a.DueAt.Before(*b.DueAt), true
+			}
+		default:
+			// Hybrid: recent issues by priority first, then older issues by age
+			if ra, rb := recent(a), recent(b); ra != rb {
+				return ra, true
+			} else if ra && a.Priority != b.Priority {
+				return a.Priority < b.Priority, true
+			}
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt), true
+		}
+		return false, false
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if result, decided := less(a, b); decided {
+			return result
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,316 @@
+// Package github synchronizes beads issues with GitHub Issues through the
+// GitHub REST API. Issues are linked by their external_ref ("gh-<number>").
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the GitHub REST API endpoint; GitHub Enterprise installs
+// use https://<host>/api/v3
+const DefaultBaseURL = "https://api.github.com"
+
+// pageSize is the number of issues or comments requested per page (the API maximum)
+const pageSize = 100
+
+// Issue is a GitHub issue as returned by the REST API
+type Issue struct {
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	Body        string          `json:"body"`
+	State       string          `json:"state"` // "open" or "closed"
+	StateReason string          `json:"state_reason,omitempty"`
+	Labels      []Label         `json:"labels"`
+	Assignee    *User           `json:"assignee"`
+	Comments    int             `json:"comments"` // Number of comments
+	HTMLURL     string          `json:"html_url"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	ClosedAt    *time.Time      `json:"closed_at"`
+	PullRequest json.RawMessage `json:"pull_request,omitempty"` // Set when the issue is a pull request
+}
+
+// LabelNames returns the names of the issue's labels
+func (i *Issue) LabelNames() []string {
+	names := make([]string, len(i.Labels))
+	for n, label := range i.Labels {
+		names[n] = label.Name
+	}
+	return names
+}
+
+// Label is a GitHub issue label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// User is a GitHub account
+type User struct {
+	Login string `json:"login"`
+}
+
+// Comment is a comment on a GitHub issue
+type Comment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	User      *User     `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IssueRequest creates or edits an issue. Nil fields are left unchanged.
+type IssueRequest struct {
+	Title  *string   `json:"title,omitempty"`
+	Body   *string   `json:"body,omitempty"`
+	State  *string   `json:"state,omitempty"`
+	Labels *[]string `json:"labels,omitempty"` // A pointer so an empty list clears the labels
+}
+
+// IsEmpty reports whether the request changes nothing
+func (r *IssueRequest) IsEmpty() bool {
+	return r.Title == nil && r.Body == nil && r.State == nil && r.Labels == nil
+}
+
+// APIError is a non-2xx response from the GitHub API
+type APIError struct {
+	StatusCode int
+	Message    string
+	RateLimit  bool   // The request was rejected because the rate limit is exhausted
+	ResetAt    string // When the rate limit resets, if RateLimit
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("GitHub API error: %d %s", e.StatusCode, e.Message)
+	if e.RateLimit {
+		msg += fmt.Sprintf(" (rate limit exceeded, resets at %s)", e.ResetAt)
+	}
+	return msg
+}
+
+// Client talks to the issues API of a single repository. Point BaseURL and
+// HTTPClient at a test server to exercise it without network access.
+type Client struct {
+	BaseURL    string
+	Owner      string
+	Repo       string
+	Token      string // Optional for public repositories when only pulling
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client for repo ("owner/name") using the public API
+func NewClient(repo, token string) (*Client, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid repository %q (expected owner/repo)", repo)
+	}
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		Owner:      owner,
+		Repo:       name,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// FullName returns "owner/repo"
+func (c *Client) FullName() string {
+	return c.Owner + "/" + c.Repo
+}
+
+// Ref returns the external_ref linking a beads issue to a GitHub issue number
+func Ref(number int) string {
+	return "gh-" + strconv.Itoa(number)
+}
+
+// issueURLPattern matches GitHub issue URLs, as written by examples/github-import
+var issueURLPattern = regexp.MustCompile(`^https?://[^/]+/([^/]+)/([^/]+)/issues/(\d+)$`)
+
+// ParseRef returns the issue number an external_ref links to. Both "gh-<number>"
+// and issue URLs in this client's repository are recognized.
+func (c *Client) ParseRef(ref string) (int, bool) {
+	if rest, ok := strings.CutPrefix(ref, "gh-"); ok {
+		n, err := strconv.Atoi(rest)
+		return n, err == nil && n > 0
+	}
+	m := issueURLPattern.FindStringSubmatch(ref)
+	if m == nil || !strings.EqualFold(m[1], c.Owner) || !strings.EqualFold(m[2], c.Repo) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[3])
+	return n, err == nil
+}
+
+// ListIssues returns the repository's issues, open and closed, updated at or
+// after since (all issues when since is zero). Pull requests are skipped.
+func (c *Client) ListIssues(ctx context.Context, since time.Time) ([]*Issue, error) {
+	query := url.Values{}
+	query.Set("state", "all")
+	query.Set("sort", "updated")
+	query.Set("direction", "asc")
+	query.Set("per_page", strconv.Itoa(pageSize))
+	if !since.IsZero() {
+		query.Set("since", since.UTC().Format(time.RFC3339))
+	}
+
+	var issues []*Issue
+	next := c.repoURL("/issues") + "?" + query.Encode()
+	for next != "" {
+		var page []*Issue
+		link, err := c.do(ctx, http.MethodGet, next, nil, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+		for _, issue := range page {
+			if len(issue.PullRequest) == 0 {
+				issues = append(issues, issue)
+			}
+		}
+		next = nextPage(link)
+	}
+	return issues, nil
+}
+
+// GetIssue returns a single issue
+func (c *Client) GetIssue(ctx context.Context, number int) (*Issue, error) {
+	var issue Issue
+	if _, err := c.do(ctx, http.MethodGet, c.issueURL(number, ""), nil, &issue); err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+	return &issue, nil
+}
+
+// CreateIssue opens a new issue
+func (c *Client) CreateIssue(ctx context.Context, req *IssueRequest) (*Issue, error) {
+	var issue Issue
+	if _, err := c.do(ctx, http.MethodPost, c.repoURL("/issues"), req, &issue); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	return &issue, nil
+}
+
+// UpdateIssue edits an issue
+func (c *Client) UpdateIssue(ctx context.Context, number int, req *IssueRequest) (*Issue, error) {
+	var issue Issue
+	if _, err := c.do(ctx, http.MethodPatch, c.issueURL(number, ""), req, &issue); err != nil {
+		return nil, fmt.Errorf("failed to update issue #%d: %w", number, err)
+	}
+	return &issue, nil
+}
+
+// ListComments returns all comments on an issue, oldest first
+func (c *Client) ListComments(ctx context.Context, number int) ([]*Comment, error) {
+	var comments []*Comment
+	next := c.issueURL(number, "/comments") + "?per_page=" + strconv.Itoa(pageSize)
+	for next != "" {
+		var page []*Comment
+		link, err := c.do(ctx, http.MethodGet, next, nil, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments on #%d: %w", number, err)
+		}
+		comments = append(comments, page...)
+		next = nextPage(link)
+	}
+	return comments, nil
+}
+
+// CreateComment adds a comment to an issue
+func (c *Client) CreateComment(ctx context.Context, number int, body string) (*Comment, error) {
+	var comment Comment
+	req := map[string]string{"body": body}
+	if _, err := c.do(ctx, http.MethodPost, c.issueURL(number, "/comments"), req, &comment); err != nil {
+		return nil, fmt.Errorf("failed to comment on #%d: %w", number, err)
+	}
+	return &comment, nil
+}
+
+func (c *Client) repoURL(path string) string {
+	return strings.TrimSuffix(c.BaseURL, "/") + "/repos/" + url.PathEscape(c.Owner) + "/" + url.PathEscape(c.Repo) + path
+}
+
+func (c *Client) issueURL(number int, path string) string {
+	return c.repoURL("/issues/" + strconv.Itoa(number) + path)
+}
+
+// do sends a request and decodes the JSON response into out, returning the
+// Link header for pagination
+func (c *Client) do(ctx context.Context, method, rawURL string, body, out interface{}) (string, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return "", err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reqBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "beads-sync")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+		var payload struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &payload) == nil && payload.Message != "" {
+			apiErr.Message = payload.Message
+		}
+		if (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) &&
+			resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			apiErr.RateLimit = true
+			apiErr.ResetAt = resp.Header.Get("X-RateLimit-Reset")
+			if secs, err := strconv.ParseInt(apiErr.ResetAt, 10, 64); err == nil {
+				apiErr.ResetAt = time.Unix(secs, 0).Format(time.RFC3339)
+			}
+		}
+		return "", apiErr
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return "", fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return resp.Header.Get("Link"), nil
+}
+
+// linkNextPattern extracts the rel="next" URL from a Link header
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+func nextPage(link string) string {
+	if m := linkNextPattern.FindStringSubmatch(link); m != nil {
+		return m[1]
+	}
+	return ""
+}
@@ -0,0 +1,570 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// Metadata keys, followed by owner/repo, recording when each direction of a
+// repository was last synced. --no-pull and --no-push only advance the
+// direction that ran, so a one-way sync doesn't hide the other side's changes.
+const (
+	lastPullKeyPrefix = "github_last_pull:"
+	lastPushKeyPrefix = "github_last_push:"
+
+	// lastSyncKeyPrefix is the single sync point used before pull and push
+	// were tracked separately; it seeds both when they're unset
+	lastSyncKeyPrefix = "github_last_sync:"
+)
+
+// Options controls a sync
+type Options struct {
+	Actor  string // Recorded in the audit trail for local changes
+	NoPull bool   // Don't apply GitHub changes locally
+	NoPush bool   // Don't send local changes to GitHub
+	DryRun bool   // Report what would change without writing anything
+
+	// CreateRemote opens GitHub issues for local issues that have no external_ref
+	CreateRemote bool
+
+	// TypeLabels maps issue types to the GitHub labels representing them. A
+	// matching label sets the issue type on pull instead of becoming a label,
+	// and the type's label is added on push.
+	TypeLabels map[types.IssueType]string
+}
+
+// DefaultTypeLabels are used when no github.label_map config is set
+var DefaultTypeLabels = map[types.IssueType]string{
+	types.TypeBug:     "bug",
+	types.TypeFeature: "enhancement",
+}
+
+// Stats counts the changes made in one direction
+type Stats struct {
+	Created  int `json:"created"`
+	Updated  int `json:"updated"`
+	Comments int `json:"comments"`
+}
+
+// Result summarizes a sync
+type Result struct {
+	Repo    string   `json:"repo"`
+	Pulled  Stats    `json:"pulled"`
+	Pushed  Stats    `json:"pushed"`
+	Skipped []string `json:"skipped,omitempty"` // Conflicts resolved in favor of the other side
+	DryRun  bool     `json:"dry_run,omitempty"`
+}
+
+// Syncer syncs one repository with a beads store
+type Syncer struct {
+	client *Client
+	store  storage.Storage
+	opts   Options
+}
+
+// NewSyncer returns a syncer for the client's repository
+func NewSyncer(client *Client, store storage.Storage, opts Options) *Syncer {
+	if opts.TypeLabels == nil {
+		opts.TypeLabels = DefaultTypeLabels
+	}
+	return &Syncer{client: client, store: store, opts: opts}
+}
+
+// localIssue is a beads issue with the labels and comments sync compares
+type localIssue struct {
+	*types.Issue
+	comments []*types.Comment
+}
+
+// Sync pulls GitHub changes made since the last sync, then pushes local ones.
+// When both sides changed an issue, the more recently updated side wins and
+// the other is reported in Result.Skipped. Comments are merged in both
+// directions by text. now is recorded as the new sync point of each direction
+// that ran.
+func (s *Syncer) Sync(ctx context.Context, now time.Time) (*Result, error) {
+	result := &Result{Repo: s.client.FullName(), DryRun: s.opts.DryRun}
+
+	lastPull, err := s.lastSync(ctx, lastPullKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	lastPush, err := s.lastSync(ctx, lastPushKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	linked, unlinked, err := s.loadLocal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Remote issues are needed since the older sync point: for pulling, and
+	// for spotting GitHub edits that win over local ones being pushed
+	since := lastPull
+	if lastPush.Before(since) {
+		since = lastPush
+	}
+	remote, err := s.client.ListIssues(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	remoteByNumber := make(map[int]*Issue, len(remote))
+	for _, issue := range remote {
+		remoteByNumber[issue.Number] = issue
+	}
+
+	// Issues whose remote version won, so they are not pushed back
+	pulled := make(map[int]bool)
+	if !s.opts.NoPull {
+		for _, ri := range remote {
+			if ri.UpdatedAt.Before(lastPull) {
+				continue // Already pulled
+			}
+			li := linked[ri.Number]
+			if li != nil && li.UpdatedAt.After(lastPush) && li.UpdatedAt.After(ri.UpdatedAt) && !lastPush.IsZero() {
+				// Changed on both sides since the last sync; the local edit is newer
+				result.Skipped = append(result.Skipped, fmt.Sprintf("#%d: kept local changes to %s (updated later)", ri.Number, li.ID))
+				continue
+			}
+			pulled[ri.Number] = true
+			if err := s.pullIssue(ctx, ri, li, &result.Pulled); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !s.opts.NoPush {
+		numbers := make([]int, 0, len(linked))
+		for n := range linked {
+			numbers = append(numbers, n)
+		}
+		sort.Ints(numbers)
+		for _, n := range numbers {
+			li := linked[n]
+			if pulled[n] {
+				continue
+			}
+			ri := remoteByNumber[n]
+			if ri != nil && ri.UpdatedAt.After(li.UpdatedAt) {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("#%d: kept GitHub changes over %s (updated later)", n, li.ID))
+				continue
+			}
+			if err := s.pushIssue(ctx, n, li, ri, lastPush, &result.Pushed); err != nil {
+				return nil, err
+			}
+		}
+		if s.opts.CreateRemote {
+			for _, li := range unlinked {
+				if err := s.createRemote(ctx, li, &result.Pushed); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if !s.opts.DryRun {
+		if !s.opts.NoPull {
+			if err := s.setLastSync(ctx, lastPullKeyPrefix, now); err != nil {
+				return nil, err
+			}
+		}
+		if !s.opts.NoPush {
+			if err := s.setLastSync(ctx, lastPushKeyPrefix, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// lastSync returns when the direction recorded under prefix was last synced,
+// or zero
+func (s *Syncer) lastSync(ctx context.Context, prefix string) (time.Time, error) {
+	value, err := s.store.GetMetadata(ctx, prefix+s.client.FullName())
+	if err == nil && value == "" {
+		value, err = s.store.GetMetadata(ctx, lastSyncKeyPrefix+s.client.FullName())
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read last sync time: %w", err)
+	}
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid last sync time %q: %w", value, err)
+	}
+	return t, nil
+}
+
+// setLastSync records now as the sync point of the direction under prefix
+func (s *Syncer) setLastSync(ctx context.Context, prefix string, now time.Time) error {
+	if err := s.store.SetMetadata(ctx, prefix+s.client.FullName(), now.UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("failed to record sync time: %w", err)
+	}
+	return nil
+}
+
+// loadLocal returns the local issues linked to this repository by number, and
+// the issues not linked to anything
+func (s *Syncer) loadLocal(ctx context.Context) (map[int]*localIssue, []*localIssue, error) {
+	issues, err := s.store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+	linked := make(map[int]*localIssue)
+	var unlinked []*localIssue
+	for _, issue := range issues {
+		var number int
+		if issue.ExternalRef != nil && *issue.ExternalRef != "" {
+			n, ok := s.client.ParseRef(*issue.ExternalRef)
+			if !ok {
+				// Linked to another tracker
+				continue
+			}
+			number = n
+		}
+		labels, err := s.store.GetLabels(ctx, issue.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get labels for %s: %w", issue.ID, err)
+		}
+		issue.Labels = labels
+		comments, err := s.store.GetIssueComments(ctx, issue.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get comments for %s: %w", issue.ID, err)
+		}
+		li := &localIssue{Issue: issue, comments: comments}
+		if number == 0 {
+			unlinked = append(unlinked, li)
+		} else {
+			linked[number] = li
+		}
+	}
+	return linked, unlinked, nil
+}
+
+// pullIssue applies a GitHub issue to its local counterpart, creating it if li is nil
+func (s *Syncer) pullIssue(ctx context.Context, ri *Issue, li *localIssue, stats *Stats) error {
+	issueType, labels := s.splitLabels(ri.LabelNames())
+	assignee := ""
+	if ri.Assignee != nil {
+		assignee = ri.Assignee.Login
+	}
+
+	var comments []*Comment
+	if ri.Comments > 0 {
+		var err error
+		if comments, err = s.client.ListComments(ctx, ri.Number); err != nil {
+			return err
+		}
+	}
+
+	if li == nil {
+		stats.Created++
+		stats.Comments += len(comments)
+		if s.opts.DryRun {
+			return nil
+		}
+		ref := Ref(ri.Number)
+		if issueType == "" {
+			issueType = types.TypeTask
+		}
+		issue := &types.Issue{
+			Title:       ri.Title,
+			Description: ri.Body,
+			Status:      types.StatusOpen,
+			Priority:    2,
+			IssueType:   issueType,
+			Assignee:    assignee,
+			ExternalRef: &ref,
+		}
+		if err := s.store.CreateIssue(ctx, issue, s.opts.Actor); err != nil {
+			return fmt.Errorf("failed to create issue for #%d: %w", ri.Number, err)
+		}
+		for _, label := range labels {
+			if err := s.store.AddLabel(ctx, issue.ID, label, s.opts.Actor); err != nil {
+				return fmt.Errorf("failed to add label to %s: %w", issue.ID, err)
+			}
+		}
+		for _, c := range comments {
+			if _, err := s.store.AddIssueComment(ctx, issue.ID, commentAuthor(c), c.Body); err != nil {
+				return fmt.Errorf("failed to add comment to %s: %w", issue.ID, err)
+			}
+		}
+		if ri.State == "closed" {
+			if err := s.store.CloseIssue(ctx, issue.ID, closeReason(ri), s.opts.Actor); err != nil {
+				return fmt.Errorf("failed to close %s: %w", issue.ID, err)
+			}
+		}
+		return nil
+	}
+
+	updates := make(map[string]interface{})
+	if li.Title != ri.Title {
+		updates["title"] = ri.Title
+	}
+	if li.Description != ri.Body {
+		updates["description"] = ri.Body
+	}
+	if li.Assignee != assignee {
+		updates["assignee"] = assignee
+	}
+	if issueType != "" && li.IssueType != issueType {
+		updates["issue_type"] = string(issueType)
+	}
+	done := li.Status.Category() == types.CategoryDone
+	reopen := ri.State == "open" && done
+	if reopen {
+		updates["status"] = string(types.StatusOpen)
+	}
+	closing := ri.State == "closed" && !done
+	removed, added := labelDiff(li.Labels, labels)
+	var newComments []*Comment
+	for _, c := range comments {
+		if !hasComment(li.comments, c.Body) {
+			newComments = append(newComments, c)
+		}
+	}
+
+	if len(updates) == 0 && !closing && len(removed) == 0 && len(added) == 0 && len(newComments) == 0 {
+		return nil
+	}
+	if len(updates) > 0 || closing || len(removed) > 0 || len(added) > 0 {
+		stats.Updated++
+	}
+	stats.Comments += len(newComments)
+	if s.opts.DryRun {
+		return nil
+	}
+
+	if len(updates) > 0 {
+		if err := s.store.UpdateIssue(ctx, li.ID, updates, s.opts.Actor); err != nil {
+			return fmt.Errorf("failed to update %s: %w", li.ID, err)
+		}
+	}
+	for _, label := range removed {
+		if err := s.store.RemoveLabel(ctx, li.ID, label, s.opts.Actor); err != nil {
+			return fmt.Errorf("failed to remove label from %s: %w", li.ID, err)
+		}
+	}
+	for _, label := range added {
+		if err := s.store.AddLabel(ctx, li.ID, label, s.opts.Actor); err != nil {
+			return fmt.Errorf("failed to add label to %s: %w", li.ID, err)
+		}
+	}
+	for _, c := range newComments {
+		if _, err := s.store.AddIssueComment(ctx, li.ID, commentAuthor(c), c.Body); err != nil {
+			return fmt.Errorf("failed to add comment to %s: %w", li.ID, err)
+		}
+	}
+	if closing {
+		if err := s.store.CloseIssue(ctx, li.ID, closeReason(ri), s.opts.Actor); err != nil {
+			return fmt.Errorf("failed to close %s: %w", li.ID, err)
+		}
+	}
+	return nil
+}
+
+// pushIssue sends local changes made since lastPush to GitHub issue number. ri
+// is the remote issue if it changed recently, and is fetched when needed otherwise.
+func (s *Syncer) pushIssue(ctx context.Context, number int, li *localIssue, ri *Issue, lastPush time.Time, stats *Stats) error {
+	changed := lastPush.IsZero() || li.UpdatedAt.After(lastPush)
+	var newComments []*types.Comment
+	for _, c := range li.comments {
+		if lastPush.IsZero() || c.CreatedAt.After(lastPush) {
+			newComments = append(newComments, c)
+		}
+	}
+	if !changed && len(newComments) == 0 {
+		return nil
+	}
+
+	if ri == nil {
+		var err error
+		if ri, err = s.client.GetIssue(ctx, number); err != nil {
+			return err
+		}
+	}
+
+	if changed {
+		req := s.issueRequest(li, ri)
+		if !req.IsEmpty() {
+			stats.Updated++
+			if !s.opts.DryRun {
+				if _, err := s.client.UpdateIssue(ctx, number, req); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if len(newComments) == 0 {
+		return nil
+	}
+	var remoteComments []*Comment
+	if ri.Comments > 0 {
+		var err error
+		if remoteComments, err = s.client.ListComments(ctx, number); err != nil {
+			return err
+		}
+	}
+	for _, c := range newComments {
+		if hasRemoteComment(remoteComments, c.Text) {
+			continue
+		}
+		stats.Comments++
+		if s.opts.DryRun {
+			continue
+		}
+		if _, err := s.client.CreateComment(ctx, number, c.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createRemote opens a GitHub issue for an unlinked local issue and links it
+func (s *Syncer) createRemote(ctx context.Context, li *localIssue, stats *Stats) error {
+	stats.Created++
+	stats.Comments += len(li.comments)
+	if s.opts.DryRun {
+		return nil
+	}
+
+	req := s.issueRequest(li, &Issue{State: "open"})
+	req.Title, req.Body = &li.Title, &li.Description
+	created, err := s.client.CreateIssue(ctx, req)
+	if err != nil {
+		return err
+	}
+	if req.State != nil {
+		// New issues are always opened; close it separately
+		if _, err := s.client.UpdateIssue(ctx, created.Number, &IssueRequest{State: req.State}); err != nil {
+			return err
+		}
+	}
+	for _, c := range li.comments {
+		if _, err := s.client.CreateComment(ctx, created.Number, c.Text); err != nil {
+			return err
+		}
+	}
+	if err := s.store.UpdateIssue(ctx, li.ID, map[string]interface{}{"external_ref": Ref(created.Number)}, s.opts.Actor); err != nil {
+		return fmt.Errorf("failed to link %s to #%d: %w", li.ID, created.Number, err)
+	}
+	return nil
+}
+
+// issueRequest returns the edits that make ri match li
+func (s *Syncer) issueRequest(li *localIssue, ri *Issue) *IssueRequest {
+	req := &IssueRequest{}
+	if li.Title != ri.Title {
+		req.Title = &li.Title
+	}
+	if li.Description != ri.Body {
+		req.Body = &li.Description
+	}
+	state := "open"
+	if li.Status.Category() == types.CategoryDone {
+		state = "closed"
+	}
+	if state != ri.State {
+		req.State = &state
+	}
+
+	labels := append([]string(nil), li.Labels...)
+	if typeLabel, ok := s.opts.TypeLabels[li.IssueType]; ok && !hasLabel(labels, typeLabel) {
+		labels = append(labels, typeLabel)
+	}
+	if removed, added := labelDiff(ri.LabelNames(), labels); len(removed) > 0 || len(added) > 0 {
+		sort.Strings(labels)
+		req.Labels = &labels
+	}
+	return req
+}
+
+// splitLabels separates the label naming the issue type from the other labels
+func (s *Syncer) splitLabels(names []string) (types.IssueType, []string) {
+	var issueType types.IssueType
+	var labels []string
+	for _, name := range names {
+		if t := s.typeForLabel(name); t != "" {
+			if issueType == "" {
+				issueType = t
+			}
+			continue
+		}
+		labels = append(labels, name)
+	}
+	return issueType, labels
+}
+
+func (s *Syncer) typeForLabel(name string) types.IssueType {
+	for t, label := range s.opts.TypeLabels {
+		if strings.EqualFold(label, name) {
+			return t
+		}
+	}
+	return ""
+}
+
+// labelDiff returns the labels only in current (to remove) and only in want (to add)
+func labelDiff(current, want []string) (removed, added []string) {
+	for _, label := range current {
+		if !hasLabel(want, label) {
+			removed = append(removed, label)
+		}
+	}
+	for _, label := range want {
+		if !hasLabel(current, label) {
+			added = append(added, label)
+		}
+	}
+	return removed, added
+}
+
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// hasComment reports whether a local comment has the given text
+func hasComment(comments []*types.Comment, text string) bool {
+	for _, c := range comments {
+		if strings.TrimSpace(c.Text) == strings.TrimSpace(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRemoteComment reports whether a GitHub comment has the given text
+func hasRemoteComment(comments []*Comment, text string) bool {
+	for _, c := range comments {
+		if strings.TrimSpace(c.Body) == strings.TrimSpace(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func commentAuthor(c *Comment) string {
+	if c.User != nil && c.User.Login != "" {
+		return c.User.Login
+	}
+	return "github"
+}
+
+func closeReason(ri *Issue) string {
+	if ri.StateReason == "not_planned" {
+		return "Closed on GitHub as not planned"
+	}
+	return "Closed on GitHub"
+}
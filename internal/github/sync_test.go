@@ -0,0 +1,506 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// fakeGitHub serves the subset of the issues API used by sync from memory
+type fakeGitHub struct {
+	mu       sync.Mutex
+	issues   map[int]*Issue
+	comments map[int][]*Comment
+	nextID   int64
+	pageSize int
+	server   *httptest.Server
+}
+
+func newFakeGitHub(t *testing.T) *fakeGitHub {
+	f := &fakeGitHub{issues: make(map[int]*Issue), comments: make(map[int][]*Comment), pageSize: 2}
+	mux := http.NewServeMux()
+	const base = "/repos/acme/widgets/issues"
+	mux.HandleFunc("GET "+base, f.listIssues)
+	mux.HandleFunc("POST "+base, f.createIssue)
+	mux.HandleFunc("GET "+base+"/{number}", f.getIssue)
+	mux.HandleFunc("PATCH "+base+"/{number}", f.updateIssue)
+	mux.HandleFunc("GET "+base+"/{number}/comments", f.listComments)
+	mux.HandleFunc("POST "+base+"/{number}/comments", f.createComment)
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeGitHub) client() *Client {
+	c, _ := NewClient("acme/widgets", "secret")
+	c.BaseURL = f.server.URL
+	c.HTTPClient = f.server.Client()
+	return c
+}
+
+// add stores an issue as if it were created on GitHub
+func (f *fakeGitHub) add(issue *Issue, comments ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, body := range comments {
+		f.nextID++
+		f.comments[issue.Number] = append(f.comments[issue.Number], &Comment{ID: f.nextID, Body: body, User: &User{Login: "octocat"}, CreatedAt: issue.CreatedAt})
+	}
+	issue.Comments = len(f.comments[issue.Number])
+	f.issues[issue.Number] = issue
+}
+
+// edit changes an issue as if it were edited on GitHub
+func (f *fakeGitHub) edit(number int, change func(*Issue)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	change(f.issues[number])
+	f.issues[number].UpdatedAt = time.Now()
+}
+
+func (f *fakeGitHub) get(number int) Issue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return *f.issues[number]
+}
+
+func (f *fakeGitHub) commentBodies(number int) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var bodies []string
+	for _, c := range f.comments[number] {
+		bodies = append(bodies, c.Body)
+	}
+	return bodies
+}
+
+func (f *fakeGitHub) listIssues(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var since time.Time
+	if s := r.URL.Query().Get("since"); s != "" {
+		since, _ = time.Parse(time.RFC3339, s)
+	}
+	var matched []*Issue
+	for _, issue := range f.issues {
+		if !issue.UpdatedAt.Before(since) {
+			matched = append(matched, issue)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Number < matched[j].Number })
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	start := min((page-1)*f.pageSize, len(matched))
+	end := min(start+f.pageSize, len(matched))
+	if end < len(matched) {
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(page+1))
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?%s>; rel="next"`, f.server.URL, r.URL.Path, q.Encode()))
+	}
+	writeJSON(w, http.StatusOK, matched[start:end])
+}
+
+func (f *fakeGitHub) issue(w http.ResponseWriter, r *http.Request) *Issue {
+	number, _ := strconv.Atoi(r.PathValue("number"))
+	issue := f.issues[number]
+	if issue == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+	}
+	return issue
+}
+
+func (f *fakeGitHub) getIssue(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if issue := f.issue(w, r); issue != nil {
+		writeJSON(w, http.StatusOK, issue)
+	}
+}
+
+func (f *fakeGitHub) createIssue(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	issue := &Issue{Number: len(f.issues) + 1, State: "open", CreatedAt: time.Now()}
+	if !applyRequest(w, r, issue) {
+		return
+	}
+	f.issues[issue.Number] = issue
+	writeJSON(w, http.StatusCreated, issue)
+}
+
+func (f *fakeGitHub) updateIssue(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if issue := f.issue(w, r); issue != nil && applyRequest(w, r, issue) {
+		writeJSON(w, http.StatusOK, issue)
+	}
+}
+
+func (f *fakeGitHub) listComments(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if issue := f.issue(w, r); issue != nil {
+		writeJSON(w, http.StatusOK, f.comments[issue.Number])
+	}
+}
+
+func (f *fakeGitHub) createComment(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	issue := f.issue(w, r)
+	if issue == nil {
+		return
+	}
+	var req struct {
+		Body string `json:"body"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+	f.nextID++
+	comment := &Comment{ID: f.nextID, Body: req.Body, User: &User{Login: "beads-bot"}, CreatedAt: time.Now()}
+	f.comments[issue.Number] = append(f.comments[issue.Number], comment)
+	issue.Comments++
+	issue.UpdatedAt = time.Now()
+	writeJSON(w, http.StatusCreated, comment)
+}
+
+func applyRequest(w http.ResponseWriter, r *http.Request, issue *Issue) bool {
+	if r.Header.Get("Authorization") != "Bearer secret" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+		return false
+	}
+	var req IssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return false
+	}
+	if req.Title != nil {
+		issue.Title = *req.Title
+	}
+	if req.Body != nil {
+		issue.Body = *req.Body
+	}
+	if req.State != nil {
+		issue.State = *req.State
+	}
+	if req.Labels != nil {
+		issue.Labels = nil
+		for _, name := range *req.Labels {
+			issue.Labels = append(issue.Labels, Label{Name: name})
+		}
+	}
+	issue.UpdatedAt = time.Now()
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestStore(t *testing.T) *sqlite.SQLiteStorage {
+	t.Helper()
+	store, err := sqlite.New(t.TempDir() + "/test.db")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(context.Background(), "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+	return store
+}
+
+func syncOnce(t *testing.T, s *Syncer) *Result {
+	t.Helper()
+	result, err := s.Sync(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	// Keep consecutive syncs in distinct seconds, the precision of the sync point
+	time.Sleep(1100 * time.Millisecond)
+	return result
+}
+
+// findByRef returns the local issue linked to a GitHub issue, with its labels
+func findByRef(t *testing.T, store *sqlite.SQLiteStorage, number int) *types.Issue {
+	t.Helper()
+	ctx := context.Background()
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	for _, issue := range issues {
+		if issue.ExternalRef != nil && *issue.ExternalRef == Ref(number) {
+			if issue.Labels, err = store.GetLabels(ctx, issue.ID); err != nil {
+				t.Fatalf("GetLabels failed: %v", err)
+			}
+			return issue
+		}
+	}
+	t.Fatalf("no local issue linked to #%d", number)
+	return nil
+}
+
+func TestSyncPullAndPush(t *testing.T) {
+	ctx := context.Background()
+	gh := newFakeGitHub(t)
+	created := time.Now().Add(-24 * time.Hour)
+	closedAt := created.Add(time.Hour)
+	gh.add(&Issue{Number: 1, Title: "Login fails", Body: "On Safari", State: "open",
+		Labels: []Label{{Name: "bug"}, {Name: "ui"}}, Assignee: &User{Login: "alice"},
+		CreatedAt: created, UpdatedAt: created}, "Can reproduce")
+	gh.add(&Issue{Number: 2, Title: "Old task", State: "closed", StateReason: "not_planned",
+		CreatedAt: created, UpdatedAt: created, ClosedAt: &closedAt})
+	gh.add(&Issue{Number: 3, Title: "A pull request", State: "open", PullRequest: json.RawMessage(`{}`),
+		CreatedAt: created, UpdatedAt: created})
+
+	store := newTestStore(t)
+	s := NewSyncer(gh.client(), store, Options{Actor: "sync", CreateRemote: true})
+
+	// Initial pull
+	result := syncOnce(t, s)
+	if result.Pulled != (Stats{Created: 2, Comments: 1}) || result.Pushed != (Stats{}) {
+		t.Fatalf("first sync = %+v", result)
+	}
+	one := findByRef(t, store, 1)
+	if one.Title != "Login fails" || one.Description != "On Safari" || one.IssueType != types.TypeBug ||
+		one.Assignee != "alice" || !reflect.DeepEqual(one.Labels, []string{"ui"}) {
+		t.Errorf("pulled issue = %+v", one)
+	}
+	comments, _ := store.GetIssueComments(ctx, one.ID)
+	if len(comments) != 1 || comments[0].Author != "octocat" || comments[0].Text != "Can reproduce" {
+		t.Errorf("pulled comments = %+v", comments)
+	}
+	if two := findByRef(t, store, 2); two.Status != types.StatusClosed {
+		t.Errorf("#2 status = %s, want closed", two.Status)
+	}
+
+	// Nothing changed: syncing again is a no-op
+	if result := syncOnce(t, s); result.Pulled != (Stats{}) || result.Pushed != (Stats{}) {
+		t.Errorf("idempotent sync = %+v", result)
+	}
+
+	// Local edits, a local comment and a new local issue are pushed
+	if err := store.UpdateIssue(ctx, one.ID, map[string]interface{}{"title": "Login fails on Safari"}, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddLabel(ctx, one.ID, "auth", "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddIssueComment(ctx, one.ID, "bob", "Fixed in the next release"); err != nil {
+		t.Fatal(err)
+	}
+	local := &types.Issue{Title: "Write docs", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	if err := store.CreateIssue(ctx, local, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	result = syncOnce(t, s)
+	if result.Pushed != (Stats{Created: 1, Updated: 1, Comments: 1}) {
+		t.Errorf("push stats = %+v", result.Pushed)
+	}
+	remote := gh.get(1)
+	if remote.Title != "Login fails on Safari" || !reflect.DeepEqual(remote.LabelNames(), []string{"auth", "bug", "ui"}) {
+		t.Errorf("remote #1 = %+v", remote)
+	}
+	if bodies := gh.commentBodies(1); !reflect.DeepEqual(bodies, []string{"Can reproduce", "Fixed in the next release"}) {
+		t.Errorf("remote comments = %v", bodies)
+	}
+	if remote := gh.get(4); remote.Title != "Write docs" || !reflect.DeepEqual(remote.LabelNames(), []string{"enhancement"}) {
+		t.Errorf("created remote = %+v", remote)
+	}
+	if linked, _ := store.GetIssue(ctx, local.ID); linked.ExternalRef == nil || *linked.ExternalRef != "gh-4" {
+		t.Errorf("local issue not linked: %v", linked.ExternalRef)
+	}
+
+	// Remote edits are pulled, including closing
+	gh.edit(1, func(i *Issue) { i.Body = "On Safari 17"; i.State = "closed" })
+	result = syncOnce(t, s)
+	if result.Pulled.Updated != 1 || result.Pushed != (Stats{}) {
+		t.Errorf("pull after remote edit = %+v", result)
+	}
+	one = findByRef(t, store, 1)
+	if one.Description != "On Safari 17" || one.Status != types.StatusClosed {
+		t.Errorf("pulled edit = %+v", one)
+	}
+	if comments, _ := store.GetIssueComments(ctx, one.ID); len(comments) != 2 {
+		t.Errorf("comments duplicated: %+v", comments)
+	}
+}
+
+func TestSyncConflictNewerWins(t *testing.T) {
+	ctx := context.Background()
+	gh := newFakeGitHub(t)
+	created := time.Now().Add(-time.Hour)
+	gh.add(&Issue{Number: 1, Title: "Original", State: "open", CreatedAt: created, UpdatedAt: created})
+
+	store := newTestStore(t)
+	s := NewSyncer(gh.client(), store, Options{Actor: "sync"})
+	syncOnce(t, s)
+	local := findByRef(t, store, 1)
+
+	// Edited on GitHub, then locally: the local edit wins
+	gh.edit(1, func(i *Issue) { i.Title = "Remote title" })
+	time.Sleep(10 * time.Millisecond)
+	if err := store.UpdateIssue(ctx, local.ID, map[string]interface{}{"title": "Local title"}, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	result := syncOnce(t, s)
+	if len(result.Skipped) != 1 || !strings.Contains(result.Skipped[0], "kept local changes") {
+		t.Errorf("Skipped = %v", result.Skipped)
+	}
+	if remote := gh.get(1); remote.Title != "Local title" {
+		t.Errorf("remote title = %q, want the newer local title", remote.Title)
+	}
+	if local := findByRef(t, store, 1); local.Title != "Local title" {
+		t.Errorf("local title = %q", local.Title)
+	}
+
+	// Edited locally, then on GitHub: the remote edit wins
+	if err := store.UpdateIssue(ctx, local.ID, map[string]interface{}{"title": "Local again"}, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	gh.edit(1, func(i *Issue) { i.Title = "Remote again" })
+	syncOnce(t, s)
+	if local := findByRef(t, store, 1); local.Title != "Remote again" {
+		t.Errorf("local title = %q, want the newer remote title", local.Title)
+	}
+	if remote := gh.get(1); remote.Title != "Remote again" {
+		t.Errorf("remote title = %q", remote.Title)
+	}
+}
+
+func TestSyncDryRun(t *testing.T) {
+	gh := newFakeGitHub(t)
+	created := time.Now().Add(-time.Hour)
+	gh.add(&Issue{Number: 1, Title: "Remote", State: "open", CreatedAt: created, UpdatedAt: created})
+	store := newTestStore(t)
+
+	s := NewSyncer(gh.client(), store, Options{Actor: "sync", DryRun: true})
+	result, err := s.Sync(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if !result.DryRun || result.Pulled.Created != 1 {
+		t.Errorf("dry run result = %+v", result)
+	}
+	issues, _ := store.SearchIssues(context.Background(), "", types.IssueFilter{})
+	if len(issues) != 0 {
+		t.Errorf("dry run created %d issues", len(issues))
+	}
+	for _, prefix := range []string{lastPullKeyPrefix, lastPushKeyPrefix} {
+		if last, _ := s.lastSync(context.Background(), prefix); !last.IsZero() {
+			t.Errorf("dry run recorded %s time %v", prefix, last)
+		}
+	}
+}
+
+func TestSyncOneWayKeepsOtherDirection(t *testing.T) {
+	ctx := context.Background()
+	gh := newFakeGitHub(t)
+	created := time.Now().Add(-time.Hour)
+	gh.add(&Issue{Number: 1, Title: "Original", State: "open", CreatedAt: created, UpdatedAt: created})
+	gh.add(&Issue{Number: 2, Title: "Other", State: "open", CreatedAt: created, UpdatedAt: created})
+
+	store := newTestStore(t)
+	syncOnce(t, NewSyncer(gh.client(), store, Options{Actor: "sync"}))
+
+	// A local edit is left unpushed by a pull-only sync...
+	local := findByRef(t, store, 1)
+	if err := store.UpdateIssue(ctx, local.ID, map[string]interface{}{"title": "Local title"}, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(1100 * time.Millisecond)
+	if result := syncOnce(t, NewSyncer(gh.client(), store, Options{Actor: "sync", NoPush: true})); result.Pushed != (Stats{}) {
+		t.Errorf("--no-push pushed %+v", result.Pushed)
+	}
+	if remote := gh.get(1); remote.Title != "Original" {
+		t.Fatalf("--no-push changed remote title to %q", remote.Title)
+	}
+
+	// ...and a full sync still sends it
+	result := syncOnce(t, NewSyncer(gh.client(), store, Options{Actor: "sync"}))
+	if result.Pushed.Updated != 1 {
+		t.Errorf("full sync after --no-push = %+v", result)
+	}
+	if remote := gh.get(1); remote.Title != "Local title" {
+		t.Errorf("remote title = %q, want the local edit", remote.Title)
+	}
+
+	// Likewise a GitHub edit left unpulled by a push-only sync is pulled later
+	gh.edit(2, func(i *Issue) { i.Title = "Remote title" })
+	time.Sleep(1100 * time.Millisecond)
+	if result := syncOnce(t, NewSyncer(gh.client(), store, Options{Actor: "sync", NoPull: true})); result.Pulled != (Stats{}) {
+		t.Errorf("--no-pull pulled %+v", result.Pulled)
+	}
+	result = syncOnce(t, NewSyncer(gh.client(), store, Options{Actor: "sync"}))
+	if result.Pulled.Updated != 1 {
+		t.Errorf("full sync after --no-pull = %+v", result)
+	}
+	if other := findByRef(t, store, 2); other.Title != "Remote title" {
+		t.Errorf("local title = %q, want the GitHub edit", other.Title)
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	c, err := NewClient("acme/widgets", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		ref  string
+		want int
+		ok   bool
+	}{
+		{"gh-9", 9, true},
+		{"https://github.com/acme/widgets/issues/12", 12, true},
+		{"https://github.com/other/repo/issues/12", 0, false},
+		{"jira-ABC-1", 0, false},
+		{"gh-x", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := c.ParseRef(tt.ref)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseRef(%q) = %d, %v; want %d, %v", tt.ref, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if _, err := NewClient("widgets", ""); err == nil {
+		t.Error("expected error for repository without owner")
+	}
+}
+
+func TestAPIErrorRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		writeJSON(w, http.StatusForbidden, map[string]string{"message": "API rate limit exceeded"})
+	}))
+	defer server.Close()
+
+	c, _ := NewClient("acme/widgets", "")
+	c.BaseURL = server.URL
+	_, err := c.ListIssues(context.Background(), time.Time{})
+	if err == nil || !strings.Contains(err.Error(), "rate limit exceeded, resets at") {
+		t.Errorf("expected rate limit error, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package history
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	lines := func(n int, edit map[int]string) string {
+		var out []string
+		for i := 1; i <= n; i++ {
+			if s, ok := edit[i]; ok {
+				if s != "" {
+					out = append(out, s)
+				}
+				continue
+			}
+			out = append(out, "line "+string(rune('a'+i-1)))
+		}
+		return strings.Join(out, "\n")
+	}
+
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{"equal", "same", "same", ""},
+		{"from empty", "", "a\nb", "@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"to empty", "a", "", "@@ -1 +0,0 @@\n-a\n"},
+		{"append", "a\nb", "a\nb\nc", "@@ -1,2 +1,3 @@\n a\n b\n+c\n"},
+		{
+			"separate hunks",
+			lines(12, nil),
+			lines(12, map[int]string{1: "first", 12: "last"}),
+			"@@ -1,4 +1,4 @@\n-line a\n+first\n line b\n line c\n line d\n" +
+				"@@ -9,4 +9,4 @@\n line i\n line j\n line k\n-line l\n+last\n",
+		},
+		{
+			"nearby changes merge",
+			lines(8, nil),
+			lines(8, map[int]string{2: "two", 6: ""}),
+			"@@ -1,8 +1,7 @@\n line a\n-line b\n+two\n line c\n line d\n line e\n-line f\n line g\n line h\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff(tt.old, tt.new); got != tt.want {
+				t.Errorf("UnifiedDiff =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// Change is a single field-level difference made by an event. Labels and
+// dependencies use Old for removals and New for additions.
+type Change struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+	Diff  string `json:"diff,omitempty"` // Unified diff, for edits to long text fields
+}
+
+// Entry is one event in an issue's history with the changes it made
+type Entry struct {
+	EventID   int64           `json:"event_id"`
+	IssueID   string          `json:"issue_id"`
+	EventType types.EventType `json:"event_type"`
+	Actor     string          `json:"actor"`
+	Time      time.Time       `json:"time"`
+	Comment   string          `json:"comment,omitempty"` // Close reason or audit comment
+	Changes   []Change        `json:"changes,omitempty"`
+}
+
+// Change fields for labels and dependencies
+const (
+	FieldLabel      = "label"
+	FieldDependency = "dependency"
+)
+
+// trackedField is a field whose changes are reported, in display order
+type trackedField struct {
+	name     string // JSON name, as used in update events
+	longText bool   // Reported with a unified diff
+	value    func(*types.Issue) string
+}
+
+var trackedFields = []trackedField{
+	{name: "id", value: func(i *types.Issue) string { return i.ID }},
+	{name: "title", value: func(i *types.Issue) string { return i.Title }},
+	{name: "status", value: func(i *types.Issue) string { return string(i.Status) }},
+	{name: "priority", value: func(i *types.Issue) string { return "P" + strconv.Itoa(i.Priority) }},
+	{name: "issue_type", value: func(i *types.Issue) string { return string(i.IssueType) }},
+	{name: "assignee", value: func(i *types.Issue) string { return i.Assignee }},
+	{name: "estimated_minutes", value: func(i *types.Issue) string {
+		if i.EstimatedMinutes == nil {
+			return ""
+		}
+		return strconv.Itoa(*i.EstimatedMinutes)
+	}},
+	{name: "external_ref", value: func(i *types.Issue) string {
+		if i.ExternalRef == nil {
+			return ""
+		}
+		return *i.ExternalRef
+	}},
+	{name: "description", longText: true, value: func(i *types.Issue) string { return i.Description }},
+	{name: "design", longText: true, value: func(i *types.Issue) string { return i.Design }},
+	{name: "acceptance_criteria", longText: true, value: func(i *types.Issue) string { return i.AcceptanceCriteria }},
+	{name: "notes", longText: true, value: func(i *types.Issue) string { return i.Notes }},
+}
+
+// Log turns an issue's events, in any order, into a chronological history of
+// field-level changes
+func Log(events []*types.Event) ([]*Entry, error) {
+	ordered := make([]*types.Event, len(events))
+	copy(ordered, events)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].ID < ordered[j].ID })
+
+	r := NewReplayer()
+	var entries []*Entry
+	for _, e := range ordered {
+		before := r.Issue()
+		if before != nil && e.OldValue != nil && e.NewValue != nil && e.EventType != eventRenamed {
+			// Compare against the recorded pre-update state rather than the replay
+			var recorded types.Issue
+			if err := json.Unmarshal([]byte(*e.OldValue), &recorded); err == nil && !recorded.CreatedAt.IsZero() {
+				recorded.Labels, recorded.Dependencies = before.Labels, before.Dependencies
+				before = &recorded
+			}
+		}
+		if err := r.Apply(e); err != nil {
+			return nil, err
+		}
+		after := r.Issue()
+
+		entry := &Entry{
+			EventID:   e.ID,
+			IssueID:   e.IssueID,
+			EventType: e.EventType,
+			Actor:     e.Actor,
+			Time:      e.CreatedAt,
+		}
+		switch e.EventType {
+		case types.EventClosed, types.EventCommented:
+			if e.Comment != nil {
+				entry.Comment = *e.Comment
+			}
+		}
+		if after != nil {
+			changes, err := diffIssues(before, after, e)
+			if err != nil {
+				return nil, err
+			}
+			entry.Changes = changes
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// IssueLog loads a stored issue's history, oldest first. A positive limit keeps
+// only the most recent entries.
+func IssueLog(ctx context.Context, store storage.Storage, id string, limit int) ([]*Entry, error) {
+	// The whole trail is needed to replay state, so limit after replaying
+	events, err := store.GetEvents(ctx, id, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events for %s: %w", id, err)
+	}
+	entries, err := Log(events)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+// diffIssues lists the differences between two replayed states. For updates
+// only the fields the event names are reported, so changes made outside the
+// audit trail are not attributed to it.
+func diffIssues(before, after *types.Issue, e *types.Event) ([]Change, error) {
+	var touched map[string]json.RawMessage
+	if before != nil && e.NewValue != nil && e.EventType != eventRenamed {
+		if err := json.Unmarshal([]byte(*e.NewValue), &touched); err != nil {
+			return nil, fmt.Errorf("failed to parse updates in event %d: %w", e.ID, err)
+		}
+	}
+
+	var changes []Change
+	for _, f := range trackedFields {
+		if before == nil && f.name == "id" {
+			continue
+		}
+		if touched != nil {
+			if _, ok := touched[f.name]; !ok {
+				continue
+			}
+		}
+		var oldValue string
+		if before != nil {
+			oldValue = f.value(before)
+		}
+		newValue := f.value(after)
+		if oldValue == newValue {
+			continue
+		}
+		change := Change{Field: f.name, Old: oldValue, New: newValue}
+		if f.longText && before != nil {
+			change.Diff = UnifiedDiff(oldValue, newValue)
+		}
+		changes = append(changes, change)
+	}
+
+	var oldLabels []string
+	var oldDeps []*types.Dependency
+	if before != nil {
+		oldLabels, oldDeps = before.Labels, before.Dependencies
+	}
+	removed, added := setDiff(oldLabels, after.Labels)
+	for _, label := range removed {
+		changes = append(changes, Change{Field: FieldLabel, Old: label})
+	}
+	for _, label := range added {
+		changes = append(changes, Change{Field: FieldLabel, New: label})
+	}
+	removed, added = setDiff(dependencyKeys(oldDeps), dependencyKeys(after.Dependencies))
+	for _, dep := range removed {
+		changes = append(changes, Change{Field: FieldDependency, Old: dep})
+	}
+	for _, dep := range added {
+		changes = append(changes, Change{Field: FieldDependency, New: dep})
+	}
+	return changes, nil
+}
+
+// dependencyKeys renders dependencies as "<type> <depends-on>"
+func dependencyKeys(deps []*types.Dependency) []string {
+	keys := make([]string, len(deps))
+	for i, dep := range deps {
+		keys[i] = fmt.Sprintf("%s %s", dep.Type, dep.DependsOnID)
+	}
+	return keys
+}
+
+// setDiff returns the sorted values only in a and only in b
+func setDiff(a, b []string) (onlyA, onlyB []string) {
+	inA := make(map[string]bool, len(a))
+	for _, s := range a {
+		inA[s] = true
+	}
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+		if !inA[s] {
+			onlyB = append(onlyB, s)
+		}
+	}
+	for _, s := range a {
+		if !inB[s] {
+			onlyA = append(onlyA, s)
+		}
+	}
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	return onlyA, onlyB
+}
@@ -0,0 +1,72 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestLog(t *testing.T) {
+	entries, err := Log(buildEvents(t))
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if len(entries) != 10 {
+		t.Fatalf("expected 10 entries, got %d", len(entries))
+	}
+
+	want := [][]Change{
+		{
+			{Field: "title", New: "Login fails"},
+			{Field: "status", New: "open"},
+			{Field: "priority", New: "P2"},
+			{Field: "issue_type", New: "bug"},
+			{Field: "description", New: "500 on submit"},
+		},
+		{
+			{Field: "title", Old: "Login fails", New: "Login fails on Safari"},
+			{Field: "assignee", New: "alice"},
+		},
+		{{Field: FieldLabel, New: "frontend"}},
+		{{Field: FieldLabel, New: "urgent"}},
+		{{Field: FieldDependency, New: "blocks bd-9"}},
+		{{Field: FieldLabel, Old: "urgent"}},
+		{{Field: "status", Old: "open", New: "closed"}},
+		// The reopen event's recorded pre-update state predates the close, so
+		// only the fields it names are compared
+		nil,
+		{{Field: FieldDependency, Old: "blocks bd-9"}},
+		{{Field: "id", Old: "bd-1", New: "bd-2"}},
+	}
+	for i, entry := range entries {
+		if !reflect.DeepEqual(entry.Changes, want[i]) {
+			t.Errorf("entry %d (%s): changes = %+v, want %+v", i, entry.EventType, entry.Changes, want[i])
+		}
+	}
+	if entries[6].Comment != "fixed" {
+		t.Errorf("close reason = %q, want fixed", entries[6].Comment)
+	}
+}
+
+func TestLogDescriptionDiff(t *testing.T) {
+	created := &types.Issue{ID: "bd-1", Title: "Task", Description: "line one\nline two\nline three",
+		Status: types.StatusOpen, IssueType: types.TypeTask, CreatedAt: base}
+	events := []*types.Event{
+		{ID: 1, EventType: types.EventCreated, NewValue: jsonPtr(t, created), CreatedAt: minute(0)},
+		{ID: 2, EventType: types.EventUpdated, OldValue: jsonPtr(t, created),
+			NewValue: jsonPtr(t, map[string]interface{}{"description": "line one\nline 2\nline three"}), CreatedAt: minute(1)},
+	}
+	entries, err := Log(events)
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	changes := entries[1].Changes
+	if len(changes) != 1 || changes[0].Field != "description" {
+		t.Fatalf("changes = %+v", changes)
+	}
+	want := "@@ -1,3 +1,3 @@\n line one\n-line two\n+line 2\n line three\n"
+	if changes[0].Diff != want {
+		t.Errorf("diff = %q, want %q", changes[0].Diff, want)
+	}
+}
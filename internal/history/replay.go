@@ -0,0 +1,317 @@
+// Package history reconstructs past issue state by replaying the events audit trail.
+//
+// Every mutation records an event: creation stores a full snapshot of the issue,
+// updates store the issue as it was before the change plus the changed fields,
+// and label and dependency changes are described in the event comment. Replaying
+// an issue's events up to a point in time yields the issue as it was then.
+//
+// Changes that bypass the audit trail (compaction, renames rewriting text
+// references) are not visible in the replayed state.
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// Event comment formats written by the storage backends
+const (
+	labelAddedPrefix   = "Added label: "
+	labelRemovedPrefix = "Removed label: "
+	depAddedPrefix     = "Added dependency: "
+	depRemovedPrefix   = "Removed dependency on "
+)
+
+// eventRenamed is recorded by UpdateIssueID; it has no EventType constant
+const eventRenamed types.EventType = "renamed"
+
+// Replayer applies events in order to rebuild an issue
+type Replayer struct {
+	issue  *types.Issue
+	labels map[string]bool
+	deps   map[string]*types.Dependency // Keyed by depends_on_id
+}
+
+// NewReplayer returns a Replayer with no state. The first event applied should
+// be the issue's created event.
+func NewReplayer() *Replayer {
+	return &Replayer{
+		labels: make(map[string]bool),
+		deps:   make(map[string]*types.Dependency),
+	}
+}
+
+// Apply advances the replayed state by one event. Events before the created
+// event are ignored.
+func (r *Replayer) Apply(e *types.Event) error {
+	if e.EventType == types.EventCreated {
+		return r.applySnapshot(e)
+	}
+	if r.issue == nil {
+		return nil
+	}
+
+	switch e.EventType {
+	case types.EventUpdated, types.EventStatusChanged, types.EventClosed, types.EventReopened:
+		if e.NewValue == nil {
+			// CloseIssue records a bare closed event with the reason as comment
+			if e.EventType == types.EventClosed {
+				r.setStatus(types.StatusClosed, e.CreatedAt)
+				r.issue.UpdatedAt = e.CreatedAt
+			}
+			return nil
+		}
+		return r.applyUpdate(e)
+
+	case types.EventCommented:
+		r.issue.UpdatedAt = e.CreatedAt
+
+	case types.EventLabelAdded:
+		if label, ok := commentSuffix(e, labelAddedPrefix); ok {
+			r.labels[label] = true
+		}
+
+	case types.EventLabelRemoved:
+		if label, ok := commentSuffix(e, labelRemovedPrefix); ok {
+			delete(r.labels, label)
+		}
+
+	case types.EventDependencyAdded:
+		if rest, ok := commentSuffix(e, depAddedPrefix); ok {
+			// "<issue> <type> <depends-on>"
+			parts := strings.Fields(rest)
+			if len(parts) == 3 {
+				r.deps[parts[2]] = &types.Dependency{
+					IssueID:     r.issue.ID,
+					DependsOnID: parts[2],
+					Type:        types.DependencyType(parts[1]),
+					CreatedAt:   e.CreatedAt,
+					CreatedBy:   e.Actor,
+				}
+			}
+		}
+
+	case types.EventDependencyRemoved:
+		if dependsOnID, ok := commentSuffix(e, depRemovedPrefix); ok {
+			delete(r.deps, dependsOnID)
+		}
+
+	case eventRenamed:
+		if e.NewValue != nil {
+			r.issue.ID = *e.NewValue
+			for _, dep := range r.deps {
+				dep.IssueID = *e.NewValue
+			}
+		}
+	}
+	return nil
+}
+
+// Issue returns a copy of the replayed issue with Labels and Dependencies
+// populated, or nil if no created event has been applied.
+func (r *Replayer) Issue() *types.Issue {
+	if r.issue == nil {
+		return nil
+	}
+	issue := *r.issue
+	issue.Labels = nil
+	for label := range r.labels {
+		issue.Labels = append(issue.Labels, label)
+	}
+	sort.Strings(issue.Labels)
+	issue.Dependencies = nil
+	for _, dep := range r.deps {
+		d := *dep
+		issue.Dependencies = append(issue.Dependencies, &d)
+	}
+	sort.Slice(issue.Dependencies, func(i, j int) bool {
+		return issue.Dependencies[i].DependsOnID < issue.Dependencies[j].DependsOnID
+	})
+	issue.Comments = nil
+	return &issue
+}
+
+func (r *Replayer) applySnapshot(e *types.Event) error {
+	if e.NewValue == nil {
+		return fmt.Errorf("created event %d for %s has no snapshot", e.ID, e.IssueID)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal([]byte(*e.NewValue), &issue); err != nil {
+		return fmt.Errorf("failed to parse snapshot in event %d: %w", e.ID, err)
+	}
+
+	r.labels = make(map[string]bool)
+	for _, label := range issue.Labels {
+		r.labels[label] = true
+	}
+	r.deps = make(map[string]*types.Dependency)
+	for _, dep := range issue.Dependencies {
+		d := *dep
+		r.deps[d.DependsOnID] = &d
+	}
+	r.issue = &issue
+	return nil
+}
+
+// applyUpdate handles an UpdateIssue event: old_value is the full issue before
+// the change and new_value the map of changed columns.
+func (r *Replayer) applyUpdate(e *types.Event) error {
+	if e.OldValue != nil {
+		// The recorded pre-update state is authoritative for scalar fields,
+		// which corrects for changes made outside the audit trail
+		var before types.Issue
+		if err := json.Unmarshal([]byte(*e.OldValue), &before); err == nil && !before.CreatedAt.IsZero() {
+			r.issue = &before
+		}
+	}
+
+	var updates map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(*e.NewValue), &updates); err != nil {
+		return fmt.Errorf("failed to parse updates in event %d: %w", e.ID, err)
+	}
+
+	wasClosed := r.issue.Status == types.StatusClosed
+	current, err := json.Marshal(r.issue)
+	if err != nil {
+		return fmt.Errorf("failed to encode issue: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(current, &fields); err != nil {
+		return fmt.Errorf("failed to encode issue: %w", err)
+	}
+	for key, value := range updates {
+		fields[key] = value
+	}
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("failed to apply updates in event %d: %w", e.ID, err)
+	}
+	// Decode into a fresh struct so fields set to null are cleared
+	var next types.Issue
+	if err := json.Unmarshal(merged, &next); err != nil {
+		return fmt.Errorf("failed to apply updates in event %d: %w", e.ID, err)
+	}
+	r.issue = &next
+	r.issue.UpdatedAt = e.CreatedAt
+
+	if _, ok := updates["status"]; ok {
+		// Mirror the storage layer, which maintains closed_at on status changes
+		switch {
+		case next.Status == types.StatusClosed && !wasClosed:
+			closedAt := e.CreatedAt
+			next.ClosedAt = &closedAt
+		case next.Status != types.StatusClosed:
+			next.ClosedAt = nil
+		}
+	}
+	return nil
+}
+
+// setStatus changes the status, maintaining closed_at like the storage layer
+func (r *Replayer) setStatus(status types.Status, at time.Time) {
+	wasClosed := r.issue.Status == types.StatusClosed
+	r.issue.Status = status
+	switch {
+	case status == types.StatusClosed && !wasClosed:
+		closedAt := at
+		r.issue.ClosedAt = &closedAt
+	case status != types.StatusClosed:
+		r.issue.ClosedAt = nil
+	}
+}
+
+func commentSuffix(e *types.Event, prefix string) (string, bool) {
+	if e.Comment == nil || !strings.HasPrefix(*e.Comment, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(*e.Comment, prefix), true
+}
+
+// Replay rebuilds an issue as it was at the given time from its events, which
+// may be in any order. It returns nil if the issue did not exist yet.
+func Replay(events []*types.Event, at time.Time) (*types.Issue, error) {
+	ordered := make([]*types.Event, len(events))
+	copy(ordered, events)
+	// IDs reflect insertion order; timestamps only have second precision
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].ID < ordered[j].ID })
+
+	r := NewReplayer()
+	for _, e := range ordered {
+		if e.CreatedAt.After(at) {
+			break
+		}
+		if err := r.Apply(e); err != nil {
+			return nil, err
+		}
+	}
+	if issue := r.Issue(); issue != nil {
+		return issue, nil
+	}
+
+	// Imported issues are recorded when they arrive, which can be long after
+	// they were created elsewhere. Use the import snapshot for that window.
+	for _, e := range ordered {
+		if e.EventType != types.EventCreated {
+			continue
+		}
+		if err := r.Apply(e); err != nil {
+			return nil, err
+		}
+		if issue := r.Issue(); !issue.CreatedAt.After(at) {
+			return issue, nil
+		}
+		break
+	}
+	return nil, nil
+}
+
+// IssueAt reconstructs a stored issue as it was at the given time, including
+// the comments that existed then. It returns nil if the issue did not exist.
+func IssueAt(ctx context.Context, store storage.Storage, id string, at time.Time) (*types.Issue, error) {
+	events, err := store.GetEvents(ctx, id, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events for %s: %w", id, err)
+	}
+	issue, err := Replay(events, at)
+	if err != nil || issue == nil {
+		return nil, err
+	}
+
+	comments, err := store.GetIssueComments(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments for %s: %w", id, err)
+	}
+	for _, c := range comments {
+		if !c.CreatedAt.After(at) {
+			issue.Comments = append(issue.Comments, c)
+		}
+	}
+	return issue, nil
+}
+
+// IssuesAt reconstructs every stored issue as it was at the given time. Issues
+// created later are omitted, as are deleted issues, whose events are gone.
+func IssuesAt(ctx context.Context, store storage.Storage, at time.Time) ([]*types.Issue, error) {
+	current, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	var issues []*types.Issue
+	for _, c := range current {
+		issue, err := IssueAt(ctx, store, c.ID, at)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
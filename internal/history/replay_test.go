@@ -0,0 +1,286 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+var base = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func minute(n int) time.Time {
+	return base.Add(time.Duration(n) * time.Minute)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func jsonPtr(t *testing.T, v interface{}) *string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	return strPtr(string(data))
+}
+
+// buildEvents returns the audit trail of an issue that is created, edited,
+// labeled, blocked, closed, reopened and renamed, one event per minute
+func buildEvents(t *testing.T) []*types.Event {
+	created := &types.Issue{
+		ID: "bd-1", Title: "Login fails", Description: "500 on submit",
+		Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug,
+		CreatedAt: base, UpdatedAt: base,
+	}
+	edited := *created
+	edited.Title = "Login fails on Safari"
+	edited.Assignee = "alice"
+	edited.UpdatedAt = minute(1)
+
+	return []*types.Event{
+		{ID: 1, IssueID: "bd-1", EventType: types.EventCreated, NewValue: jsonPtr(t, created), CreatedAt: minute(0)},
+		{ID: 2, IssueID: "bd-1", EventType: types.EventUpdated, OldValue: jsonPtr(t, created),
+			NewValue: jsonPtr(t, map[string]interface{}{"title": "Login fails on Safari", "assignee": "alice"}), CreatedAt: minute(1)},
+		{ID: 3, IssueID: "bd-1", EventType: types.EventLabelAdded, Comment: strPtr("Added label: frontend"), CreatedAt: minute(2)},
+		{ID: 4, IssueID: "bd-1", EventType: types.EventLabelAdded, Comment: strPtr("Added label: urgent"), CreatedAt: minute(2)},
+		{ID: 5, IssueID: "bd-1", EventType: types.EventDependencyAdded, Comment: strPtr("Added dependency: bd-1 blocks bd-9"), CreatedAt: minute(3)},
+		{ID: 6, IssueID: "bd-1", EventType: types.EventLabelRemoved, Comment: strPtr("Removed label: urgent"), CreatedAt: minute(4)},
+		{ID: 7, IssueID: "bd-1", EventType: types.EventClosed, Comment: strPtr("fixed"), CreatedAt: minute(5)},
+		{ID: 8, IssueID: "bd-1", EventType: types.EventReopened, OldValue: jsonPtr(t, edited),
+			NewValue: jsonPtr(t, map[string]interface{}{"status": "open"}), CreatedAt: minute(6)},
+		{ID: 9, IssueID: "bd-1", EventType: types.EventDependencyRemoved, Comment: strPtr("Removed dependency on bd-9"), CreatedAt: minute(7)},
+		{ID: 10, IssueID: "bd-2", EventType: "renamed", OldValue: strPtr("bd-1"), NewValue: strPtr("bd-2"), CreatedAt: minute(8)},
+	}
+}
+
+func TestReplay(t *testing.T) {
+	events := buildEvents(t)
+	// Storage returns newest first; Replay must not depend on the order
+	reversed := make([]*types.Event, len(events))
+	for i, e := range events {
+		reversed[len(events)-1-i] = e
+	}
+
+	tests := []struct {
+		name  string
+		at    time.Time
+		check func(t *testing.T, issue *types.Issue)
+	}{
+		{"before creation", base.Add(-time.Second), func(t *testing.T, issue *types.Issue) {
+			if issue != nil {
+				t.Errorf("expected nil before creation, got %+v", issue)
+			}
+		}},
+		{"created", minute(0), func(t *testing.T, issue *types.Issue) {
+			if issue.Title != "Login fails" || issue.Assignee != "" || len(issue.Labels) != 0 {
+				t.Errorf("unexpected initial state: %+v", issue)
+			}
+		}},
+		{"edited", minute(1).Add(30 * time.Second), func(t *testing.T, issue *types.Issue) {
+			if issue.Title != "Login fails on Safari" || issue.Assignee != "alice" {
+				t.Errorf("update not applied: %+v", issue)
+			}
+			if issue.Description != "500 on submit" {
+				t.Errorf("untouched field changed: %q", issue.Description)
+			}
+			if !issue.UpdatedAt.Equal(minute(1)) {
+				t.Errorf("UpdatedAt = %v, want %v", issue.UpdatedAt, minute(1))
+			}
+		}},
+		{"labeled and blocked", minute(3), func(t *testing.T, issue *types.Issue) {
+			if !reflect.DeepEqual(issue.Labels, []string{"frontend", "urgent"}) {
+				t.Errorf("Labels = %v", issue.Labels)
+			}
+			if len(issue.Dependencies) != 1 || issue.Dependencies[0].DependsOnID != "bd-9" || issue.Dependencies[0].Type != types.DepBlocks {
+				t.Errorf("Dependencies = %+v", issue.Dependencies)
+			}
+		}},
+		{"closed", minute(5), func(t *testing.T, issue *types.Issue) {
+			if issue.Status != types.StatusClosed || issue.ClosedAt == nil || !issue.ClosedAt.Equal(minute(5)) {
+				t.Errorf("expected closed at %v, got %s %v", minute(5), issue.Status, issue.ClosedAt)
+			}
+			if !reflect.DeepEqual(issue.Labels, []string{"frontend"}) {
+				t.Errorf("Labels = %v", issue.Labels)
+			}
+		}},
+		{"reopened", minute(7), func(t *testing.T, issue *types.Issue) {
+			if issue.Status != types.StatusOpen || issue.ClosedAt != nil {
+				t.Errorf("expected open without closed_at, got %s %v", issue.Status, issue.ClosedAt)
+			}
+			if len(issue.Dependencies) != 0 {
+				t.Errorf("Dependencies = %+v", issue.Dependencies)
+			}
+			// Labels survive resyncing from the pre-update snapshot, which has none
+			if !reflect.DeepEqual(issue.Labels, []string{"frontend"}) {
+				t.Errorf("Labels = %v", issue.Labels)
+			}
+		}},
+		{"renamed", minute(9), func(t *testing.T, issue *types.Issue) {
+			if issue.ID != "bd-2" {
+				t.Errorf("ID = %q, want bd-2", issue.ID)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue, err := Replay(reversed, tt.at)
+			if err != nil {
+				t.Fatalf("Replay failed: %v", err)
+			}
+			tt.check(t, issue)
+		})
+	}
+}
+
+func TestReplayClearsFields(t *testing.T) {
+	estimate := 30
+	created := &types.Issue{ID: "bd-1", Title: "Task", Status: types.StatusOpen, IssueType: types.TypeTask,
+		EstimatedMinutes: &estimate, Assignee: "bob", CreatedAt: base}
+	events := []*types.Event{
+		{ID: 1, EventType: types.EventCreated, NewValue: jsonPtr(t, created), CreatedAt: minute(0)},
+		{ID: 2, EventType: types.EventUpdated, OldValue: jsonPtr(t, created),
+			NewValue: jsonPtr(t, map[string]interface{}{"estimated_minutes": nil, "assignee": ""}), CreatedAt: minute(1)},
+	}
+	issue, err := Replay(events, minute(1))
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if issue.EstimatedMinutes != nil || issue.Assignee != "" {
+		t.Errorf("fields not cleared: estimate=%v assignee=%q", issue.EstimatedMinutes, issue.Assignee)
+	}
+}
+
+func TestReplayStatusClose(t *testing.T) {
+	created := &types.Issue{ID: "bd-1", Title: "Task", Status: types.StatusOpen, IssueType: types.TypeTask, CreatedAt: base}
+	events := []*types.Event{
+		{ID: 1, EventType: types.EventCreated, NewValue: jsonPtr(t, created), CreatedAt: minute(0)},
+		{ID: 2, EventType: types.EventClosed, OldValue: jsonPtr(t, created),
+			NewValue: jsonPtr(t, map[string]interface{}{"status": "closed"}), CreatedAt: minute(1)},
+	}
+	issue, err := Replay(events, minute(2))
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if issue.Status != types.StatusClosed || issue.ClosedAt == nil || !issue.ClosedAt.Equal(minute(1)) {
+		t.Errorf("expected closed at %v, got %s %v", minute(1), issue.Status, issue.ClosedAt)
+	}
+}
+
+func TestReplayImportedIssue(t *testing.T) {
+	// Created elsewhere in January, imported in March
+	imported := &types.Issue{ID: "bd-1", Title: "Old issue", Status: types.StatusOpen, IssueType: types.TypeTask,
+		CreatedAt: base.AddDate(0, -2, 0), Labels: []string{"legacy"}}
+	events := []*types.Event{
+		{ID: 1, EventType: types.EventCreated, NewValue: jsonPtr(t, imported), CreatedAt: minute(0)},
+	}
+
+	issue, err := Replay(events, base.AddDate(0, -1, 0))
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if issue == nil || issue.Title != "Old issue" || !reflect.DeepEqual(issue.Labels, []string{"legacy"}) {
+		t.Errorf("expected import snapshot, got %+v", issue)
+	}
+
+	issue, err = Replay(events, base.AddDate(0, -3, 0))
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if issue != nil {
+		t.Errorf("expected nil before the issue was created, got %+v", issue)
+	}
+}
+
+func TestIssueAtSQLite(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlite.New(t.TempDir() + "/test.db")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+
+	issue := &types.Issue{Title: "Original title", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, issue.ID, "backend", "alice"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Renamed title", "priority": 0}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "bob"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	// Event timestamps have second precision, so space them out explicitly
+	db := store.UnderlyingDB()
+	start := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	rows, err := db.QueryContext(ctx, `SELECT id FROM events WHERE issue_id = ? ORDER BY id`, issue.ID)
+	if err != nil {
+		t.Fatalf("failed to query events: %v", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("failed to scan: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if len(ids) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(ids))
+	}
+	for i, id := range ids {
+		if _, err := db.ExecContext(ctx, `UPDATE events SET created_at = ? WHERE id = ?`, start.Add(time.Duration(i)*time.Minute), id); err != nil {
+			t.Fatalf("failed to set event time: %v", err)
+		}
+	}
+
+	at := func(i int) time.Time { return start.Add(time.Duration(i)*time.Minute + 30*time.Second) }
+
+	got, err := IssueAt(ctx, store, issue.ID, at(1))
+	if err != nil {
+		t.Fatalf("IssueAt failed: %v", err)
+	}
+	if got.Title != "Original title" || got.Priority != 2 || !reflect.DeepEqual(got.Labels, []string{"backend"}) {
+		t.Errorf("unexpected state after labeling: %+v", got)
+	}
+
+	got, err = IssueAt(ctx, store, issue.ID, at(2))
+	if err != nil {
+		t.Fatalf("IssueAt failed: %v", err)
+	}
+	if got.Title != "Renamed title" || got.Priority != 0 || got.Status != types.StatusOpen {
+		t.Errorf("unexpected state after update: %+v", got)
+	}
+
+	all, err := IssuesAt(ctx, store, at(3))
+	if err != nil {
+		t.Fatalf("IssuesAt failed: %v", err)
+	}
+	if len(all) != 1 || all[0].Status != types.StatusClosed {
+		t.Errorf("expected one closed issue, got %+v", all)
+	}
+
+	all, err = IssuesAt(ctx, store, start.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("IssuesAt failed: %v", err)
+	}
+	// The issue's own created_at precedes the (shifted) created event, so the
+	// import fallback applies
+	if len(all) != 1 || all[0].Title != "Original title" {
+		t.Errorf("expected the creation snapshot, got %+v", all)
+	}
+}
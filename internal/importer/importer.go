@@ -454,6 +454,17 @@ func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 				} else {
 					updates["external_ref"] = nil
 				}
+				updates["recurrence"] = incoming.Recurrence
+				if incoming.DueAt != nil {
+					updates["due_at"] = *incoming.DueAt
+				} else {
+					updates["due_at"] = nil
+				}
+				if incoming.RecurredAt != nil {
+					updates["recurred_at"] = *incoming.RecurredAt
+				} else {
+					updates["recurred_at"] = nil
+				}
 
 				// Only update if data actually changed
 				if IssueDataChanged(existingWithID, updates) {
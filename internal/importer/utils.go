@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/shaneholloman/beads/internal/types"
 	"github.com/shaneholloman/beads/internal/utils"
@@ -85,6 +86,19 @@ func (fc *fieldComparator) equalPtrStr(existing *string, newVal interface{}) boo
 	return *existing == s
 }
 
+func (fc *fieldComparator) equalTimePtr(existing *time.Time, newVal interface{}) bool {
+	switch t := newVal.(type) {
+	case nil:
+		return existing == nil
+	case time.Time:
+		return existing != nil && existing.Equal(t)
+	case *time.Time:
+		return (existing == nil && t == nil) || (existing != nil && t != nil && existing.Equal(*t))
+	default:
+		return false
+	}
+}
+
 func (fc *fieldComparator) equalStatus(existing types.Status, newVal interface{}) bool {
 	switch t := newVal.(type) {
 	case types.Status:
@@ -134,6 +148,12 @@ func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue,
 		return !fc.equalStr(existing.Assignee, newVal)
 	case "external_ref":
 		return !fc.equalPtrStr(existing.ExternalRef, newVal)
+	case "recurrence":
+		return !fc.equalStr(existing.Recurrence, newVal)
+	case "due_at":
+		return !fc.equalTimePtr(existing.DueAt, newVal)
+	case "recurred_at":
+		return !fc.equalTimePtr(existing.RecurredAt, newVal)
 	default:
 		return false
 	}
@@ -0,0 +1,212 @@
+// Package jira maps issues between beads and Jira through the Jira REST API (v2).
+// Issues are linked by their external_ref ("jira-<KEY>").
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchPageSize is the number of issues requested per search page
+const searchPageSize = 100
+
+// Issue is a Jira issue. Fields are kept raw because custom fields vary by site;
+// see the accessor methods for the standard ones.
+type Issue struct {
+	Key    string                     `json:"key"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// Transition is a workflow transition available on an issue
+type Transition struct {
+	ID string `json:"id"`
+	To struct {
+		Name string `json:"name"`
+	} `json:"to"`
+}
+
+// APIError is a non-2xx response from Jira
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Jira API error: %d %s", e.StatusCode, e.Message)
+}
+
+// Client talks to a Jira site. Jira Cloud authenticates with an account email
+// and API token (basic auth); Jira Server and Data Center with a personal
+// access token alone. Point BaseURL and HTTPClient at a test server to
+// exercise it without network access.
+type Client struct {
+	BaseURL    string // e.g. https://company.atlassian.net
+	Email      string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client for the Jira site at baseURL
+func NewClient(baseURL, email, token string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Email:      email,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Search returns all issues matching a JQL query, with all fields
+func (c *Client) Search(ctx context.Context, jql string) ([]*Issue, error) {
+	var issues []*Issue
+	for startAt := 0; ; {
+		query := url.Values{}
+		query.Set("jql", jql)
+		query.Set("startAt", strconv.Itoa(startAt))
+		query.Set("maxResults", strconv.Itoa(searchPageSize))
+		query.Set("fields", "*all")
+		var page struct {
+			Issues []*Issue `json:"issues"`
+			Total  int      `json:"total"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, &page); err != nil {
+			return nil, fmt.Errorf("failed to search issues: %w", err)
+		}
+		issues = append(issues, page.Issues...)
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			return issues, nil
+		}
+	}
+}
+
+// GetIssue returns a single issue
+func (c *Client) GetIssue(ctx context.Context, key string) (*Issue, error) {
+	var issue Issue
+	if err := c.do(ctx, http.MethodGet, "/rest/api/2/issue/"+url.PathEscape(key), nil, &issue); err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	return &issue, nil
+}
+
+// CreateIssue creates an issue and returns its key
+func (c *Client) CreateIssue(ctx context.Context, fields map[string]interface{}) (string, error) {
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/rest/api/2/issue", map[string]interface{}{"fields": fields}, &created); err != nil {
+		return "", fmt.Errorf("failed to create issue: %w", err)
+	}
+	return created.Key, nil
+}
+
+// UpdateIssue sets fields on an issue
+func (c *Client) UpdateIssue(ctx context.Context, key string, fields map[string]interface{}) error {
+	if err := c.do(ctx, http.MethodPut, "/rest/api/2/issue/"+url.PathEscape(key), map[string]interface{}{"fields": fields}, nil); err != nil {
+		return fmt.Errorf("failed to update %s: %w", key, err)
+	}
+	return nil
+}
+
+// Transitions returns the transitions currently available on an issue
+func (c *Client) Transitions(ctx context.Context, key string) ([]Transition, error) {
+	var resp struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/rest/api/2/issue/"+url.PathEscape(key)+"/transitions", nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get transitions for %s: %w", key, err)
+	}
+	return resp.Transitions, nil
+}
+
+// Transition moves an issue through a workflow transition
+func (c *Client) Transition(ctx context.Context, key, transitionID string) error {
+	body := map[string]interface{}{"transition": map[string]string{"id": transitionID}}
+	if err := c.do(ctx, http.MethodPost, "/rest/api/2/issue/"+url.PathEscape(key)+"/transitions", body, nil); err != nil {
+		return fmt.Errorf("failed to transition %s: %w", key, err)
+	}
+	return nil
+}
+
+// do sends a request to path and decodes the JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case c.Email != "":
+		req.SetBasicAuth(c.Email, c.Token)
+	case c.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &APIError{StatusCode: resp.StatusCode, Message: errorMessage(data)}
+	}
+	if out != nil && len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+	return nil
+}
+
+// errorMessage extracts the messages from a Jira error response
+func errorMessage(data []byte) string {
+	var payload struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if json.Unmarshal(data, &payload) != nil {
+		return strings.TrimSpace(string(data))
+	}
+	messages := payload.ErrorMessages
+	fields := make([]string, 0, len(payload.Errors))
+	for field := range payload.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, field+": "+payload.Errors[field])
+	}
+	if len(messages) == 0 {
+		return strings.TrimSpace(string(data))
+	}
+	return strings.Join(messages, "; ")
+}
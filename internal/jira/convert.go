@@ -0,0 +1,239 @@
+package jira
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// Ref returns the external_ref linking a beads issue to a Jira issue key
+func Ref(key string) string {
+	return "jira-" + key
+}
+
+// ParseRef returns the Jira key an external_ref links to
+func ParseRef(ref string) (string, bool) {
+	key, ok := strings.CutPrefix(ref, "jira-")
+	return key, ok && key != ""
+}
+
+// timeLayouts are the timestamp formats Jira returns
+var timeLayouts = []string{"2006-01-02T15:04:05.000-0700", time.RFC3339Nano}
+
+// String returns a text field, or "" if it is unset or not a string
+func (i *Issue) String(field string) string {
+	var s string
+	if raw, ok := i.Fields[field]; ok {
+		_ = json.Unmarshal(raw, &s)
+	}
+	return s
+}
+
+// Name returns the name of an object field such as issuetype, status or priority
+func (i *Issue) Name(field string) string {
+	var obj struct {
+		Name string `json:"name"`
+	}
+	if raw, ok := i.Fields[field]; ok {
+		_ = json.Unmarshal(raw, &obj)
+	}
+	return obj.Name
+}
+
+// Strings returns a list field such as labels
+func (i *Issue) Strings(field string) []string {
+	var list []string
+	if raw, ok := i.Fields[field]; ok {
+		_ = json.Unmarshal(raw, &list)
+	}
+	return list
+}
+
+// IssueKey returns the key of an issue reference field such as parent
+func (i *Issue) IssueKey(field string) string {
+	var ref struct {
+		Key string `json:"key"`
+	}
+	if raw, ok := i.Fields[field]; ok {
+		_ = json.Unmarshal(raw, &ref)
+	}
+	return ref.Key
+}
+
+// Time returns a timestamp field, or the zero time
+func (i *Issue) Time(field string) time.Time {
+	s := i.String(field)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// StatusCategory returns the key of the issue status's category
+func (i *Issue) StatusCategory() string {
+	var status struct {
+		Category struct {
+			Key string `json:"key"`
+		} `json:"statusCategory"`
+	}
+	if raw, ok := i.Fields["status"]; ok {
+		_ = json.Unmarshal(raw, &status)
+	}
+	return status.Category.Key
+}
+
+// ParentKey returns the key of the issue's parent from the parent field or
+// the epic link field, whichever is set
+func (i *Issue) ParentKey(m *Mapping) string {
+	if m.Fields.Parent != "" {
+		if key := i.IssueKey(m.Fields.Parent); key != "" {
+			return key
+		}
+	}
+	if m.Fields.EpicLink != "" {
+		return i.String(m.Fields.EpicLink)
+	}
+	return ""
+}
+
+// user returns a display name for a user object
+func user(raw json.RawMessage) string {
+	var u struct {
+		DisplayName string `json:"displayName"`
+		Name        string `json:"name"`
+	}
+	_ = json.Unmarshal(raw, &u)
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Name
+}
+
+// ToBeads converts Jira issues to beads issues ready for importer.ImportIssues.
+// Issues already linked to a local issue keep its ID; others get an ID derived
+// from the Jira key, so importing the same issue in two clones yields the same
+// ID. Parent links become parent-child dependencies when the parent is part
+// of the import or already linked locally.
+func ToBeads(ctx context.Context, store storage.Storage, prefix string, issues []*Issue, m *Mapping) ([]*types.Issue, error) {
+	local, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load issues: %w", err)
+	}
+	idByKey := make(map[string]string)
+	taken := make(map[string]bool, len(local))
+	for _, issue := range local {
+		taken[issue.ID] = true
+		if issue.ExternalRef != nil {
+			if key, ok := ParseRef(*issue.ExternalRef); ok {
+				idByKey[key] = issue.ID
+			}
+		}
+	}
+	for _, ji := range issues {
+		if _, ok := idByKey[ji.Key]; !ok {
+			id := idForKey(prefix, ji.Key, taken)
+			idByKey[ji.Key] = id
+			taken[id] = true
+		}
+	}
+
+	result := make([]*types.Issue, 0, len(issues))
+	for _, ji := range issues {
+		issue := convertIssue(ji, m)
+		issue.ID = idByKey[ji.Key]
+		if parentKey := ji.ParentKey(m); parentKey != "" {
+			if parentID, ok := idByKey[parentKey]; ok {
+				issue.Dependencies = []*types.Dependency{{
+					IssueID:     issue.ID,
+					DependsOnID: parentID,
+					Type:        types.DepParentChild,
+					CreatedAt:   issue.CreatedAt,
+					CreatedBy:   "jira",
+				}}
+			}
+		}
+		for _, c := range issue.Comments {
+			c.IssueID = issue.ID
+		}
+		result = append(result, issue)
+	}
+	return result, nil
+}
+
+// convertIssue maps a Jira issue's fields onto a beads issue without an ID
+func convertIssue(ji *Issue, m *Mapping) *types.Issue {
+	ref := Ref(ji.Key)
+	issue := &types.Issue{
+		Title:       ji.String("summary"),
+		Description: ji.String("description"),
+		Status:      m.ImportStatus(ji.Name("status"), ji.StatusCategory()),
+		Priority:    m.ImportPriority(ji.Name("priority")),
+		IssueType:   m.ImportType(ji.Name("issuetype")),
+		ExternalRef: &ref,
+		CreatedAt:   ji.Time("created"),
+		UpdatedAt:   ji.Time("updated"),
+	}
+	if m.Fields.Design != "" {
+		issue.Design = ji.String(m.Fields.Design)
+	}
+	if m.Fields.AcceptanceCriteria != "" {
+		issue.AcceptanceCriteria = ji.String(m.Fields.AcceptanceCriteria)
+	}
+	if m.Fields.Notes != "" {
+		issue.Notes = ji.String(m.Fields.Notes)
+	}
+	if raw, ok := ji.Fields["assignee"]; ok {
+		issue.Assignee = user(raw)
+	}
+	if issue.Status == types.StatusClosed {
+		closed := ji.Time("resolutiondate")
+		if closed.IsZero() {
+			closed = issue.UpdatedAt
+		}
+		issue.ClosedAt = &closed
+	}
+	issue.Labels = ji.Strings("labels")
+
+	var comments struct {
+		Comments []struct {
+			Author  json.RawMessage `json:"author"`
+			Body    string          `json:"body"`
+			Created string          `json:"created"`
+		} `json:"comments"`
+	}
+	if raw, ok := ji.Fields["comment"]; ok {
+		_ = json.Unmarshal(raw, &comments)
+	}
+	for _, c := range comments.Comments {
+		comment := &types.Comment{Author: user(c.Author), Text: c.Body}
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, c.Created); err == nil {
+				comment.CreatedAt = t
+				break
+			}
+		}
+		issue.Comments = append(issue.Comments, comment)
+	}
+	return issue
+}
+
+// idForKey derives a hash ID from a Jira key, lengthening it on collision
+func idForKey(prefix, key string, taken map[string]bool) string {
+	sum := sha256.Sum256([]byte("jira:" + key))
+	hash := hex.EncodeToString(sum[:])
+	for length := 6; length < len(hash); length++ {
+		if id := prefix + "-" + hash[:length]; !taken[id] {
+			return id
+		}
+	}
+	return prefix + "-" + hash
+}
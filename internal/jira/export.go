@@ -0,0 +1,221 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// ExportOptions controls an export
+type ExportOptions struct {
+	Project string // Key of the project new issues are created in
+	Actor   string // Recorded when linking local issues to created Jira issues
+	DryRun  bool   // Report what would change without writing anything
+}
+
+// ExportResult summarizes an export
+type ExportResult struct {
+	Created      []string          `json:"created,omitempty"`      // Beads IDs of issues created in Jira
+	Updated      []string          `json:"updated,omitempty"`      // Beads IDs of Jira issues whose fields changed
+	Transitioned []string          `json:"transitioned,omitempty"` // Beads IDs of Jira issues moved to a new status
+	Unchanged    int               `json:"unchanged"`
+	Keys         map[string]string `json:"keys"`               // Beads ID to Jira key
+	Warnings     []string          `json:"warnings,omitempty"` // Statuses that could not be reached, and similar
+	DryRun       bool              `json:"dry_run,omitempty"`
+}
+
+// Export creates or updates Jira issues for the given beads issues. Issues
+// linked by external_ref are updated in place; others are created in the
+// project and linked. Parents are exported before their children so
+// parent-child dependencies can be set as Jira parent links.
+func Export(ctx context.Context, client *Client, store storage.Storage, issues []*types.Issue, m *Mapping, opts ExportOptions) (*ExportResult, error) {
+	result := &ExportResult{Keys: make(map[string]string), DryRun: opts.DryRun}
+
+	parents := make(map[string]*types.Issue) // Child ID to parent issue
+	for _, issue := range issues {
+		if issue.ExternalRef != nil {
+			if key, ok := ParseRef(*issue.ExternalRef); ok {
+				result.Keys[issue.ID] = key
+			}
+		}
+		deps, err := store.GetDependencyRecords(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies for %s: %w", issue.ID, err)
+		}
+		for _, dep := range deps {
+			if dep.Type != types.DepParentChild {
+				continue
+			}
+			parent, err := store.GetIssue(ctx, dep.DependsOnID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get parent of %s: %w", issue.ID, err)
+			}
+			if parent != nil {
+				parents[issue.ID] = parent
+				if parent.ExternalRef != nil {
+					if key, ok := ParseRef(*parent.ExternalRef); ok {
+						result.Keys[parent.ID] = key
+					}
+				}
+			}
+		}
+	}
+
+	for _, issue := range byDepth(issues, parents) {
+		labels, err := store.GetLabels(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for %s: %w", issue.ID, err)
+		}
+		fields := exportFields(issue, labels, parents[issue.ID], result.Keys, m)
+
+		key, linked := result.Keys[issue.ID]
+		if !linked {
+			if opts.Project == "" {
+				return nil, fmt.Errorf("cannot create %s in Jira: no project configured", issue.ID)
+			}
+			result.Created = append(result.Created, issue.ID)
+			if opts.DryRun {
+				continue
+			}
+			fields["project"] = map[string]string{"key": opts.Project}
+			fields["issuetype"] = map[string]string{"name": m.ExportType(issue.IssueType)}
+			if key, err = client.CreateIssue(ctx, fields); err != nil {
+				return nil, fmt.Errorf("failed to export %s: %w", issue.ID, err)
+			}
+			result.Keys[issue.ID] = key
+			if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"external_ref": Ref(key)}, opts.Actor); err != nil {
+				return nil, fmt.Errorf("failed to link %s to %s: %w", issue.ID, key, err)
+			}
+		}
+
+		current, err := client.GetIssue(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		updated := false
+		if linked {
+			if changed := changedFields(current, fields); len(changed) > 0 {
+				updated = true
+				result.Updated = append(result.Updated, issue.ID)
+				if !opts.DryRun {
+					if err := client.UpdateIssue(ctx, key, changed); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+
+		if m.ImportStatus(current.Name("status"), current.StatusCategory()) == issue.Status {
+			if linked && !updated {
+				result.Unchanged++
+			}
+			continue
+		}
+		target := m.ExportStatus(issue.Status)
+		transitions, err := client.Transitions(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		transitionID := ""
+		for _, t := range transitions {
+			if strings.EqualFold(t.To.Name, target) {
+				transitionID = t.ID
+				break
+			}
+		}
+		if transitionID == "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s (%s): no transition from %q to %q", issue.ID, key, current.Name("status"), target))
+			continue
+		}
+		result.Transitioned = append(result.Transitioned, issue.ID)
+		if !opts.DryRun {
+			if err := client.Transition(ctx, key, transitionID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// exportFields returns the Jira fields for a beads issue. The issue type is
+// only set on creation, since Jira restricts changing it.
+func exportFields(issue *types.Issue, labels []string, parent *types.Issue, keys map[string]string, m *Mapping) map[string]interface{} {
+	if labels == nil {
+		labels = []string{}
+	}
+	sort.Strings(labels)
+	fields := map[string]interface{}{
+		"summary":     issue.Title,
+		"description": issue.Description,
+		"labels":      labels,
+	}
+	if priority := m.ExportPriority(issue.Priority); priority != "" {
+		fields["priority"] = map[string]string{"name": priority}
+	}
+	for field, value := range map[string]string{
+		m.Fields.Design:             issue.Design,
+		m.Fields.AcceptanceCriteria: issue.AcceptanceCriteria,
+		m.Fields.Notes:              issue.Notes,
+	} {
+		if field != "" {
+			fields[field] = value
+		}
+	}
+	if parent != nil {
+		if parentKey, ok := keys[parent.ID]; ok {
+			switch {
+			case parent.IssueType == types.TypeEpic && m.Fields.EpicLink != "":
+				fields[m.Fields.EpicLink] = parentKey
+			case m.Fields.Parent != "":
+				fields[m.Fields.Parent] = map[string]string{"key": parentKey}
+			}
+		}
+	}
+	return fields
+}
+
+// changedFields returns the fields whose values differ from the Jira issue
+func changedFields(current *Issue, fields map[string]interface{}) map[string]interface{} {
+	changed := make(map[string]interface{})
+	for field, value := range fields {
+		switch v := value.(type) {
+		case string:
+			if current.String(field) != v {
+				changed[field] = v
+			}
+		case []string:
+			existing := current.Strings(field)
+			sort.Strings(existing)
+			if strings.Join(existing, "\x00") != strings.Join(v, "\x00") {
+				changed[field] = v
+			}
+		case map[string]string:
+			if name, ok := v["name"]; ok {
+				if !strings.EqualFold(current.Name(field), name) {
+					changed[field] = v
+				}
+			} else if key, ok := v["key"]; ok && current.IssueKey(field) != key {
+				changed[field] = v
+			}
+		}
+	}
+	return changed
+}
+
+// byDepth orders issues so parents come before their children
+func byDepth(issues []*types.Issue, parents map[string]*types.Issue) []*types.Issue {
+	depth := func(issue *types.Issue) int {
+		d := 0
+		for p := parents[issue.ID]; p != nil && d < len(issues); p = parents[p.ID] {
+			d++
+		}
+		return d
+	}
+	ordered := append([]*types.Issue(nil), issues...)
+	sort.SliceStable(ordered, func(i, j int) bool { return depth(ordered[i]) < depth(ordered[j]) })
+	return ordered
+}
@@ -0,0 +1,443 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/importer"
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// fakeJira serves the subset of the Jira REST API used by import and export
+type fakeJira struct {
+	mu     sync.Mutex
+	issues map[string]map[string]interface{}
+	server *httptest.Server
+}
+
+// fakeStatuses are the statuses the fake workflow can transition to, by transition ID
+var fakeStatuses = map[string][2]string{
+	"11": {"To Do", "new"},
+	"21": {"In Progress", "indeterminate"},
+	"31": {"Done", "done"},
+}
+
+func newFakeJira(t *testing.T) *fakeJira {
+	f := &fakeJira{issues: make(map[string]map[string]interface{})}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/2/search", f.search)
+	mux.HandleFunc("POST /rest/api/2/issue", f.create)
+	mux.HandleFunc("GET /rest/api/2/issue/{key}", f.get)
+	mux.HandleFunc("PUT /rest/api/2/issue/{key}", f.update)
+	mux.HandleFunc("GET /rest/api/2/issue/{key}/transitions", f.transitions)
+	mux.HandleFunc("POST /rest/api/2/issue/{key}/transitions", f.transition)
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeJira) client() *Client {
+	c := NewClient(f.server.URL, "me@example.com", "token")
+	c.HTTPClient = f.server.Client()
+	return c
+}
+
+func status(name, category string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "statusCategory": map[string]string{"key": category}}
+}
+
+func (f *fakeJira) add(key string, fields map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.issues[key] = fields
+}
+
+func (f *fakeJira) fields(key string) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.issues[key]
+}
+
+func (f *fakeJira) issue(key string) map[string]interface{} {
+	return map[string]interface{}{"key": key, "fields": f.issues[key]}
+}
+
+func (f *fakeJira) search(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.issues))
+	for key := range f.issues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+	end := min(startAt+2, len(keys)) // Small pages to exercise pagination
+	var page []interface{}
+	for _, key := range keys[min(startAt, len(keys)):end] {
+		page = append(page, f.issue(key))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"issues": page, "total": len(keys)})
+}
+
+func (f *fakeJira) get(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := r.PathValue("key")
+	if f.issues[key] == nil {
+		writeJSON(w, http.StatusNotFound, map[string][]string{"errorMessages": {"Issue does not exist"}})
+		return
+	}
+	writeJSON(w, http.StatusOK, f.issue(key))
+}
+
+func (f *fakeJira) create(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var req struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{"errorMessages": {err.Error()}})
+		return
+	}
+	if req.Fields["summary"] == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]map[string]string{"errors": {"summary": "You must specify a summary"}})
+		return
+	}
+	project := req.Fields["project"].(map[string]interface{})["key"].(string)
+	key := fmt.Sprintf("%s-%d", project, len(f.issues)+1)
+	req.Fields["status"] = status("To Do", "new")
+	f.issues[key] = req.Fields
+	writeJSON(w, http.StatusCreated, map[string]string{"key": key})
+}
+
+func (f *fakeJira) update(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var req struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{"errorMessages": {err.Error()}})
+		return
+	}
+	for k, v := range req.Fields {
+		f.issues[r.PathValue("key")][k] = v
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeJira) transitions(w http.ResponseWriter, _ *http.Request) {
+	var list []Transition
+	for id, s := range fakeStatuses {
+		t := Transition{ID: id}
+		t.To.Name = s[0]
+		list = append(list, t)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"transitions": list})
+}
+
+func (f *fakeJira) transition(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var req struct {
+		Transition struct {
+			ID string `json:"id"`
+		} `json:"transition"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	s := fakeStatuses[req.Transition.ID]
+	f.issues[r.PathValue("key")]["status"] = status(s[0], s[1])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestStore(t *testing.T) *sqlite.SQLiteStorage {
+	t.Helper()
+	store, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(context.Background(), "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+	return store
+}
+
+func importAll(t *testing.T, client *Client, store *sqlite.SQLiteStorage, m *Mapping) *importer.Result {
+	t.Helper()
+	ctx := context.Background()
+	jiraIssues, err := client.Search(ctx, "project = ABC")
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	issues, err := ToBeads(ctx, store, "test", jiraIssues, m)
+	if err != nil {
+		t.Fatalf("ToBeads failed: %v", err)
+	}
+	result, err := importer.ImportIssues(ctx, "", store, issues, importer.Options{})
+	if err != nil {
+		t.Fatalf("ImportIssues failed: %v", err)
+	}
+	return result
+}
+
+func findByRef(t *testing.T, store *sqlite.SQLiteStorage, key string) *types.Issue {
+	t.Helper()
+	issues, err := store.SearchIssues(context.Background(), "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	for _, issue := range issues {
+		if issue.ExternalRef != nil && *issue.ExternalRef == Ref(key) {
+			return issue
+		}
+	}
+	t.Fatalf("no issue linked to %s", key)
+	return nil
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	jira := newFakeJira(t)
+	jira.add("ABC-1", map[string]interface{}{
+		"summary": "Checkout revamp", "description": "Epic body",
+		"issuetype": map[string]string{"name": "Epic"}, "priority": map[string]string{"name": "High"},
+		"status":  status("In Progress", "indeterminate"),
+		"created": "2025-01-10T09:00:00.000+0000", "updated": "2025-01-11T09:00:00.000+0000",
+	})
+	jira.add("ABC-2", map[string]interface{}{
+		"summary": "Card form", "issuetype": map[string]string{"name": "Story"},
+		"priority": map[string]string{"name": "Blocker"}, "status": status("Code Review", "indeterminate"),
+		"labels": []string{"frontend"}, "assignee": map[string]string{"displayName": "Alice"},
+		"customfield_10014": "ABC-1", "customfield_10020": "Accepts Visa",
+		"comment": map[string]interface{}{"comments": []map[string]interface{}{
+			{"author": map[string]string{"displayName": "Bob"}, "body": "Design attached", "created": "2025-01-12T10:00:00.000+0000"},
+		}},
+		"created": "2025-01-10T10:00:00.000+0000", "updated": "2025-01-12T10:00:00.000+0000",
+	})
+	jira.add("ABC-3", map[string]interface{}{
+		"summary": "Old bug", "issuetype": map[string]string{"name": "Bug"},
+		"status": status("Won't Do", "done"), "parent": map[string]string{"key": "ABC-1"},
+		"created": "2025-01-01T10:00:00.000+0000", "updated": "2025-01-02T10:00:00.000+0000",
+		"resolutiondate": "2025-01-02T10:00:00.000+0000",
+	})
+
+	m := DefaultMapping()
+	m.Fields.EpicLink = "customfield_10014"
+	m.Fields.AcceptanceCriteria = "customfield_10020"
+	store := newTestStore(t)
+
+	result := importAll(t, jira.client(), store, m)
+	if result.Created != 3 {
+		t.Fatalf("Created = %d, want 3", result.Created)
+	}
+
+	epic := findByRef(t, store, "ABC-1")
+	if epic.IssueType != types.TypeEpic || epic.Priority != 1 || epic.Status != types.StatusInProgress {
+		t.Errorf("epic = %+v", epic)
+	}
+	story := findByRef(t, store, "ABC-2")
+	if story.IssueType != types.TypeFeature || story.Priority != 0 || story.Status != types.StatusInProgress ||
+		story.Assignee != "Alice" || story.AcceptanceCriteria != "Accepts Visa" {
+		t.Errorf("story = %+v", story)
+	}
+	bug := findByRef(t, store, "ABC-3")
+	if bug.Status != types.StatusClosed || bug.ClosedAt == nil {
+		t.Errorf("unmapped done status should close the issue: %+v", bug)
+	}
+	for _, child := range []*types.Issue{story, bug} {
+		deps, _ := store.GetDependencyRecords(ctx, child.ID)
+		if len(deps) != 1 || deps[0].DependsOnID != epic.ID || deps[0].Type != types.DepParentChild {
+			t.Errorf("%s dependencies = %+v, want parent-child on %s", child.ID, deps, epic.ID)
+		}
+	}
+	if labels, _ := store.GetLabels(ctx, story.ID); !reflect.DeepEqual(labels, []string{"frontend"}) {
+		t.Errorf("labels = %v", labels)
+	}
+	if comments, _ := store.GetIssueComments(ctx, story.ID); len(comments) != 1 || comments[0].Author != "Bob" {
+		t.Errorf("comments = %+v", comments)
+	}
+
+	// IDs derive from the Jira key, so another clone gets the same IDs
+	other := newTestStore(t)
+	importAll(t, jira.client(), other, m)
+	if findByRef(t, other, "ABC-2").ID != story.ID {
+		t.Error("expected the same ID when importing into another database")
+	}
+
+	// Re-importing is idempotent, and picks up later Jira edits
+	if result := importAll(t, jira.client(), store, m); result.Created != 0 || result.Updated != 0 {
+		t.Errorf("re-import = %+v", result)
+	}
+	jira.fields("ABC-2")["summary"] = "Card form v2"
+	jira.fields("ABC-2")["updated"] = "2025-02-01T10:00:00.000+0000"
+	if result := importAll(t, jira.client(), store, m); result.Created != 0 || result.Updated != 1 {
+		t.Errorf("import after edit = %+v", result)
+	}
+	if got := findByRef(t, store, "ABC-2"); got.ID != story.ID || got.Title != "Card form v2" {
+		t.Errorf("updated story = %+v", got)
+	}
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	jira := newFakeJira(t)
+	jira.add("ABC-1", map[string]interface{}{
+		"summary": "Existing", "issuetype": map[string]string{"name": "Task"},
+		"priority": map[string]string{"name": "Medium"}, "status": status("To Do", "new"), "labels": []string{},
+	})
+	store := newTestStore(t)
+
+	ref := Ref("ABC-1")
+	existing := &types.Issue{Title: "Existing, renamed", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeTask, ExternalRef: &ref}
+	epic := &types.Issue{Title: "Payments", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	child := &types.Issue{Title: "Refunds", Description: "Partial refunds", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeFeature}
+	for _, issue := range []*types.Issue{existing, child, epic} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.CloseIssue(ctx, child.ID, "done", "test"); err != nil {
+		t.Fatal(err)
+	}
+	child, _ = store.GetIssue(ctx, child.ID)
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddLabel(ctx, child.ID, "billing", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	m := DefaultMapping()
+	m.Fields.EpicLink = "customfield_10014"
+	opts := ExportOptions{Project: "ABC", Actor: "test"}
+
+	// Export the child before the epic to check that parents go first
+	result, err := Export(ctx, jira.client(), store, []*types.Issue{existing, child, epic}, m, opts)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(result.Created) != 2 || !reflect.DeepEqual(result.Updated, []string{existing.ID}) || len(result.Transitioned) != 2 {
+		t.Errorf("result = %+v", result)
+	}
+
+	if f := jira.fields("ABC-1"); f["summary"] != "Existing, renamed" || f["status"].(map[string]interface{})["name"] != "In Progress" {
+		t.Errorf("ABC-1 = %+v", f)
+	}
+	epicKey, childKey := result.Keys[epic.ID], result.Keys[child.ID]
+	if epicKey != "ABC-2" || childKey != "ABC-3" {
+		t.Fatalf("keys = %v", result.Keys)
+	}
+	f := jira.fields(childKey)
+	if f["customfield_10014"] != epicKey || f["issuetype"].(map[string]interface{})["name"] != "Story" ||
+		f["priority"].(map[string]interface{})["name"] != "Low" || f["status"].(map[string]interface{})["name"] != "Done" {
+		t.Errorf("child fields = %+v", f)
+	}
+	if labels := f["labels"].([]interface{}); len(labels) != 1 || labels[0] != "billing" {
+		t.Errorf("child labels = %v", labels)
+	}
+	if linked, _ := store.GetIssue(ctx, child.ID); linked.ExternalRef == nil || *linked.ExternalRef != Ref(childKey) {
+		t.Errorf("child not linked: %v", linked.ExternalRef)
+	}
+
+	// A second export changes nothing
+	var issues []*types.Issue
+	for _, id := range []string{existing.ID, child.ID, epic.ID} {
+		issue, _ := store.GetIssue(ctx, id)
+		issues = append(issues, issue)
+	}
+	result, err = Export(ctx, jira.client(), store, issues, m, opts)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if result.Unchanged != 3 || len(result.Created)+len(result.Updated)+len(result.Transitioned) != 0 {
+		t.Errorf("second export = %+v", result)
+	}
+}
+
+func TestExportErrors(t *testing.T) {
+	ctx := context.Background()
+	jira := newFakeJira(t)
+	store := newTestStore(t)
+	issue := &types.Issue{Title: "x", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Export(ctx, jira.client(), store, []*types.Issue{issue}, DefaultMapping(), ExportOptions{}); err == nil || !strings.Contains(err.Error(), "no project") {
+		t.Errorf("expected missing project error, got %v", err)
+	}
+	issue.Title = ""
+	_, err := Export(ctx, jira.client(), store, []*types.Issue{issue}, DefaultMapping(), ExportOptions{Project: "ABC"})
+	if err == nil || !strings.Contains(err.Error(), "summary: You must specify a summary") {
+		t.Errorf("expected Jira field error, got %v", err)
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jira.yaml")
+	content := `types:
+  feature: [Story, Spike]
+statuses:
+  closed: [Done, "Won't Do"]
+priorities:
+  0: [P0]
+fields:
+  epic_link: customfield_10014
+  notes: customfield_10030
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadMapping(path)
+	if err != nil {
+		t.Fatalf("LoadMapping failed: %v", err)
+	}
+	if m.ImportType("spike") != types.TypeFeature || m.ImportType("Bug") != types.TypeBug || m.ImportType("Whatever") != types.TypeTask {
+		t.Errorf("type mapping = %v", m.Types)
+	}
+	if m.ImportStatus("Won't Do", "done") != types.StatusClosed || m.ExportStatus(types.StatusClosed) != "Done" {
+		t.Errorf("status mapping = %v", m.Statuses)
+	}
+	if m.ImportPriority("P0") != 0 || m.ExportPriority(0) != "P0" || m.ImportPriority("Unknown") != 2 {
+		t.Errorf("priority mapping = %v", m.Priorities)
+	}
+	if m.Fields != (FieldMapping{Parent: "parent", EpicLink: "customfield_10014", Notes: "customfield_10030"}) {
+		t.Errorf("fields = %+v", m.Fields)
+	}
+
+	if err := m.ApplyConfig(map[string]string{"jira.status_map.closed": "Won't Do", "jira.type_map.feature": "Spike", "other": "x"}); err != nil {
+		t.Fatalf("ApplyConfig failed: %v", err)
+	}
+	if m.ExportStatus(types.StatusClosed) != "Won't Do" || m.ExportType(types.TypeFeature) != "Spike" || m.ImportStatus("Done", "") != types.StatusClosed {
+		t.Errorf("config overrides not applied: %v %v", m.Statuses, m.Types)
+	}
+
+	for _, bad := range []string{"types:\n  story: [Story]\n", "priorities:\n  7: [P7]\n", "fields:\n  owner: x\n", "statuses:\n  nope: [X]\n"} {
+		if err := os.WriteFile(path, []byte(bad), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadMapping(path); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
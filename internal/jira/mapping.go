@@ -0,0 +1,265 @@
+package jira
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/spf13/viper"
+)
+
+// Mapping translates Jira issue types, statuses and priorities to beads values
+// and back. Each beads value lists the Jira names that map to it; the first
+// name is the one used on export. Matching is case-insensitive.
+type Mapping struct {
+	Types      map[types.IssueType][]string
+	Statuses   map[types.Status][]string
+	Priorities map[int][]string
+	Fields     FieldMapping
+}
+
+// FieldMapping names the Jira fields holding parent links and the beads text
+// fields Jira has no standard field for. Empty names are not synced.
+type FieldMapping struct {
+	Parent             string // Parent issue (sub-tasks, and epics on team-managed projects)
+	EpicLink           string // Classic "Epic Link" custom field, e.g. customfield_10014
+	Design             string
+	AcceptanceCriteria string
+	Notes              string
+}
+
+// DefaultMapping returns the mapping for Jira's default schemes
+func DefaultMapping() *Mapping {
+	return &Mapping{
+		Types: map[types.IssueType][]string{
+			types.TypeBug:     {"Bug", "Defect"},
+			types.TypeFeature: {"Story", "New Feature", "Improvement"},
+			types.TypeTask:    {"Task", "Sub-task", "Subtask"},
+			types.TypeEpic:    {"Epic"},
+			types.TypeChore:   {"Chore"},
+		},
+		Statuses: map[types.Status][]string{
+			types.StatusOpen:       {"To Do", "Open", "Backlog", "Reopened"},
+			types.StatusInProgress: {"In Progress", "In Review"},
+			types.StatusBlocked:    {"Blocked"},
+			types.StatusClosed:     {"Done", "Closed", "Resolved"},
+		},
+		Priorities: map[int][]string{
+			0: {"Highest", "Blocker"},
+			1: {"High", "Critical"},
+			2: {"Medium", "Major"},
+			3: {"Low", "Minor"},
+			4: {"Lowest", "Trivial"},
+		},
+		Fields: FieldMapping{Parent: "parent"},
+	}
+}
+
+// mappingFile is the on-disk form of a mapping
+type mappingFile struct {
+	Types      map[string][]string `mapstructure:"types"`
+	Statuses   map[string][]string `mapstructure:"statuses"`
+	Priorities map[string][]string `mapstructure:"priorities"`
+	Fields     map[string]string   `mapstructure:"fields"`
+}
+
+// LoadMapping reads a YAML mapping file over the defaults. Entries in the file
+// replace the default entry for the same beads value.
+//
+// Example:
+//
+//	types:
+//	  feature: [Story, Improvement]
+//	statuses:
+//	  in_review: [Code Review]
+//	  closed: [Done, "Won't Do"]
+//	priorities:
+//	  0: [Blocker]
+//	fields:
+//	  epic_link: customfield_10014
+//	  acceptance_criteria: customfield_10020
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - user-specified mapping file
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	cfg := viper.New()
+	cfg.SetConfigType("yaml")
+	if err := cfg.ReadConfig(strings.NewReader(string(data))); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %s: %w", path, err)
+	}
+	var file mappingFile
+	if err := cfg.Unmarshal(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %s: %w", path, err)
+	}
+
+	m := DefaultMapping()
+	for name, jiraNames := range file.Types {
+		t := types.IssueType(name)
+		if !t.IsValid() {
+			return nil, fmt.Errorf("%s: invalid issue type %q", path, name)
+		}
+		m.Types[t] = jiraNames
+	}
+	for name, jiraNames := range file.Statuses {
+		s := types.Status(name)
+		if _, ok := types.CurrentWorkflow().Category(s); !ok {
+			return nil, fmt.Errorf("%s: unknown status %q (custom statuses must be defined in the workflow)", path, name)
+		}
+		m.Statuses[s] = jiraNames
+	}
+	for name, jiraNames := range file.Priorities {
+		p, err := strconv.Atoi(name)
+		if err != nil || p < 0 || p > 4 {
+			return nil, fmt.Errorf("%s: invalid priority %q (must be 0-4)", path, name)
+		}
+		m.Priorities[p] = jiraNames
+	}
+	for name, field := range file.Fields {
+		switch name {
+		case "parent":
+			m.Fields.Parent = field
+		case "epic_link":
+			m.Fields.EpicLink = field
+		case "design":
+			m.Fields.Design = field
+		case "acceptance_criteria":
+			m.Fields.AcceptanceCriteria = field
+		case "notes":
+			m.Fields.Notes = field
+		default:
+			return nil, fmt.Errorf("%s: unknown field %q (expected parent, epic_link, design, acceptance_criteria or notes)", path, name)
+		}
+	}
+	return m, nil
+}
+
+// ApplyConfig applies the jira.type_map.<type> and jira.status_map.<status>
+// config settings, which name the Jira value used on export
+func (m *Mapping) ApplyConfig(cfg map[string]string) error {
+	for key, value := range cfg {
+		if value == "" {
+			continue
+		}
+		if name, ok := strings.CutPrefix(key, "jira.type_map."); ok {
+			t := types.IssueType(name)
+			if !t.IsValid() {
+				return fmt.Errorf("invalid issue type in %s", key)
+			}
+			m.Types[t] = preferName(m.Types[t], value)
+		}
+		if name, ok := strings.CutPrefix(key, "jira.status_map."); ok {
+			s := types.Status(name)
+			if _, ok := types.CurrentWorkflow().Category(s); !ok {
+				return fmt.Errorf("unknown status in %s", key)
+			}
+			m.Statuses[s] = preferName(m.Statuses[s], value)
+		}
+	}
+	return nil
+}
+
+// preferName moves name to the front of names, adding it if missing
+func preferName(names []string, name string) []string {
+	result := []string{name}
+	for _, n := range names {
+		if !strings.EqualFold(n, name) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// ImportType returns the beads type for a Jira issue type (task if unmapped)
+func (m *Mapping) ImportType(name string) types.IssueType {
+	for _, t := range sortedKeys(m.Types) {
+		if containsFold(m.Types[t], name) {
+			return t
+		}
+	}
+	return types.TypeTask
+}
+
+// ExportType returns the Jira issue type for a beads type
+func (m *Mapping) ExportType(t types.IssueType) string {
+	if names := m.Types[t]; len(names) > 0 {
+		return names[0]
+	}
+	return "Task"
+}
+
+// ImportStatus returns the beads status for a Jira status. Unmapped statuses
+// fall back on Jira's status category ("new", "indeterminate" or "done").
+func (m *Mapping) ImportStatus(name, category string) types.Status {
+	for _, s := range sortedKeys(m.Statuses) {
+		if containsFold(m.Statuses[s], name) {
+			return s
+		}
+	}
+	switch category {
+	case "done":
+		return types.StatusClosed
+	case "indeterminate":
+		return types.StatusInProgress
+	}
+	return types.StatusOpen
+}
+
+// ExportStatus returns the Jira status for a beads status. Custom statuses
+// without a mapping use the status of their category's built-in status.
+func (m *Mapping) ExportStatus(s types.Status) string {
+	if names := m.Statuses[s]; len(names) > 0 {
+		return names[0]
+	}
+	fallback := types.StatusOpen
+	switch s.Category() {
+	case types.CategoryDone:
+		fallback = types.StatusClosed
+	case types.CategoryBlocked:
+		fallback = types.StatusBlocked
+	}
+	if names := m.Statuses[fallback]; len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+// ImportPriority returns the beads priority for a Jira priority (2 if unmapped)
+func (m *Mapping) ImportPriority(name string) int {
+	for p := 0; p <= 4; p++ {
+		if containsFold(m.Priorities[p], name) {
+			return p
+		}
+	}
+	return 2
+}
+
+// ExportPriority returns the Jira priority for a beads priority
+func (m *Mapping) ExportPriority(p int) string {
+	if names := m.Priorities[p]; len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns map keys in a stable order so overlapping mappings resolve consistently
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
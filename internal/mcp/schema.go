@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Schema is the subset of JSON Schema used for tool inputs
+type Schema struct {
+	Type                 string             `json:"type"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+}
+
+// hint documents one argument of a tool
+type hint struct {
+	desc string
+	enum []string
+}
+
+// schemaFor builds the input schema for an rpc Args struct from its JSON
+// encoding. defaults is a pointer to the struct pre-filled with the tool's
+// defaults: non-zero fields become schema defaults, and fields that are
+// neither omitempty nor defaulted are required.
+func schemaFor(defaults interface{}, hints map[string]hint) *Schema {
+	v := reflect.Indirect(reflect.ValueOf(defaults))
+	schema := objectSchema(v.Type())
+	closed := false
+	schema.AdditionalProperties = &closed
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, omitempty, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+		value := v.Field(i)
+		if !value.IsZero() {
+			schema.Properties[name].Default = value.Interface()
+		} else if !omitempty {
+			schema.Required = append(schema.Required, name)
+		}
+		if h, ok := hints[name]; ok {
+			schema.Properties[name].Description = h.desc
+			schema.Properties[name].Enum = h.enum
+		}
+	}
+	return schema
+}
+
+// typeSchema maps a Go type to the schema of its JSON encoding
+func typeSchema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: typeSchema(t.Elem())}
+	case reflect.Struct:
+		return objectSchema(t)
+	default:
+		return &Schema{Type: "object"}
+	}
+}
+
+func objectSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name, _, ok := jsonName(field); ok {
+			schema.Properties[name] = typeSchema(field.Type)
+		}
+	}
+	return schema
+}
+
+// jsonName returns the JSON name of an exported field and whether it is
+// omitempty; ok is false for fields that are never encoded
+func jsonName(field reflect.StructField) (name string, omitempty bool, ok bool) {
+	if !field.IsExported() {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(","+opts+",", ",omitempty,"), true
+}
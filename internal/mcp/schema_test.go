@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSchemaFor(t *testing.T) {
+	type args struct {
+		ID       string   `json:"id"`
+		Title    *string  `json:"title,omitempty"`
+		Count    int      `json:"count"`
+		Ratio    float64  `json:"ratio,omitempty"`
+		Tags     []string `json:"tags,omitempty"`
+		Enabled  bool     `json:"enabled,omitempty"`
+		Untagged string
+		Skipped  string `json:"-"`
+		hidden   string
+	}
+	schema := schemaFor(&args{Count: 3}, map[string]hint{"id": {desc: "Issue ID", enum: []string{"a", "b"}}})
+
+	if strings.Join(schema.Required, ",") != "id,Untagged" {
+		t.Errorf("required = %v, want id and Untagged", schema.Required)
+	}
+	if len(schema.Properties) != 7 || schema.Properties["Skipped"] != nil || schema.Properties["hidden"] != nil {
+		t.Errorf("unexpected properties: %v", schema.Properties)
+	}
+	for name, want := range map[string]string{"id": "string", "title": "string", "count": "integer", "ratio": "number", "tags": "array", "enabled": "boolean"} {
+		if got := schema.Properties[name].Type; got != want {
+			t.Errorf("%s: type %s, want %s", name, got, want)
+		}
+	}
+	if schema.Properties["count"].Default != 3 || schema.Properties["tags"].Items.Type != "string" {
+		t.Errorf("unexpected count or tags schema")
+	}
+	if id := schema.Properties["id"]; id.Description != "Issue ID" || len(id.Enum) != 2 {
+		t.Errorf("expected the hint on id, got %+v", id)
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("failed to marshal schema: %v", err)
+	}
+	if !strings.Contains(string(data), `"additionalProperties":false`) {
+		t.Errorf("expected a closed object schema, got %s", data)
+	}
+}
@@ -0,0 +1,274 @@
+// Package mcp implements a Model Context Protocol server over stdio whose
+// tools map onto daemon RPC operations.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/shaneholloman/beads/internal/rpc"
+)
+
+// ProtocolVersions are the MCP revisions the server speaks, newest first
+var ProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// maxMessageBytes bounds the size of one incoming message
+const maxMessageBytes = 16 << 20
+
+// instructions tell the model how the tools fit together
+const instructions = `beads tracks work as issues with dependencies.
+Call ready to find unblocked work, or claim to take the next ready issue and
+keep it with heartbeat. Record new work with create (link it with
+dependencies, e.g. discovered-from:<id>), progress with update and
+comment_add, and finish with close.`
+
+// Executor runs rpc operations: an *rpc.Client talking to the daemon, or an
+// *rpc.Server running them in-process
+type Executor interface {
+	Execute(operation string, args interface{}) (*rpc.Response, error)
+}
+
+// Server answers MCP requests read from a stream
+type Server struct {
+	exec    Executor
+	version string
+	tools   []tool
+	schemas map[string]*Schema
+
+	// OnMutation runs after a tool changes data, e.g. to schedule a JSONL flush
+	OnMutation func()
+}
+
+// New returns a server that runs tools with exec. version is reported to
+// clients; actor is the default agent and comment author.
+func New(exec Executor, version, actor string) *Server {
+	s := &Server{
+		exec:    exec,
+		version: version,
+		tools:   tools(actor),
+		schemas: make(map[string]*Schema),
+	}
+	for _, t := range s.tools {
+		s.schemas[t.name] = schemaFor(t.args(), t.hints)
+	}
+	return s
+}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve reads newline-delimited JSON-RPC messages from r and writes replies
+// to w until r is exhausted. Requests are handled one at a time.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	reader := bufio.NewReaderSize(r, 64*1024)
+	encoder := json.NewEncoder(w)
+	for {
+		line, err := readLine(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		reply := s.handle(line)
+		if reply == nil {
+			continue
+		}
+		if err := encoder.Encode(reply); err != nil {
+			return fmt.Errorf("failed to write reply: %w", err)
+		}
+	}
+}
+
+// readLine reads one message, refusing ones over maxMessageBytes
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		line = append(line, chunk...)
+		if len(line) > maxMessageBytes {
+			return nil, fmt.Errorf("message exceeds %d bytes", maxMessageBytes)
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			return line, nil
+		}
+		return line, err
+	}
+}
+
+// handle processes one message and returns the reply, or nil for notifications
+func (s *Server) handle(line []byte) *message {
+	var req message
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &message{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{codeParseError, fmt.Sprintf("parse error: %v", err)}}
+	}
+	if req.ID == nil {
+		// Notifications (initialized, cancelled, ...) need no reply
+		return nil
+	}
+	reply := &message{JSONRPC: "2.0", ID: req.ID}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		reply.Error = &rpcError{codeInvalidRequest, "invalid request"}
+		return reply
+	}
+
+	switch req.Method {
+	case "initialize":
+		reply.Result, reply.Error = s.initialize(req.Params)
+	case "ping":
+		reply.Result = struct{}{}
+	case "tools/list":
+		reply.Result = s.listTools()
+	case "tools/call":
+		reply.Result, reply.Error = s.callTool(req.Params)
+	default:
+		reply.Error = &rpcError{codeMethodNotFound, "method not found: " + req.Method}
+	}
+	return reply
+}
+
+func (s *Server) initialize(params json.RawMessage) (interface{}, *rpcError) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{codeInvalidParams, fmt.Sprintf("invalid params: %v", err)}
+		}
+	}
+	// Answer in the client's revision when we speak it, else our newest
+	version := ProtocolVersions[0]
+	if slices.Contains(ProtocolVersions, p.ProtocolVersion) {
+		version = p.ProtocolVersion
+	}
+	return map[string]interface{}{
+		"protocolVersion": version,
+		"capabilities":    map[string]interface{}{"tools": map[string]bool{"listChanged": false}},
+		"serverInfo":      map[string]string{"name": "beads", "version": s.version},
+		"instructions":    instructions,
+	}, nil
+}
+
+type toolInfo struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	InputSchema *Schema `json:"inputSchema"`
+}
+
+func (s *Server) listTools() interface{} {
+	infos := make([]toolInfo, 0, len(s.tools))
+	for _, t := range s.tools {
+		infos = append(infos, toolInfo{Name: t.name, Description: t.description, InputSchema: s.schemas[t.name]})
+	}
+	return map[string]interface{}{"tools": infos}
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type toolResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+func textResult(text string, isError bool) toolResult {
+	return toolResult{Content: []content{{Type: "text", Text: text}}, IsError: isError}
+}
+
+// callTool runs a tool. Failures of the operation itself are reported in the
+// result so the model can see and correct them; only unknown tools are
+// protocol errors.
+func (s *Server) callTool(params json.RawMessage) (interface{}, *rpcError) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{codeInvalidParams, fmt.Sprintf("invalid params: %v", err)}
+	}
+	idx := slices.IndexFunc(s.tools, func(t tool) bool { return t.name == p.Name })
+	if idx < 0 {
+		return nil, &rpcError{codeInvalidParams, "unknown tool: " + p.Name}
+	}
+	t := s.tools[idx]
+
+	args := t.args()
+	if err := decodeArguments(p.Arguments, args, s.schemas[t.name]); err != nil {
+		return textResult("invalid arguments: "+err.Error(), true), nil
+	}
+	resp, err := s.exec.Execute(t.name, args)
+	if err != nil {
+		if resp != nil && resp.Error != "" {
+			return textResult(resp.Error, true), nil
+		}
+		return textResult(err.Error(), true), nil
+	}
+	if t.mutates && s.OnMutation != nil {
+		s.OnMutation()
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, resp.Data, "", "  "); err != nil {
+		return textResult(string(resp.Data), false), nil
+	}
+	return textResult(out.String(), false), nil
+}
+
+// decodeArguments decodes tool arguments over the defaults in args, rejecting
+// unknown and missing required arguments
+func decodeArguments(raw json.RawMessage, args interface{}, schema *Schema) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		raw = json.RawMessage("{}")
+	}
+	var given map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &given); err != nil {
+		return fmt.Errorf("arguments must be an object: %w", err)
+	}
+	var missing []string
+	for _, name := range schema.Required {
+		if _, ok := given[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required %s", strings.Join(missing, ", "))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(args)
+}
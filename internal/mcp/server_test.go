@@ -0,0 +1,210 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func newTestServer(t *testing.T) (*Server, *int) {
+	t.Helper()
+	store, err := sqlite.New(t.TempDir() + "/test.db")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(context.Background(), "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+	server := New(rpc.NewServer("", store, t.TempDir(), store.Path()), "1.2.3", "alice")
+	mutations := 0
+	server.OnMutation = func() { mutations++ }
+	return server, &mutations
+}
+
+// roundTrip sends each message and returns the replies
+func roundTrip(t *testing.T, s *Server, messages ...string) []map[string]interface{} {
+	t.Helper()
+	var out strings.Builder
+	if err := s.Serve(strings.NewReader(strings.Join(messages, "\n")), &out); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	var replies []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var reply map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &reply); err != nil {
+			t.Fatalf("invalid reply %q: %v", line, err)
+		}
+		replies = append(replies, reply)
+	}
+	return replies
+}
+
+// call invokes a tool and returns its text and whether it is an error
+func call(t *testing.T, s *Server, name string, args interface{}) (string, bool) {
+	t.Helper()
+	params, _ := json.Marshal(map[string]interface{}{"name": name, "arguments": args})
+	replies := roundTrip(t, s, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":`+string(params)+`}`)
+	if len(replies) != 1 {
+		t.Fatalf("expected 1 reply, got %d", len(replies))
+	}
+	result, ok := replies[0]["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("%s: expected a result, got %v", name, replies[0])
+	}
+	text := result["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	isError, _ := result["isError"].(bool)
+	return text, isError
+}
+
+func callIssue(t *testing.T, s *Server, name string, args interface{}) *types.Issue {
+	t.Helper()
+	text, isError := call(t, s, name, args)
+	if isError {
+		t.Fatalf("%s failed: %s", name, text)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal([]byte(text), &issue); err != nil {
+		t.Fatalf("%s returned %q: %v", name, text, err)
+	}
+	return &issue
+}
+
+func TestProtocol(t *testing.T) {
+	s, _ := newTestServer(t)
+	replies := roundTrip(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		``,
+		`{"jsonrpc":"2.0","id":"two","method":"ping"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+		`{not json`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"nope"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`,
+	)
+	if len(replies) != 6 {
+		t.Fatalf("expected 6 replies (none for the notification), got %d: %v", len(replies), replies)
+	}
+
+	init := replies[0]["result"].(map[string]interface{})
+	if init["protocolVersion"] != "2025-03-26" || init["serverInfo"].(map[string]interface{})["version"] != "1.2.3" {
+		t.Errorf("unexpected initialize result: %v", init)
+	}
+	if replies[1]["id"] != "two" || replies[1]["result"] == nil {
+		t.Errorf("expected ping to echo its id, got %v", replies[1])
+	}
+	for i, code := range map[int]float64{2: codeMethodNotFound, 3: codeParseError, 4: codeInvalidParams} {
+		if e, ok := replies[i]["error"].(map[string]interface{}); !ok || e["code"] != code {
+			t.Errorf("reply %d: expected error %v, got %v", i, code, replies[i])
+		}
+	}
+	if v := replies[5]["result"].(map[string]interface{})["protocolVersion"]; v != ProtocolVersions[0] {
+		t.Errorf("expected an unknown revision to get %s, got %v", ProtocolVersions[0], v)
+	}
+}
+
+func TestListTools(t *testing.T) {
+	s, _ := newTestServer(t)
+	replies := roundTrip(t, s, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	var result struct {
+		Tools []toolInfo `json:"tools"`
+	}
+	data, _ := json.Marshal(replies[0]["result"])
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("invalid tools/list result: %v", err)
+	}
+	byName := make(map[string]*Schema)
+	for _, info := range result.Tools {
+		byName[info.Name] = info.InputSchema
+	}
+	for _, name := range []string{"create", "update", "close", "list", "ready", "show", "dep_add", "comment_add", "claim", "heartbeat"} {
+		if byName[name] == nil {
+			t.Errorf("missing tool %s", name)
+		}
+	}
+
+	create := byName["create"]
+	if strings.Join(create.Required, ",") != "title" {
+		t.Errorf("expected only title to be required, got %v", create.Required)
+	}
+	if create.Properties["priority"].Type != "integer" || create.Properties["priority"].Default != float64(2) {
+		t.Errorf("unexpected priority schema: %+v", create.Properties["priority"])
+	}
+	if create.Properties["labels"].Items.Type != "string" || len(create.Properties["issue_type"].Enum) != 5 {
+		t.Errorf("unexpected create schema: %+v", create.Properties)
+	}
+	if comment := byName["comment_add"]; comment.Properties["author"].Default != "alice" || strings.Join(comment.Required, ",") != "id,text" {
+		t.Errorf("expected author to default to the actor, got %+v", comment)
+	}
+}
+
+func TestToolCalls(t *testing.T) {
+	s, mutations := newTestServer(t)
+
+	parent := callIssue(t, s, "create", map[string]interface{}{"title": "Parent", "priority": 1})
+	if parent.Priority != 1 || parent.IssueType != types.TypeTask {
+		t.Errorf("expected a P1 task, got %+v", parent)
+	}
+	child := callIssue(t, s, "create", map[string]interface{}{"title": "Child", "issue_type": "bug"})
+	if child.Priority != 2 || child.IssueType != types.TypeBug {
+		t.Errorf("expected a P2 bug, got %+v", child)
+	}
+	if text, isError := call(t, s, "dep_add", map[string]interface{}{"from_id": child.ID, "to_id": parent.ID}); isError {
+		t.Fatalf("dep_add failed: %s", text)
+	}
+
+	text, _ := call(t, s, "ready", nil)
+	if !strings.Contains(text, parent.ID) || strings.Contains(text, child.ID) {
+		t.Errorf("expected only %s to be ready, got %s", parent.ID, text)
+	}
+	text, _ = call(t, s, "show", map[string]interface{}{"id": child.ID})
+	if !strings.Contains(text, `"dependencies"`) || !strings.Contains(text, parent.ID) {
+		t.Errorf("expected show to include the dependency, got %s", text)
+	}
+
+	if text, isError := call(t, s, "claim", map[string]interface{}{}); isError || !strings.Contains(text, parent.ID) || !strings.Contains(text, `"alice"`) {
+		t.Errorf("expected alice to claim %s, got %s", parent.ID, text)
+	}
+	if text, isError := call(t, s, "comment_add", map[string]interface{}{"id": parent.ID, "text": "On it"}); isError || !strings.Contains(text, `"author": "alice"`) {
+		t.Errorf("expected a comment by alice, got %s", text)
+	}
+	notes := "Halfway"
+	if updated := callIssue(t, s, "update", map[string]interface{}{"id": parent.ID, "notes": notes}); updated.Notes != notes {
+		t.Errorf("expected notes to be updated, got %+v", updated)
+	}
+	before := *mutations
+	if closed := callIssue(t, s, "close", map[string]interface{}{"id": parent.ID}); closed.Status != types.StatusClosed {
+		t.Errorf("expected %s closed, got %s", parent.ID, closed.Status)
+	}
+	if *mutations != before+1 {
+		t.Errorf("expected close to report a mutation")
+	}
+
+	before = *mutations
+	for _, tc := range []struct {
+		name string
+		args interface{}
+		want string
+	}{
+		{"show", map[string]interface{}{"id": "test-missing"}, "not found"},
+		{"show", map[string]interface{}{}, "missing required id"},
+		{"create", map[string]interface{}{"title": "X", "colour": "red"}, "unknown field"},
+		{"create", map[string]interface{}{"title": 7}, "invalid arguments"},
+		{"dep_add", map[string]interface{}{"from_id": child.ID, "to_id": parent.ID, "dep_type": "likes"}, "invalid"},
+	} {
+		if text, isError := call(t, s, tc.name, tc.args); !isError || !strings.Contains(text, tc.want) {
+			t.Errorf("%s %v: expected an error about %q, got %q", tc.name, tc.args, tc.want, text)
+		}
+	}
+	if *mutations != before {
+		t.Errorf("failed calls should not report mutations")
+	}
+}
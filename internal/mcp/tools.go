@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// tool is an MCP tool backed by one rpc operation
+type tool struct {
+	name        string // Also the rpc operation
+	description string
+	args        func() interface{} // Fresh rpc Args struct pre-filled with defaults
+	hints       map[string]hint
+	mutates     bool
+}
+
+var (
+	issueTypes = []string{string(types.TypeBug), string(types.TypeFeature), string(types.TypeTask), string(types.TypeEpic), string(types.TypeChore)}
+	depTypes   = []string{string(types.DepBlocks), string(types.DepRelated), string(types.DepParentChild), string(types.DepDiscoveredFrom)}
+	priority   = hint{desc: "0 (critical) to 4 (backlog)"}
+	issueID    = hint{desc: "Issue ID, e.g. beads-a1b2"}
+)
+
+// tools lists the tools in the order clients show them. actor fills in
+// arguments that default to the caller, like the CLI's --actor.
+func tools(actor string) []tool {
+	return []tool{
+		{
+			name:        rpc.OpReady,
+			description: "Find open issues with no blockers, ready to be worked on.",
+			args:        func() interface{} { return &rpc.ReadyArgs{Limit: 10} },
+			hints: map[string]hint{
+				"priority":    priority,
+				"sort_policy": {desc: "How to order results", enum: []string{string(types.SortPolicyHybrid), string(types.SortPolicyPriority), string(types.SortPolicyOldest)}},
+			},
+		},
+		{
+			name:        rpc.OpClaim,
+			description: "Atomically take the highest-priority ready issue: sets it in_progress, assigns it and starts a lease that expires unless renewed with heartbeat.",
+			args:        func() interface{} { return &rpc.ClaimArgs{Agent: actor} },
+			hints: map[string]hint{
+				"agent":    {desc: "Who is claiming the work"},
+				"ttl":      {desc: "Lease duration, e.g. 30m"},
+				"priority": {desc: "Only claim issues at this priority"},
+			},
+			mutates: true,
+		},
+		{
+			name:        rpc.OpHeartbeat,
+			description: "Renew the lease on a claimed issue so it isn't handed to another agent.",
+			args:        func() interface{} { return &rpc.HeartbeatArgs{Agent: actor} },
+			hints: map[string]hint{
+				"id":    issueID,
+				"agent": {desc: "Lease holder"},
+				"ttl":   {desc: "Lease extension, e.g. 30m"},
+			},
+			mutates: true,
+		},
+		{
+			name:        rpc.OpList,
+			description: "List issues, optionally filtered.",
+			args:        func() interface{} { return &rpc.ListArgs{Limit: 50} },
+			hints: map[string]hint{
+				"query":      {desc: "Text to find in titles, descriptions or IDs"},
+				"status":     {desc: "open, in_progress, blocked, closed or a custom workflow status"},
+				"priority":   priority,
+				"issue_type": {enum: issueTypes},
+				"label":      {desc: "Deprecated: use labels"},
+				"labels":     {desc: "Issues must have all of these labels"},
+				"labels_any": {desc: "Issues must have at least one of these labels"},
+				"filter":     {desc: "Filter expression as in 'beads list', e.g. \"priority<=1 -status:closed\""},
+			},
+		},
+		{
+			name:        rpc.OpShow,
+			description: "Show an issue with its labels, dependencies and dependents.",
+			args:        func() interface{} { return &rpc.ShowArgs{} },
+			hints:       map[string]hint{"id": issueID},
+		},
+		{
+			name:        rpc.OpCreate,
+			description: "Create an issue.",
+			args:        func() interface{} { return &rpc.CreateArgs{IssueType: string(types.TypeTask), Priority: 2} },
+			hints: map[string]hint{
+				"id":                  {desc: "Explicit ID; normally generated"},
+				"issue_type":          {enum: issueTypes},
+				"priority":            priority,
+				"acceptance_criteria": {desc: "How to tell the work is done"},
+				"dependencies":        {desc: "Issues this one depends on, as 'id' (blocks) or 'type:id', e.g. discovered-from:beads-a1b2"},
+			},
+			mutates: true,
+		},
+		{
+			name:        rpc.OpUpdate,
+			description: "Update fields of an issue. Only the given fields change.",
+			args:        func() interface{} { return &rpc.UpdateArgs{} },
+			hints: map[string]hint{
+				"id":       issueID,
+				"status":   {desc: "open, in_progress, blocked, closed or a custom workflow status"},
+				"priority": priority,
+			},
+			mutates: true,
+		},
+		{
+			name:        rpc.OpClose,
+			description: "Close an issue once the work is done.",
+			args:        func() interface{} { return &rpc.CloseArgs{Reason: "Completed"} },
+			hints:       map[string]hint{"id": issueID},
+			mutates:     true,
+		},
+		{
+			name:        rpc.OpDepAdd,
+			description: "Add a dependency: from_id depends on to_id.",
+			args:        func() interface{} { return &rpc.DepAddArgs{DepType: string(types.DepBlocks)} },
+			hints: map[string]hint{
+				"from_id":  {desc: "The dependent issue"},
+				"to_id":    {desc: "The issue it depends on"},
+				"dep_type": {desc: "blocks keeps from_id out of ready work until to_id closes", enum: depTypes},
+			},
+			mutates: true,
+		},
+		{
+			name:        rpc.OpCommentAdd,
+			description: "Add a comment to an issue.",
+			args:        func() interface{} { return &rpc.CommentAddArgs{Author: actor} },
+			hints:       map[string]hint{"id": issueID},
+			mutates:     true,
+		},
+	}
+}
@@ -0,0 +1,369 @@
+// Package merge implements a three-way merge of issues JSONL files, used by
+// `beads merge-driver` so concurrent branches don't conflict on .beads/*.jsonl.
+//
+// Issues are matched by ID and merged field by field. A field changed on only
+// one side takes that side's value; a field changed differently on both sides
+// takes the value from the side with the later updated_at and the discarded
+// value is recorded in a comment on the issue. Labels, dependencies and
+// comments are the union of both sides, so a removal on one side does not
+// carry over.
+package merge
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// ConflictAuthor is the author of comments recording merge conflicts
+const ConflictAuthor = "beads-merge"
+
+// Conflict is a change that could not be merged cleanly
+type Conflict struct {
+	IssueID string
+	Field   string // Empty when the whole issue conflicts (deleted vs. modified)
+	Message string
+}
+
+// Result is the outcome of a merge
+type Result struct {
+	Issues    []*types.Issue // Sorted by ID
+	Conflicts []Conflict
+}
+
+// specialFields are not merged as plain values but handled explicitly
+var specialFields = map[string]bool{
+	"labels":       true,
+	"dependencies": true,
+	"comments":     true,
+	"created_at":   true, // Earliest wins
+	"updated_at":   true, // Latest wins
+	"content_hash": true,
+	"closed_at":    true, // Follows status
+}
+
+// Merge performs a three-way merge of issues. base may be empty when the file
+// was added on both sides. now stamps the conflict comments.
+func Merge(base, ours, theirs []*types.Issue, now time.Time) (*Result, error) {
+	baseByID, oursByID, theirsByID := indexByID(base), indexByID(ours), indexByID(theirs)
+
+	ids := make(map[string]bool)
+	for _, m := range []map[string]*types.Issue{baseByID, oursByID, theirsByID} {
+		for id := range m {
+			ids[id] = true
+		}
+	}
+
+	result := &Result{}
+	for id := range ids {
+		b, o, t := baseByID[id], oursByID[id], theirsByID[id]
+		var merged *types.Issue
+		var conflicts []Conflict
+		var err error
+
+		switch {
+		case o == nil && t == nil:
+			// Deleted on both sides
+			continue
+		case o == nil || t == nil:
+			merged, conflicts, err = mergeDeletion(b, o, t)
+		default:
+			merged, conflicts, err = mergeIssue(b, o, t)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge %s: %w", id, err)
+		}
+		if merged == nil {
+			continue
+		}
+		for _, c := range conflicts {
+			merged.Comments = appendComment(merged.Comments, &types.Comment{
+				IssueID:   merged.ID,
+				Author:    ConflictAuthor,
+				Text:      c.Message,
+				CreatedAt: now,
+			})
+		}
+		result.Issues = append(result.Issues, merged)
+		result.Conflicts = append(result.Conflicts, conflicts...)
+	}
+
+	sort.Slice(result.Issues, func(i, j int) bool { return result.Issues[i].ID < result.Issues[j].ID })
+	sort.SliceStable(result.Conflicts, func(i, j int) bool { return result.Conflicts[i].IssueID < result.Conflicts[j].IssueID })
+	return result, nil
+}
+
+// mergeDeletion handles an issue missing from exactly one side
+func mergeDeletion(base, ours, theirs *types.Issue) (*types.Issue, []Conflict, error) {
+	kept, side := ours, "ours"
+	if kept == nil {
+		kept, side = theirs, "theirs"
+	}
+	if base == nil {
+		// Added on one side only
+		return kept, nil, nil
+	}
+	same, err := sameIssue(base, kept)
+	if err != nil {
+		return nil, nil, err
+	}
+	if same {
+		// Deleted on one side, untouched on the other
+		return nil, nil, nil
+	}
+	deletedOn := "theirs"
+	if side == "theirs" {
+		deletedOn = "ours"
+	}
+	return kept, []Conflict{{
+		IssueID: kept.ID,
+		Message: fmt.Sprintf("Merge conflict: issue was deleted on %s but modified on %s; kept the modified issue", deletedOn, side),
+	}}, nil
+}
+
+// mergeIssue merges an issue present on both sides. base is nil when both
+// sides added it independently.
+func mergeIssue(base, ours, theirs *types.Issue) (*types.Issue, []Conflict, error) {
+	b, err := fieldMap(base)
+	if err != nil {
+		return nil, nil, err
+	}
+	o, err := fieldMap(ours)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := fieldMap(theirs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	theirsNewer := theirs.UpdatedAt.After(ours.UpdatedAt)
+	merged := make(map[string]json.RawMessage)
+	var conflicts []Conflict
+	for _, key := range unionKeys(o, t) {
+		if specialFields[key] {
+			continue
+		}
+		ov, tv, bv := o[key], t[key], b[key]
+		switch {
+		case bytes.Equal(ov, tv):
+			setRaw(merged, key, ov)
+		case base != nil && bytes.Equal(ov, bv):
+			setRaw(merged, key, tv)
+		case base != nil && bytes.Equal(tv, bv):
+			setRaw(merged, key, ov)
+		default:
+			// Changed on both sides: last writer wins
+			kept, keptSide, dropped, droppedSide := ov, "ours", tv, "theirs"
+			if theirsNewer {
+				kept, keptSide, dropped, droppedSide = tv, "theirs", ov, "ours"
+			}
+			setRaw(merged, key, kept)
+			conflicts = append(conflicts, Conflict{
+				IssueID: ours.ID,
+				Field:   key,
+				Message: fmt.Sprintf("Merge conflict on %s: kept %s from %s (updated later), discarded %s from %s",
+					key, displayValue(kept), keptSide, displayValue(dropped), droppedSide),
+			})
+		}
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, nil, err
+	}
+
+	issue.CreatedAt = ours.CreatedAt
+	if theirs.CreatedAt.Before(ours.CreatedAt) {
+		issue.CreatedAt = theirs.CreatedAt
+	}
+	issue.UpdatedAt = ours.UpdatedAt
+	if theirsNewer {
+		issue.UpdatedAt = theirs.UpdatedAt
+	}
+	issue.ClosedAt = nil
+	if issue.Status == types.StatusClosed {
+		// Keep the close time from a side that has the issue closed
+		switch {
+		case ours.Status == types.StatusClosed && theirs.Status == types.StatusClosed:
+			issue.ClosedAt = ours.ClosedAt
+			if theirsNewer {
+				issue.ClosedAt = theirs.ClosedAt
+			}
+		case ours.Status == types.StatusClosed:
+			issue.ClosedAt = ours.ClosedAt
+		default:
+			issue.ClosedAt = theirs.ClosedAt
+		}
+	}
+
+	issue.Labels = mergeSet(ours.Labels, theirs.Labels, func(s string) string { return s })
+	sort.Strings(issue.Labels)
+	issue.Dependencies = mergeSet(ours.Dependencies, theirs.Dependencies, func(d *types.Dependency) string {
+		return d.DependsOnID + " " + string(d.Type)
+	})
+	issue.Comments = mergeSet(ours.Comments, theirs.Comments, commentKey)
+	sort.SliceStable(issue.Comments, func(i, j int) bool {
+		return issue.Comments[i].CreatedAt.Before(issue.Comments[j].CreatedAt)
+	})
+
+	if ours.ContentHash != "" || theirs.ContentHash != "" {
+		issue.ContentHash = issue.ComputeContentHash()
+	}
+	return &issue, conflicts, nil
+}
+
+// mergeSet returns the union of two versions of a set, keyed by key. A
+// removal on one side does not carry over, so nothing is lost in a merge
+func mergeSet[T any](ours, theirs []T, key func(T) string) []T {
+	var out []T
+	seen := make(map[string]bool)
+	for _, side := range [][]T{ours, theirs} {
+		for _, item := range side {
+			k := key(item)
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// commentKey identifies a comment across clones, matching the importer
+func commentKey(c *types.Comment) string {
+	return c.Author + ":" + strings.TrimSpace(c.Text)
+}
+
+func appendComment(comments []*types.Comment, c *types.Comment) []*types.Comment {
+	for _, existing := range comments {
+		if commentKey(existing) == commentKey(c) {
+			return comments
+		}
+	}
+	return append(comments, c)
+}
+
+// fieldMap returns an issue's JSON fields; nil yields an empty map
+func fieldMap(issue *types.Issue) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if issue == nil {
+		return fields, nil
+	}
+	data, err := json.Marshal(issue)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// sameIssue reports whether two versions of an issue are identical
+func sameIssue(a, b *types.Issue) (bool, error) {
+	ad, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+	bd, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(ad, bd), nil
+}
+
+func setRaw(m map[string]json.RawMessage, key string, value json.RawMessage) {
+	if value != nil {
+		m[key] = value
+	}
+}
+
+func unionKeys(maps ...map[string]json.RawMessage) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// displayValue renders a JSON value for a conflict comment
+func displayValue(raw json.RawMessage) string {
+	if raw == nil {
+		return "(unset)"
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if s == "" {
+			return "(empty)"
+		}
+		const maxLen = 80
+		if len(s) > maxLen {
+			s = s[:maxLen] + "…"
+		}
+		return fmt.Sprintf("%q", s)
+	}
+	return string(raw)
+}
+
+func indexByID(issues []*types.Issue) map[string]*types.Issue {
+	m := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		m[issue.ID] = issue
+	}
+	return m
+}
+
+// ParseJSONL reads issues from JSONL, skipping blank lines
+func ParseJSONL(data []byte) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		issues = append(issues, &issue)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// WriteJSONL encodes issues one per line, the way export does
+func WriteJSONL(issues []*types.Issue) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, issue := range issues {
+		if err := encoder.Encode(issue); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", issue.ID, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,228 @@
+package merge
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+var (
+	t0  = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	now = time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC)
+)
+
+func newIssue(id, title string) *types.Issue {
+	return &types.Issue{
+		ID: id, Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		CreatedAt: t0, UpdatedAt: t0,
+	}
+}
+
+// edit returns a copy of issue updated at t0 + minutes
+func edit(issue *types.Issue, minutes int, change func(*types.Issue)) *types.Issue {
+	c := *issue
+	c.Labels = append([]string(nil), issue.Labels...)
+	c.Dependencies = append([]*types.Dependency(nil), issue.Dependencies...)
+	c.Comments = append([]*types.Comment(nil), issue.Comments...)
+	change(&c)
+	c.UpdatedAt = t0.Add(time.Duration(minutes) * time.Minute)
+	return &c
+}
+
+func mergeOne(t *testing.T, base, ours, theirs *types.Issue) (*types.Issue, []Conflict) {
+	t.Helper()
+	wrap := func(i *types.Issue) []*types.Issue {
+		if i == nil {
+			return nil
+		}
+		return []*types.Issue{i}
+	}
+	result, err := Merge(wrap(base), wrap(ours), wrap(theirs), now)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	switch len(result.Issues) {
+	case 0:
+		return nil, result.Conflicts
+	case 1:
+		return result.Issues[0], result.Conflicts
+	}
+	t.Fatalf("expected at most one issue, got %d", len(result.Issues))
+	return nil, nil
+}
+
+func TestMergeDisjointFields(t *testing.T) {
+	base := newIssue("bd-1", "Login fails")
+	ours := edit(base, 1, func(i *types.Issue) { i.Status = types.StatusInProgress; i.Assignee = "alice" })
+	theirs := edit(base, 2, func(i *types.Issue) { i.Priority = 0; i.Description = "Safari only" })
+
+	merged, conflicts := mergeOne(t, base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Errorf("unexpected conflicts: %+v", conflicts)
+	}
+	if merged.Status != types.StatusInProgress || merged.Assignee != "alice" || merged.Priority != 0 || merged.Description != "Safari only" {
+		t.Errorf("changes not combined: %+v", merged)
+	}
+	if !merged.UpdatedAt.Equal(theirs.UpdatedAt) {
+		t.Errorf("UpdatedAt = %v, want the later %v", merged.UpdatedAt, theirs.UpdatedAt)
+	}
+	if len(merged.Comments) != 0 {
+		t.Errorf("unexpected comments: %+v", merged.Comments)
+	}
+}
+
+func TestMergeConflictLastWriterWins(t *testing.T) {
+	base := newIssue("bd-1", "Login fails")
+	ours := edit(base, 5, func(i *types.Issue) { i.Title = "Login fails on Safari" })
+	theirs := edit(base, 2, func(i *types.Issue) { i.Title = "Login fails on mobile" })
+
+	merged, conflicts := mergeOne(t, base, ours, theirs)
+	if merged.Title != "Login fails on Safari" {
+		t.Errorf("Title = %q, want ours (updated later)", merged.Title)
+	}
+	if len(conflicts) != 1 || conflicts[0].Field != "title" {
+		t.Fatalf("conflicts = %+v", conflicts)
+	}
+	if len(merged.Comments) != 1 {
+		t.Fatalf("expected a conflict comment, got %+v", merged.Comments)
+	}
+	comment := merged.Comments[0]
+	if comment.Author != ConflictAuthor || !strings.Contains(comment.Text, `discarded "Login fails on mobile" from theirs`) {
+		t.Errorf("comment = %+v", comment)
+	}
+
+	// Swapping the timestamps flips the winner
+	theirs.UpdatedAt = t0.Add(10 * time.Minute)
+	merged, _ = mergeOne(t, base, ours, theirs)
+	if merged.Title != "Login fails on mobile" {
+		t.Errorf("Title = %q, want theirs (updated later)", merged.Title)
+	}
+}
+
+func TestMergeStatusAndClosedAt(t *testing.T) {
+	base := newIssue("bd-1", "Task")
+	closedAt := t0.Add(3 * time.Minute)
+	ours := edit(base, 3, func(i *types.Issue) { i.Status = types.StatusClosed; i.ClosedAt = &closedAt })
+	theirs := edit(base, 4, func(i *types.Issue) { i.Notes = "context" })
+
+	merged, _ := mergeOne(t, base, ours, theirs)
+	if merged.Status != types.StatusClosed || merged.ClosedAt == nil || !merged.ClosedAt.Equal(closedAt) {
+		t.Errorf("expected closed at %v, got %s %v", closedAt, merged.Status, merged.ClosedAt)
+	}
+	if merged.Notes != "context" {
+		t.Errorf("Notes = %q", merged.Notes)
+	}
+}
+
+func TestMergeSets(t *testing.T) {
+	base := newIssue("bd-1", "Task")
+	base.Labels = []string{"backend", "old"}
+	base.Dependencies = []*types.Dependency{{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks}}
+	base.Comments = []*types.Comment{{Author: "alice", Text: "first", CreatedAt: t0}}
+
+	ours := edit(base, 1, func(i *types.Issue) {
+		i.Labels = []string{"backend", "frontend"} // removed old, added frontend
+		i.Dependencies = append(i.Dependencies, &types.Dependency{IssueID: "bd-1", DependsOnID: "bd-3", Type: types.DepRelated})
+		i.Comments = append(i.Comments, &types.Comment{Author: "alice", Text: "ours", CreatedAt: t0.Add(time.Minute)})
+	})
+	theirs := edit(base, 2, func(i *types.Issue) {
+		i.Labels = []string{"backend", "old", "urgent"}
+		i.Dependencies = nil // removed bd-2
+		i.Comments = append(i.Comments, &types.Comment{Author: "bob", Text: "theirs", CreatedAt: t0.Add(2 * time.Minute)})
+	})
+
+	merged, conflicts := mergeOne(t, base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Errorf("unexpected conflicts: %+v", conflicts)
+	}
+	// Sets are a union: a removal on one branch does not carry over
+	if !reflect.DeepEqual(merged.Labels, []string{"backend", "frontend", "old", "urgent"}) {
+		t.Errorf("Labels = %v", merged.Labels)
+	}
+	var deps []string
+	for _, d := range merged.Dependencies {
+		deps = append(deps, d.DependsOnID)
+	}
+	if !reflect.DeepEqual(deps, []string{"bd-2", "bd-3"}) {
+		t.Errorf("Dependencies = %v", deps)
+	}
+	var texts []string
+	for _, c := range merged.Comments {
+		texts = append(texts, c.Text)
+	}
+	if !reflect.DeepEqual(texts, []string{"first", "ours", "theirs"}) {
+		t.Errorf("Comments = %v", texts)
+	}
+}
+
+func TestMergeAddsAndDeletes(t *testing.T) {
+	base := newIssue("bd-1", "Task")
+
+	// Added on one side
+	added := newIssue("bd-2", "New")
+	if merged, _ := mergeOne(t, nil, nil, added); merged == nil || merged.ID != "bd-2" {
+		t.Errorf("expected added issue, got %+v", merged)
+	}
+
+	// Deleted on one side, untouched on the other
+	if merged, conflicts := mergeOne(t, base, nil, base); merged != nil || len(conflicts) != 0 {
+		t.Errorf("expected deletion, got %+v %+v", merged, conflicts)
+	}
+
+	// Deleted on one side, modified on the other
+	modified := edit(base, 1, func(i *types.Issue) { i.Priority = 0 })
+	merged, conflicts := mergeOne(t, base, modified, nil)
+	if merged == nil || merged.Priority != 0 {
+		t.Fatalf("expected modified issue to be kept, got %+v", merged)
+	}
+	if len(conflicts) != 1 || !strings.Contains(merged.Comments[0].Text, "deleted on theirs but modified on ours") {
+		t.Errorf("conflicts = %+v, comments = %+v", conflicts, merged.Comments)
+	}
+
+	// Added on both sides independently: differing fields conflict, created_at does not
+	ours := newIssue("bd-3", "Same")
+	theirs := edit(newIssue("bd-3", "Same"), 1, func(i *types.Issue) { i.CreatedAt = t0.Add(-time.Hour); i.Priority = 1 })
+	merged, conflicts = mergeOne(t, nil, ours, theirs)
+	if !merged.CreatedAt.Equal(t0.Add(-time.Hour)) || merged.Priority != 1 {
+		t.Errorf("unexpected merge of independent adds: %+v", merged)
+	}
+	if len(conflicts) != 1 || conflicts[0].Field != "priority" {
+		t.Errorf("conflicts = %+v", conflicts)
+	}
+}
+
+func TestMergeIdempotentConflictComment(t *testing.T) {
+	base := newIssue("bd-1", "Task")
+	ours := edit(base, 2, func(i *types.Issue) { i.Assignee = "alice" })
+	theirs := edit(base, 1, func(i *types.Issue) { i.Assignee = "bob" })
+
+	first, _ := mergeOne(t, base, ours, theirs)
+	// Re-running the merge with the previous result (as after a rebase) does
+	// not duplicate the comment
+	again, _ := mergeOne(t, base, first, theirs)
+	if len(again.Comments) != 1 {
+		t.Errorf("expected one conflict comment, got %d", len(again.Comments))
+	}
+}
+
+func TestJSONLRoundTrip(t *testing.T) {
+	issues := []*types.Issue{newIssue("bd-1", "One"), newIssue("bd-2", "Two <b>")}
+	data, err := WriteJSONL(issues)
+	if err != nil {
+		t.Fatalf("WriteJSONL failed: %v", err)
+	}
+	parsed, err := ParseJSONL(append(data, '\n'))
+	if err != nil {
+		t.Fatalf("ParseJSONL failed: %v", err)
+	}
+	if len(parsed) != 2 || parsed[1].Title != "Two <b>" {
+		t.Errorf("round trip = %+v", parsed)
+	}
+
+	if _, err := ParseJSONL([]byte("<<<<<<< HEAD\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected parse error with line number, got %v", err)
+	}
+}
@@ -0,0 +1,386 @@
+// Package plan estimates how long an epic will take from the estimates on its
+// issues and the dependencies between them.
+package plan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// maxTreeDepth bounds the dependency tree walked to find an epic's issues
+const maxTreeDepth = 50
+
+// Task is one issue in an epic's plan. Times are in minutes of work from now.
+type Task struct {
+	ID               string       `json:"id"`
+	Title            string       `json:"title"`
+	Status           types.Status `json:"status"`
+	Priority         int          `json:"priority"`
+	EstimatedMinutes *int         `json:"estimated_minutes,omitempty"`
+	RemainingMinutes int          `json:"remaining_minutes"` // 0 once done or when unestimated
+	DependsOn        []string     `json:"depends_on,omitempty"`
+
+	// Critical path method, with unlimited workers
+	EarliestStart  int  `json:"earliest_start"`
+	EarliestFinish int  `json:"earliest_finish"`
+	LatestStart    int  `json:"latest_start"`
+	LatestFinish   int  `json:"latest_finish"`
+	Slack          int  `json:"slack"`
+	Critical       bool `json:"critical"`
+
+	// Schedule with the plan's number of workers. Worker is 0 for tasks with
+	// no remaining work, which take no one's time.
+	Start  int `json:"start"`
+	Finish int `json:"finish"`
+	Worker int `json:"worker"`
+}
+
+// Plan is the schedule for the open work under an epic
+type Plan struct {
+	EpicID           string   `json:"epic_id"`
+	EpicTitle        string   `json:"epic_title"`
+	Workers          int      `json:"workers"`
+	TotalMinutes     int      `json:"total_minutes"`     // All estimates, including done work
+	RemainingMinutes int      `json:"remaining_minutes"` // Estimates of work not yet done
+	CriticalMinutes  int      `json:"critical_minutes"`  // Earliest finish with unlimited workers
+	FinishMinutes    int      `json:"finish_minutes"`    // Earliest finish with Workers workers
+	CriticalPath     []string `json:"critical_path"`
+	Unestimated      []string `json:"unestimated"` // Open issues without an estimate, counted as 0
+	Tasks            []*Task  `json:"tasks"`       // In schedule order
+}
+
+// Build plans the issues under epicID: its children, their children and so
+// on, following parent-child dependencies found with GetDependencyTree.
+// Issues outside the epic that block its issues are not scheduled.
+func Build(ctx context.Context, store storage.Storage, epicID string, workers int) (*Plan, error) {
+	epic, err := store.GetIssue(ctx, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", epicID, err)
+	}
+	if epic == nil {
+		return nil, fmt.Errorf("issue %s not found", epicID)
+	}
+
+	// Everything that depends on the epic, directly or not; Compute keeps the
+	// issues reached through parent-child edges
+	tree, err := store.GetDependencyTree(ctx, epicID, maxTreeDepth, false, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency tree: %w", err)
+	}
+	var issues []*types.Issue
+	var deps []*types.Dependency
+	for _, node := range tree {
+		if node.ID == epicID {
+			continue
+		}
+		issue := node.Issue
+		issues = append(issues, &issue)
+		records, err := store.GetDependencyRecords(ctx, node.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies of %s: %w", node.ID, err)
+		}
+		deps = append(deps, records...)
+	}
+
+	return Compute(epic, issues, deps, workers)
+}
+
+// Compute plans the issues under epic. issues may include unrelated issues,
+// which are ignored; deps are the dependency records of those issues.
+//
+// An issue precedes another if it blocks it, or if it is its child: a parent
+// is done only when all of its children are. Each issue takes its estimate,
+// or no time once it is done. With unlimited workers the earliest finish is
+// the length of the critical path; the schedule for a fixed number of workers
+// starts ready issues with the least slack first.
+func Compute(epic *types.Issue, issues []*types.Issue, deps []*types.Dependency, workers int) (*Plan, error) {
+	if workers < 1 {
+		return nil, fmt.Errorf("workers must be at least 1, got %d", workers)
+	}
+
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+
+	// The epic's issues are those connected to it by a chain of parent-child edges
+	members := map[string]bool{epic.ID: true}
+	for changed := true; changed; {
+		changed = false
+		for _, dep := range deps {
+			if dep.Type == types.DepParentChild && members[dep.DependsOnID] && !members[dep.IssueID] && byID[dep.IssueID] != nil {
+				members[dep.IssueID] = true
+				changed = true
+			}
+		}
+	}
+	delete(members, epic.ID)
+
+	p := &Plan{
+		EpicID:       epic.ID,
+		EpicTitle:    epic.Title,
+		Workers:      workers,
+		CriticalPath: []string{},
+		Unestimated:  []string{},
+		Tasks:        []*Task{},
+	}
+	tasks := make(map[string]*Task, len(members))
+	var ids []string
+	for id := range members {
+		issue := byID[id]
+		task := &Task{
+			ID:               issue.ID,
+			Title:            issue.Title,
+			Status:           issue.Status,
+			Priority:         issue.Priority,
+			EstimatedMinutes: issue.EstimatedMinutes,
+		}
+		if issue.EstimatedMinutes != nil {
+			p.TotalMinutes += *issue.EstimatedMinutes
+		}
+		if issue.Status.Category() != types.CategoryDone {
+			if issue.EstimatedMinutes == nil {
+				p.Unestimated = append(p.Unestimated, issue.ID)
+			} else {
+				task.RemainingMinutes = *issue.EstimatedMinutes
+			}
+		}
+		p.RemainingMinutes += task.RemainingMinutes
+		tasks[id] = task
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	sort.Strings(p.Unestimated)
+
+	// Precedence edges between the epic's issues
+	successors := make(map[string][]string)
+	seen := make(map[[2]string]bool)
+	for _, dep := range deps {
+		before, after := dep.DependsOnID, dep.IssueID
+		switch dep.Type {
+		case types.DepBlocks:
+		case types.DepParentChild:
+			before, after = after, before
+		default:
+			continue
+		}
+		if tasks[before] == nil || tasks[after] == nil || seen[[2]string{before, after}] {
+			continue
+		}
+		seen[[2]string{before, after}] = true
+		successors[before] = append(successors[before], after)
+		tasks[after].DependsOn = append(tasks[after].DependsOn, before)
+	}
+	for _, id := range ids {
+		sort.Strings(successors[id])
+		sort.Strings(tasks[id].DependsOn)
+	}
+
+	order, err := topoSort(ids, tasks, successors)
+	if err != nil {
+		return nil, err
+	}
+
+	// Forward pass: earliest start and finish
+	for _, id := range order {
+		task := tasks[id]
+		for _, pred := range task.DependsOn {
+			task.EarliestStart = max(task.EarliestStart, tasks[pred].EarliestFinish)
+		}
+		task.EarliestFinish = task.EarliestStart + task.RemainingMinutes
+		p.CriticalMinutes = max(p.CriticalMinutes, task.EarliestFinish)
+	}
+
+	// Backward pass: latest start and finish that keep the critical path length
+	for i := len(order) - 1; i >= 0; i-- {
+		task := tasks[order[i]]
+		task.LatestFinish = p.CriticalMinutes
+		for _, succ := range successors[task.ID] {
+			task.LatestFinish = min(task.LatestFinish, tasks[succ].LatestStart)
+		}
+		task.LatestStart = task.LatestFinish - task.RemainingMinutes
+		task.Slack = task.LatestStart - task.EarliestStart
+		task.Critical = task.Slack == 0 && task.RemainingMinutes > 0
+	}
+
+	p.CriticalPath = criticalPath(order, tasks)
+	p.FinishMinutes = schedule(order, tasks, successors, workers)
+
+	for _, id := range ids {
+		p.Tasks = append(p.Tasks, tasks[id])
+	}
+	sort.SliceStable(p.Tasks, func(i, j int) bool {
+		a, b := p.Tasks[i], p.Tasks[j]
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		if a.Finish != b.Finish {
+			return a.Finish < b.Finish
+		}
+		return a.ID < b.ID
+	})
+	return p, nil
+}
+
+// topoSort orders tasks so every task comes after the tasks it depends on,
+// taking ready tasks in ID order
+func topoSort(ids []string, tasks map[string]*Task, successors map[string][]string) ([]string, error) {
+	indegree := make(map[string]int, len(ids))
+	var ready []string
+	for _, id := range ids {
+		indegree[id] = len(tasks[id].DependsOn)
+		if indegree[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	order := make([]string, 0, len(ids))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+		for _, succ := range successors[id] {
+			indegree[succ]--
+			if indegree[succ] == 0 {
+				ready = append(ready, succ)
+			}
+		}
+		sort.Strings(ready)
+	}
+
+	if len(order) < len(ids) {
+		var cyclic []string
+		for _, id := range ids {
+			if indegree[id] > 0 {
+				cyclic = append(cyclic, id)
+			}
+		}
+		return nil, fmt.Errorf("dependency cycle among %s (see 'beads dep cycles')", strings.Join(cyclic, ", "))
+	}
+	return order, nil
+}
+
+// criticalPath follows zero-slack tasks back from the last to finish. Tasks
+// with no remaining work are left out.
+func criticalPath(order []string, tasks map[string]*Task) []string {
+	var last *Task
+	for _, id := range order {
+		task := tasks[id]
+		if task.Critical && (last == nil || task.EarliestFinish > last.EarliestFinish) {
+			last = task
+		}
+	}
+
+	var path []string
+	for task := last; task != nil; {
+		if task.RemainingMinutes > 0 {
+			path = append(path, task.ID)
+		}
+		var prev *Task
+		for _, pred := range task.DependsOn {
+			candidate := tasks[pred]
+			if candidate.Slack == 0 && candidate.EarliestFinish == task.EarliestStart {
+				prev = candidate
+				break
+			}
+		}
+		task = prev
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	if path == nil {
+		path = []string{}
+	}
+	return path
+}
+
+// schedule assigns tasks to workers as they become ready, least slack first,
+// and returns when the last one finishes. Tasks with no remaining work finish
+// as soon as they are ready without taking a worker.
+func schedule(order []string, tasks map[string]*Task, successors map[string][]string, workers int) int {
+	waiting := make(map[string]int, len(order))
+	var ready []*Task
+	for _, id := range order {
+		waiting[id] = len(tasks[id].DependsOn)
+		if waiting[id] == 0 {
+			ready = append(ready, tasks[id])
+		}
+	}
+
+	freeAt := make([]int, workers) // When each worker finishes its current task
+	var running []*Task
+	now, finish := 0, 0
+	complete := func(task *Task) {
+		finish = max(finish, task.Finish)
+		for _, succ := range successors[task.ID] {
+			waiting[succ]--
+			if waiting[succ] == 0 {
+				ready = append(ready, tasks[succ])
+			}
+		}
+	}
+
+	for len(ready) > 0 || len(running) > 0 {
+		// Start what can start now
+		for len(ready) > 0 {
+			sort.SliceStable(ready, func(i, j int) bool {
+				a, b := ready[i], ready[j]
+				if a.Slack != b.Slack {
+					return a.Slack < b.Slack
+				}
+				if a.Priority != b.Priority {
+					return a.Priority < b.Priority
+				}
+				return a.ID < b.ID
+			})
+			task := ready[0]
+			if task.RemainingMinutes == 0 {
+				ready = ready[1:]
+				task.Start, task.Finish = now, now
+				complete(task)
+				continue
+			}
+			worker := -1
+			for w, at := range freeAt {
+				if at <= now {
+					worker = w
+					break
+				}
+			}
+			if worker < 0 {
+				break
+			}
+			ready = ready[1:]
+			task.Start, task.Finish, task.Worker = now, now+task.RemainingMinutes, worker+1
+			freeAt[worker] = task.Finish
+			running = append(running, task)
+		}
+		if len(running) == 0 {
+			break
+		}
+
+		// Advance to the next finish
+		next := running[0].Finish
+		for _, task := range running[1:] {
+			next = min(next, task.Finish)
+		}
+		now = next
+		var still []*Task
+		for _, task := range running {
+			if task.Finish <= now {
+				complete(task)
+			} else {
+				still = append(still, task)
+			}
+		}
+		running = still
+	}
+	return finish
+}
@@ -0,0 +1,183 @@
+package plan
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func minutes(m int) *int { return &m }
+
+func child(id, parent string) *types.Dependency {
+	return &types.Dependency{IssueID: id, DependsOnID: parent, Type: types.DepParentChild}
+}
+
+func blocks(blocker, blocked string) *types.Dependency {
+	return &types.Dependency{IssueID: blocked, DependsOnID: blocker, Type: types.DepBlocks}
+}
+
+func taskByID(t *testing.T, p *Plan, id string) *Task {
+	t.Helper()
+	for _, task := range p.Tasks {
+		if task.ID == id {
+			return task
+		}
+	}
+	t.Fatalf("no task %s in plan", id)
+	return nil
+}
+
+// epic e with a -> b -> d and c -> d, where c is short, plus closed and
+// unestimated issues and an unrelated one
+func testIssues() (*types.Issue, []*types.Issue, []*types.Dependency) {
+	epic := &types.Issue{ID: "e", Title: "Epic", Status: types.StatusOpen, IssueType: types.TypeEpic}
+	issues := []*types.Issue{
+		{ID: "a", Title: "A", Status: types.StatusClosed, EstimatedMinutes: minutes(60)},
+		{ID: "b", Title: "B", Status: types.StatusInProgress, EstimatedMinutes: minutes(120)},
+		{ID: "c", Title: "C", Status: types.StatusOpen, EstimatedMinutes: minutes(30)},
+		{ID: "d", Title: "D", Status: types.StatusOpen, EstimatedMinutes: minutes(60)},
+		{ID: "u", Title: "Unestimated", Status: types.StatusOpen},
+		{ID: "x", Title: "Elsewhere", Status: types.StatusOpen, EstimatedMinutes: minutes(600)},
+	}
+	deps := []*types.Dependency{
+		child("a", "e"), child("b", "e"), child("c", "e"), child("d", "e"), child("u", "e"),
+		blocks("a", "b"), blocks("b", "d"), blocks("c", "d"),
+		blocks("x", "d"), // Outside the epic: not scheduled
+		{IssueID: "u", DependsOnID: "c", Type: types.DepRelated},
+	}
+	return epic, issues, deps
+}
+
+func TestCompute(t *testing.T) {
+	epic, issues, deps := testIssues()
+	p, err := Compute(epic, issues, deps, 1)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+
+	if p.TotalMinutes != 270 || p.RemainingMinutes != 210 {
+		t.Errorf("expected 270 total and 210 remaining minutes, got %d and %d", p.TotalMinutes, p.RemainingMinutes)
+	}
+	if p.CriticalMinutes != 180 {
+		t.Errorf("expected a 180 minute critical path, got %d", p.CriticalMinutes)
+	}
+	if want := []string{"b", "d"}; !reflect.DeepEqual(p.CriticalPath, want) {
+		t.Errorf("expected critical path %v, got %v", want, p.CriticalPath)
+	}
+	if want := []string{"u"}; !reflect.DeepEqual(p.Unestimated, want) {
+		t.Errorf("expected unestimated %v, got %v", want, p.Unestimated)
+	}
+	if len(p.Tasks) != 5 {
+		t.Fatalf("expected the epic's 5 issues, got %d", len(p.Tasks))
+	}
+
+	c := taskByID(t, p, "c")
+	if c.Slack != 90 || c.Critical {
+		t.Errorf("expected c to have 90 minutes of slack, got %+v", c)
+	}
+	if d := taskByID(t, p, "d"); !reflect.DeepEqual(d.DependsOn, []string{"b", "c"}) || d.EarliestStart != 120 {
+		t.Errorf("unexpected task d: %+v", d)
+	}
+
+	// One worker does all remaining work in sequence, critical work first
+	if p.FinishMinutes != 210 {
+		t.Errorf("expected one worker to finish in 210 minutes, got %d", p.FinishMinutes)
+	}
+	if b := taskByID(t, p, "b"); b.Start != 0 || b.Worker != 1 {
+		t.Errorf("expected b to start first, got %+v", b)
+	}
+
+	p, err = Compute(epic, issues, deps, 2)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	if p.FinishMinutes != 180 {
+		t.Errorf("expected two workers to finish in 180 minutes, got %d", p.FinishMinutes)
+	}
+	if c := taskByID(t, p, "c"); c.Start != 0 || c.Worker != 2 {
+		t.Errorf("expected c to run alongside b, got %+v", c)
+	}
+}
+
+func TestComputeNestedEpics(t *testing.T) {
+	epic := &types.Issue{ID: "e", Title: "Epic", Status: types.StatusOpen}
+	issues := []*types.Issue{
+		{ID: "sub", Title: "Sub-epic", Status: types.StatusOpen},
+		{ID: "t1", Title: "T1", Status: types.StatusOpen, EstimatedMinutes: minutes(45)},
+		{ID: "t2", Title: "T2", Status: types.StatusOpen, EstimatedMinutes: minutes(15)},
+	}
+	deps := []*types.Dependency{child("sub", "e"), child("t1", "sub"), child("t2", "e"), blocks("sub", "t2")}
+
+	p, err := Compute(epic, issues, deps, 3)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	// The sub-epic is done when t1 is, and only then can t2 start
+	if p.CriticalMinutes != 60 || p.FinishMinutes != 60 {
+		t.Errorf("expected 60 minutes, got critical %d and finish %d", p.CriticalMinutes, p.FinishMinutes)
+	}
+	if want := []string{"t1", "t2"}; !reflect.DeepEqual(p.CriticalPath, want) {
+		t.Errorf("expected critical path %v, got %v", want, p.CriticalPath)
+	}
+}
+
+func TestComputeErrors(t *testing.T) {
+	epic, issues, deps := testIssues()
+	if _, err := Compute(epic, issues, deps, 0); err == nil {
+		t.Error("expected 0 workers to be rejected")
+	}
+
+	deps = append(deps, blocks("d", "c"))
+	if _, err := Compute(epic, issues, deps, 1); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected a cycle error, got %v", err)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlite.New(t.TempDir() + "/test.db")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+
+	epic := &types.Issue{Title: "Epic", Status: types.StatusOpen, IssueType: types.TypeEpic}
+	first := &types.Issue{Title: "First", Status: types.StatusOpen, IssueType: types.TypeTask, EstimatedMinutes: minutes(90)}
+	second := &types.Issue{Title: "Second", Status: types.StatusOpen, IssueType: types.TypeTask, EstimatedMinutes: minutes(30)}
+	other := &types.Issue{Title: "Other", Status: types.StatusOpen, IssueType: types.TypeTask, EstimatedMinutes: minutes(5)}
+	for _, issue := range []*types.Issue{epic, first, second, other} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		child(first.ID, epic.ID), child(second.ID, epic.ID), blocks(first.ID, second.ID),
+		{IssueID: other.ID, DependsOnID: epic.ID, Type: types.DepDiscoveredFrom},
+	} {
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	p, err := Build(ctx, store, epic.ID, 2)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(p.Tasks) != 2 || p.CriticalMinutes != 120 || p.FinishMinutes != 120 {
+		t.Errorf("unexpected plan: %d tasks, critical %d, finish %d", len(p.Tasks), p.CriticalMinutes, p.FinishMinutes)
+	}
+	if want := []string{first.ID, second.ID}; !reflect.DeepEqual(p.CriticalPath, want) {
+		t.Errorf("expected critical path %v, got %v", want, p.CriticalPath)
+	}
+
+	if _, err := Build(ctx, store, "missing", 1); err == nil {
+		t.Error("expected an unknown epic to be rejected")
+	}
+}
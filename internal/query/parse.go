@@ -0,0 +1,498 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Parse parses filter text into an expression tree, resolving relative times
+// such as 7d against the current time. Returns nil for empty input.
+//
+// Syntax:
+//
+//	status:open              field equals value (text fields: contains)
+//	type:(bug|task)          any of several values
+//	priority<=1              comparisons on priority and created/updated/closed/due
+//	updated>7d               times: 30m, 12h, 7d, 2w ago, +3d from now, now,
+//	                         2006-01-02 or RFC 3339
+//	assignee:none            unset assignee, no labels, never closed
+//	-label:wontfix           negation (also NOT)
+//	a OR b, (a b) OR c       alternatives and grouping; terms are ANDed by default
+//	login, "session token"   bare words match title, description or ID
+func Parse(input string) (Expr, error) {
+	return ParseAt(input, time.Now())
+}
+
+// ParseAt is Parse with an explicit reference time for relative times
+func ParseAt(input string, now time.Time) (Expr, error) {
+	toks, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks, now: now}
+	if p.peek().kind == tokEOF {
+		return nil, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := p.peek(); tok.kind {
+	case tokEOF:
+		return expr, nil
+	case tokRParen:
+		return nil, fmt.Errorf("unexpected ')' at position %d", tok.pos+1)
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+	}
+}
+
+// Combine ANDs expressions together, skipping nils
+func Combine(exprs ...Expr) Expr {
+	var out []Expr
+	for _, e := range exprs {
+		if e == nil {
+			continue
+		}
+		if and, ok := e.(And); ok {
+			out = append(out, and.Exprs...)
+		} else {
+			out = append(out, e)
+		}
+	}
+	switch len(out) {
+	case 0:
+		return nil
+	case 1:
+		return out[0]
+	}
+	return And{Exprs: out}
+}
+
+// Fields returns the names accepted in field:value terms, sorted
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for f := range fields {
+		if f != FieldText {
+			names = append(names, string(f))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokLParen
+	tokRParen
+	tokOr
+	tokAnd
+	tokNot
+	tokTerm
+)
+
+// rawValue is an unparsed value as written; quoted values are never keywords
+type rawValue struct {
+	text   string
+	quoted bool
+}
+
+type token struct {
+	kind   tokenKind
+	pos    int
+	text   string // Source text, for error messages
+	field  string // tokTerm: field name, empty for bare words
+	op     string // tokTerm: operator as written
+	values []rawValue
+}
+
+// lex splits filter text into tokens
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var toks []token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case r == '(':
+			toks = append(toks, token{kind: tokLParen, pos: i, text: "("})
+			i++
+			continue
+		case r == ')':
+			toks = append(toks, token{kind: tokRParen, pos: i, text: ")"})
+			i++
+			continue
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			toks = append(toks, token{kind: tokNot, pos: i, text: "-"})
+			i++
+			continue
+		case r == '"':
+			text, next, err := lexQuoted(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokTerm, pos: start, text: string(runes[start:next]), values: []rawValue{{text: text, quoted: true}}})
+			i = next
+			continue
+		}
+
+		// field<op>value, or a bare word
+		j := i
+		for j < len(runes) && (unicode.IsLetter(runes[j]) || runes[j] == '_') {
+			j++
+		}
+		if j > i && j < len(runes) {
+			if op := lexOp(runes[j:]); op != "" {
+				tok := token{kind: tokTerm, pos: start, field: string(runes[i:j]), op: op}
+				values, next, err := lexValues(runes, j+len(op))
+				if err != nil {
+					return nil, err
+				}
+				tok.values = values
+				tok.text = string(runes[start:next])
+				toks = append(toks, tok)
+				i = next
+				continue
+			}
+		}
+
+		j = i
+		for j < len(runes) && !unicode.IsSpace(runes[j]) && runes[j] != '(' && runes[j] != ')' && runes[j] != '"' {
+			j++
+		}
+		word := string(runes[i:j])
+		switch word {
+		case "OR":
+			toks = append(toks, token{kind: tokOr, pos: start, text: word})
+		case "AND":
+			toks = append(toks, token{kind: tokAnd, pos: start, text: word})
+		case "NOT":
+			toks = append(toks, token{kind: tokNot, pos: start, text: word})
+		default:
+			toks = append(toks, token{kind: tokTerm, pos: start, text: word, values: []rawValue{{text: word}}})
+		}
+		i = j
+	}
+
+	return append(toks, token{kind: tokEOF, pos: len(runes)}), nil
+}
+
+// lexOp returns the operator at the start of runes, if any
+func lexOp(runes []rune) string {
+	for _, op := range []string{"<=", ">=", "!=", ":", "=", "<", ">"} {
+		if strings.HasPrefix(string(runes[:min(len(runes), 2)]), op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// lexValues reads the value after an operator: a bare word, a "quoted string"
+// or a (a|b|c) list. Returns the values and the index after them.
+func lexValues(runes []rune, i int) ([]rawValue, int, error) {
+	if i >= len(runes) || unicode.IsSpace(runes[i]) || runes[i] == ')' {
+		return nil, i, fmt.Errorf("missing value at position %d", i+1)
+	}
+
+	if runes[i] != '(' {
+		v, next, err := lexValue(runes, i, false)
+		if err != nil {
+			return nil, i, err
+		}
+		return []rawValue{v}, next, nil
+	}
+
+	open := i
+	var values []rawValue
+	i++
+	for {
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		if i >= len(runes) {
+			return nil, i, fmt.Errorf("unterminated value list at position %d", open+1)
+		}
+		if runes[i] == ')' && len(values) == 0 {
+			return nil, i, fmt.Errorf("empty value list at position %d", open+1)
+		}
+		v, next, err := lexValue(runes, i, true)
+		if err != nil {
+			return nil, i, err
+		}
+		values = append(values, v)
+		i = next
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		if i >= len(runes) {
+			return nil, i, fmt.Errorf("unterminated value list at position %d", open+1)
+		}
+		if runes[i] == ')' {
+			return values, i + 1, nil
+		}
+		if runes[i] != '|' {
+			return nil, i, fmt.Errorf("expected '|' or ')' at position %d", i+1)
+		}
+		i++
+	}
+}
+
+// lexValue reads one bare or quoted value. Inside a list, '|' also ends a bare value.
+func lexValue(runes []rune, i int, inList bool) (rawValue, int, error) {
+	if runes[i] == '"' {
+		text, next, err := lexQuoted(runes, i)
+		return rawValue{text: text, quoted: true}, next, err
+	}
+	j := i
+	for j < len(runes) && !unicode.IsSpace(runes[j]) && runes[j] != ')' && runes[j] != '"' && !(inList && runes[j] == '|') {
+		j++
+	}
+	if j == i {
+		return rawValue{}, i, fmt.Errorf("missing value at position %d", i+1)
+	}
+	return rawValue{text: string(runes[i:j])}, j, nil
+}
+
+// lexQuoted reads a double-quoted string starting at runes[i], handling \" and \\ escapes
+func lexQuoted(runes []rune, i int) (string, int, error) {
+	var b strings.Builder
+	for j := i + 1; j < len(runes); j++ {
+		switch runes[j] {
+		case '\\':
+			if j+1 < len(runes) {
+				j++
+				b.WriteRune(runes[j])
+			}
+		case '"':
+			return b.String(), j + 1, nil
+		default:
+			b.WriteRune(runes[j])
+		}
+	}
+	return "", i, fmt.Errorf("unterminated quote at position %d", i+1)
+}
+
+type parser struct {
+	toks []token
+	pos  int
+	now  time.Time
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.toks[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseOr parses and-terms separated by OR
+func (p *parser) parseOr() (Expr, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	exprs := []Expr{first}
+	for p.peek().kind == tokOr {
+		p.next()
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, next)
+	}
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return Or{Exprs: exprs}, nil
+}
+
+// parseAnd parses a run of unary terms, optionally separated by AND
+func (p *parser) parseAnd() (Expr, error) {
+	var exprs []Expr
+	for {
+		tok := p.peek()
+		switch tok.kind {
+		case tokEOF, tokRParen, tokOr:
+			if len(exprs) == 0 {
+				return nil, p.missingTerm(tok)
+			}
+			return Combine(exprs...), nil
+		case tokAnd:
+			if len(exprs) == 0 {
+				return nil, fmt.Errorf("unexpected AND at position %d", tok.pos+1)
+			}
+			p.next()
+			if k := p.peek().kind; k == tokEOF || k == tokRParen || k == tokOr || k == tokAnd {
+				return nil, p.missingTerm(p.peek())
+			}
+			continue
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+}
+
+// parseUnary parses a negation, a parenthesized group or a single term
+func (p *parser) parseUnary() (Expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNot:
+		if k := p.peek().kind; k == tokEOF || k == tokRParen || k == tokOr || k == tokAnd {
+			return nil, p.missingTerm(p.peek())
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if not, ok := expr.(Not); ok {
+			return not.Expr, nil
+		}
+		return Not{Expr: expr}, nil
+	case tokLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokRParen {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", tok.pos+1)
+		}
+		return expr, nil
+	case tokTerm:
+		return p.parseTerm(tok)
+	}
+	return nil, p.missingTerm(tok)
+}
+
+func (p *parser) missingTerm(tok token) error {
+	if tok.kind == tokEOF {
+		return fmt.Errorf("expected a filter term at end of input")
+	}
+	return fmt.Errorf("expected a filter term before %q at position %d", tok.text, tok.pos+1)
+}
+
+// parseTerm turns a field<op>value token into a condition
+func (p *parser) parseTerm(tok token) (Expr, error) {
+	if tok.field == "" {
+		return Cond{Field: FieldText, Op: OpEq, Values: []Value{{Str: tok.values[0].text}}}, nil
+	}
+
+	name := strings.ToLower(tok.field)
+	field := Field(name)
+	if alias, ok := fieldAliases[name]; ok {
+		field = alias
+	}
+	info, ok := fields[field]
+	if !ok || field == FieldText {
+		return nil, fmt.Errorf("unknown field %q in %q (fields: %s)", tok.field, tok.text, strings.Join(Fields(), ", "))
+	}
+
+	negate := false
+	var op Op
+	switch tok.op {
+	case ":", "=":
+		op = OpEq
+	case "!=":
+		op, negate = OpEq, true
+	default:
+		op = Op(tok.op)
+		if info.kind != KindNumber && info.kind != KindTime {
+			return nil, fmt.Errorf("%s does not support %s in %q", field, tok.op, tok.text)
+		}
+		if len(tok.values) > 1 {
+			return nil, fmt.Errorf("%s takes a single value in %q", tok.op, tok.text)
+		}
+	}
+
+	cond := Cond{Field: field, Op: op}
+	for _, raw := range tok.values {
+		v, err := p.parseValue(field, info, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%v in %q", err, tok.text)
+		}
+		if op != OpEq && v.None {
+			return nil, fmt.Errorf("none cannot be compared with %s in %q", tok.op, tok.text)
+		}
+		if op == OpEq && info.kind == KindTime && !v.None {
+			return nil, fmt.Errorf("%s needs a comparison such as %s>7d, or %s:none, in %q", field, field, field, tok.text)
+		}
+		cond.Values = append(cond.Values, v)
+	}
+
+	if negate {
+		return Not{Expr: cond}, nil
+	}
+	return cond, nil
+}
+
+func (p *parser) parseValue(field Field, info fieldInfo, raw rawValue) (Value, error) {
+	if !raw.quoted && raw.text == "none" {
+		if !info.allowNone {
+			return Value{}, fmt.Errorf("%s cannot be none", field)
+		}
+		return Value{None: true}, nil
+	}
+
+	switch info.kind {
+	case KindNumber:
+		n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(raw.text), "P"))
+		if err != nil || n < 0 || n > 4 {
+			return Value{}, fmt.Errorf("invalid %s %q (expected 0-4)", field, raw.text)
+		}
+		return Value{Int: n}, nil
+	case KindTime:
+		t, err := ParseTime(raw.text, p.now)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Time: t}, nil
+	}
+	return Value{Str: raw.text}, nil
+}
+
+// ParseTime parses a filter time value: a relative age (30m, 12h, 7d, 2w)
+// counted back from now, a relative time ahead of now (+3d), now itself, a
+// local date or an RFC 3339 timestamp
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	if s == "now" {
+		return now, nil
+	}
+	sign := time.Duration(-1)
+	rel := s
+	if strings.HasPrefix(rel, "+") {
+		sign, rel = 1, rel[1:]
+	}
+	if n := len(rel); n > 1 {
+		if amount, err := strconv.Atoi(rel[:n-1]); err == nil && amount >= 0 {
+			units := map[byte]time.Duration{'m': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+			if unit, ok := units[rel[n-1]]; ok {
+				return now.Add(sign * time.Duration(amount) * unit), nil
+			}
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (expected e.g. 7d, 12h, +3d, now, 2025-01-31 or 2025-01-31T15:04:05Z)", s)
+}
@@ -0,0 +1,215 @@
+// Package query implements the structured filter language used by `beads list`,
+// e.g. `status:open priority<=1 label:backend -label:wontfix updated>7d`.
+//
+// Parse turns filter text into an Expr tree. The tree is plain data: each storage
+// backend compiles it into its own form (a SQL WHERE clause, an in-memory predicate).
+package query
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field is an issue attribute that can appear in a filter
+type Field string
+
+// Filterable fields
+const (
+	FieldID          Field = "id"
+	FieldTitle       Field = "title"
+	FieldDescription Field = "description"
+	FieldText        Field = "text" // Bare words: title, description or ID contains the text
+	FieldStatus      Field = "status"
+	FieldPriority    Field = "priority"
+	FieldType        Field = "type"
+	FieldAssignee    Field = "assignee"
+	FieldLabel       Field = "label"
+	FieldCreated     Field = "created"
+	FieldUpdated     Field = "updated"
+	FieldClosed      Field = "closed"
+	FieldDue         Field = "due"
+)
+
+// Kind describes how a field's values are parsed and compared
+type Kind int
+
+// Field kinds
+const (
+	KindKeyword Kind = iota // Exact string match
+	KindText                // Case-insensitive substring match
+	KindNumber              // Integer, supports comparisons
+	KindTime                // Timestamp, supports comparisons
+)
+
+type fieldInfo struct {
+	kind      Kind
+	allowNone bool // Accepts the literal none (unset value / no labels)
+}
+
+var fields = map[Field]fieldInfo{
+	FieldID:          {kind: KindKeyword},
+	FieldTitle:       {kind: KindText},
+	FieldDescription: {kind: KindText, allowNone: true},
+	FieldText:        {kind: KindText},
+	FieldStatus:      {kind: KindKeyword},
+	FieldPriority:    {kind: KindNumber},
+	FieldType:        {kind: KindKeyword},
+	FieldAssignee:    {kind: KindKeyword, allowNone: true},
+	FieldLabel:       {kind: KindKeyword, allowNone: true},
+	FieldCreated:     {kind: KindTime},
+	FieldUpdated:     {kind: KindTime},
+	FieldClosed:      {kind: KindTime, allowNone: true},
+	FieldDue:         {kind: KindTime, allowNone: true},
+}
+
+// fieldAliases are alternative spellings accepted by the parser
+var fieldAliases = map[string]Field{
+	"desc":       FieldDescription,
+	"issue_type": FieldType,
+	"labels":     FieldLabel,
+}
+
+// Kind returns how the field's values are compared
+func (f Field) Kind() Kind {
+	return fields[f].kind
+}
+
+// Op is a comparison operator
+type Op string
+
+// Comparison operators. OpEq means "is one of" for keyword and number fields,
+// "contains" for text fields and "has" for labels.
+const (
+	OpEq Op = ":"
+	OpLt Op = "<"
+	OpLe Op = "<="
+	OpGt Op = ">"
+	OpGe Op = ">="
+)
+
+// Expr is a node in a parsed filter: And, Or, Not or Cond
+type Expr interface {
+	String() string
+	isExpr()
+}
+
+// And matches when every child matches
+type And struct {
+	Exprs []Expr
+}
+
+// Or matches when any child matches
+type Or struct {
+	Exprs []Expr
+}
+
+// Not matches when its child does not
+type Not struct {
+	Expr Expr
+}
+
+// Cond compares a single field. With OpEq it matches when the field equals any
+// of Values; the other operators always have exactly one value.
+type Cond struct {
+	Field  Field
+	Op     Op
+	Values []Value
+}
+
+// Value is a parsed literal. Which member is set depends on the field's kind.
+type Value struct {
+	Str  string    // Keyword and text fields
+	Int  int       // Number fields
+	Time time.Time // Time fields
+	None bool      // The literal none: no assignee, no labels, never closed, no due date
+}
+
+func (And) isExpr()  {}
+func (Or) isExpr()   {}
+func (Not) isExpr()  {}
+func (Cond) isExpr() {}
+
+// String renders the expression in filter syntax. Relative times are rendered
+// as absolute timestamps, so the result parses back to the same tree.
+func (e And) String() string {
+	parts := make([]string, len(e.Exprs))
+	for i, child := range e.Exprs {
+		parts[i] = child.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+func (e Or) String() string {
+	parts := make([]string, len(e.Exprs))
+	for i, child := range e.Exprs {
+		parts[i] = groupString(child)
+	}
+	return "(" + strings.Join(parts, " OR ") + ")"
+}
+
+func (e Not) String() string {
+	return "-" + groupString(e.Expr)
+}
+
+func (e Cond) String() string {
+	values := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		values[i] = e.Field.formatValue(v)
+	}
+	rendered := values[0]
+	if len(values) > 1 {
+		rendered = "(" + strings.Join(values, "|") + ")"
+	}
+	if e.Field == FieldText {
+		return rendered
+	}
+	return string(e.Field) + string(e.Op) + rendered
+}
+
+// groupString parenthesizes multi-term conjunctions so they nest correctly
+func groupString(e Expr) string {
+	if and, ok := e.(And); ok && len(and.Exprs) > 1 {
+		return "(" + and.String() + ")"
+	}
+	return e.String()
+}
+
+func (f Field) formatValue(v Value) string {
+	switch {
+	case v.None:
+		return "none"
+	case f.Kind() == KindNumber:
+		return strconv.Itoa(v.Int)
+	case f.Kind() == KindTime:
+		return v.Time.UTC().Format(time.RFC3339Nano)
+	}
+	if v.Str == "" || isKeyword(v.Str) || strings.HasPrefix(v.Str, "-") || strings.IndexFunc(v.Str, isSpecial) >= 0 {
+		return quote(v.Str)
+	}
+	return v.Str
+}
+
+// quote wraps s in double quotes, escaping the characters the lexer unescapes
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// isKeyword reports whether a bare word would be read as syntax rather than a value
+func isKeyword(s string) bool {
+	switch s {
+	case "none", "OR", "AND", "NOT":
+		return true
+	}
+	return false
+}
+
+// isSpecial reports whether r ends a bare word, so values containing it need quoting
+func isSpecial(r rune) bool {
+	switch r {
+	case ' ', '\t', '\n', '\r', '(', ')', '|', '"', ':', '<', '>', '=', '!':
+		return true
+	}
+	return false
+}
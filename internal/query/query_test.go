@@ -0,0 +1,179 @@
+package query
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func eq(field Field, values ...string) Cond {
+	c := Cond{Field: field, Op: OpEq}
+	for _, v := range values {
+		c.Values = append(c.Values, Value{Str: v})
+	}
+	return c
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Expr
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"status:open", eq(FieldStatus, "open")},
+		{"status=open", eq(FieldStatus, "open")},
+		{"Status:open", eq(FieldStatus, "open")},
+		{"type:(bug|task)", eq(FieldType, "bug", "task")},
+		{"type:( bug | task )", eq(FieldType, "bug", "task")},
+		{"desc:crash", eq(FieldDescription, "crash")},
+		{`title:"login page"`, eq(FieldTitle, "login page")},
+		{`label:"none"`, eq(FieldLabel, "none")},
+		{"login", eq(FieldText, "login")},
+		{`"session token"`, eq(FieldText, "session token")},
+		{"beads-a3f8", eq(FieldText, "beads-a3f8")},
+		{"assignee:none", Cond{Field: FieldAssignee, Op: OpEq, Values: []Value{{None: true}}}},
+		{"priority<=1", Cond{Field: FieldPriority, Op: OpLe, Values: []Value{{Int: 1}}}},
+		{"priority:(P0|p1)", Cond{Field: FieldPriority, Op: OpEq, Values: []Value{{Int: 0}, {Int: 1}}}},
+		{"updated>7d", Cond{Field: FieldUpdated, Op: OpGt, Values: []Value{{Time: testNow.Add(-7 * 24 * time.Hour)}}}},
+		{"created<2w", Cond{Field: FieldCreated, Op: OpLt, Values: []Value{{Time: testNow.Add(-14 * 24 * time.Hour)}}}},
+		{"closed>=2025-01-02T03:04:05Z", Cond{Field: FieldClosed, Op: OpGe, Values: []Value{{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}}}},
+		{"closed:none", Cond{Field: FieldClosed, Op: OpEq, Values: []Value{{None: true}}}},
+		{"due<+3d", Cond{Field: FieldDue, Op: OpLt, Values: []Value{{Time: testNow.Add(3 * 24 * time.Hour)}}}},
+		{"due<now", Cond{Field: FieldDue, Op: OpLt, Values: []Value{{Time: testNow}}}},
+		{"due:none", Cond{Field: FieldDue, Op: OpEq, Values: []Value{{None: true}}}},
+		{"-label:wontfix", Not{Expr: eq(FieldLabel, "wontfix")}},
+		{"NOT label:wontfix", Not{Expr: eq(FieldLabel, "wontfix")}},
+		{"status!=closed", Not{Expr: eq(FieldStatus, "closed")}},
+		{"--status:open", eq(FieldStatus, "open")},
+		{"status:open label:backend", And{Exprs: []Expr{eq(FieldStatus, "open"), eq(FieldLabel, "backend")}}},
+		{"status:open AND label:backend", And{Exprs: []Expr{eq(FieldStatus, "open"), eq(FieldLabel, "backend")}}},
+		{"status:open OR status:blocked", Or{Exprs: []Expr{eq(FieldStatus, "open"), eq(FieldStatus, "blocked")}}},
+		// AND binds tighter than OR
+		{"a b OR c", Or{Exprs: []Expr{And{Exprs: []Expr{eq(FieldText, "a"), eq(FieldText, "b")}}, eq(FieldText, "c")}}},
+		{"a (b OR c)", And{Exprs: []Expr{eq(FieldText, "a"), Or{Exprs: []Expr{eq(FieldText, "b"), eq(FieldText, "c")}}}}},
+		{"-(a b)", Not{Expr: And{Exprs: []Expr{eq(FieldText, "a"), eq(FieldText, "b")}}}},
+		// Nested groups flatten into the enclosing conjunction
+		{"a (b c)", And{Exprs: []Expr{eq(FieldText, "a"), eq(FieldText, "b"), eq(FieldText, "c")}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAt(tt.input, testNow)
+			if err != nil {
+				t.Fatalf("ParseAt(%q) failed: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAt(%q) = %#v, want %#v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"colour:red", `unknown field "colour"`},
+		{"status:", "missing value"},
+		{"status:(open|", "unterminated value list"},
+		{"status:()", "empty value list"},
+		{"status:(open blocked)", "expected '|' or ')'"},
+		{`title:"open`, "unterminated quote"},
+		{"status<open", "status does not support <"},
+		{"priority:high", `invalid priority "high"`},
+		{"priority<=7", `invalid priority "7"`},
+		{"priority<(1|2)", "< takes a single value"},
+		{"updated>soon", `invalid time "soon"`},
+		{"updated:7d", "updated needs a comparison"},
+		{"status:none", "status cannot be none"},
+		{"closed<none", "none cannot be compared"},
+		{"(status:open", "missing ')'"},
+		{"status:open)", "unexpected ')'"},
+		{"OR status:open", "expected a filter term"},
+		{"status:open OR", "expected a filter term at end of input"},
+		{"status:open AND", "expected a filter term"},
+		{"NOT", "expected a filter term"},
+		{"()", "expected a filter term"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseAt(tt.input, testNow)
+			if err == nil {
+				t.Fatalf("ParseAt(%q) succeeded, want error containing %q", tt.input, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseAt(%q) error = %q, want it to contain %q", tt.input, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	inputs := []string{
+		"status:open priority<=1 label:backend -label:wontfix updated>7d assignee:none type:(bug|task)",
+		`(status:open OR status:blocked) -(label:a label:b) title:"two words" "OR" "-dash" label:"none"`,
+		`description:"say \"hi\"" closed:none created<=2025-01-31`,
+	}
+	for _, input := range inputs {
+		expr, err := ParseAt(input, testNow)
+		if err != nil {
+			t.Fatalf("ParseAt(%q) failed: %v", input, err)
+		}
+		rendered := expr.String()
+		again, err := ParseAt(rendered, testNow)
+		if err != nil {
+			t.Fatalf("ParseAt(%q) of rendered %q failed: %v", input, rendered, err)
+		}
+		if !reflect.DeepEqual(normalizeTimes(expr), normalizeTimes(again)) {
+			t.Errorf("round trip changed expression:\n  input:    %s\n  rendered: %s\n  got:      %#v\n  want:     %#v", input, rendered, again, expr)
+		}
+	}
+}
+
+// normalizeTimes converts times to UTC so DeepEqual ignores the location
+func normalizeTimes(e Expr) Expr {
+	switch e := e.(type) {
+	case And:
+		out := And{}
+		for _, child := range e.Exprs {
+			out.Exprs = append(out.Exprs, normalizeTimes(child))
+		}
+		return out
+	case Or:
+		out := Or{}
+		for _, child := range e.Exprs {
+			out.Exprs = append(out.Exprs, normalizeTimes(child))
+		}
+		return out
+	case Not:
+		return Not{Expr: normalizeTimes(e.Expr)}
+	case Cond:
+		out := Cond{Field: e.Field, Op: e.Op}
+		for _, v := range e.Values {
+			v.Time = v.Time.UTC()
+			out.Values = append(out.Values, v)
+		}
+		return out
+	}
+	return e
+}
+
+func TestCombine(t *testing.T) {
+	a, b, c := eq(FieldText, "a"), eq(FieldText, "b"), eq(FieldText, "c")
+	if got := Combine(nil, nil); got != nil {
+		t.Errorf("Combine(nil, nil) = %v, want nil", got)
+	}
+	if got := Combine(nil, a); !reflect.DeepEqual(got, a) {
+		t.Errorf("Combine(nil, a) = %v, want a", got)
+	}
+	want := And{Exprs: []Expr{a, b, c}}
+	if got := Combine(And{Exprs: []Expr{a, b}}, c); !reflect.DeepEqual(got, want) {
+		t.Errorf("Combine = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,142 @@
+package recur
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// Occurrence is one scheduled copy of a recurring issue
+type Occurrence struct {
+	ID       string       `json:"id"`
+	At       time.Time    `json:"at"`
+	Template *types.Issue `json:"template"`
+	Issue    *types.Issue `json:"issue,omitempty"` // The copy, once created
+}
+
+// Due returns the occurrences to create as of now: for each open recurring
+// issue, its latest scheduled time if that is after the issue's recurred_at
+// (the last occurrence materialized, or when the rule was set) and has no copy
+// yet, so a schedule starts when it is set rather than creating an occurrence
+// from the past. Issues without recurred_at count from their creation. Missed
+// earlier occurrences are not backfilled. Issues with invalid rules are
+// skipped and reported in the error.
+func Due(ctx context.Context, store storage.Storage, now time.Time) ([]*Occurrence, error) {
+	prefix, err := store.GetConfig(ctx, "issue_prefix")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue prefix: %w", err)
+	}
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	var due []*Occurrence
+	var errs []error
+	for _, template := range issues {
+		if template.Recurrence == "" || template.Status.Category() == types.CategoryDone {
+			continue
+		}
+		schedule, err := Parse(template.Recurrence)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", template.ID, err))
+			continue
+		}
+		since := template.CreatedAt
+		if template.RecurredAt != nil {
+			since = *template.RecurredAt
+		}
+		at := schedule.Prev(now)
+		if at.IsZero() || !at.After(since) {
+			continue
+		}
+		id := OccurrenceID(prefix, template.ID, at)
+		existing, err := store.GetIssue(ctx, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", template.ID, err))
+			continue
+		}
+		if existing == nil {
+			due = append(due, &Occurrence{ID: id, At: at, Template: template})
+		}
+	}
+	return due, errors.Join(errs...)
+}
+
+// Materialize creates the occurrences that are due as of now. Each copy is an
+// open issue with the recurring issue's fields and labels, a dated title and
+// a discovered-from dependency on the recurring issue, and the recurring
+// issue's recurred_at advances to the occurrence's time. Because occurrence
+// IDs are deterministic, an occurrence that already exists (created by another
+// process, or imported from another clone) is never created twice.
+func Materialize(ctx context.Context, store storage.Storage, now time.Time, actor string) ([]*Occurrence, error) {
+	due, err := Due(ctx, store, now)
+	errs := []error{err}
+	created := make([]*Occurrence, 0, len(due))
+	for _, occ := range due {
+		if err := create(ctx, store, occ, actor); err != nil {
+			errs = append(errs, fmt.Errorf("%s: failed to create occurrence %s: %w", occ.Template.ID, occ.ID, err))
+			continue
+		}
+		if err := store.UpdateIssue(ctx, occ.Template.ID, map[string]interface{}{"recurred_at": occ.At}, actor); err != nil {
+			errs = append(errs, fmt.Errorf("%s: failed to record occurrence %s: %w", occ.Template.ID, occ.ID, err))
+		}
+		if occ.Issue != nil {
+			created = append(created, occ)
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// RuleUpdates returns the issue updates that set rule as an issue's
+// recurrence, or stop it recurring when rule is empty. A new rule's schedule
+// starts at now.
+func RuleUpdates(rule string, now time.Time) map[string]interface{} {
+	if rule == "" {
+		return map[string]interface{}{"recurrence": "", "recurred_at": nil}
+	}
+	return map[string]interface{}{"recurrence": rule, "recurred_at": now}
+}
+
+func create(ctx context.Context, store storage.Storage, occ *Occurrence, actor string) error {
+	t := occ.Template
+	loc := time.UTC
+	if schedule, err := Parse(t.Recurrence); err == nil {
+		loc = schedule.Location()
+	}
+	issue := &types.Issue{
+		ID:                 occ.ID,
+		Title:              OccurrenceTitle(t.Title, occ.At, loc),
+		Description:        t.Description,
+		Design:             t.Design,
+		AcceptanceCriteria: t.AcceptanceCriteria,
+		Status:             types.StatusOpen,
+		Priority:           t.Priority,
+		IssueType:          t.IssueType,
+		Assignee:           t.Assignee,
+		EstimatedMinutes:   t.EstimatedMinutes,
+	}
+	if err := store.CreateIssue(ctx, issue, actor); err != nil {
+		// Lost a race with another process creating the same occurrence
+		if existing, _ := store.GetIssue(ctx, occ.ID); existing != nil {
+			return nil
+		}
+		return err
+	}
+	occ.Issue = issue
+
+	for _, label := range t.Labels {
+		if err := store.AddLabel(ctx, issue.ID, label, actor); err != nil {
+			return fmt.Errorf("failed to add label %s: %w", label, err)
+		}
+	}
+	dep := &types.Dependency{IssueID: issue.ID, DependsOnID: t.ID, Type: types.DepDiscoveredFrom}
+	if err := store.AddDependency(ctx, dep, actor); err != nil {
+		return fmt.Errorf("failed to link to %s: %w", t.ID, err)
+	}
+	return nil
+}
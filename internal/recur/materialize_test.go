@@ -0,0 +1,185 @@
+package recur_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/recur"
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func newTestStore(t *testing.T) *sqlite.SQLiteStorage {
+	t.Helper()
+	store, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if err := store.SetConfig(context.Background(), "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+	return store
+}
+
+func TestMaterialize(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	template := &types.Issue{
+		Title:      "Rotate credentials",
+		Status:     types.StatusOpen,
+		Priority:   1,
+		IssueType:  types.TypeChore,
+		Recurrence: "@hourly",
+	}
+	if err := store.CreateIssue(ctx, template, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, template.ID, "ops", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, template.ID, map[string]interface{}{"recurrence": "every hour"}, "test"); err == nil {
+		t.Errorf("expected an invalid recurrence to be rejected")
+	}
+
+	// Nothing is due until the first scheduled time after the rule was set
+	created, err := recur.Materialize(ctx, store, time.Now(), "test")
+	if err != nil || len(created) != 0 {
+		t.Fatalf("expected nothing due yet, got %d, %v", len(created), err)
+	}
+
+	later := time.Now().Add(2 * time.Hour)
+	created, err = recur.Materialize(ctx, store, later, "test")
+	if err != nil {
+		t.Fatalf("Materialize failed: %v", err)
+	}
+	if len(created) != 1 {
+		t.Fatalf("expected one occurrence, got %d", len(created))
+	}
+	occ := created[0]
+	if occ.ID != recur.OccurrenceID("test", template.ID, later.Truncate(time.Hour)) {
+		t.Errorf("unexpected occurrence ID %s", occ.ID)
+	}
+
+	issue, err := store.GetIssue(ctx, occ.ID)
+	if err != nil || issue == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if !strings.HasPrefix(issue.Title, "Rotate credentials (") || issue.Priority != 1 || issue.Recurrence != "" {
+		t.Errorf("unexpected occurrence: %+v", issue)
+	}
+	labels, _ := store.GetLabels(ctx, occ.ID)
+	if len(labels) != 1 || labels[0] != "ops" {
+		t.Errorf("labels = %v, want [ops]", labels)
+	}
+	deps, _ := store.GetDependencyRecords(ctx, occ.ID)
+	if len(deps) != 1 || deps[0].DependsOnID != template.ID || deps[0].Type != types.DepDiscoveredFrom {
+		t.Errorf("unexpected dependencies: %+v", deps)
+	}
+
+	// Materializing again, as another process would, creates nothing
+	if again, err := recur.Materialize(ctx, store, later, "test"); err != nil || len(again) != 0 {
+		t.Errorf("expected no duplicates, got %d, %v", len(again), err)
+	}
+
+	// The occurrence is ready work; the recurring issue is not
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID != occ.ID {
+		t.Errorf("ready = %v, want only %s", ready, occ.ID)
+	}
+
+	// Closing the recurring issue stops the schedule
+	if err := store.CloseIssue(ctx, template.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if due, err := recur.Due(ctx, store, later.Add(time.Hour)); err != nil || len(due) != 0 {
+		t.Errorf("expected nothing due after closing, got %d, %v", len(due), err)
+	}
+}
+
+func TestDueSkipsDoneStatuses(t *testing.T) {
+	wf := types.DefaultWorkflow()
+	if err := wf.AddStatus("wontfix", types.CategoryDone); err != nil {
+		t.Fatal(err)
+	}
+	types.SetWorkflow(wf)
+	t.Cleanup(func() { types.SetWorkflow(nil) })
+
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	template := &types.Issue{
+		Title:      "Send newsletter",
+		Status:     types.StatusOpen,
+		Priority:   2,
+		IssueType:  types.TypeChore,
+		Recurrence: "@hourly",
+	}
+	if err := store.CreateIssue(ctx, template, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	later := time.Now().Add(2 * time.Hour)
+	if due, err := recur.Due(ctx, store, later); err != nil || len(due) != 1 {
+		t.Fatalf("expected one occurrence due, got %d, %v", len(due), err)
+	}
+
+	if err := store.UpdateIssue(ctx, template.ID, map[string]interface{}{"status": "wontfix"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if due, err := recur.Due(ctx, store, later.Add(time.Hour)); err != nil || len(due) != 0 {
+		t.Errorf("expected nothing due in a done status, got %d, %v", len(due), err)
+	}
+}
+
+func TestDueIgnoresTemplateEdits(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	template := &types.Issue{
+		Title:      "Review alerts",
+		Status:     types.StatusOpen,
+		Priority:   2,
+		IssueType:  types.TypeChore,
+		Recurrence: "@hourly",
+	}
+	if err := store.CreateIssue(ctx, template, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// An edit after the scheduled time, before the occurrence was created,
+	// doesn't skip it
+	later := time.Now().Add(2 * time.Hour)
+	if _, err := store.UnderlyingDB().Exec("UPDATE issues SET updated_at = ? WHERE id = ?", later, template.ID); err != nil {
+		t.Fatalf("failed to set updated_at: %v", err)
+	}
+	created, err := recur.Materialize(ctx, store, later, "test")
+	if err != nil || len(created) != 1 {
+		t.Fatalf("expected one occurrence, got %d, %v", len(created), err)
+	}
+
+	got, err := store.GetIssue(ctx, template.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.RecurredAt == nil || !got.RecurredAt.Equal(created[0].At) {
+		t.Errorf("recurred_at = %v, want %v", got.RecurredAt, created[0].At)
+	}
+
+	// Setting a new rule starts its schedule afresh
+	if err := store.UpdateIssue(ctx, template.ID, recur.RuleUpdates("@daily", later), "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if due, err := recur.Due(ctx, store, later); err != nil || len(due) != 0 {
+		t.Errorf("expected nothing due when the rule was just set, got %d, %v", len(due), err)
+	}
+	if due, err := recur.Due(ctx, store, later.Add(25*time.Hour)); err != nil || len(due) != 1 {
+		t.Errorf("expected the next daily occurrence due, got %d, %v", len(due), err)
+	}
+}
@@ -0,0 +1,254 @@
+// Package recur parses the recurrence rules of recurring issues and computes
+// when their occurrences fall due.
+package recur
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed recurrence rule
+type Schedule struct {
+	rule   string
+	loc    *time.Location
+	minute uint64 // Bit n set when minute n matches
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	anyDom bool // Day of month was *, so only the day of week restricts days
+	anyDow bool
+}
+
+var shorthands = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	dayNames   = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+// searchLimit bounds how far Prev and Next look for a matching day, so rules
+// that can never match (like February 30th) don't loop forever
+const searchLimit = 5 * 366
+
+// Parse parses a cron-style rule: five fields (minute hour day-of-month month
+// day-of-week) or a shorthand such as @weekly, optionally preceded by
+// TZ=<zone>. Fields accept *, lists, ranges, steps and month and day names.
+// Rules are evaluated in UTC unless a zone is given, so every clone computes
+// the same occurrences.
+//
+// Examples:
+//
+//	0 9 * * mon           Mondays at 09:00 UTC
+//	TZ=Europe/Berlin 0 9 1 * *   The 1st of every month at 09:00 Berlin time
+//	*/30 9-17 * * 1-5     Every half hour during working hours
+//	@daily                Midnight UTC
+func Parse(rule string) (*Schedule, error) {
+	s := &Schedule{rule: strings.TrimSpace(rule), loc: time.UTC}
+	spec := s.rule
+	if strings.HasPrefix(spec, "TZ=") {
+		zone, rest, _ := strings.Cut(spec, " ")
+		loc, err := time.LoadLocation(strings.TrimPrefix(zone, "TZ="))
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone in %q: %w", rule, err)
+		}
+		s.loc = loc
+		spec = strings.TrimSpace(rest)
+	}
+	if expanded, ok := shorthands[strings.ToLower(spec)]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid recurrence %q: expected 5 fields (minute hour day month weekday) or a shorthand like @weekly", rule)
+	}
+	var err error
+	if s.minute, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid recurrence %q: minute: %w", rule, err)
+	}
+	if s.hour, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid recurrence %q: hour: %w", rule, err)
+	}
+	if s.dom, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid recurrence %q: day of month: %w", rule, err)
+	}
+	if s.month, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid recurrence %q: month: %w", rule, err)
+	}
+	if s.dow, err = parseField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("invalid recurrence %q: day of week: %w", rule, err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1 // 7 is Sunday too
+	}
+	s.anyDom = fields[2] == "*"
+	s.anyDow = fields[4] == "*"
+	return s, nil
+}
+
+// parseField parses one cron field into a bit set of the values it matches
+func parseField(field string, lo, hi int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+			step = n
+		}
+
+		start, end := lo, hi
+		if rng != "*" {
+			from, to, isRange := strings.Cut(rng, "-")
+			var err error
+			if start, err = parseValue(from, lo, hi, names); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = parseValue(to, lo, hi, names); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				end = hi // 5/15 means from 5 onwards
+			}
+			if end < start {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(s string, lo, hi int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < lo || v > hi {
+		return 0, fmt.Errorf("invalid value %q (must be %d-%d)", s, lo, hi)
+	}
+	return v, nil
+}
+
+// String returns the rule the schedule was parsed from
+func (s *Schedule) String() string {
+	return s.rule
+}
+
+// Location returns the time zone the schedule is evaluated in
+func (s *Schedule) Location() *time.Location {
+	return s.loc
+}
+
+// matchesDay reports whether the schedule fires on the given date. As in
+// cron, when both day of month and day of week are restricted, either may match.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDom && s.anyDow:
+		return true
+	case s.anyDom:
+		return dowMatch
+	case s.anyDow:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// Prev returns the latest occurrence at or before t, or the zero time if
+// there is none in the last five years
+func (s *Schedule) Prev(t time.Time) time.Time {
+	t = t.In(s.loc).Truncate(time.Minute)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.loc)
+	for i := 0; i < searchLimit; i++ {
+		if s.matchesDay(day) {
+			for h := 23; h >= 0; h-- {
+				if s.hour&(1<<uint(h)) == 0 {
+					continue
+				}
+				for m := 59; m >= 0; m-- {
+					if s.minute&(1<<uint(m)) == 0 {
+						continue
+					}
+					at := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, s.loc)
+					if !at.After(t) {
+						return at
+					}
+				}
+			}
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return time.Time{}
+}
+
+// Next returns the earliest occurrence after t, or the zero time if there is
+// none in the next five years
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.In(s.loc).Truncate(time.Minute)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.loc)
+	for i := 0; i < searchLimit; i++ {
+		if s.matchesDay(day) {
+			for h := 0; h < 24; h++ {
+				if s.hour&(1<<uint(h)) == 0 {
+					continue
+				}
+				for m := 0; m < 60; m++ {
+					if s.minute&(1<<uint(m)) == 0 {
+						continue
+					}
+					at := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, s.loc)
+					if at.After(t) {
+						return at
+					}
+				}
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
+// OccurrenceID returns the ID of a recurring issue's occurrence at a given
+// time. It depends only on the template and the time, so clones that
+// materialize the same occurrence independently agree on its ID and the
+// copies merge instead of duplicating.
+func OccurrenceID(prefix, templateID string, at time.Time) string {
+	sum := sha256.Sum256([]byte(templateID + "@" + at.UTC().Format(time.RFC3339)))
+	return prefix + "-" + hex.EncodeToString(sum[:4])
+}
+
+// OccurrenceTitle labels an occurrence's title with its date, and with its
+// time of day unless it falls at midnight
+func OccurrenceTitle(title string, at time.Time, loc *time.Location) string {
+	at = at.In(loc)
+	if at.Hour() == 0 && at.Minute() == 0 {
+		return fmt.Sprintf("%s (%s)", title, at.Format("2006-01-02"))
+	}
+	return fmt.Sprintf("%s (%s)", title, at.Format("2006-01-02 15:04"))
+}
@@ -0,0 +1,99 @@
+package recur
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	at, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatalf("bad time %q: %v", s, err)
+	}
+	return at
+}
+
+func TestSchedulePrevNext(t *testing.T) {
+	now := "2026-10-14T10:30:00Z" // A Wednesday
+	for _, tc := range []struct {
+		rule, prev, next string
+	}{
+		{"@hourly", "2026-10-14T10:00:00Z", "2026-10-14T11:00:00Z"},
+		{"@daily", "2026-10-14T00:00:00Z", "2026-10-15T00:00:00Z"},
+		{"@weekly", "2026-10-11T00:00:00Z", "2026-10-18T00:00:00Z"},
+		{"@monthly", "2026-10-01T00:00:00Z", "2026-11-01T00:00:00Z"},
+		{"@yearly", "2026-01-01T00:00:00Z", "2027-01-01T00:00:00Z"},
+		{"0 9 * * mon", "2026-10-12T09:00:00Z", "2026-10-19T09:00:00Z"},
+		{"30 10 * * *", "2026-10-14T10:30:00Z", "2026-10-15T10:30:00Z"},
+		{"*/20 9-17 * * MON-FRI", "2026-10-14T10:20:00Z", "2026-10-14T10:40:00Z"},
+		{"0 0 * * 7", "2026-10-11T00:00:00Z", "2026-10-18T00:00:00Z"},
+		{"0 12 29 feb *", "2024-02-29T12:00:00Z", "2028-02-29T12:00:00Z"},
+		{"0 0 13 * fri", "2026-10-13T00:00:00Z", "2026-10-16T00:00:00Z"},                  // Either the 13th or a Friday
+		{"TZ=America/New_York 0 9 * * *", "2026-10-13T13:00:00Z", "2026-10-14T13:00:00Z"}, // 06:30 in New York
+	} {
+		s, err := Parse(tc.rule)
+		if err != nil {
+			t.Errorf("%q: Parse failed: %v", tc.rule, err)
+			continue
+		}
+		if got := s.Prev(mustTime(t, now)); !got.Equal(mustTime(t, tc.prev)) {
+			t.Errorf("%q: Prev = %s, want %s", tc.rule, got.UTC().Format(time.RFC3339), tc.prev)
+		}
+		if got := s.Next(mustTime(t, now)); !got.Equal(mustTime(t, tc.next)) {
+			t.Errorf("%q: Next = %s, want %s", tc.rule, got.UTC().Format(time.RFC3339), tc.next)
+		}
+	}
+
+	never, err := Parse("0 0 30 feb *")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !never.Prev(mustTime(t, now)).IsZero() || !never.Next(mustTime(t, now)).IsZero() {
+		t.Errorf("expected no occurrences for February 30th")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		rule, want string
+	}{
+		{"", "expected 5 fields"},
+		{"@fortnightly", "expected 5 fields"},
+		{"0 9 * *", "expected 5 fields"},
+		{"60 * * * *", "minute"},
+		{"0 24 * * *", "hour"},
+		{"0 0 0 * *", "day of month"},
+		{"0 0 * 13 *", "month"},
+		{"0 0 * * funday", "day of week"},
+		{"*/0 * * * *", "invalid step"},
+		{"0 17-9 * * *", "invalid range"},
+		{"TZ=Mars/Olympus 0 9 * * *", "invalid time zone"},
+	} {
+		if _, err := Parse(tc.rule); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%q: expected an error about %q, got %v", tc.rule, tc.want, err)
+		}
+	}
+}
+
+func TestOccurrenceIDAndTitle(t *testing.T) {
+	at := mustTime(t, "2026-10-12T09:00:00Z")
+	id := OccurrenceID("bd", "bd-a1b2", at)
+	if !strings.HasPrefix(id, "bd-") || len(id) != len("bd-")+8 {
+		t.Errorf("unexpected occurrence ID %q", id)
+	}
+	if other := OccurrenceID("bd", "bd-a1b2", at.In(time.FixedZone("X", 3600))); other != id {
+		t.Errorf("ID depends on the time zone: %s != %s", other, id)
+	}
+	if other := OccurrenceID("bd", "bd-a1b2", at.Add(time.Minute)); other == id {
+		t.Errorf("different times share ID %s", id)
+	}
+
+	if got := OccurrenceTitle("Standup", at, time.UTC); got != "Standup (2026-10-12 09:00)" {
+		t.Errorf("title = %q", got)
+	}
+	if got := OccurrenceTitle("Review", mustTime(t, "2026-10-12T00:00:00Z"), time.UTC); got != "Review (2026-10-12)" {
+		t.Errorf("title = %q", got)
+	}
+}
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestClaim(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	id := createTestIssue(t, client, "Claim me")
+	updates := subscribeAsync(t, server, client, &SubscribeArgs{Types: []string{EventUpdate}}, 1)
+
+	resp, err := client.Claim(&ClaimArgs{Agent: "worker-1", TTL: "10m"})
+	if err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+	var claim *types.Claim
+	if err := json.Unmarshal(resp.Data, &claim); err != nil {
+		t.Fatalf("failed to decode claim: %v", err)
+	}
+	if claim == nil || claim.Issue.ID != id {
+		t.Fatalf("expected to claim %s, got %+v", id, claim)
+	}
+	if claim.Issue.Status != types.StatusInProgress || claim.Lease.Holder != "worker-1" {
+		t.Errorf("unexpected claim: issue status %s, holder %s", claim.Issue.Status, claim.Lease.Holder)
+	}
+	if ttl := claim.Lease.ExpiresAt.Sub(claim.Lease.ClaimedAt); ttl != 10*time.Minute {
+		t.Errorf("expected a 10m lease, got %v", ttl)
+	}
+	if events := receive(t, updates); len(events) != 1 || events[0].IssueID != id || events[0].Actor != "worker-1" {
+		t.Errorf("unexpected update events: %+v", events)
+	}
+
+	// Nothing left to claim
+	resp, err = client.Claim(&ClaimArgs{Agent: "worker-2"})
+	if err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+	if string(resp.Data) != "null" {
+		t.Errorf("expected null when nothing is ready, got %s", resp.Data)
+	}
+
+	if _, err := client.Claim(&ClaimArgs{TTL: "soon"}); err == nil {
+		t.Error("expected an invalid TTL to be rejected")
+	}
+}
@@ -2,9 +2,13 @@ package rpc
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"os"
 	"time"
 )
@@ -20,6 +24,10 @@ type Client struct {
 	socketPath string
 	timeout    time.Duration
 	dbPath     string // Expected database path for validation
+	// Remote daemons are reached over HTTP instead (see ConnectURL)
+	url        string
+	token      string
+	httpClient *http.Client
 }
 
 // TryConnect attempts to connect to the daemon socket
@@ -83,6 +91,9 @@ func TryConnectWithTimeout(socketPath string, dialTimeout time.Duration) (*Clien
 
 // Close closes the connection to the daemon
 func (c *Client) Close() error {
+	if c.httpClient != nil {
+		c.httpClient.CloseIdleConnections()
+	}
 	if c.conn != nil {
 		return c.conn.Close()
 	}
@@ -129,6 +140,10 @@ func (c *Client) ExecuteWithCwd(operation string, args interface{}, cwd string)
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	if c.IsRemote() {
+		return c.executeHTTP(req)
+	}
+
 	if c.timeout > 0 {
 		deadline := time.Now().Add(c.timeout)
 		if err := c.conn.SetDeadline(deadline); err != nil {
@@ -250,11 +265,21 @@ func (c *Client) List(args *ListArgs) (*Response, error) {
 	return c.Execute(OpList, args)
 }
 
+// Search runs a ranked full-text search via the daemon
+func (c *Client) Search(args *SearchArgs) (*Response, error) {
+	return c.Execute(OpSearch, args)
+}
+
 // Show shows an issue via the daemon
 func (c *Client) Show(args *ShowArgs) (*Response, error) {
 	return c.Execute(OpShow, args)
 }
 
+// History returns an issue's change history via the daemon
+func (c *Client) History(args *HistoryArgs) (*Response, error) {
+	return c.Execute(OpHistory, args)
+}
+
 // ResolveID resolves a partial issue ID to a full ID via the daemon
 func (c *Client) ResolveID(args *ResolveIDArgs) (*Response, error) {
 	return c.Execute(OpResolveID, args)
@@ -265,6 +290,16 @@ func (c *Client) Ready(args *ReadyArgs) (*Response, error) {
 	return c.Execute(OpReady, args)
 }
 
+// Claim atomically claims the next ready issue via the daemon
+func (c *Client) Claim(args *ClaimArgs) (*Response, error) {
+	return c.Execute(OpClaim, args)
+}
+
+// Heartbeat renews the caller's lease on an in_progress issue via the daemon
+func (c *Client) Heartbeat(args *HeartbeatArgs) (*Response, error) {
+	return c.Execute(OpHeartbeat, args)
+}
+
 // Stats gets statistics via the daemon
 func (c *Client) Stats() (*Response, error) {
 	return c.Execute(OpStats, nil)
@@ -280,6 +315,11 @@ func (c *Client) RemoveDependency(args *DepRemoveArgs) (*Response, error) {
 	return c.Execute(OpDepRemove, args)
 }
 
+// DepTree gets the dependency tree of an issue via the daemon
+func (c *Client) DepTree(args *DepTreeArgs) (*Response, error) {
+	return c.Execute(OpDepTree, args)
+}
+
 // AddLabel adds a label via the daemon
 func (c *Client) AddLabel(args *LabelAddArgs) (*Response, error) {
 	return c.Execute(OpLabelAdd, args)
@@ -314,3 +354,81 @@ func (c *Client) Export(args *ExportArgs) (*Response, error) {
 func (c *Client) EpicStatus(args *EpicStatusArgs) (*Response, error) {
 	return c.Execute(OpEpicStatus, args)
 }
+
+// Subscribe streams mutation events to handle until the daemon closes the
+// connection or handle returns an error, which Subscribe returns. The client
+// cannot be used for other requests afterwards.
+func (c *Client) Subscribe(args *SubscribeArgs, handle func(MutationEvent) error) error {
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to marshal args: %w", err)
+	}
+	cwd, _ := os.Getwd()
+	req := Request{
+		Operation:     OpSubscribe,
+		Args:          argsJSON,
+		ClientVersion: ClientVersion,
+		Cwd:           cwd,
+		ExpectedDB:    c.dbPath,
+	}
+
+	// One reader for the reply and the stream, so no buffered events are lost
+	var reader *bufio.Reader
+	if c.IsRemote() {
+		httpResp, err := c.postHTTP(context.Background(), req)
+		if err != nil {
+			return err
+		}
+		defer httpResp.Body.Close()
+		reader = bufio.NewReader(httpResp.Body)
+	} else {
+		reqJSON, err := json.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		if c.timeout > 0 {
+			if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+				return fmt.Errorf("failed to set deadline: %w", err)
+			}
+		}
+		if _, err := c.conn.Write(append(reqJSON, '\n')); err != nil {
+			return fmt.Errorf("failed to write request: %w", err)
+		}
+		reader = bufio.NewReader(c.conn)
+	}
+
+	respLine, err := reader.ReadBytes('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	var resp Response
+	if err := json.Unmarshal(respLine, &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("operation failed: %s", resp.Error)
+	}
+
+	// Events arrive whenever they happen
+	if !c.IsRemote() {
+		if err := c.conn.SetDeadline(time.Time{}); err != nil {
+			return fmt.Errorf("failed to clear deadline: %w", err)
+		}
+	}
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read event: %w", err)
+		}
+		var event MutationEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal event: %w", err)
+		}
+		if err := handle(event); err != nil {
+			return err
+		}
+	}
+}
@@ -142,6 +142,38 @@ func TestRemoveDependency(t *testing.T) {
 	}
 }
 
+func TestDepTree(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+	defer client.Close()
+
+	blocked := createTestIssue(t, client, "Blocked")
+	blocker := createTestIssue(t, client, "Blocker")
+	if _, err := client.AddDependency(&DepAddArgs{FromID: blocked, ToID: blocker, DepType: "blocks"}); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	for _, tc := range []struct {
+		root, child string
+		reverse     bool
+	}{
+		{blocked, blocker, false},
+		{blocker, blocked, true},
+	} {
+		resp, err := client.DepTree(&DepTreeArgs{ID: tc.root, Reverse: tc.reverse})
+		if err != nil {
+			t.Fatalf("DepTree failed: %v", err)
+		}
+		var tree []*types.TreeNode
+		if err := json.Unmarshal(resp.Data, &tree); err != nil {
+			t.Fatalf("failed to decode tree: %v", err)
+		}
+		if len(tree) != 2 || tree[0].ID != tc.root || tree[1].ID != tc.child || tree[1].Depth != 1 {
+			t.Errorf("unexpected tree for %s (reverse=%v): %+v", tc.root, tc.reverse, tree)
+		}
+	}
+}
+
 func TestAddLabel(t *testing.T) {
 	_, client, cleanup := setupTestServer(t)
 	defer cleanup()
@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestHeartbeatAndReap(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	id := createTestIssue(t, client, "Keep me alive")
+	if _, err := client.Claim(&ClaimArgs{Agent: "worker-1", TTL: "1s"}); err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+
+	resp, err := client.Heartbeat(&HeartbeatArgs{ID: id, Agent: "worker-1", TTL: "1h"})
+	if err != nil {
+		t.Fatalf("heartbeat failed: %v", err)
+	}
+	var lease types.Lease
+	if err := json.Unmarshal(resp.Data, &lease); err != nil {
+		t.Fatalf("failed to decode lease: %v", err)
+	}
+	if lease.Holder != "worker-1" || time.Until(lease.ExpiresAt) < 59*time.Minute {
+		t.Errorf("heartbeat did not renew the lease: %+v", lease)
+	}
+	if _, err := client.Heartbeat(&HeartbeatArgs{ID: id, Agent: "worker-2"}); err == nil {
+		t.Error("expected a heartbeat from another agent to be rejected")
+	}
+
+	// A short heartbeat lets the lease lapse; the reaper returns the issue to open
+	if _, err := client.Heartbeat(&HeartbeatArgs{ID: id, Agent: "worker-1", TTL: "1ms"}); err != nil {
+		t.Fatalf("heartbeat failed: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	updates := subscribeAsync(t, server, client, &SubscribeArgs{Types: []string{EventUpdate}}, 1)
+	released, err := server.ReapExpiredLeases(context.Background())
+	if err != nil {
+		t.Fatalf("ReapExpiredLeases failed: %v", err)
+	}
+	if len(released) != 1 || released[0].IssueID != id || released[0].Holder != "worker-1" {
+		t.Fatalf("expected worker-1's lease on %s released, got %+v", id, released)
+	}
+	if events := receive(t, updates); len(events) != 1 || events[0].IssueID != id {
+		t.Errorf("unexpected update events: %+v", events)
+	}
+
+	issue, err := server.storage.GetIssue(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if issue.Status != types.StatusOpen || issue.Assignee != "" {
+		t.Errorf("reaped issue not returned to open: status=%s assignee=%s", issue.Status, issue.Assignee)
+	}
+}
+
+func TestLeaseTTLDefaultAndUnleasedReap(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	// Claims without a TTL use the server's lease TTL
+	server.SetLeaseTTL(2 * time.Hour)
+	createTestIssue(t, client, "Claim me")
+	resp, err := client.Claim(&ClaimArgs{Agent: "worker-1"})
+	if err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+	var claim types.Claim
+	if err := json.Unmarshal(resp.Data, &claim); err != nil || claim.Lease == nil {
+		t.Fatalf("failed to decode claim: %v", err)
+	}
+	if ttl := claim.Lease.ExpiresAt.Sub(claim.Lease.ClaimedAt); ttl != 2*time.Hour {
+		t.Errorf("claim lease lasts %v, want the server's 2h", ttl)
+	}
+
+	// An issue set in_progress without a claim is reaped once idle for the TTL
+	id := createTestIssue(t, client, "Started by hand")
+	status := string(types.StatusInProgress)
+	if _, err := client.Update(&UpdateArgs{ID: id, Status: &status}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	released, err := server.ReapUnleasedWork(context.Background())
+	if err != nil {
+		t.Fatalf("ReapUnleasedWork failed: %v", err)
+	}
+	if len(released) != 0 {
+		t.Fatalf("reaped work updated within the TTL: %+v", released)
+	}
+
+	// Work idle since before the server started gets the TTL from the start
+	server.SetLeaseTTL(time.Hour)
+	db := server.storage.(*sqlite.SQLiteStorage).UnderlyingDB()
+	if _, err := db.Exec("UPDATE issues SET updated_at = ? WHERE id = ?", time.Now().Add(-2*time.Hour), id); err != nil {
+		t.Fatalf("failed to age issue: %v", err)
+	}
+	unleased, err := server.UnleasedWork(context.Background())
+	if err != nil {
+		t.Fatalf("UnleasedWork failed: %v", err)
+	}
+	if len(unleased) != 1 || unleased[0].ID != id {
+		t.Fatalf("expected only %s unleased, got %+v", id, unleased)
+	}
+	if deadline := server.UnleasedDeadline(unleased[0]); !deadline.Equal(server.startTime.Add(time.Hour)) {
+		t.Errorf("deadline = %v, want an hour after the server start", deadline)
+	}
+	released, err = server.ReapUnleasedWork(context.Background())
+	if err != nil {
+		t.Fatalf("ReapUnleasedWork failed: %v", err)
+	}
+	if len(released) != 0 {
+		t.Fatalf("reaped work within the TTL of the server start: %+v", released)
+	}
+
+	server.startTime = server.startTime.Add(-time.Hour)
+	released, err = server.ReapUnleasedWork(context.Background())
+	if err != nil {
+		t.Fatalf("ReapUnleasedWork failed: %v", err)
+	}
+	if len(released) != 1 || released[0].ID != id {
+		t.Fatalf("expected %s reaped, got %+v", id, released)
+	}
+	issue, err := server.storage.GetIssue(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if issue.Status != types.StatusOpen {
+		t.Errorf("reaped issue not returned to open: status=%s", issue.Status)
+	}
+}
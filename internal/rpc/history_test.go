@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/history"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestHistoryViaRPC(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	resp, err := client.Create(&CreateArgs{Title: "Flaky login test", Description: "Fails on CI\nPasses locally", IssueType: "bug", Priority: 2})
+	if err != nil {
+		t.Fatalf("create issue failed: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		t.Fatalf("failed to decode issue: %v", err)
+	}
+
+	status, assignee, description := "in_progress", "alice", "Fails on CI\nRace in session setup"
+	if _, err := client.Update(&UpdateArgs{ID: issue.ID, Status: &status, Assignee: &assignee, Description: &description}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if _, err := client.AddLabel(&LabelAddArgs{ID: issue.ID, Label: "ci"}); err != nil {
+		t.Fatalf("add label failed: %v", err)
+	}
+
+	resp, err = client.History(&HistoryArgs{ID: issue.ID})
+	if err != nil {
+		t.Fatalf("history failed: %v", err)
+	}
+	var entries []*history.Entry
+	if err := json.Unmarshal(resp.Data, &entries); err != nil {
+		t.Fatalf("failed to decode history: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].EventType != types.EventCreated {
+		t.Errorf("first entry = %s, want created", entries[0].EventType)
+	}
+
+	changes := map[string]history.Change{}
+	for _, c := range entries[1].Changes {
+		changes[c.Field] = c
+	}
+	if c := changes["status"]; c.Old != "open" || c.New != "in_progress" {
+		t.Errorf("status change = %+v", c)
+	}
+	if c := changes["assignee"]; c.Old != "" || c.New != "alice" {
+		t.Errorf("assignee change = %+v", c)
+	}
+	if c := changes["description"]; !strings.Contains(c.Diff, "-Passes locally\n+Race in session setup\n") {
+		t.Errorf("description diff = %q", c.Diff)
+	}
+	if got := entries[2].Changes; len(got) != 1 || got[0].Field != history.FieldLabel || got[0].New != "ci" {
+		t.Errorf("label change = %+v", got)
+	}
+
+	// Limit keeps the most recent entries
+	resp, err = client.History(&HistoryArgs{ID: issue.ID, Limit: 1})
+	if err != nil {
+		t.Fatalf("history failed: %v", err)
+	}
+	entries = nil
+	if err := json.Unmarshal(resp.Data, &entries); err != nil {
+		t.Fatalf("failed to decode history: %v", err)
+	}
+	if len(entries) != 1 || entries[0].EventType != types.EventLabelAdded {
+		t.Errorf("expected only the label entry, got %+v", entries)
+	}
+
+	if _, err := client.History(&HistoryArgs{ID: "bd-missing"}); err == nil {
+		t.Error("expected error for missing issue")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/shaneholloman/beads/internal/compact"
 )
 
 // Metrics holds all telemetry data for the daemon
@@ -173,6 +175,8 @@ type MetricsSnapshot struct {
 	MemoryAllocMB  uint64             `json:"memory_alloc_mb"`
 	MemorySysMB    uint64             `json:"memory_sys_mb"`
 	GoroutineCount int                `json:"goroutine_count"`
+
+	AutoCompact *compact.AutoStatus `json:"auto_compact,omitempty"` // Background compaction, SQLite only
 }
 
 // OperationMetrics holds metrics for a single operation type
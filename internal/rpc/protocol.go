@@ -2,6 +2,9 @@ package rpc
 
 import (
 	"encoding/json"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/compact"
 )
 
 // Operation constants for all beads commands
@@ -14,8 +17,12 @@ const (
 	OpUpdate      = "update"
 	OpClose       = "close"
 	OpList        = "list"
+	OpSearch      = "search"
 	OpShow        = "show"
+	OpHistory     = "history"
 	OpReady       = "ready"
+	OpClaim       = "claim"
+	OpHeartbeat   = "heartbeat"
 	OpStats       = "stats"
 	OpDepAdd      = "dep_add"
 	OpDepRemove   = "dep_remove"
@@ -33,6 +40,7 @@ const (
 	OpImport       = "import"
 	OpEpicStatus   = "epic_status"
 	OpShutdown     = "shutdown"
+	OpSubscribe    = "subscribe"
 )
 
 // Request represents an RPC request from client to daemon
@@ -55,16 +63,18 @@ type Response struct {
 
 // CreateArgs represents arguments for the create operation
 type CreateArgs struct {
-	ID                 string   `json:"id,omitempty"`
-	Title              string   `json:"title"`
-	Description        string   `json:"description,omitempty"`
-	IssueType          string   `json:"issue_type"`
-	Priority           int      `json:"priority"`
-	Design             string   `json:"design,omitempty"`
-	AcceptanceCriteria string   `json:"acceptance_criteria,omitempty"`
-	Assignee           string   `json:"assignee,omitempty"`
-	Labels             []string `json:"labels,omitempty"`
-	Dependencies       []string `json:"dependencies,omitempty"`
+	ID                 string     `json:"id,omitempty"`
+	Title              string     `json:"title"`
+	Description        string     `json:"description,omitempty"`
+	IssueType          string     `json:"issue_type"`
+	Priority           int        `json:"priority"`
+	Design             string     `json:"design,omitempty"`
+	AcceptanceCriteria string     `json:"acceptance_criteria,omitempty"`
+	Assignee           string     `json:"assignee,omitempty"`
+	Labels             []string   `json:"labels,omitempty"`
+	Dependencies       []string   `json:"dependencies,omitempty"`
+	EstimatedMinutes   *int       `json:"estimated_minutes,omitempty"`
+	DueAt              *time.Time `json:"due_at,omitempty"`
 }
 
 // UpdateArgs represents arguments for the update operation
@@ -78,6 +88,9 @@ type UpdateArgs struct {
 	AcceptanceCriteria *string `json:"acceptance_criteria,omitempty"`
 	Notes              *string `json:"notes,omitempty"`
 	Assignee           *string `json:"assignee,omitempty"`
+	EstimatedMinutes   *int    `json:"estimated_minutes,omitempty"`
+	Recurrence         *string `json:"recurrence,omitempty"` // Empty string stops recurring
+	DueAt              *string `json:"due_at,omitempty"`     // RFC 3339; empty string clears the due date
 }
 
 // CloseArgs represents arguments for the close operation
@@ -97,6 +110,19 @@ type ListArgs struct {
 	Labels    []string `json:"labels,omitempty"`     // AND semantics
 	LabelsAny []string `json:"labels_any,omitempty"` // OR semantics
 	IDs       []string `json:"ids,omitempty"`        // Filter by specific issue IDs
+	Filter    string   `json:"filter,omitempty"`     // Structured filter expression (see query.Parse)
+	Limit     int      `json:"limit,omitempty"`
+}
+
+// SearchArgs represents arguments for the full-text search operation
+type SearchArgs struct {
+	Query     string   `json:"query"`
+	Status    string   `json:"status,omitempty"`
+	Priority  *int     `json:"priority,omitempty"`
+	IssueType string   `json:"issue_type,omitempty"`
+	Assignee  string   `json:"assignee,omitempty"`
+	Labels    []string `json:"labels,omitempty"`     // AND semantics
+	LabelsAny []string `json:"labels_any,omitempty"` // OR semantics
 	Limit     int      `json:"limit,omitempty"`
 }
 
@@ -105,6 +131,12 @@ type ShowArgs struct {
 	ID string `json:"id"`
 }
 
+// HistoryArgs represents arguments for the history operation
+type HistoryArgs struct {
+	ID    string `json:"id"`
+	Limit int    `json:"limit,omitempty"` // Most recent entries only
+}
+
 // ResolveIDArgs represents arguments for the resolve_id operation
 type ResolveIDArgs struct {
 	ID string `json:"id"`
@@ -118,6 +150,20 @@ type ReadyArgs struct {
 	SortPolicy string `json:"sort_policy,omitempty"`
 }
 
+// ClaimArgs represents arguments for the claim operation
+type ClaimArgs struct {
+	Agent    string `json:"agent,omitempty"` // Lease holder (default: request actor)
+	TTL      string `json:"ttl,omitempty"`   // Lease duration, e.g. "30m" (default: the lease-ttl setting)
+	Priority *int   `json:"priority,omitempty"`
+}
+
+// HeartbeatArgs represents arguments for the heartbeat operation
+type HeartbeatArgs struct {
+	ID    string `json:"id"`
+	Agent string `json:"agent,omitempty"` // Lease holder (default: request actor)
+	TTL   string `json:"ttl,omitempty"`   // Lease extension, e.g. "30m" (default: the lease-ttl setting)
+}
+
 // DepAddArgs represents arguments for adding a dependency
 type DepAddArgs struct {
 	FromID  string `json:"from_id"`
@@ -136,6 +182,7 @@ type DepRemoveArgs struct {
 type DepTreeArgs struct {
 	ID       string `json:"id"`
 	MaxDepth int    `json:"max_depth,omitempty"`
+	Reverse  bool   `json:"reverse,omitempty"` // Show dependents instead of dependencies
 }
 
 // LabelAddArgs represents arguments for adding a label
@@ -251,16 +298,20 @@ type CompactResponse struct {
 	Reduction     string            `json:"reduction,omitempty"`
 	Duration      string            `json:"duration,omitempty"`
 	DryRun        bool              `json:"dry_run,omitempty"`
+	Staged        bool              `json:"staged,omitempty"`       // Summary staged for review (compact_mode pending_review)
+	MissingRefs   []string          `json:"missing_refs,omitempty"` // References the summary dropped
 }
 
 // CompactResult represents the result of compacting a single issue
 type CompactResult struct {
-	IssueID       string `json:"issue_id"`
-	Success       bool   `json:"success"`
-	Error         string `json:"error,omitempty"`
-	OriginalSize  int    `json:"original_size,omitempty"`
-	CompactedSize int    `json:"compacted_size,omitempty"`
-	Reduction     string `json:"reduction,omitempty"`
+	IssueID       string   `json:"issue_id"`
+	Success       bool     `json:"success"`
+	Error         string   `json:"error,omitempty"`
+	OriginalSize  int      `json:"original_size,omitempty"`
+	CompactedSize int      `json:"compacted_size,omitempty"`
+	Reduction     string   `json:"reduction,omitempty"`
+	Staged        bool     `json:"staged,omitempty"`
+	MissingRefs   []string `json:"missing_refs,omitempty"`
 }
 
 // CompactStatsData represents compaction statistics
@@ -271,6 +322,8 @@ type CompactStatsData struct {
 	Tier1MinAge      string `json:"tier1_min_age"`
 	Tier2MinAge      string `json:"tier2_min_age"`
 	EstimatedSavings string `json:"estimated_savings,omitempty"`
+
+	AutoCompact *compact.AutoStatus `json:"auto_compact,omitempty"` // Background compaction by the daemon
 }
 
 // ExportArgs represents arguments for the export operation
@@ -282,3 +335,10 @@ type ExportArgs struct {
 type ImportArgs struct {
 	JSONLPath string `json:"jsonl_path"` // Path to import JSONL file
 }
+
+// SubscribeArgs represents arguments for the subscribe operation. After the
+// initial response the connection streams one MutationEvent per line.
+type SubscribeArgs struct {
+	Types    []string `json:"types,omitempty"`     // Event types to receive (default: all)
+	IssueIDs []string `json:"issue_ids,omitempty"` // Only events for these issues (default: all)
+}
@@ -231,6 +231,49 @@ func TestUpdateIssue(t *testing.T) {
 	}
 }
 
+func TestUpdateIssueDueAt(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	due := time.Date(2030, 1, 31, 17, 0, 0, 0, time.UTC)
+	createResp, err := client.Create(&CreateArgs{Title: "Has a deadline", IssueType: "task", Priority: 2, DueAt: &due})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	json.Unmarshal(createResp.Data, &issue)
+	if issue.DueAt == nil || !issue.DueAt.Equal(due) {
+		t.Fatalf("Expected due date %v, got %v", due, issue.DueAt)
+	}
+
+	later := due.Add(24 * time.Hour).Format(time.RFC3339)
+	updateResp, err := client.Update(&UpdateArgs{ID: issue.ID, DueAt: &later})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	var updated types.Issue
+	json.Unmarshal(updateResp.Data, &updated)
+	if updated.DueAt == nil || !updated.DueAt.Equal(due.Add(24*time.Hour)) {
+		t.Errorf("Expected due date moved a day later, got %v", updated.DueAt)
+	}
+
+	none := ""
+	updateResp, err = client.Update(&UpdateArgs{ID: issue.ID, DueAt: &none})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	updated = types.Issue{}
+	json.Unmarshal(updateResp.Data, &updated)
+	if updated.DueAt != nil {
+		t.Errorf("Expected due date cleared, got %v", updated.DueAt)
+	}
+
+	invalid := "next tuesday"
+	if _, err := client.Update(&UpdateArgs{ID: issue.ID, DueAt: &invalid}); err == nil {
+		t.Error("Expected an invalid due date to be rejected")
+	}
+}
+
 func TestCloseIssue(t *testing.T) {
 	_, client, cleanup := setupTestServer(t)
 	defer cleanup()
@@ -313,6 +356,50 @@ func TestListIssues(t *testing.T) {
 	}
 }
 
+func TestListIssuesWithFilter(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	for _, args := range []*CreateArgs{
+		{Title: "Urgent bug", IssueType: "bug", Priority: 0, Labels: []string{"backend"}},
+		{Title: "Minor bug", IssueType: "bug", Priority: 3},
+		{Title: "Urgent task", IssueType: "task", Priority: 1, Labels: []string{"backend", "wontfix"}},
+	} {
+		if _, err := client.Create(args); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	resp, err := client.List(&ListArgs{Filter: "priority<=1 label:backend -label:wontfix"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	var issues []types.Issue
+	if err := json.Unmarshal(resp.Data, &issues); err != nil {
+		t.Fatalf("Failed to unmarshal issues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "Urgent bug" {
+		t.Errorf("Expected only \"Urgent bug\", got %+v", issues)
+	}
+
+	// The filter is ANDed with the other arguments
+	resp, err = client.List(&ListArgs{Filter: "priority<=1", IssueType: "task"})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	issues = nil
+	if err := json.Unmarshal(resp.Data, &issues); err != nil {
+		t.Fatalf("Failed to unmarshal issues: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "Urgent task" {
+		t.Errorf("Expected only \"Urgent task\", got %+v", issues)
+	}
+
+	if _, err := client.List(&ListArgs{Filter: "colour:red"}); err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Errorf("Expected invalid filter error, got %v", err)
+	}
+}
+
 func TestSocketCleanup(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "beads-rpc-cleanup-test-*")
 	if err != nil {
@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestSearchViaRPC(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	for _, args := range []*CreateArgs{
+		{Title: "Websocket reconnect loop", Description: "Client never backs off", IssueType: "bug", Priority: 1},
+		{Title: "Dashboard polish", Description: "Switch polling to websockets", IssueType: "feature", Priority: 3},
+		{Title: "Unrelated chore", IssueType: "chore", Priority: 2},
+	} {
+		if _, err := client.Create(args); err != nil {
+			t.Fatalf("create issue failed: %v", err)
+		}
+	}
+
+	resp, err := client.Search(&SearchArgs{Query: "websocket"})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	var results []*types.SearchResult
+	if err := json.Unmarshal(resp.Data, &results); err != nil {
+		t.Fatalf("failed to decode search response: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Title != "Websocket reconnect loop" {
+		t.Errorf("expected title match first, got %q", results[0].Title)
+	}
+	if !strings.Contains(results[1].Snippet, "**websockets**") {
+		t.Errorf("expected highlighted snippet, got %q", results[1].Snippet)
+	}
+
+	resp, err = client.Search(&SearchArgs{Query: "websocket", IssueType: "feature"})
+	if err != nil {
+		t.Fatalf("filtered search failed: %v", err)
+	}
+	results = nil
+	if err := json.Unmarshal(resp.Data, &results); err != nil {
+		t.Fatalf("failed to decode search response: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "Dashboard polish" {
+		t.Errorf("expected only the feature, got %+v", results)
+	}
+
+	if _, err := client.Search(&SearchArgs{Query: `""`}); err == nil {
+		t.Error("expected error for query without searchable terms")
+	}
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -28,6 +29,16 @@ func (s *Server) handleCompact(req *Request) Response {
 		}
 	}
 
+	if !args.DryRun {
+		model, _ := sqliteStore.GetConfig(s.reqCtx(req), "compact_model")
+		if err := compact.CheckAPIKey(model, args.APIKey); err != nil {
+			return Response{
+				Success: false,
+				Error:   err.Error(),
+			}
+		}
+	}
+
 	config := &compact.Config{
 		APIKey:      args.APIKey,
 		Concurrency: args.Workers,
@@ -50,7 +61,7 @@ func (s *Server) handleCompact(req *Request) Response {
 
 	if args.IssueID != "" {
 		if !args.Force {
-			eligible, reason, err := sqliteStore.CheckEligibility(ctx, args.IssueID, args.Tier)
+			eligible, reason, err := compact.CheckEligibility(ctx, sqliteStore, args.IssueID, args.Tier)
 			if err != nil {
 				return Response{
 					Success: false,
@@ -83,6 +94,9 @@ func (s *Server) handleCompact(req *Request) Response {
 				Reduction:    "70-80%",
 				DryRun:       true,
 			}
+			if args.Tier == 2 {
+				result.Reduction = "90-95%"
+			}
 			data, _ := json.Marshal(result)
 			return Response{
 				Success: true,
@@ -90,15 +104,11 @@ func (s *Server) handleCompact(req *Request) Response {
 			}
 		}
 
-		if args.Tier == 1 {
-			err = compactor.CompactTier1(ctx, args.IssueID)
-		} else {
-			return Response{
-				Success: false,
-				Error:   "Tier 2 compaction not yet implemented",
-			}
+		tier := args.Tier
+		if tier != 2 {
+			tier = 1
 		}
-
+		compacted, err := compactor.Compact(ctx, args.IssueID, tier)
 		if err != nil {
 			return Response{
 				Success: false,
@@ -106,12 +116,7 @@ func (s *Server) handleCompact(req *Request) Response {
 			}
 		}
 
-		issueAfter, _ := sqliteStore.GetIssue(ctx, args.IssueID)
-		compactedSize := 0
-		if issueAfter != nil {
-			compactedSize = len(issueAfter.Description)
-		}
-
+		compactedSize := compacted.CompactedSize
 		duration := time.Since(startTime)
 		result := CompactResponse{
 			Success:       true,
@@ -120,6 +125,8 @@ func (s *Server) handleCompact(req *Request) Response {
 			CompactedSize: compactedSize,
 			Reduction:     fmt.Sprintf("%.1f%%", float64(originalSize-compactedSize)/float64(originalSize)*100),
 			Duration:      duration.String(),
+			Staged:        compacted.Staged,
+			MissingRefs:   compacted.MissingRefs,
 		}
 		data, _ := json.Marshal(result)
 		return Response{
@@ -142,7 +149,7 @@ func (s *Server) handleCompact(req *Request) Response {
 			}
 			candidates = tier1
 		case 2:
-			tier2, err := sqliteStore.GetTier2Candidates(ctx)
+			tier2, err := compact.Tier2Candidates(ctx, sqliteStore)
 			if err != nil {
 				return Response{
 					Success: false,
@@ -174,7 +181,12 @@ func (s *Server) handleCompact(req *Request) Response {
 			issueIDs[i] = c.IssueID
 		}
 
-		batchResults, err := compactor.CompactTier1Batch(ctx, issueIDs)
+		var batchResults []*compact.Result
+		if args.Tier == 2 {
+			batchResults, err = compactor.CompactTier2Batch(ctx, issueIDs)
+		} else {
+			batchResults, err = compactor.CompactTier1Batch(ctx, issueIDs)
+		}
 		if err != nil {
 			return Response{
 				Success: false,
@@ -189,6 +201,8 @@ func (s *Server) handleCompact(req *Request) Response {
 				Success:       r.Err == nil,
 				OriginalSize:  r.OriginalSize,
 				CompactedSize: r.CompactedSize,
+				Staged:        r.Staged,
+				MissingRefs:   r.MissingRefs,
 			}
 			if r.Err != nil {
 				result.Error = r.Err.Error()
@@ -247,7 +261,7 @@ func (s *Server) handleCompactStats(req *Request) Response {
 		}
 	}
 
-	tier2, err := sqliteStore.GetTier2Candidates(ctx)
+	tier2, err := compact.Tier2Candidates(ctx, sqliteStore)
 	if err != nil {
 		return Response{
 			Success: false,
@@ -261,6 +275,7 @@ func (s *Server) handleCompactStats(req *Request) Response {
 		Tier1MinAge:     "30 days",
 		Tier2MinAge:     "90 days",
 		TotalClosed:     0, // Could query for this but not critical
+		AutoCompact:     s.autoCompactStatus(ctx),
 	}
 
 	result := CompactResponse{
@@ -273,3 +288,36 @@ func (s *Server) handleCompactStats(req *Request) Response {
 		Data:    data,
 	}
 }
+
+// AutoCompact runs a background compaction pass if auto_compact_enabled is
+// set, the daemon has been idle long enough, and the run interval and daily
+// budget allow it. It returns nil if nothing ran.
+func (s *Server) AutoCompact(ctx context.Context) (*compact.AutoRun, error) {
+	sqliteStore, ok := s.storage.(*sqlite.SQLiteStorage)
+	if !ok {
+		return nil, nil
+	}
+
+	lastActivity, _ := s.lastActivityTime.Load().(time.Time)
+	run, err := compact.RunAuto(ctx, sqliteStore, time.Since(lastActivity), time.Now())
+	if run != nil {
+		for _, id := range run.IssueIDs {
+			s.emitMutation(MutationEvent{Type: EventUpdate, IssueID: id, Actor: compact.AutoActor})
+		}
+	}
+	return run, err
+}
+
+// autoCompactStatus reports background compaction, or nil for storage that
+// cannot be compacted
+func (s *Server) autoCompactStatus(ctx context.Context) *compact.AutoStatus {
+	sqliteStore, ok := s.storage.(*sqlite.SQLiteStorage)
+	if !ok {
+		return nil
+	}
+	status, err := compact.LoadAutoStatus(ctx, sqliteStore, time.Now())
+	if err != nil {
+		return nil
+	}
+	return status
+}
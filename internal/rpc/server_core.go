@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -8,7 +9,9 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/shaneholloman/beads/internal/federation"
 	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
 )
 
 // ServerVersion is the version of this RPC server
@@ -49,13 +52,39 @@ type Server struct {
 	// Mutation events for event-driven daemon
 	mutationChan  chan MutationEvent
 	droppedEvents atomic.Int64 // Counter for dropped mutation events
+	// Mutation event subscribers (OpSubscribe streams, webhooks)
+	subMu       sync.Mutex
+	subscribers map[*subscription]struct{}
+	// Repository registry for cross-repository references (alias → path)
+	repos map[string]string
+	// Other repositories' databases, opened read-only for ready requests
+	foreignStores *federation.StoreCache
+	// Lease duration for claims and heartbeats that do not give one
+	leaseTTL time.Duration
 }
 
-// MutationEvent represents a database mutation for event-driven sync
+// Mutation event types
+const (
+	EventCreate    = "create"
+	EventUpdate    = "update"
+	EventClose     = "close"
+	EventDelete    = "delete"
+	EventComment   = "comment"
+	EventDepAdd    = "dep_add"
+	EventDepRemove = "dep_remove"
+)
+
+// EventTypes lists the mutation event types in a stable order
+var EventTypes = []string{EventCreate, EventUpdate, EventClose, EventDelete, EventComment, EventDepAdd, EventDepRemove}
+
+// MutationEvent represents a database mutation, consumed by the event-driven
+// sync loop and streamed to subscribers
 type MutationEvent struct {
-	Type      string // "create", "update", "delete", "comment"
-	IssueID   string // e.g., "beads-42"
-	Timestamp time.Time
+	Type        string    `json:"type"`                    // One of the Event* constants
+	IssueID     string    `json:"issue_id"`                // e.g., "beads-42"
+	DependsOnID string    `json:"depends_on_id,omitempty"` // Dependency target for dep_add/dep_remove
+	Actor       string    `json:"actor,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
 }
 
 // NewServer creates a new RPC server
@@ -84,31 +113,55 @@ func NewServer(socketPath string, store storage.Storage, workspacePath string, d
 		shutdownChan:   make(chan struct{}),
 		doneChan:       make(chan struct{}),
 		startTime:      time.Now(),
+		foreignStores:  federation.NewStoreCache(),
 		metrics:        NewMetrics(),
 		maxConns:       maxConns,
 		connSemaphore:  make(chan struct{}, maxConns),
 		requestTimeout: requestTimeout,
 		readyChan:      make(chan struct{}),
 		mutationChan:   make(chan MutationEvent, 100), // Buffered to avoid blocking
+		subscribers:    make(map[*subscription]struct{}),
+		leaseTTL:       types.DefaultLeaseTTL,
 	}
 	s.lastActivityTime.Store(time.Now())
 	return s
 }
 
-// emitMutation sends a mutation event to the daemon's event-driven loop.
+// Execute runs an operation in-process, without a socket, and reports
+// failures the way Client.Execute does. It lets callers that hold the storage
+// themselves, such as 'beads mcp' in direct mode, reuse the daemon's handlers.
+func (s *Server) Execute(operation string, args interface{}) (*Response, error) {
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal args: %w", err)
+	}
+	resp := s.handleRequest(&Request{
+		Operation:     operation,
+		Args:          argsJSON,
+		ClientVersion: ServerVersion,
+		ExpectedDB:    s.storage.Path(),
+	})
+	if !resp.Success {
+		return &resp, fmt.Errorf("operation failed: %s", resp.Error)
+	}
+	return &resp, nil
+}
+
+// emitMutation sends a mutation event to the daemon's event-driven loop and
+// to any subscribers.
 // Non-blocking: drops event if channel is full (sync will happen eventually).
-func (s *Server) emitMutation(eventType, issueID string) {
+func (s *Server) emitMutation(event MutationEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
 	select {
-	case s.mutationChan <- MutationEvent{
-		Type:      eventType,
-		IssueID:   issueID,
-		Timestamp: time.Now(),
-	}:
+	case s.mutationChan <- event:
 		// Event sent successfully
 	default:
 		// Channel full, increment dropped events counter
 		s.droppedEvents.Add(1)
 	}
+	s.publish(event)
 }
 
 // MutationChan returns the mutation event channel for the daemon to consume
@@ -120,3 +173,33 @@ func (s *Server) MutationChan() <-chan MutationEvent {
 func (s *Server) ResetDroppedEventsCount() int64 {
 	return s.droppedEvents.Swap(0)
 }
+
+// SetRepos sets the repository registry (see config.LoadRepos) used to check
+// blockers in other repositories
+func (s *Server) SetRepos(repos map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.repos = repos
+}
+
+// SetLeaseTTL sets the lease duration (see config.LoadLeaseTTL) used for
+// claims and heartbeats that do not give one, and by the lease reaper
+func (s *Server) SetLeaseTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leaseTTL = ttl
+}
+
+// LeaseTTL returns the default lease duration
+func (s *Server) LeaseTTL() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.leaseTTL
+}
+
+// Repos returns the repository registry
+func (s *Server) Repos() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.repos
+}
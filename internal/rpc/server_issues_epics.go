@@ -1,10 +1,16 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/shaneholloman/beads/internal/federation"
+	"github.com/shaneholloman/beads/internal/history"
+	"github.com/shaneholloman/beads/internal/query"
+	"github.com/shaneholloman/beads/internal/recur"
 	"github.com/shaneholloman/beads/internal/types"
 	"github.com/shaneholloman/beads/internal/utils"
 )
@@ -49,16 +55,33 @@ func updatesFromArgs(a UpdateArgs) map[string]interface{} {
 		u["priority"] = *a.Priority
 	}
 	if a.Design != nil {
-		u["design"] = a.Design
+		u["design"] = *a.Design
 	}
 	if a.AcceptanceCriteria != nil {
-		u["acceptance_criteria"] = a.AcceptanceCriteria
+		u["acceptance_criteria"] = *a.AcceptanceCriteria
 	}
 	if a.Notes != nil {
-		u["notes"] = a.Notes
+		u["notes"] = *a.Notes
 	}
 	if a.Assignee != nil {
-		u["assignee"] = a.Assignee
+		u["assignee"] = *a.Assignee
+	}
+	if a.EstimatedMinutes != nil {
+		u["estimated_minutes"] = *a.EstimatedMinutes
+	}
+	if a.Recurrence != nil {
+		for k, v := range recur.RuleUpdates(*a.Recurrence, time.Now()) {
+			u[k] = v
+		}
+	}
+	if a.DueAt != nil {
+		if *a.DueAt == "" {
+			u["due_at"] = nil
+		} else if due, err := time.Parse(time.RFC3339Nano, *a.DueAt); err == nil {
+			u["due_at"] = due
+		} else {
+			u["due_at"] = *a.DueAt // Rejected by the storage validator
+		}
 	}
 	return u
 }
@@ -95,6 +118,8 @@ func (s *Server) handleCreate(req *Request) Response {
 		AcceptanceCriteria: strValue(acceptance),
 		Assignee:           strValue(assignee),
 		Status:             types.StatusOpen,
+		EstimatedMinutes:   createArgs.EstimatedMinutes,
+		DueAt:              createArgs.DueAt,
 	}
 
 	ctx := s.reqCtx(req)
@@ -161,7 +186,7 @@ func (s *Server) handleCreate(req *Request) Response {
 	}
 
 	// Emit mutation event for event-driven daemon
-	s.emitMutation("create", issue.ID)
+	s.emitMutation(MutationEvent{Type: EventCreate, IssueID: issue.ID, Actor: s.reqActor(req)})
 
 	data, _ := json.Marshal(issue)
 	return Response{
@@ -195,7 +220,11 @@ func (s *Server) handleUpdate(req *Request) Response {
 	}
 
 	// Emit mutation event for event-driven daemon
-	s.emitMutation("update", updateArgs.ID)
+	eventType := EventUpdate
+	if updateArgs.Status != nil && types.Status(*updateArgs.Status).Category() == types.CategoryDone {
+		eventType = EventClose
+	}
+	s.emitMutation(MutationEvent{Type: eventType, IssueID: updateArgs.ID, Actor: s.reqActor(req)})
 
 	issue, err := store.GetIssue(ctx, updateArgs.ID)
 	if err != nil {
@@ -232,7 +261,7 @@ func (s *Server) handleClose(req *Request) Response {
 	}
 
 	// Emit mutation event for event-driven daemon
-	s.emitMutation("update", closeArgs.ID)
+	s.emitMutation(MutationEvent{Type: EventClose, IssueID: closeArgs.ID, Actor: s.reqActor(req)})
 
 	issue, _ := store.GetIssue(ctx, closeArgs.ID)
 	data, _ := json.Marshal(issue)
@@ -298,6 +327,17 @@ func (s *Server) handleList(req *Request) Response {
 		}
 	}
 
+	if listArgs.Filter != "" {
+		expr, err := query.Parse(listArgs.Filter)
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("invalid filter: %v", err),
+			}
+		}
+		filter.Expr = expr
+	}
+
 	ctx := s.reqCtx(req)
 	issues, err := store.SearchIssues(ctx, listArgs.Query, filter)
 	if err != nil {
@@ -320,6 +360,49 @@ func (s *Server) handleList(req *Request) Response {
 	}
 }
 
+func (s *Server) handleSearch(req *Request) Response {
+	var searchArgs SearchArgs
+	if err := json.Unmarshal(req.Args, &searchArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid search args: %v", err),
+		}
+	}
+
+	filter := types.IssueFilter{
+		Limit:     searchArgs.Limit,
+		Priority:  searchArgs.Priority,
+		Labels:    normalizeLabels(searchArgs.Labels),
+		LabelsAny: normalizeLabels(searchArgs.LabelsAny),
+	}
+	if searchArgs.Status != "" {
+		status := types.Status(searchArgs.Status)
+		filter.Status = &status
+	}
+	if searchArgs.IssueType != "" {
+		issueType := types.IssueType(searchArgs.IssueType)
+		filter.IssueType = &issueType
+	}
+	if searchArgs.Assignee != "" {
+		filter.Assignee = &searchArgs.Assignee
+	}
+
+	ctx := s.reqCtx(req)
+	results, err := s.storage.SearchIssuesRanked(ctx, searchArgs.Query, filter)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to search issues: %v", err),
+		}
+	}
+
+	data, _ := json.Marshal(results)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
 func (s *Server) handleResolveID(req *Request) Response {
 	var args ResolveIDArgs
 	if err := json.Unmarshal(req.Args, &args); err != nil {
@@ -364,6 +447,12 @@ func (s *Server) handleShow(req *Request) Response {
 			Error:   fmt.Sprintf("failed to get issue: %v", err),
 		}
 	}
+	if issue == nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("issue not found: %s", showArgs.ID),
+		}
+	}
 
 	// Populate labels, dependencies, and dependents
 	labels, _ := store.GetLabels(ctx, issue.ID)
@@ -392,6 +481,45 @@ func (s *Server) handleShow(req *Request) Response {
 	}
 }
 
+func (s *Server) handleHistory(req *Request) Response {
+	var historyArgs HistoryArgs
+	if err := json.Unmarshal(req.Args, &historyArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid history args: %v", err),
+		}
+	}
+
+	ctx := s.reqCtx(req)
+	issue, err := s.storage.GetIssue(ctx, historyArgs.ID)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get issue: %v", err),
+		}
+	}
+	if issue == nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("issue not found: %s", historyArgs.ID),
+		}
+	}
+
+	entries, err := history.IssueLog(ctx, s.storage, issue.ID, historyArgs.Limit)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get history: %v", err),
+		}
+	}
+
+	data, _ := json.Marshal(entries)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
 func (s *Server) handleReady(req *Request) Response {
 	var readyArgs ReadyArgs
 	if err := json.Unmarshal(req.Args, &readyArgs); err != nil {
@@ -413,8 +541,13 @@ func (s *Server) handleReady(req *Request) Response {
 		wf.Assignee = &readyArgs.Assignee
 	}
 
+	// Issues blocked by open issues in other repositories aren't ready either.
+	// Their databases stay open in foreignStores between requests.
+	resolver := federation.NewCachedResolver(s.Repos(), s.foreignStores)
+	defer func() { _ = resolver.Close() }()
+
 	ctx := s.reqCtx(req)
-	issues, err := store.GetReadyWork(ctx, wf)
+	issues, err := federation.GetReadyWork(ctx, store, wf, resolver)
 	if err != nil {
 		return Response{
 			Success: false,
@@ -429,6 +562,169 @@ func (s *Server) handleReady(req *Request) Response {
 	}
 }
 
+func (s *Server) handleClaim(req *Request) Response {
+	var claimArgs ClaimArgs
+	if err := json.Unmarshal(req.Args, &claimArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid claim args: %v", err),
+		}
+	}
+
+	ttl := s.LeaseTTL()
+	if claimArgs.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(claimArgs.TTL); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("invalid lease TTL %q: %v", claimArgs.TTL, err),
+			}
+		}
+	}
+	agent := claimArgs.Agent
+	if agent == "" {
+		agent = s.reqActor(req)
+	}
+
+	ctx := s.reqCtx(req)
+	claim, err := s.storage.ClaimReadyWork(ctx, types.WorkFilter{Priority: claimArgs.Priority}, agent, ttl)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to claim work: %v", err),
+		}
+	}
+	if claim != nil {
+		s.emitMutation(MutationEvent{Type: EventUpdate, IssueID: claim.Issue.ID, Actor: agent})
+	}
+
+	data, _ := json.Marshal(claim)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleHeartbeat(req *Request) Response {
+	var heartbeatArgs HeartbeatArgs
+	if err := json.Unmarshal(req.Args, &heartbeatArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid heartbeat args: %v", err),
+		}
+	}
+
+	ttl := s.LeaseTTL()
+	if heartbeatArgs.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(heartbeatArgs.TTL); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("invalid lease TTL %q: %v", heartbeatArgs.TTL, err),
+			}
+		}
+	}
+	agent := heartbeatArgs.Agent
+	if agent == "" {
+		agent = s.reqActor(req)
+	}
+
+	// Heartbeats only touch the lease, not the issue, so no mutation event
+	ctx := s.reqCtx(req)
+	lease, err := s.storage.Heartbeat(ctx, heartbeatArgs.ID, agent, ttl)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to record heartbeat: %v", err),
+		}
+	}
+
+	data, _ := json.Marshal(lease)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+// ReapExpiredLeases returns issues whose lease expired without a heartbeat to
+// open and unassigned, and emits an update event for each. The daemon calls
+// this periodically.
+func (s *Server) ReapExpiredLeases(ctx context.Context) ([]*types.Lease, error) {
+	released, err := s.storage.ReleaseExpiredLeases(ctx, time.Now(), "daemon")
+	if err != nil {
+		return nil, err
+	}
+	for _, lease := range released {
+		s.emitMutation(MutationEvent{Type: EventUpdate, IssueID: lease.IssueID, Actor: "daemon"})
+	}
+	return released, nil
+}
+
+// ReapUnleasedWork returns in_progress issues with no lease that have gone a
+// lease TTL without an update to open and unassigned, and emits an update
+// event for each. The TTL is counted from the server start at the earliest, so
+// work that predates the daemon (or leases) gets a full TTL to send a
+// heartbeat. The daemon calls this alongside ReapExpiredLeases.
+func (s *Server) ReapUnleasedWork(ctx context.Context) ([]*types.Issue, error) {
+	before := time.Now().Add(-s.LeaseTTL())
+	if before.Before(s.startTime) {
+		return nil, nil
+	}
+	released, err := s.storage.ReleaseUnleasedWork(ctx, before, "daemon")
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range released {
+		s.emitMutation(MutationEvent{Type: EventUpdate, IssueID: issue.ID, Actor: "daemon"})
+	}
+	return released, nil
+}
+
+// UnleasedWork returns the in_progress issues with no lease, which
+// ReapUnleasedWork releases at their UnleasedDeadline
+func (s *Server) UnleasedWork(ctx context.Context) ([]*types.Issue, error) {
+	status := types.StatusInProgress
+	issues, err := s.storage.SearchIssues(ctx, "", types.IssueFilter{Status: &status})
+	if err != nil {
+		return nil, err
+	}
+	leases, err := s.storage.GetLeases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	leased := make(map[string]bool, len(leases))
+	for _, lease := range leases {
+		leased[lease.IssueID] = true
+	}
+	var unleased []*types.Issue
+	for _, issue := range issues {
+		if !leased[issue.ID] {
+			unleased = append(unleased, issue)
+		}
+	}
+	return unleased, nil
+}
+
+// UnleasedDeadline returns when ReapUnleasedWork releases an in_progress issue
+// with no lease, unless it is updated or sends a heartbeat first
+func (s *Server) UnleasedDeadline(issue *types.Issue) time.Time {
+	since := issue.UpdatedAt
+	if since.Before(s.startTime) {
+		since = s.startTime
+	}
+	return since.Add(s.LeaseTTL())
+}
+
+// MaterializeRecurring creates the occurrences of recurring issues that are
+// due, and emits a create event for each. The daemon calls this periodically.
+func (s *Server) MaterializeRecurring(ctx context.Context) ([]*recur.Occurrence, error) {
+	created, err := recur.Materialize(ctx, s.storage, time.Now(), "daemon")
+	for _, occ := range created {
+		s.emitMutation(MutationEvent{Type: EventCreate, IssueID: occ.ID, Actor: "daemon"})
+	}
+	return created, err
+}
+
 func (s *Server) handleStats(req *Request) Response {
 	store := s.storage
 
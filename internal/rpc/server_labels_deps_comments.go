@@ -35,14 +35,15 @@ func (s *Server) handleDepAdd(req *Request) Response {
 	}
 
 	// Emit mutation event for event-driven daemon
-	s.emitMutation("update", depArgs.FromID)
+	s.emitMutation(MutationEvent{Type: EventDepAdd, IssueID: depArgs.FromID, DependsOnID: depArgs.ToID, Actor: s.reqActor(req)})
 
 	return Response{Success: true}
 }
 
-// Generic handler for simple store operations with standard error handling
+// Generic handler for simple store operations with standard error handling.
+// mutation builds the event to emit once the args are decoded.
 func (s *Server) handleSimpleStoreOp(req *Request, argsPtr interface{}, argDesc string,
-	opFunc func(context.Context, storage.Storage, string) error, issueID string) Response {
+	opFunc func(context.Context, storage.Storage, string) error, mutation func() MutationEvent) Response {
 	if err := json.Unmarshal(req.Args, argsPtr); err != nil {
 		return Response{
 			Success: false,
@@ -61,7 +62,9 @@ func (s *Server) handleSimpleStoreOp(req *Request, argsPtr interface{}, argDesc
 	}
 
 	// Emit mutation event for event-driven daemon
-	s.emitMutation("update", issueID)
+	event := mutation()
+	event.Actor = s.reqActor(req)
+	s.emitMutation(event)
 
 	return Response{Success: true}
 }
@@ -70,21 +73,48 @@ func (s *Server) handleDepRemove(req *Request) Response {
 	var depArgs DepRemoveArgs
 	return s.handleSimpleStoreOp(req, &depArgs, "dep remove", func(ctx context.Context, store storage.Storage, actor string) error {
 		return store.RemoveDependency(ctx, depArgs.FromID, depArgs.ToID, actor)
-	}, depArgs.FromID)
+	}, func() MutationEvent {
+		return MutationEvent{Type: EventDepRemove, IssueID: depArgs.FromID, DependsOnID: depArgs.ToID}
+	})
+}
+
+func (s *Server) handleDepTree(req *Request) Response {
+	var treeArgs DepTreeArgs
+	if err := json.Unmarshal(req.Args, &treeArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid dep tree args: %v", err),
+		}
+	}
+
+	ctx := s.reqCtx(req)
+	tree, err := s.storage.GetDependencyTree(ctx, treeArgs.ID, treeArgs.MaxDepth, false, treeArgs.Reverse)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get dependency tree: %v", err),
+		}
+	}
+
+	data, _ := json.Marshal(tree)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
 }
 
 func (s *Server) handleLabelAdd(req *Request) Response {
 	var labelArgs LabelAddArgs
 	return s.handleSimpleStoreOp(req, &labelArgs, "label add", func(ctx context.Context, store storage.Storage, actor string) error {
 		return store.AddLabel(ctx, labelArgs.ID, labelArgs.Label, actor)
-	}, labelArgs.ID)
+	}, func() MutationEvent { return MutationEvent{Type: EventUpdate, IssueID: labelArgs.ID} })
 }
 
 func (s *Server) handleLabelRemove(req *Request) Response {
 	var labelArgs LabelRemoveArgs
 	return s.handleSimpleStoreOp(req, &labelArgs, "label remove", func(ctx context.Context, store storage.Storage, actor string) error {
 		return store.RemoveLabel(ctx, labelArgs.ID, labelArgs.Label, actor)
-	}, labelArgs.ID)
+	}, func() MutationEvent { return MutationEvent{Type: EventUpdate, IssueID: labelArgs.ID} })
 }
 
 func (s *Server) handleCommentList(req *Request) Response {
@@ -135,7 +165,7 @@ func (s *Server) handleCommentAdd(req *Request) Response {
 	}
 
 	// Emit mutation event for event-driven daemon
-	s.emitMutation("comment", commentArgs.ID)
+	s.emitMutation(MutationEvent{Type: EventComment, IssueID: commentArgs.ID, Actor: s.reqActor(req)})
 
 	data, _ := json.Marshal(comment)
 	return Response{
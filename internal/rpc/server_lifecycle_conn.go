@@ -110,6 +110,9 @@ func (s *Server) Stop() error {
 				fmt.Fprintf(os.Stderr, "Warning: failed to close default storage: %v\n", closeErr)
 			}
 		}
+		if closeErr := s.foreignStores.Close(); closeErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close other repositories' databases: %v\n", closeErr)
+		}
 
 		// Close listener under lock
 		s.mu.Lock()
@@ -203,6 +206,12 @@ func (s *Server) handleConnection(conn net.Conn) {
 			continue
 		}
 
+		// Subscriptions take over the connection for streaming
+		if req.Operation == OpSubscribe {
+			s.handleSubscribe(conn, reader, writer, &req)
+			return
+		}
+
 		// Set write deadline for the response
 		if err := conn.SetWriteDeadline(time.Now().Add(s.requestTimeout)); err != nil {
 			return
@@ -166,18 +166,28 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleClose(req)
 	case OpList:
 		resp = s.handleList(req)
+	case OpSearch:
+		resp = s.handleSearch(req)
 	case OpShow:
 		resp = s.handleShow(req)
+	case OpHistory:
+		resp = s.handleHistory(req)
 	case OpResolveID:
 		resp = s.handleResolveID(req)
 	case OpReady:
 		resp = s.handleReady(req)
+	case OpClaim:
+		resp = s.handleClaim(req)
+	case OpHeartbeat:
+		resp = s.handleHeartbeat(req)
 	case OpStats:
 		resp = s.handleStats(req)
 	case OpDepAdd:
 		resp = s.handleDepAdd(req)
 	case OpDepRemove:
 		resp = s.handleDepRemove(req)
+	case OpDepTree:
+		resp = s.handleDepTree(req)
 	case OpLabelAdd:
 		resp = s.handleLabelAdd(req)
 	case OpLabelRemove:
@@ -332,12 +342,17 @@ func (s *Server) handleHealth(req *Request) Response {
 	}
 }
 
-func (s *Server) handleMetrics(_ *Request) Response {
+// MetricsSnapshot returns the daemon's current metrics
+func (s *Server) MetricsSnapshot() MetricsSnapshot {
 	snapshot := s.metrics.Snapshot(
 		int(atomic.LoadInt32(&s.activeConns)),
 	)
+	snapshot.AutoCompact = s.autoCompactStatus(context.Background())
+	return snapshot
+}
 
-	data, _ := json.Marshal(snapshot)
+func (s *Server) handleMetrics(_ *Request) Response {
+	data, _ := json.Marshal(s.MetricsSnapshot())
 	return Response{
 		Success: true,
 		Data:    data,
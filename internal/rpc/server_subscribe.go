@@ -0,0 +1,159 @@
+package rpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// events are dropped for it
+const subscriberBuffer = 256
+
+type subscription struct {
+	events chan MutationEvent
+}
+
+// Subscribe registers a listener for mutation events. Delivery never blocks
+// mutations: a subscriber that falls too far behind misses events. Call the
+// returned function to unsubscribe; it closes the channel.
+func (s *Server) Subscribe() (<-chan MutationEvent, func()) {
+	sub := &subscription{events: make(chan MutationEvent, subscriberBuffer)}
+	s.subMu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.subMu.Unlock()
+
+	cancel := func() {
+		s.subMu.Lock()
+		defer s.subMu.Unlock()
+		if _, ok := s.subscribers[sub]; ok {
+			delete(s.subscribers, sub)
+			close(sub.events)
+		}
+	}
+	return sub.events, cancel
+}
+
+// publish delivers an event to every subscriber without blocking
+func (s *Server) publish(event MutationEvent) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+	for sub := range s.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			// Subscriber is behind; drop rather than stall the mutation
+		}
+	}
+}
+
+// validateSubscribeArgs checks the requested event types
+func validateSubscribeArgs(args *SubscribeArgs) error {
+	for _, t := range args.Types {
+		if !slices.Contains(EventTypes, t) {
+			return fmt.Errorf("unknown event type %q (valid: %v)", t, EventTypes)
+		}
+	}
+	return nil
+}
+
+// matches reports whether an event passes the subscription's filters
+func (args *SubscribeArgs) matches(event MutationEvent) bool {
+	if len(args.Types) > 0 && !slices.Contains(args.Types, event.Type) {
+		return false
+	}
+	if len(args.IssueIDs) > 0 && !slices.Contains(args.IssueIDs, event.IssueID) &&
+		(event.DependsOnID == "" || !slices.Contains(args.IssueIDs, event.DependsOnID)) {
+		return false
+	}
+	return true
+}
+
+// parseSubscribe checks a subscribe request like handleRequest checks other
+// operations and returns its filters
+func (s *Server) parseSubscribe(req *Request) (*SubscribeArgs, error) {
+	if err := s.validateDatabaseBinding(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkVersionCompatibility(req.ClientVersion); err != nil {
+		return nil, err
+	}
+	var args SubscribeArgs
+	if len(req.Args) > 0 && string(req.Args) != "null" {
+		if err := json.Unmarshal(req.Args, &args); err != nil {
+			return nil, fmt.Errorf("invalid subscribe args: %w", err)
+		}
+	}
+	if err := validateSubscribeArgs(&args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// handleSubscribe turns the connection into an event stream. It replies to
+// the subscribe request like any other operation, then writes one
+// MutationEvent JSON object per line until the client disconnects or the
+// server shuts down.
+func (s *Server) handleSubscribe(conn net.Conn, reader *bufio.Reader, writer *bufio.Writer, req *Request) {
+	s.metrics.RecordRequest(req.Operation, 0)
+	args, err := s.parseSubscribe(req)
+	if err != nil {
+		s.metrics.RecordError(req.Operation)
+		s.writeResponse(writer, Response{Success: false, Error: err.Error()})
+		return
+	}
+
+	events, cancel := s.Subscribe()
+	defer cancel()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(s.requestTimeout)); err != nil {
+		return
+	}
+	s.writeResponse(writer, Response{Success: true, Data: json.RawMessage(`{"message":"subscribed"}`)})
+
+	// The client sends nothing more; a read returning means it hung up
+	_ = conn.SetReadDeadline(time.Time{})
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		_, _ = reader.ReadByte()
+	}()
+
+	encoder := json.NewEncoder(writer)
+	s.streamEvents(events, args, closed, func(event MutationEvent) error {
+		if err := conn.SetWriteDeadline(time.Now().Add(s.requestTimeout)); err != nil {
+			return err
+		}
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+		return writer.Flush()
+	})
+}
+
+// streamEvents writes each event that passes the filters until the
+// subscription ends, closed is closed, the server shuts down or a write fails
+func (s *Server) streamEvents(events <-chan MutationEvent, args *SubscribeArgs, closed <-chan struct{}, write func(MutationEvent) error) {
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if !args.matches(event) {
+				continue
+			}
+			s.lastActivityTime.Store(time.Now())
+			if err := write(event); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		case <-s.shutdownChan:
+			return
+		}
+	}
+}
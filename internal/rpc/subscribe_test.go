@@ -0,0 +1,167 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// subscribeAsync subscribes on a new connection and returns the received
+// events once want events arrived or the stream ended
+func subscribeAsync(t *testing.T, server *Server, client *Client, args *SubscribeArgs, want int) <-chan []MutationEvent {
+	t.Helper()
+	sub, err := TryConnect(server.socketPath)
+	if err != nil || sub == nil {
+		t.Fatalf("failed to connect subscriber: %v", err)
+	}
+	sub.dbPath = client.dbPath
+
+	before := subscriberCount(server)
+	result := make(chan []MutationEvent, 1)
+	errStop := errors.New("done")
+	go func() {
+		defer sub.Close()
+		var events []MutationEvent
+		err := sub.Subscribe(args, func(event MutationEvent) error {
+			events = append(events, event)
+			if len(events) == want {
+				return errStop
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStop) {
+			t.Errorf("subscribe failed: %v", err)
+		}
+		result <- events
+	}()
+
+	waitFor(t, func() bool { return subscriberCount(server) > before })
+	return result
+}
+
+func subscriberCount(server *Server) int {
+	server.subMu.Lock()
+	defer server.subMu.Unlock()
+	return len(server.subscribers)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func receive(t *testing.T, result <-chan []MutationEvent) []MutationEvent {
+	t.Helper()
+	select {
+	case events := <-result:
+		return events
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for events")
+		return nil
+	}
+}
+
+func createTestIssue(t *testing.T, client *Client, title string) string {
+	t.Helper()
+	resp, err := client.Create(&CreateArgs{Title: title, IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		t.Fatalf("failed to decode issue: %v", err)
+	}
+	return issue.ID
+}
+
+func TestSubscribeStreamsMutations(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	all := subscribeAsync(t, server, client, nil, 6)
+	closes := subscribeAsync(t, server, client, &SubscribeArgs{Types: []string{EventClose}}, 1)
+
+	a := createTestIssue(t, client, "Parent")
+	b := createTestIssue(t, client, "Child")
+	title := "Parent (renamed)"
+	if _, err := client.Update(&UpdateArgs{ID: a, Title: &title}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if _, err := client.AddDependency(&DepAddArgs{FromID: b, ToID: a, DepType: "blocks"}); err != nil {
+		t.Fatalf("dep add failed: %v", err)
+	}
+	if _, err := client.CloseIssue(&CloseArgs{ID: a}); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	if _, err := client.AddComment(&CommentAddArgs{ID: b, Author: "alice", Text: "Unblocked"}); err != nil {
+		t.Fatalf("comment failed: %v", err)
+	}
+
+	events := receive(t, all)
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Type+":"+e.IssueID)
+	}
+	want := []string{"create:" + a, "create:" + b, "update:" + a, "dep_add:" + b, "close:" + a, "comment:" + b}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if events[3].DependsOnID != a {
+		t.Errorf("dep_add depends_on_id = %q, want %q", events[3].DependsOnID, a)
+	}
+	if events[0].Timestamp.IsZero() || events[0].Actor == "" {
+		t.Errorf("event missing timestamp or actor: %+v", events[0])
+	}
+
+	if closed := receive(t, closes); len(closed) != 1 || closed[0].IssueID != a {
+		t.Errorf("filtered events = %+v, want only the close of %s", closed, a)
+	}
+
+	// Subscribers are removed once their connections close
+	waitFor(t, func() bool { return subscriberCount(server) == 0 })
+}
+
+func TestSubscribeIssueFilter(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	a := createTestIssue(t, client, "Watched")
+	b := createTestIssue(t, client, "Other")
+	result := subscribeAsync(t, server, client, &SubscribeArgs{IssueIDs: []string{a}}, 2)
+
+	status := "in_progress"
+	if _, err := client.Update(&UpdateArgs{ID: b, Status: &status}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if _, err := client.AddDependency(&DepAddArgs{FromID: b, ToID: a, DepType: "blocks"}); err != nil {
+		t.Fatalf("dep add failed: %v", err)
+	}
+	if _, err := client.Update(&UpdateArgs{ID: a, Status: &status}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	events := receive(t, result)
+	if len(events) != 2 || events[0].Type != EventDepAdd || events[1].IssueID != a {
+		t.Errorf("events = %+v, want the dependency on %s and its update", events, a)
+	}
+}
+
+func TestSubscribeInvalidType(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	err := client.Subscribe(&SubscribeArgs{Types: []string{"moved"}}, func(MutationEvent) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "unknown event type") {
+		t.Errorf("expected unknown event type error, got %v", err)
+	}
+}
@@ -0,0 +1,295 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Environment variables that point the client at a remote daemon
+const (
+	EnvDaemonURL   = "BEADS_DAEMON_URL"   // e.g. https://host:7777
+	EnvDaemonToken = "BEADS_DAEMON_TOKEN" // Bearer token, also read by 'beads daemon --rpc-addr'
+	EnvDaemonCA    = "BEADS_DAEMON_CA"    // PEM file to trust for self-signed daemon certificates
+)
+
+// HTTPPath is where the daemon accepts RPC requests over HTTP
+const HTTPPath = "/rpc"
+
+// maxHTTPRequestBytes bounds the size of a request body
+const maxHTTPRequestBytes = 16 << 20
+
+// HTTPConfig configures the daemon's optional TCP/HTTP transport. Requests and
+// responses are the same Request/Response envelopes as on the local socket,
+// one per POST to /rpc.
+type HTTPConfig struct {
+	Addr     string // host:port to listen on
+	Token    string // Bearer token every request must present
+	CertFile string // TLS certificate (PEM); plain HTTP when empty
+	KeyFile  string // TLS private key (PEM)
+}
+
+// Validate checks that the configuration is usable
+func (cfg HTTPConfig) Validate() error {
+	if cfg.Addr == "" {
+		return errors.New("no listen address")
+	}
+	if cfg.Token == "" {
+		return fmt.Errorf("a token is required (set %s or use a token file)", EnvDaemonToken)
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return errors.New("TLS needs both a certificate and a key file")
+	}
+	return nil
+}
+
+// ListenHTTP serves RPC requests over HTTP (or HTTPS when a certificate is
+// configured) until ctx is done or the server stops
+func (s *Server) ListenHTTP(ctx context.Context, cfg HTTPConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.Addr, err)
+	}
+	return s.serveHTTP(ctx, ln, cfg)
+}
+
+func (s *Server) serveHTTP(ctx context.Context, ln net.Listener, cfg HTTPConfig) error {
+	// Cancelling the base context ends open subscription streams on shutdown
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	srv := &http.Server{
+		Handler:           s.HTTPHandler(cfg.Token),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		if cfg.CertFile != "" {
+			errCh <- srv.ServeTLS(ln, cfg.CertFile, cfg.KeyFile)
+		} else {
+			errCh <- srv.Serve(ln)
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	case <-s.shutdownChan:
+	}
+	cancel()
+	shutdownCtx, done := context.WithTimeout(context.Background(), 5*time.Second)
+	defer done()
+	return srv.Shutdown(shutdownCtx)
+}
+
+// HTTPHandler returns the handler behind ListenHTTP. Every request must carry
+// "Authorization: Bearer <token>".
+func (s *Server) HTTPHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+HTTPPath, s.handleHTTP)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="beads"`)
+			writeHTTPResponse(w, http.StatusUnauthorized, Response{Error: "unauthorized"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleHTTP(w http.ResponseWriter, r *http.Request) {
+	// Share the connection limit with the socket listener
+	select {
+	case s.connSemaphore <- struct{}{}:
+		defer func() { <-s.connSemaphore }()
+		s.metrics.RecordConnection()
+		atomic.AddInt32(&s.activeConns, 1)
+		defer atomic.AddInt32(&s.activeConns, -1)
+	default:
+		s.metrics.RecordRejectedConnection()
+		writeHTTPResponse(w, http.StatusServiceUnavailable, Response{Error: "too many connections"})
+		return
+	}
+
+	var req Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxHTTPRequestBytes)).Decode(&req); err != nil {
+		writeHTTPResponse(w, http.StatusBadRequest, Response{Error: fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+
+	// Remote clients can't know where the daemon keeps its database; the
+	// token is what binds them to this daemon
+	if req.ExpectedDB == "" && s.storage != nil {
+		req.ExpectedDB = s.storage.Path()
+	}
+
+	switch req.Operation {
+	case OpShutdown:
+		writeHTTPResponse(w, http.StatusForbidden, Response{Error: "shutdown is only available on the local socket"})
+	case OpSubscribe:
+		s.handleHTTPSubscribe(w, r, &req)
+	default:
+		writeHTTPResponse(w, http.StatusOK, s.handleRequest(&req))
+	}
+}
+
+// handleHTTPSubscribe streams events as newline-delimited JSON in the response
+// body, after the usual reply line, until the client goes away
+func (s *Server) handleHTTPSubscribe(w http.ResponseWriter, r *http.Request, req *Request) {
+	s.metrics.RecordRequest(req.Operation, 0)
+	args, err := s.parseSubscribe(req)
+	if err != nil {
+		s.metrics.RecordError(req.Operation)
+		writeHTTPResponse(w, http.StatusOK, Response{Error: err.Error()})
+		return
+	}
+
+	events, cancel := s.Subscribe()
+	defer cancel()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	encoder := json.NewEncoder(w)
+	write := func(v interface{}) error {
+		if err := rc.SetWriteDeadline(time.Now().Add(s.requestTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	if err := write(Response{Success: true, Data: json.RawMessage(`{"message":"subscribed"}`)}); err != nil {
+		return
+	}
+	s.streamEvents(events, args, r.Context().Done(), func(event MutationEvent) error { return write(event) })
+}
+
+func writeHTTPResponse(w http.ResponseWriter, status int, resp Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// ConnectURL connects to a daemon's HTTP transport, such as one started with
+// 'beads daemon --rpc-addr'. caFile optionally names a PEM file of
+// certificates to trust in addition to the system roots. Unlike TryConnect,
+// failing to reach a healthy daemon is an error.
+func ConnectURL(rawURL, token, caFile string) (*Client, error) {
+	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
+		return nil, fmt.Errorf("invalid daemon URL %q: must start with http:// or https://", rawURL)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile) // #nosec G304 - user-specified CA file
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	client := &Client{
+		url:        strings.TrimSuffix(rawURL, "/") + HTTPPath,
+		token:      token,
+		httpClient: &http.Client{Transport: transport},
+		timeout:    30 * time.Second,
+	}
+	health, err := client.Health()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach daemon at %s: %w", rawURL, err)
+	}
+	if health.Status == statusUnhealthy {
+		return nil, fmt.Errorf("daemon at %s is unhealthy: %s", rawURL, health.Error)
+	}
+	return client, nil
+}
+
+// ConnectFromEnv connects to the daemon named by BEADS_DAEMON_URL, with the
+// token from BEADS_DAEMON_TOKEN and extra CA from BEADS_DAEMON_CA. It returns
+// nil, nil when BEADS_DAEMON_URL is unset.
+func ConnectFromEnv() (*Client, error) {
+	rawURL := os.Getenv(EnvDaemonURL)
+	if rawURL == "" {
+		return nil, nil
+	}
+	return ConnectURL(rawURL, os.Getenv(EnvDaemonToken), os.Getenv(EnvDaemonCA))
+}
+
+// IsRemote reports whether the client talks to the daemon over HTTP
+func (c *Client) IsRemote() bool {
+	return c.httpClient != nil
+}
+
+// postHTTP sends one request envelope and returns the response body
+func (c *Client) postHTTP(ctx context.Context, req Request) (*http.Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		defer httpResp.Body.Close()
+		var resp Response
+		if json.NewDecoder(httpResp.Body).Decode(&resp) == nil && resp.Error != "" {
+			return nil, fmt.Errorf("daemon returned %s: %s", httpResp.Status, resp.Error)
+		}
+		return nil, fmt.Errorf("daemon returned %s", httpResp.Status)
+	}
+	return httpResp, nil
+}
+
+// executeHTTP is ExecuteWithCwd for remote daemons
+func (c *Client) executeHTTP(req Request) (*Response, error) {
+	ctx := context.Background()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	httpResp, err := c.postHTTP(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	var resp Response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if !resp.Success {
+		return &resp, fmt.Errorf("operation failed: %s", resp.Error)
+	}
+	return &resp, nil
+}
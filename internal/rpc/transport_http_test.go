@@ -0,0 +1,223 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testToken = "s3cret"
+
+// startHTTP serves the server's HTTP transport on a random local port and
+// returns its base URL
+func startHTTP(t *testing.T, server *Server, cfg HTTPConfig) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := server.serveHTTP(ctx, ln, cfg); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			t.Errorf("serveHTTP failed: %v", err)
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	scheme := "http"
+	if cfg.CertFile != "" {
+		scheme = "https"
+	}
+	return scheme + "://" + ln.Addr().String()
+}
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 and its key
+func writeTestCert(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "beads test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestHTTPTransport(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+	defer client.Close()
+	url := startHTTP(t, server, HTTPConfig{Token: testToken})
+
+	remote, err := ConnectURL(url, testToken, "")
+	if err != nil {
+		t.Fatalf("ConnectURL failed: %v", err)
+	}
+	defer remote.Close()
+	if !remote.IsRemote() || client.IsRemote() {
+		t.Errorf("expected only the HTTP client to be remote")
+	}
+
+	// Both transports see the same database
+	id := createTestIssue(t, remote, "Created remotely")
+	resp, err := client.Show(&ShowArgs{ID: id})
+	if err != nil || !resp.Success {
+		t.Fatalf("local Show of remote issue failed: %v", err)
+	}
+	if resp, err := remote.ResolveID(&ResolveIDArgs{ID: "beads-missing"}); err == nil || resp == nil || resp.Success {
+		t.Errorf("expected a failed response for a missing issue, got %+v, %v", resp, err)
+	}
+
+	if err := remote.Shutdown(); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected shutdown to be forbidden over HTTP, got %v", err)
+	}
+	if err := client.Ping(); err != nil {
+		t.Errorf("daemon should still be running: %v", err)
+	}
+
+	if _, err := ConnectURL(url, "wrong", ""); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected a wrong token to be rejected, got %v", err)
+	}
+	if _, err := ConnectURL(url, "", ""); err == nil {
+		t.Error("expected a missing token to be rejected")
+	}
+	if _, err := ConnectURL(strings.TrimPrefix(url, "http://"), testToken, ""); err == nil || !strings.Contains(err.Error(), "http://") {
+		t.Errorf("expected a URL without a scheme to be rejected, got %v", err)
+	}
+
+	get, err := http.Get(url + HTTPPath)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	get.Body.Close()
+	if get.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected unauthenticated GET to get 401, got %d", get.StatusCode)
+	}
+}
+
+func TestHTTPTransportTLS(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+	defer client.Close()
+	certFile, keyFile := writeTestCert(t)
+	url := startHTTP(t, server, HTTPConfig{Token: testToken, CertFile: certFile, KeyFile: keyFile})
+
+	if _, err := ConnectURL(url, testToken, ""); err == nil {
+		t.Error("expected an untrusted certificate to be rejected")
+	}
+	remote, err := ConnectURL(url, testToken, certFile)
+	if err != nil {
+		t.Fatalf("ConnectURL with CA failed: %v", err)
+	}
+	defer remote.Close()
+	createTestIssue(t, remote, "Over TLS")
+
+	if _, err := ConnectURL(url, testToken, keyFile); err == nil || !strings.Contains(err.Error(), "no certificates") {
+		t.Errorf("expected a CA file without certificates to be rejected, got %v", err)
+	}
+}
+
+func TestHTTPSubscribe(t *testing.T) {
+	server, client, cleanup := setupTestServer(t)
+	defer cleanup()
+	defer client.Close()
+	url := startHTTP(t, server, HTTPConfig{Token: testToken})
+
+	remote, err := ConnectURL(url, testToken, "")
+	if err != nil {
+		t.Fatalf("ConnectURL failed: %v", err)
+	}
+	defer remote.Close()
+
+	result := make(chan MutationEvent, 1)
+	errStop := errors.New("done")
+	go func() {
+		err := remote.Subscribe(&SubscribeArgs{Types: []string{EventCreate}}, func(event MutationEvent) error {
+			result <- event
+			return errStop
+		})
+		if !errors.Is(err, errStop) {
+			t.Errorf("subscribe failed: %v", err)
+		}
+	}()
+	waitFor(t, func() bool { return subscriberCount(server) == 1 })
+
+	id := createTestIssue(t, client, "Streamed")
+	select {
+	case event := <-result:
+		if event.IssueID != id {
+			t.Errorf("expected create of %s, got %+v", id, event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	waitFor(t, func() bool { return subscriberCount(server) == 0 })
+
+	if err := remote.Subscribe(&SubscribeArgs{Types: []string{"bogus"}}, nil); err == nil || !strings.Contains(err.Error(), "unknown event type") {
+		t.Errorf("expected invalid subscribe args to be rejected, got %v", err)
+	}
+}
+
+func TestHTTPConfigValidate(t *testing.T) {
+	for _, tc := range []struct {
+		cfg  HTTPConfig
+		want string
+	}{
+		{HTTPConfig{Addr: ":7777", Token: "t"}, ""},
+		{HTTPConfig{Addr: ":7777", Token: "t", CertFile: "c", KeyFile: "k"}, ""},
+		{HTTPConfig{Token: "t"}, "address"},
+		{HTTPConfig{Addr: ":7777"}, "token"},
+		{HTTPConfig{Addr: ":7777", Token: "t", CertFile: "c"}, "key"},
+	} {
+		err := tc.cfg.Validate()
+		if tc.want == "" && err != nil {
+			t.Errorf("%+v: unexpected error %v", tc.cfg, err)
+		}
+		if tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)) {
+			t.Errorf("%+v: expected error about %s, got %v", tc.cfg, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Package factory opens the storage backend selected by configuration.
+package factory
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/shaneholloman/beads/internal/configfile"
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/storage/postgres"
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+)
+
+// New opens the backend described by cfg. An empty Backend means SQLite.
+func New(cfg storage.Config) (storage.Storage, error) {
+	switch cfg.Backend {
+	case "", configfile.BackendSQLite:
+		return sqlite.New(cfg.Path)
+	case configfile.BackendPostgres:
+		return postgres.New(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+// Open opens the database at dbPath, honoring the backend selected in the
+// config.json next to it. Without a config.json (or with the sqlite backend)
+// dbPath is opened as a SQLite file.
+func Open(dbPath string) (storage.Storage, error) {
+	beadsDir := filepath.Dir(dbPath)
+	cfg, err := configfile.Load(beadsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", configfile.ConfigPath(beadsDir), err)
+	}
+	if cfg == nil || !cfg.IsPostgres() {
+		return sqlite.New(dbPath)
+	}
+
+	storageCfg, err := cfg.StorageConfig(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	// Report the path the caller asked for so daemon/client binding checks match
+	storageCfg.Path = dbPath
+	return New(storageCfg)
+}
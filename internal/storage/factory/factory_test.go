@@ -0,0 +1,46 @@
+package factory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/configfile"
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+)
+
+func TestOpenWithoutConfigUsesSQLite(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+
+	store, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open() failed: %v", err)
+	}
+	defer func() { _ = store.Close() }()
+
+	if _, ok := store.(*sqlite.SQLiteStorage); !ok {
+		t.Errorf("Open() returned %T, want *sqlite.SQLiteStorage", store)
+	}
+	if store.Path() != dbPath {
+		t.Errorf("Path() = %q, want %q", store.Path(), dbPath)
+	}
+}
+
+func TestOpenPostgresWithoutSection(t *testing.T) {
+	beadsDir := t.TempDir()
+	cfg := configfile.DefaultConfig("test")
+	cfg.Backend = configfile.BackendPostgres
+	if err := cfg.Save(beadsDir); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	if _, err := Open(filepath.Join(beadsDir, "beads.db")); err == nil {
+		t.Error("expected error for postgres backend without postgres section")
+	}
+}
+
+func TestNewUnknownBackend(t *testing.T) {
+	if _, err := New(storage.Config{Backend: "mysql"}); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+}
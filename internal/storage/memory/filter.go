@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/query"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// matchesExpr evaluates a structured filter against an issue and its labels
+func matchesExpr(expr query.Expr, issue *types.Issue, labels []string) bool {
+	switch e := expr.(type) {
+	case query.And:
+		for _, child := range e.Exprs {
+			if !matchesExpr(child, issue, labels) {
+				return false
+			}
+		}
+		return true
+	case query.Or:
+		for _, child := range e.Exprs {
+			if matchesExpr(child, issue, labels) {
+				return true
+			}
+		}
+		return false
+	case query.Not:
+		return !matchesExpr(e.Expr, issue, labels)
+	case query.Cond:
+		for _, v := range e.Values {
+			if matchesValue(e.Field, e.Op, v, issue, labels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesValue checks one value of a condition
+func matchesValue(field query.Field, op query.Op, v query.Value, issue *types.Issue, labels []string) bool {
+	switch field {
+	case query.FieldID:
+		return issue.ID == v.Str
+	case query.FieldStatus:
+		return string(issue.Status) == v.Str
+	case query.FieldType:
+		return string(issue.IssueType) == v.Str
+	case query.FieldAssignee:
+		return issue.Assignee == v.Str // none has an empty Str
+	case query.FieldLabel:
+		if v.None {
+			return len(labels) == 0
+		}
+		for _, label := range labels {
+			if label == v.Str {
+				return true
+			}
+		}
+		return false
+	case query.FieldTitle:
+		return containsFold(issue.Title, v.Str)
+	case query.FieldDescription:
+		if v.None {
+			return issue.Description == ""
+		}
+		return containsFold(issue.Description, v.Str)
+	case query.FieldText:
+		return containsFold(issue.Title, v.Str) || containsFold(issue.Description, v.Str) || containsFold(issue.ID, v.Str)
+	case query.FieldPriority:
+		return compareInts(issue.Priority, op, v.Int)
+	case query.FieldCreated:
+		return compareTimes(issue.CreatedAt, op, v.Time)
+	case query.FieldUpdated:
+		return compareTimes(issue.UpdatedAt, op, v.Time)
+	case query.FieldClosed:
+		if v.None {
+			return issue.ClosedAt == nil
+		}
+		return issue.ClosedAt != nil && compareTimes(*issue.ClosedAt, op, v.Time)
+	case query.FieldDue:
+		if v.None {
+			return issue.DueAt == nil
+		}
+		return issue.DueAt != nil && compareTimes(*issue.DueAt, op, v.Time)
+	}
+	return false
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func compareInts(a int, op query.Op, b int) bool {
+	switch op {
+	case query.OpLt:
+		return a < b
+	case query.OpLe:
+		return a <= b
+	case query.OpGt:
+		return a > b
+	case query.OpGe:
+		return a >= b
+	}
+	return a == b
+}
+
+func compareTimes(a time.Time, op query.Op, b time.Time) bool {
+	switch op {
+	case query.OpLt:
+		return a.Before(b)
+	case query.OpLe:
+		return !a.After(b)
+	case query.OpGt:
+		return a.After(b)
+	case query.OpGe:
+		return !a.Before(b)
+	}
+	return a.Equal(b)
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/query"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestSearchIssuesFilterExpr(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	create := func(title, description string, priority int, issueType types.IssueType, assignee string, labels ...string) *types.Issue {
+		issue := &types.Issue{Title: title, Description: description, Status: types.StatusOpen, Priority: priority, IssueType: issueType, Assignee: assignee}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		return issue
+	}
+
+	login := create("login", "Users cannot sign in", 0, types.TypeBug, "alice", "backend")
+	create("signup", "Promo code rejected", 1, types.TypeTask, "")
+	cleanup := create("cleanup", "Remove dead code", 3, types.TypeChore, "bob", "backend", "wontfix")
+	if err := store.CloseIssue(ctx, cleanup.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	store.issues[login.ID].UpdatedAt = time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"-status:closed", "login,signup"},
+		{"priority<=1", "login,signup"},
+		{"type:(bug|task)", "login,signup"},
+		{"assignee:none", "signup"},
+		{"label:backend -label:wontfix", "login"},
+		{"label:none", "signup"},
+		{"closed:none", "login,signup"},
+		{"closed>1h", "cleanup"},
+		{"updated<2025-01-01T08:30:00Z", "login"},
+		{"title:LOG", "login"},
+		{"sign", "login,signup"},
+		{"status:open OR label:wontfix", "cleanup,login,signup"},
+		{"-(label:backend assignee:alice)", "cleanup,signup"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			expr, err := query.Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.filter, err)
+			}
+			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Expr: expr})
+			if err != nil {
+				t.Fatalf("SearchIssues failed: %v", err)
+			}
+			titles := make([]string, len(issues))
+			for i, issue := range issues {
+				titles[i] = issue.Title
+			}
+			sort.Strings(titles)
+			if got := strings.Join(titles, ","); got != tt.want {
+				t.Errorf("filter %q = [%s], want [%s]", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// ClaimReadyWork assigns the highest-priority unclaimed ready issue to agentID
+// and records a lease expiring after leaseTTL. Leases are not written to JSONL,
+// so in --no-db mode they last only as long as the process.
+func (m *MemoryStorage) ClaimReadyWork(ctx context.Context, filter types.WorkFilter, agentID string, leaseTTL time.Duration) (*types.Claim, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("agent ID is required to claim work")
+	}
+	if leaseTTL <= 0 {
+		return nil, fmt.Errorf("lease TTL must be positive, got %v", leaseTTL)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.releaseExpiredLeasesLocked(now, agentID)
+
+	status := filter.Status
+	if status == "" {
+		status = types.StatusOpen
+	}
+	var candidates []*types.Issue
+	for id, issue := range m.issues {
+		if issue.Status != status || issue.Recurrence != "" || m.leases[id] != nil || m.isBlockedLocked(id) {
+			continue
+		}
+		if filter.Priority != nil && issue.Priority != *filter.Priority {
+			continue
+		}
+		if filter.Assignee != nil && issue.Assignee != *filter.Assignee {
+			continue
+		}
+		candidates = append(candidates, issue)
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Priority != candidates[j].Priority {
+			return candidates[i].Priority < candidates[j].Priority
+		}
+		return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+	})
+
+	issue := candidates[0]
+	if err := types.CurrentWorkflow().CanTransition(issue.Status, types.StatusInProgress); err != nil {
+		return nil, fmt.Errorf("cannot claim %s: %w", issue.ID, err)
+	}
+	issue.Status = types.StatusInProgress
+	issue.Assignee = agentID
+	issue.UpdatedAt = now
+
+	lease := &types.Lease{IssueID: issue.ID, Holder: agentID, ClaimedAt: now, LastHeartbeat: now, ExpiresAt: now.Add(leaseTTL)}
+	m.leases[issue.ID] = lease
+	m.dirty[issue.ID] = true
+	m.events[issue.ID] = append(m.events[issue.ID], &types.Event{
+		IssueID:   issue.ID,
+		EventType: types.EventStatusChanged,
+		Actor:     agentID,
+		CreatedAt: now,
+	})
+
+	claimed := *issue
+	claimedLease := *lease
+	return &types.Claim{Issue: &claimed, Lease: &claimedLease}, nil
+}
+
+// GetLeases returns all current leases, soonest to expire first
+func (m *MemoryStorage) GetLeases(ctx context.Context) ([]*types.Lease, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	leases := make([]*types.Lease, 0, len(m.leases))
+	for _, lease := range m.leases {
+		copied := *lease
+		leases = append(leases, &copied)
+	}
+	sort.Slice(leases, func(i, j int) bool {
+		if !leases[i].ExpiresAt.Equal(leases[j].ExpiresAt) {
+			return leases[i].ExpiresAt.Before(leases[j].ExpiresAt)
+		}
+		return leases[i].IssueID < leases[j].IssueID
+	})
+	return leases, nil
+}
+
+// Heartbeat extends holder's lease on an in_progress issue assigned to it,
+// starting one if the issue has none
+func (m *MemoryStorage) Heartbeat(ctx context.Context, issueID, holder string, leaseTTL time.Duration) (*types.Lease, error) {
+	if holder == "" {
+		return nil, fmt.Errorf("holder is required for a heartbeat")
+	}
+	if leaseTTL <= 0 {
+		return nil, fmt.Errorf("lease TTL must be positive, got %v", leaseTTL)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, ok := m.issues[issueID]
+	if !ok {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+	if issue.Status != types.StatusInProgress {
+		return nil, fmt.Errorf("issue %s is %s, not in_progress", issueID, issue.Status)
+	}
+	if issue.Assignee != holder {
+		if issue.Assignee == "" {
+			return nil, fmt.Errorf("issue %s is not assigned (assign it to %s first)", issueID, holder)
+		}
+		return nil, fmt.Errorf("issue %s is assigned to %s, not %s", issueID, issue.Assignee, holder)
+	}
+
+	now := time.Now()
+	lease, ok := m.leases[issueID]
+	if !ok || lease.Holder != holder {
+		lease = &types.Lease{IssueID: issueID, Holder: holder, ClaimedAt: now}
+		m.leases[issueID] = lease
+	}
+	lease.LastHeartbeat = now
+	lease.ExpiresAt = now.Add(leaseTTL)
+
+	renewed := *lease
+	return &renewed, nil
+}
+
+// ReleaseExpiredLeases releases leases that expired at or before the given
+// time, returning issues still held by their lease holder to open
+func (m *MemoryStorage) ReleaseExpiredLeases(ctx context.Context, before time.Time, actor string) ([]*types.Lease, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.releaseExpiredLeasesLocked(before, actor), nil
+}
+
+func (m *MemoryStorage) releaseExpiredLeasesLocked(before time.Time, actor string) []*types.Lease {
+	var released []*types.Lease
+	for id, lease := range m.leases {
+		if !lease.Expired(before) {
+			continue
+		}
+		delete(m.leases, id)
+		released = append(released, lease)
+
+		issue, ok := m.issues[id]
+		if !ok || issue.Status != types.StatusInProgress || issue.Assignee != lease.Holder {
+			continue
+		}
+		now := time.Now()
+		issue.Status = types.StatusOpen
+		issue.Assignee = ""
+		issue.UpdatedAt = now
+		m.dirty[id] = true
+		m.events[id] = append(m.events[id], &types.Event{
+			IssueID:   id,
+			EventType: types.EventStatusChanged,
+			Actor:     actor,
+			CreatedAt: now,
+		})
+	}
+	sort.Slice(released, func(i, j int) bool { return released[i].ExpiresAt.Before(released[j].ExpiresAt) })
+	return released
+}
+
+// ReleaseUnleasedWork returns in_progress issues that have no lease and were
+// last updated at or before the given time to open and unassigned. Returns the
+// issues as they were before release.
+func (m *MemoryStorage) ReleaseUnleasedWork(ctx context.Context, before time.Time, actor string) ([]*types.Issue, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var idle []*types.Issue
+	for id, issue := range m.issues {
+		if issue.Status != types.StatusInProgress || issue.UpdatedAt.After(before) {
+			continue
+		}
+		if _, leased := m.leases[id]; leased {
+			continue
+		}
+		snapshot := *issue
+		idle = append(idle, &snapshot)
+
+		now := time.Now()
+		issue.Status = types.StatusOpen
+		issue.Assignee = ""
+		issue.UpdatedAt = now
+		m.dirty[id] = true
+		m.events[id] = append(m.events[id], &types.Event{
+			IssueID:   id,
+			EventType: types.EventStatusChanged,
+			Actor:     actor,
+			CreatedAt: now,
+		})
+	}
+	sort.Slice(idle, func(i, j int) bool { return idle[i].UpdatedAt.Before(idle[j].UpdatedAt) })
+	return idle, nil
+}
+
+// isBlockedLocked reports whether an issue has an unfinished 'blocks' dependency
+func (m *MemoryStorage) isBlockedLocked(id string) bool {
+	for _, dep := range m.dependencies[id] {
+		if dep.Type != types.DepBlocks {
+			continue
+		}
+		if blocker, ok := m.issues[dep.DependsOnID]; ok && blocker.Status.Category() != types.CategoryDone {
+			return true
+		}
+	}
+	return false
+}
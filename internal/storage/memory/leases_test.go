@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestClaimReadyWork(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	low := &types.Issue{Title: "Low", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask}
+	high := &types.Issue{Title: "High", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{low, high} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	claim, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-1", time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimReadyWork failed: %v", err)
+	}
+	if claim == nil || claim.Issue.ID != high.ID || claim.Issue.Assignee != "agent-1" {
+		t.Fatalf("expected agent-1 to claim %s, got %+v", high.ID, claim)
+	}
+
+	claim, err = store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-2", time.Minute)
+	if err != nil || claim == nil || claim.Issue.ID != low.ID {
+		t.Fatalf("expected agent-2 to claim %s, got %+v (err %v)", low.ID, claim, err)
+	}
+
+	released, err := store.ReleaseExpiredLeases(ctx, time.Now().Add(time.Hour), "reaper")
+	if err != nil {
+		t.Fatalf("ReleaseExpiredLeases failed: %v", err)
+	}
+	if len(released) != 2 {
+		t.Fatalf("expected 2 released leases, got %d", len(released))
+	}
+	issue, _ := store.GetIssue(ctx, high.ID)
+	if issue.Status != types.StatusOpen || issue.Assignee != "" {
+		t.Errorf("expired issue not returned to ready: status=%s assignee=%s", issue.Status, issue.Assignee)
+	}
+}
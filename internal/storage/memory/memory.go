@@ -28,6 +28,7 @@ type MemoryStorage struct {
 	config       map[string]string              // Config key-value pairs
 	metadata     map[string]string              // Metadata key-value pairs
 	counters     map[string]int                 // Prefix -> Last ID
+	leases       map[string]*types.Lease        // IssueID -> Lease
 
 	// For tracking
 	dirty map[string]bool // IssueIDs that have been modified
@@ -47,6 +48,7 @@ func New(jsonlPath string) *MemoryStorage {
 		config:       make(map[string]string),
 		metadata:     make(map[string]string),
 		counters:     make(map[string]int),
+		leases:       make(map[string]*types.Lease),
 		dirty:        make(map[string]bool),
 		jsonlPath:    jsonlPath,
 	}
@@ -288,6 +290,13 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return fmt.Errorf("issue %s not found", id)
 	}
 
+	// Enforce workflow transition rules before applying anything
+	if v, ok := statusFromValue(updates["status"]); ok {
+		if err := types.CurrentWorkflow().CanTransition(issue.Status, v); err != nil {
+			return fmt.Errorf("cannot update %s: %w", id, err)
+		}
+	}
+
 	now := time.Now()
 	issue.UpdatedAt = now
 
@@ -315,9 +324,9 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 				issue.Notes = v
 			}
 		case "status":
-			if v, ok := value.(string); ok {
+			if v, ok := statusFromValue(value); ok {
 				oldStatus := issue.Status
-				issue.Status = types.Status(v)
+				issue.Status = v
 
 				// Manage closed_at
 				if issue.Status == types.StatusClosed && oldStatus != types.StatusClosed {
@@ -346,17 +355,48 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 			} else if value == nil {
 				issue.ExternalRef = nil
 			}
+		case "estimated_minutes":
+			if v, ok := value.(int); ok {
+				issue.EstimatedMinutes = &v
+			} else if value == nil {
+				issue.EstimatedMinutes = nil
+			}
+		case "recurrence":
+			if v, ok := value.(string); ok {
+				issue.Recurrence = v
+			}
+		case "due_at":
+			switch v := value.(type) {
+			case time.Time:
+				issue.DueAt = &v
+			case *time.Time:
+				issue.DueAt = v
+			case nil:
+				issue.DueAt = nil
+			}
+		case "recurred_at":
+			switch v := value.(type) {
+			case time.Time:
+				issue.RecurredAt = &v
+			case *time.Time:
+				issue.RecurredAt = v
+			case nil:
+				issue.RecurredAt = nil
+			}
 		}
 	}
 
+	// Drop the lease when the update takes the issue away from its holder
+	if lease, ok := m.leases[id]; ok && (issue.Status != types.StatusInProgress || issue.Assignee != lease.Holder) {
+		delete(m.leases, id)
+	}
+
 	m.dirty[id] = true
 
 	// Record event
 	eventType := types.EventUpdated
-	if status, hasStatus := updates["status"]; hasStatus {
-		if status == string(types.StatusClosed) {
-			eventType = types.EventClosed
-		}
+	if status, ok := statusFromValue(updates["status"]); ok && status == types.StatusClosed {
+		eventType = types.EventClosed
 	}
 
 	event := &types.Event{
@@ -370,6 +410,17 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	return nil
 }
 
+// statusFromValue extracts a status from an update value (string or types.Status)
+func statusFromValue(value interface{}) (types.Status, bool) {
+	switch v := value.(type) {
+	case string:
+		return types.Status(v), true
+	case types.Status:
+		return v, true
+	}
+	return "", false
+}
+
 // CloseIssue closes an issue with a reason
 func (m *MemoryStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
 	return m.UpdateIssue(ctx, id, map[string]interface{}{
@@ -385,17 +436,7 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 	var results []*types.Issue
 
 	for _, issue := range m.issues {
-		// Apply filters
-		if filter.Status != nil && issue.Status != *filter.Status {
-			continue
-		}
-		if filter.Priority != nil && issue.Priority != *filter.Priority {
-			continue
-		}
-		if filter.IssueType != nil && issue.IssueType != *filter.IssueType {
-			continue
-		}
-		if filter.Assignee != nil && issue.Assignee != *filter.Assignee {
+		if !m.matchesFilter(issue, filter) {
 			continue
 		}
 
@@ -409,42 +450,6 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 			}
 		}
 
-		// Label filtering: must have ALL specified labels
-		if len(filter.Labels) > 0 {
-			issueLabels := m.labels[issue.ID]
-			hasAllLabels := true
-			for _, reqLabel := range filter.Labels {
-				found := false
-				for _, label := range issueLabels {
-					if label == reqLabel {
-						found = true
-						break
-					}
-				}
-				if !found {
-					hasAllLabels = false
-					break
-				}
-			}
-			if !hasAllLabels {
-				continue
-			}
-		}
-
-		// ID filtering
-		if len(filter.IDs) > 0 {
-			found := false
-			for _, filterID := range filter.IDs {
-				if issue.ID == filterID {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
-		}
-
 		// Copy issue and attach metadata
 		issueCopy := *issue
 		if deps, ok := m.dependencies[issue.ID]; ok {
@@ -473,8 +478,83 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 	return results, nil
 }
 
+// matchesFilter reports whether issue passes every IssueFilter criterion except Limit.
+// Caller must hold the read lock.
+func (m *MemoryStorage) matchesFilter(issue *types.Issue, filter types.IssueFilter) bool {
+	if filter.Status != nil && issue.Status != *filter.Status {
+		return false
+	}
+	if filter.Priority != nil && issue.Priority != *filter.Priority {
+		return false
+	}
+	if filter.IssueType != nil && issue.IssueType != *filter.IssueType {
+		return false
+	}
+	if filter.Assignee != nil && issue.Assignee != *filter.Assignee {
+		return false
+	}
+	if filter.TitleSearch != "" && !strings.Contains(strings.ToLower(issue.Title), strings.ToLower(filter.TitleSearch)) {
+		return false
+	}
+
+	issueLabels := m.labels[issue.ID]
+	hasLabel := func(want string) bool {
+		for _, label := range issueLabels {
+			if label == want {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Label filtering: must have ALL specified labels
+	for _, reqLabel := range filter.Labels {
+		if !hasLabel(reqLabel) {
+			return false
+		}
+	}
+
+	// Label filtering (OR): must have AT LEAST ONE of these labels
+	if len(filter.LabelsAny) > 0 {
+		found := false
+		for _, label := range filter.LabelsAny {
+			if hasLabel(label) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	// ID filtering
+	if len(filter.IDs) > 0 {
+		found := false
+		for _, filterID := range filter.IDs {
+			if issue.ID == filterID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if filter.Expr != nil && !matchesExpr(filter.Expr, issue, issueLabels) {
+		return false
+	}
+
+	return true
+}
+
 // AddDependency adds a dependency between issues
 func (m *MemoryStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	if types.IsQualifiedID(dep.DependsOnID) {
+		return fmt.Errorf("cross-repository dependency on %s requires SQLite storage", dep.DependsOnID)
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -718,9 +798,42 @@ func (m *MemoryStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 // Stub implementations for other required methods
 func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
 	// Simplified: return open issues with no blocking dependencies
-	return m.SearchIssues(ctx, "", types.IssueFilter{
-		Status: func() *types.Status { s := types.StatusOpen; return &s }(),
-	})
+	issues, err := m.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	var ready []*types.Issue
+	for _, issue := range issues {
+		if issue.Recurrence != "" {
+			continue // Recurring issues are templates; their occurrences are the work
+		}
+		if filter.Status == "" {
+			if issue.Status.Category() != types.CategoryActive {
+				continue
+			}
+		} else if issue.Status != filter.Status {
+			continue
+		}
+		ready = append(ready, issue)
+	}
+	if filter.SortPolicy == types.SortPolicyDeadline {
+		now := time.Now()
+		sort.SliceStable(ready, func(i, j int) bool {
+			a, b := ready[i], ready[j]
+			if pa, pb := a.Priority-a.DeadlineWeight(now), b.Priority-b.DeadlineWeight(now); pa != pb {
+				return pa < pb
+			}
+			if (a.DueAt == nil) != (b.DueAt == nil) {
+				return a.DueAt != nil
+			}
+			if a.DueAt != nil && !a.DueAt.Equal(*b.DueAt) {
+				return a.DueAt.Before(*b.DueAt)
+			}
+			return a.CreatedAt.Before(b.CreatedAt)
+		})
+	}
+	return ready, nil
 }
 
 func (m *MemoryStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
@@ -780,15 +893,21 @@ func (m *MemoryStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 		TotalIssues: len(m.issues),
 	}
 
+	now := time.Now()
 	for _, issue := range m.issues {
-		switch issue.Status {
-		case types.StatusOpen:
+		if issue.IsOverdue(now) {
+			stats.OverdueIssues++
+		}
+		if issue.Status == types.StatusOpen {
 			stats.OpenIssues++
-		case types.StatusInProgress:
+			continue
+		}
+		switch issue.Status.Category() {
+		case types.CategoryActive:
 			stats.InProgressIssues++
-		case types.StatusBlocked:
+		case types.CategoryBlocked:
 			stats.BlockedIssues++
-		case types.StatusClosed:
+		case types.CategoryDone:
 			stats.ClosedIssues++
 		}
 	}
@@ -878,3 +878,39 @@ func TestClose(t *testing.T) {
 		t.Error("Store should be closed")
 	}
 }
+
+func TestUpdateIssueEnforcesTransitions(t *testing.T) {
+	wf := types.DefaultWorkflow()
+	if err := wf.SetTransitions(types.StatusClosed, []types.Status{types.StatusOpen}); err != nil {
+		t.Fatal(err)
+	}
+	types.SetWorkflow(wf)
+	t.Cleanup(func() { types.SetWorkflow(nil) })
+
+	store := setupTestMemory(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	closedAt := time.Now()
+	issue := &types.Issue{Title: "Done", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask, ClosedAt: &closedAt}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// Both string and types.Status values are checked
+	for _, status := range []interface{}{string(types.StatusInProgress), types.StatusInProgress} {
+		if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": status}, "test-user"); err == nil {
+			t.Errorf("expected closed → in_progress (%T) to be rejected", status)
+		}
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": types.StatusOpen}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	updated, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if updated.Status != types.StatusOpen || updated.ClosedAt != nil {
+		t.Errorf("expected reopened issue, got status=%s closed_at=%v", updated.Status, updated.ClosedAt)
+	}
+}
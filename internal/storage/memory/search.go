@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// snippetWords is the number of words shown in a search snippet
+const snippetWords = 16
+
+// SearchIssuesRanked runs a full-text search over issue text and comments.
+// There is no index: each issue is scored by weighted term frequency, with
+// terms matching words that start with them (a rough stand-in for stemming).
+func (m *MemoryStorage) SearchIssuesRanked(ctx context.Context, query string, filter types.IssueFilter) ([]*types.SearchResult, error) {
+	parsed := storage.ParseSearchQuery(query)
+	if parsed.Empty() {
+		return nil, fmt.Errorf("search query has no searchable terms")
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var results []*types.SearchResult
+	for _, issue := range m.issues {
+		if !m.matchesFilter(issue, filter) {
+			continue
+		}
+
+		score, snippet, ok := scoreIssue(m.searchFieldValues(issue), parsed)
+		if !ok {
+			continue
+		}
+
+		result := &types.SearchResult{Issue: *issue, Score: score, Snippet: snippet}
+		if labels, ok := m.labels[issue.ID]; ok {
+			result.Labels = labels
+		}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Priority != results[j].Priority {
+			return results[i].Priority < results[j].Priority
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	if filter.Limit > 0 && len(results) > filter.Limit {
+		results = results[:filter.Limit]
+	}
+
+	return results, nil
+}
+
+// searchFieldValues returns the issue's text in storage.SearchFields order.
+// Caller must hold the read lock.
+func (m *MemoryStorage) searchFieldValues(issue *types.Issue) []string {
+	comments := make([]string, 0, len(m.comments[issue.ID]))
+	for _, c := range m.comments[issue.ID] {
+		comments = append(comments, c.Text)
+	}
+	return []string{
+		issue.Title,
+		issue.Description,
+		issue.Design,
+		issue.AcceptanceCriteria,
+		issue.Notes,
+		strings.Join(comments, "\n"),
+	}
+}
+
+// scoreIssue checks every clause of q against the field values and returns the
+// weighted hit count plus a snippet from the best scoring field
+func scoreIssue(values []string, q storage.SearchQuery) (float64, string, bool) {
+	fieldWords := make([][]string, len(values))
+	for i, v := range values {
+		fieldWords[i] = splitWords(v)
+	}
+
+	var total float64
+	bestField, bestScore := -1, 0.0
+	hits := make([]map[int]int, len(values)) // field -> word position -> matched length
+
+	for _, clause := range q.Clauses {
+		clauseMatched := false
+		for _, term := range clause {
+			termWords := splitWords(term.Text)
+			for f, words := range fieldWords {
+				for _, pos := range matchPositions(words, termWords) {
+					clauseMatched = true
+					if hits[f] == nil {
+						hits[f] = make(map[int]int)
+					}
+					hits[f][pos] = len(termWords)
+				}
+			}
+		}
+		if !clauseMatched {
+			return 0, "", false
+		}
+	}
+
+	for f, fieldHits := range hits {
+		if len(fieldHits) == 0 {
+			continue
+		}
+		score := storage.SearchFields[f].Weight * float64(len(fieldHits))
+		total += score
+		if score > bestScore {
+			bestField, bestScore = f, score
+		}
+	}
+
+	return total, buildSnippet(fieldWords[bestField], hits[bestField]), true
+}
+
+// matchPositions returns every index in words where the term's words appear in sequence
+func matchPositions(words, termWords []string) []int {
+	if len(termWords) == 0 {
+		return nil
+	}
+	var positions []int
+	for i := 0; i+len(termWords) <= len(words); i++ {
+		matched := true
+		for j, tw := range termWords {
+			if !strings.HasPrefix(strings.ToLower(words[i+j]), tw) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// buildSnippet renders a window of words around the first hit with matches highlighted
+func buildSnippet(words []string, hits map[int]int) string {
+	first := len(words)
+	for pos := range hits {
+		if pos < first {
+			first = pos
+		}
+	}
+
+	start := first - snippetWords/4
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetWords
+	if end > len(words) {
+		end = len(words)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("… ")
+	}
+	highlightUntil := -1
+	for i := start; i < end; i++ {
+		if i > start {
+			b.WriteString(" ")
+		}
+		if n, ok := hits[i]; ok && i > highlightUntil {
+			b.WriteString(storage.HighlightStart)
+			highlightUntil = i + n - 1
+		}
+		b.WriteString(words[i])
+		if i == highlightUntil || (i == end-1 && i < highlightUntil) {
+			b.WriteString(storage.HighlightEnd)
+		}
+	}
+	if end < len(words) {
+		b.WriteString(" …")
+	}
+	return b.String()
+}
+
+// splitWords breaks text into letter/digit runs, matching how the SQLite index tokenizes
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
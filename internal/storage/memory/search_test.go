@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestSearchIssuesRanked(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+	ctx := context.Background()
+
+	create := func(title, description string) *types.Issue {
+		issue := &types.Issue{Title: title, Description: description, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		return issue
+	}
+
+	inDesc := create("Refactor session handling", "Login fails when the session token expires")
+	inTitle := create("Login page broken", "Nothing renders")
+	inComment := create("Flaky CI", "")
+	if _, err := store.AddIssueComment(ctx, inComment.ID, "alice", "Started after the login change"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	results, err := store.SearchIssuesRanked(ctx, "login", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].ID != inTitle.ID || results[1].ID != inDesc.ID || results[2].ID != inComment.ID {
+		t.Errorf("unexpected ranking: %s, %s, %s", results[0].ID, results[1].ID, results[2].ID)
+	}
+	if results[1].Snippet != "**Login** fails when the session token expires" {
+		t.Errorf("unexpected snippet %q", results[1].Snippet)
+	}
+
+	results, err = store.SearchIssuesRanked(ctx, `"session token"`, types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Snippet != "Login fails when the **session token** expires" {
+		t.Errorf("unexpected phrase results: %+v", results)
+	}
+
+	results, err = store.SearchIssuesRanked(ctx, "login OR flaky", types.IssueFilter{Limit: 1})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("expected limit to apply, got %d results", len(results))
+	}
+
+	if _, err := store.SearchIssuesRanked(ctx, "*", types.IssueFilter{}); err == nil {
+		t.Error("expected error for query without searchable terms")
+	}
+}
+
+func TestBuildSnippet(t *testing.T) {
+	words := splitWords("one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen seventeen eighteen nineteen twenty")
+
+	got := buildSnippet(words, map[int]int{10: 2})
+	want := "… seven eight nine ten **eleven twelve** thirteen fourteen fifteen sixteen seventeen eighteen nineteen twenty"
+	if got != want {
+		t.Errorf("buildSnippet = %q, want %q", got, want)
+	}
+
+	got = buildSnippet(words, map[int]int{0: 1})
+	want = "**one** two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen …"
+	if got != want {
+		t.Errorf("buildSnippet = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,442 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+const (
+	// maxDependencyDepth is the maximum depth for recursive dependency traversal
+	// to prevent infinite loops and limit query complexity
+	maxDependencyDepth = 100
+)
+
+// AddDependency adds a dependency between issues with cycle prevention
+func (s *PostgresStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	if !dep.Type.IsValid() {
+		return fmt.Errorf("invalid dependency type: %s (must be blocks, related, parent-child, or discovered-from)", dep.Type)
+	}
+	if types.IsQualifiedID(dep.DependsOnID) {
+		return fmt.Errorf("cross-repository dependency on %s requires SQLite storage", dep.DependsOnID)
+	}
+
+	issueExists, err := s.GetIssue(ctx, dep.IssueID)
+	if err != nil {
+		return fmt.Errorf("failed to check issue %s: %w", dep.IssueID, err)
+	}
+	if issueExists == nil {
+		return fmt.Errorf("issue %s not found", dep.IssueID)
+	}
+
+	dependsOnExists, err := s.GetIssue(ctx, dep.DependsOnID)
+	if err != nil {
+		return fmt.Errorf("failed to check dependency %s: %w", dep.DependsOnID, err)
+	}
+	if dependsOnExists == nil {
+		return fmt.Errorf("dependency target %s not found", dep.DependsOnID)
+	}
+
+	if dep.IssueID == dep.DependsOnID {
+		return fmt.Errorf("issue cannot depend on itself")
+	}
+
+	// Child depends on parent, never the other way round
+	if dep.Type == types.DepParentChild {
+		if issueExists.IssueType == types.TypeEpic && dependsOnExists.IssueType != types.TypeEpic {
+			return fmt.Errorf("invalid parent-child dependency: parent (%s) cannot depend on child (%s). Use: beads dep add %s %s --type parent-child",
+				dep.IssueID, dep.DependsOnID, dep.DependsOnID, dep.IssueID)
+		}
+	}
+
+	if dep.CreatedAt.IsZero() {
+		dep.CreatedAt = time.Now()
+	}
+	if dep.CreatedBy == "" {
+		dep.CreatedBy = actor
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Cycle prevention across ALL dependency types, same as the SQLite backend:
+	// walk from DependsOnID and fail if IssueID is reachable
+	var cycleExists bool
+	err = tx.QueryRowContext(ctx, `
+		WITH RECURSIVE paths AS (
+			SELECT issue_id, depends_on_id, 1 as depth
+			FROM dependencies
+			WHERE issue_id = $1
+
+			UNION ALL
+
+			SELECT d.issue_id, d.depends_on_id, p.depth + 1
+			FROM dependencies d
+			JOIN paths p ON d.issue_id = p.depends_on_id
+			WHERE p.depth < $2
+		)
+		SELECT EXISTS(
+			SELECT 1 FROM paths
+			WHERE depends_on_id = $3
+		)
+	`, dep.DependsOnID, maxDependencyDepth, dep.IssueID).Scan(&cycleExists)
+	if err != nil {
+		return fmt.Errorf("failed to check for cycles: %w", err)
+	}
+
+	if cycleExists {
+		return fmt.Errorf("cannot add dependency: would create a cycle (%s → %s → ... → %s)",
+			dep.IssueID, dep.DependsOnID, dep.IssueID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES ($1, $2, $3, $4)
+	`, dep.IssueID, types.EventDependencyAdded, actor,
+		fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID))
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	// Dependencies are exported with each issue, so both sides need updating
+	if err := markIssuesDirtyTx(ctx, tx, []string{dep.IssueID, dep.DependsOnID}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RemoveDependency removes a dependency
+func (s *PostgresStorage) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM dependencies WHERE issue_id = $1 AND depends_on_id = $2
+	`, issueID, dependsOnID)
+	if err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("dependency from %s to %s does not exist", issueID, dependsOnID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES ($1, $2, $3, $4)
+	`, issueID, types.EventDependencyRemoved, actor,
+		fmt.Sprintf("Removed dependency on %s", dependsOnID))
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if err := markIssuesDirtyTx(ctx, tx, []string{issueID, dependsOnID}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetDependencies returns issues that this issue depends on
+func (s *PostgresStorage) GetDependencies(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.recurrence, i.due_at, i.recurred_at
+		FROM issues i
+		JOIN dependencies d ON i.id = d.depends_on_id
+		WHERE d.issue_id = $1
+		ORDER BY i.priority ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
+// GetDependents returns issues that depend on this issue
+func (s *PostgresStorage) GetDependents(ctx context.Context, issueID string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.recurrence, i.due_at, i.recurred_at
+		FROM issues i
+		JOIN dependencies d ON i.id = d.issue_id
+		WHERE d.depends_on_id = $1
+		ORDER BY i.priority ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependents: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
+// GetDependencyRecords returns raw dependency records for an issue
+func (s *PostgresStorage) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, depends_on_id, type, created_at, created_by
+		FROM dependencies
+		WHERE issue_id = $1
+		ORDER BY created_at ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency records: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var deps []*types.Dependency
+	for rows.Next() {
+		var dep types.Dependency
+		if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type, &dep.CreatedAt, &dep.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		deps = append(deps, &dep)
+	}
+
+	return deps, rows.Err()
+}
+
+// GetAllDependencyRecords returns all dependency records grouped by issue ID
+// This is optimized for bulk export operations to avoid N+1 queries
+func (s *PostgresStorage) GetAllDependencyRecords(ctx context.Context) (map[string][]*types.Dependency, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, depends_on_id, type, created_at, created_by
+		FROM dependencies
+		ORDER BY issue_id, created_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all dependency records: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	depsMap := make(map[string][]*types.Dependency)
+	for rows.Next() {
+		var dep types.Dependency
+		if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type, &dep.CreatedAt, &dep.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		depsMap[dep.IssueID] = append(depsMap[dep.IssueID], &dep)
+	}
+
+	return depsMap, rows.Err()
+}
+
+// GetDependencyTree returns the full dependency tree with optional deduplication
+// When showAllPaths is false (default), nodes appearing via multiple paths (diamond dependencies)
+// appear only once at their shallowest depth in the tree.
+// When reverse is true, shows dependents instead of dependencies.
+func (s *PostgresStorage) GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error) {
+	if maxDepth <= 0 {
+		maxDepth = 50
+	}
+
+	// Normal mode walks up to what blocks us; reverse mode walks down to dependents.
+	// Paths are tracked as TEXT[] so cycles are cut without string matching.
+	joinSQL := `JOIN dependencies d ON i.id = d.depends_on_id
+				JOIN tree t ON d.issue_id = t.id`
+	if reverse {
+		joinSQL = `JOIN dependencies d ON i.id = d.issue_id
+				JOIN tree t ON d.depends_on_id = t.id`
+	}
+
+	// #nosec G202 - joinSQL is one of two fixed fragments
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT
+				i.id, i.title, i.status, i.priority, i.description, i.design,
+				i.acceptance_criteria, i.notes, i.issue_type, i.assignee,
+				i.estimated_minutes, i.created_at, i.updated_at, i.closed_at,
+				i.external_ref,
+				0 as depth,
+				ARRAY[i.id] as path,
+				i.id as parent_id
+			FROM issues i
+			WHERE i.id = $1
+
+			UNION ALL
+
+			SELECT
+				i.id, i.title, i.status, i.priority, i.description, i.design,
+				i.acceptance_criteria, i.notes, i.issue_type, i.assignee,
+				i.estimated_minutes, i.created_at, i.updated_at, i.closed_at,
+				i.external_ref,
+				t.depth + 1,
+				t.path || i.id,
+				t.id
+			FROM issues i
+			` + joinSQL + `
+			WHERE t.depth < $2
+			  AND NOT i.id = ANY(t.path)
+		)
+		SELECT id, title, status, priority, description, design,
+			acceptance_criteria, notes, issue_type, assignee,
+			estimated_minutes, created_at, updated_at, closed_at,
+			external_ref, depth, parent_id
+		FROM tree
+		ORDER BY depth, priority, id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, issueID, maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependency tree: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	// Track the shallowest depth each node was seen at for deduplication
+	seen := make(map[string]int)
+	var nodes []*types.TreeNode
+
+	for rows.Next() {
+		var node types.TreeNode
+		var closedAt sql.NullTime
+		var estimatedMinutes sql.NullInt64
+		var assignee sql.NullString
+		var externalRef sql.NullString
+
+		err := rows.Scan(
+			&node.ID, &node.Title, &node.Status, &node.Priority,
+			&node.Description, &node.Design, &node.AcceptanceCriteria,
+			&node.Notes, &node.IssueType, &assignee, &estimatedMinutes,
+			&node.CreatedAt, &node.UpdatedAt, &closedAt, &externalRef,
+			&node.Depth, &node.ParentID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tree node: %w", err)
+		}
+
+		if closedAt.Valid {
+			node.ClosedAt = &closedAt.Time
+		}
+		if estimatedMinutes.Valid {
+			mins := int(estimatedMinutes.Int64)
+			node.EstimatedMinutes = &mins
+		}
+		if assignee.Valid {
+			node.Assignee = assignee.String
+		}
+		if externalRef.Valid {
+			node.ExternalRef = &externalRef.String
+		}
+
+		node.Truncated = node.Depth == maxDepth
+
+		if !showAllPaths {
+			// Rows are ordered by depth, so the first occurrence is the shallowest
+			if _, exists := seen[node.ID]; exists {
+				continue
+			}
+			seen[node.ID] = node.Depth
+		}
+		nodes = append(nodes, &node)
+	}
+
+	return nodes, rows.Err()
+}
+
+// DetectCycles finds circular dependencies and returns the actual cycle paths
+func (s *PostgresStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		WITH RECURSIVE paths AS (
+			SELECT
+				issue_id,
+				depends_on_id,
+				issue_id as start_id,
+				issue_id || '→' || depends_on_id as path,
+				0 as depth
+			FROM dependencies
+
+			UNION ALL
+
+			SELECT
+				d.issue_id,
+				d.depends_on_id,
+				p.start_id,
+				p.path || '→' || d.depends_on_id,
+				p.depth + 1
+			FROM dependencies d
+			JOIN paths p ON d.issue_id = p.depends_on_id
+			WHERE p.depth < $1
+			  AND p.path NOT LIKE '%' || d.depends_on_id || '→%'
+		)
+		SELECT DISTINCT path || '→' || start_id as cycle_path
+		FROM paths
+		WHERE depends_on_id = start_id
+		ORDER BY cycle_path
+	`, maxDependencyDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect cycles: %w", err)
+	}
+
+	var paths []string
+	for rows.Next() {
+		var pathStr string
+		if err := rows.Scan(&pathStr); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		paths = append(paths, pathStr)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var cycles [][]*types.Issue
+	seen := make(map[string]bool)
+	for _, pathStr := range paths {
+		if seen[pathStr] {
+			continue
+		}
+		seen[pathStr] = true
+
+		// Parse "beads-1→beads-2→beads-3→beads-1" and drop the closing element
+		issueIDs := strings.Split(pathStr, "→")
+		if len(issueIDs) > 1 && issueIDs[0] == issueIDs[len(issueIDs)-1] {
+			issueIDs = issueIDs[:len(issueIDs)-1]
+		}
+
+		var cycleIssues []*types.Issue
+		for _, issueID := range issueIDs {
+			issue, err := s.GetIssue(ctx, issueID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue %s: %w", issueID, err)
+			}
+			if issue != nil {
+				cycleIssues = append(cycleIssues, issue)
+			}
+		}
+
+		if len(cycleIssues) > 0 {
+			cycles = append(cycles, cycleIssues)
+		}
+	}
+
+	return cycles, nil
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// MarkIssueDirty marks an issue as dirty (needs to be exported to JSONL)
+func (s *PostgresStorage) MarkIssueDirty(ctx context.Context, issueID string) error {
+	_, err := s.db.ExecContext(ctx, markDirty, issueID, time.Now())
+	return err
+}
+
+// MarkIssuesDirty marks multiple issues as dirty in a single statement
+func (s *PostgresStorage) MarkIssuesDirty(ctx context.Context, issueIDs []string) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+	_, err := s.db.ExecContext(ctx, markDirtyMany, pq.Array(issueIDs), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark issues dirty: %w", err)
+	}
+	return nil
+}
+
+// markDirtyMany upserts a dirty_issues row for each ID in a TEXT[] parameter
+const markDirtyMany = `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		SELECT DISTINCT unnest($1::text[]), $2::timestamptz
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`
+
+// GetDirtyIssues returns the list of issue IDs that need to be exported
+func (s *PostgresStorage) GetDirtyIssues(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id FROM dirty_issues
+		ORDER BY marked_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dirty issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var issueIDs []string
+	for rows.Next() {
+		var issueID string
+		if err := rows.Scan(&issueID); err != nil {
+			return nil, fmt.Errorf("failed to scan issue ID: %w", err)
+		}
+		issueIDs = append(issueIDs, issueID)
+	}
+
+	return issueIDs, rows.Err()
+}
+
+// GetDirtyIssueHash returns the stored content hash for a dirty issue, if it exists
+func (s *PostgresStorage) GetDirtyIssueHash(ctx context.Context, issueID string) (string, error) {
+	var hash sql.NullString
+	err := s.db.QueryRowContext(ctx, `
+		SELECT content_hash FROM dirty_issues WHERE issue_id = $1
+	`, issueID).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get dirty issue hash: %w", err)
+	}
+	return hash.String, nil
+}
+
+// ClearDirtyIssues removes all entries from the dirty_issues table
+//
+// WARNING: This has a race condition (beads-52). Use ClearDirtyIssuesByID instead.
+func (s *PostgresStorage) ClearDirtyIssues(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM dirty_issues`); err != nil {
+		return fmt.Errorf("failed to clear dirty issues: %w", err)
+	}
+	return nil
+}
+
+// ClearDirtyIssuesByID removes specific issue IDs from the dirty_issues table
+func (s *PostgresStorage) ClearDirtyIssuesByID(ctx context.Context, issueIDs []string) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+	_, err := s.db.ExecContext(ctx, `DELETE FROM dirty_issues WHERE issue_id = ANY($1)`, pq.Array(issueIDs))
+	if err != nil {
+		return fmt.Errorf("failed to clear dirty issues: %w", err)
+	}
+	return nil
+}
+
+// GetDirtyIssueCount returns the count of dirty issues (for monitoring/debugging)
+func (s *PostgresStorage) GetDirtyIssueCount(ctx context.Context) (int, error) {
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM dirty_issues`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count dirty issues: %w", err)
+	}
+	return count, nil
+}
+
+// markIssuesDirtyTx marks multiple issues as dirty within an existing transaction
+func markIssuesDirtyTx(ctx context.Context, tx *sql.Tx, issueIDs []string) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, markDirtyMany, pq.Array(issueIDs), time.Now()); err != nil {
+		return fmt.Errorf("failed to mark issues dirty: %w", err)
+	}
+	return nil
+}
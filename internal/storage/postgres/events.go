@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// AddComment adds a comment event to an issue
+func (s *PostgresStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES ($1, $2, $3, $4)
+	`, issueID, types.EventCommented, actor, comment)
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, `UPDATE issues SET updated_at = $1 WHERE id = $2`, now, issueID); err != nil {
+		return fmt.Errorf("failed to update timestamp: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, markDirty, issueID, now); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetEvents returns the event history for an issue
+func (s *PostgresStorage) GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error) {
+	q := &queryBuilder{}
+	where := "issue_id = " + q.arg(issueID)
+	limitSQL := ""
+	if limit > 0 {
+		limitSQL = "LIMIT " + q.arg(limit)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	query := fmt.Sprintf(`
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		%s
+	`, where, limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, query, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*types.Event
+	for rows.Next() {
+		var event types.Event
+		var oldValue, newValue, comment sql.NullString
+
+		err := rows.Scan(
+			&event.ID, &event.IssueID, &event.EventType, &event.Actor,
+			&oldValue, &newValue, &comment, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+// GetStatistics returns aggregate statistics
+func (s *PostgresStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
+	var stats types.Statistics
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*) as total,
+			COALESCE(SUM(CASE WHEN status = 'open' THEN 1 ELSE 0 END), 0) as open,
+			COALESCE(SUM(CASE WHEN status != 'open' AND status IN `+activeStatusesSQL+` THEN 1 ELSE 0 END), 0) as in_progress,
+			COALESCE(SUM(CASE WHEN status IN `+doneStatusesSQL+` THEN 1 ELSE 0 END), 0) as closed,
+			COALESCE(SUM(CASE WHEN status NOT IN `+doneStatusesSQL+` AND due_at < now() THEN 1 ELSE 0 END), 0) as overdue
+		FROM issues
+	`).Scan(&stats.TotalIssues, &stats.OpenIssues, &stats.InProgressIssues, &stats.ClosedIssues, &stats.OverdueIssues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue counts: %w", err)
+	}
+
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT i.id)
+		FROM issues i
+		JOIN dependencies d ON i.id = d.issue_id
+		JOIN issues blocker ON d.depends_on_id = blocker.id
+		WHERE i.status NOT IN `+doneStatusesSQL+`
+		  AND d.type = 'blocks'
+		  AND blocker.status NOT IN `+doneStatusesSQL+`
+	`).Scan(&stats.BlockedIssues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked count: %w", err)
+	}
+
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM issues i
+		WHERE i.status = 'open'
+		  AND NOT EXISTS (
+		    SELECT 1 FROM dependencies d
+		    JOIN issues blocked ON d.depends_on_id = blocked.id
+		    WHERE d.issue_id = i.id
+		      AND d.type = 'blocks'
+		      AND blocked.status NOT IN `+doneStatusesSQL+`
+		  )
+	`).Scan(&stats.ReadyIssues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ready count: %w", err)
+	}
+
+	// Average lead time in hours from created to closed
+	var avgLeadTime sql.NullFloat64
+	err = s.db.QueryRowContext(ctx, `
+		SELECT AVG(EXTRACT(EPOCH FROM (closed_at - created_at)) / 3600.0)::float8
+		FROM issues
+		WHERE closed_at IS NOT NULL
+	`).Scan(&avgLeadTime)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get lead time: %w", err)
+	}
+	if avgLeadTime.Valid {
+		stats.AverageLeadTime = avgLeadTime.Float64
+	}
+
+	err = s.db.QueryRowContext(ctx, `
+		WITH epic_children AS (
+			SELECT
+				d.depends_on_id AS epic_id,
+				i.status AS child_status
+			FROM dependencies d
+			JOIN issues i ON i.id = d.issue_id
+			WHERE d.type = 'parent-child'
+		),
+		epic_stats AS (
+			SELECT
+				epic_id,
+				COUNT(*) AS total_children,
+				SUM(CASE WHEN child_status IN `+doneStatusesSQL+` THEN 1 ELSE 0 END) AS closed_children
+			FROM epic_children
+			GROUP BY epic_id
+		)
+		SELECT COUNT(*)
+		FROM issues i
+		JOIN epic_stats es ON es.epic_id = i.id
+		WHERE i.issue_type = 'epic'
+		  AND i.status NOT IN `+doneStatusesSQL+`
+		  AND es.total_children > 0
+		  AND es.closed_children = es.total_children
+	`).Scan(&stats.EpicsEligibleForClosure)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eligible epics count: %w", err)
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaneholloman/beads/internal/query"
+)
+
+// filterColumns maps filter fields to issue columns
+var filterColumns = map[query.Field]string{
+	query.FieldID:          "id",
+	query.FieldTitle:       "title",
+	query.FieldDescription: "description",
+	query.FieldStatus:      "status",
+	query.FieldPriority:    "priority",
+	query.FieldType:        "issue_type",
+	query.FieldAssignee:    "assignee",
+	query.FieldCreated:     "created_at",
+	query.FieldUpdated:     "updated_at",
+	query.FieldClosed:      "closed_at",
+	query.FieldDue:         "due_at",
+}
+
+// compileFilterExpr compiles a structured filter into a WHERE clause fragment,
+// adding its parameters to q. col is the issues table qualifier ("" or "i.").
+func compileFilterExpr(q *queryBuilder, expr query.Expr, col string) (string, error) {
+	switch e := expr.(type) {
+	case query.And:
+		return compileFilterList(q, e.Exprs, " AND ", col)
+	case query.Or:
+		return compileFilterList(q, e.Exprs, " OR ", col)
+	case query.Not:
+		clause, err := compileFilterExpr(q, e.Expr, col)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + clause + ")", nil
+	case query.Cond:
+		return compileFilterCond(q, e, col)
+	}
+	return "", fmt.Errorf("unsupported filter expression %T", expr)
+}
+
+func compileFilterList(q *queryBuilder, exprs []query.Expr, sep, col string) (string, error) {
+	clauses := make([]string, 0, len(exprs))
+	for _, child := range exprs {
+		clause, err := compileFilterExpr(q, child, col)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, clause)
+	}
+	return "(" + strings.Join(clauses, sep) + ")", nil
+}
+
+// compileFilterCond compiles a single condition. Every clause evaluates to true
+// or false, never NULL, so negation behaves as expected on unset columns.
+func compileFilterCond(q *queryBuilder, c query.Cond, col string) (string, error) {
+	if len(c.Values) == 0 {
+		return "", fmt.Errorf("filter on %s has no values", c.Field)
+	}
+
+	switch c.Field {
+	case query.FieldLabel:
+		var alternatives []string
+		var labels []string
+		for _, v := range c.Values {
+			if v.None {
+				alternatives = append(alternatives, col+"id NOT IN (SELECT issue_id FROM labels)")
+			} else {
+				labels = append(labels, v.Str)
+			}
+		}
+		if len(labels) > 0 {
+			alternatives = append(alternatives, col+"id IN (SELECT issue_id FROM labels WHERE label IN ("+q.list(labels)+"))")
+		}
+		return "(" + strings.Join(alternatives, " OR ") + ")", nil
+
+	case query.FieldText:
+		var alternatives []string
+		for _, v := range c.Values {
+			p := q.arg(likePattern(v.Str))
+			alternatives = append(alternatives, fmt.Sprintf("(%[1]stitle ILIKE %[2]s OR %[1]sdescription ILIKE %[2]s OR %[1]sid ILIKE %[2]s)", col, p))
+		}
+		return "(" + strings.Join(alternatives, " OR ") + ")", nil
+	}
+
+	column, ok := filterColumns[c.Field]
+	if !ok {
+		return "", fmt.Errorf("unsupported filter field %q", c.Field)
+	}
+	column = col + column
+
+	switch c.Field.Kind() {
+	case query.KindText:
+		var alternatives []string
+		for _, v := range c.Values {
+			if v.None {
+				alternatives = append(alternatives, fmt.Sprintf("COALESCE(%s, '') = ''", column))
+			} else {
+				alternatives = append(alternatives, column+" ILIKE "+q.arg(likePattern(v.Str)))
+			}
+		}
+		return "(" + strings.Join(alternatives, " OR ") + ")", nil
+
+	case query.KindKeyword:
+		// none is stored as NULL or '', so compare against ''
+		values := make([]string, len(c.Values))
+		for i, v := range c.Values {
+			values[i] = v.Str
+		}
+		return fmt.Sprintf("COALESCE(%s, '') IN (%s)", column, q.list(values)), nil
+
+	case query.KindNumber:
+		if c.Op != query.OpEq {
+			return fmt.Sprintf("%s %s %s", column, c.Op, q.arg(c.Values[0].Int)), nil
+		}
+		placeholders := make([]string, len(c.Values))
+		for i, v := range c.Values {
+			placeholders[i] = q.arg(v.Int)
+		}
+		return fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", ")), nil
+
+	case query.KindTime:
+		if c.Op == query.OpEq {
+			// The parser only allows none with ':' on time fields
+			return fmt.Sprintf("(%s IS NULL)", column), nil
+		}
+		return fmt.Sprintf("(%[1]s IS NOT NULL AND %[1]s %[2]s %[3]s)", column, c.Op, q.arg(c.Values[0].Time)), nil
+	}
+
+	return "", fmt.Errorf("unsupported filter field %q", c.Field)
+}
+
+// likePattern builds an ILIKE substring pattern, escaping wildcards in s
+func likePattern(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return "%" + s + "%"
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/query"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestSearchIssuesFilterExpr(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	login := createTestIssue(t, store, "login", types.TypeBug)
+	createTestIssue(t, store, "signup 50% off", types.TypeTask)
+	cleanup := createTestIssue(t, store, "cleanup", types.TypeChore)
+	if err := store.UpdateIssue(ctx, login.ID, map[string]interface{}{"priority": 0, "assignee": "alice"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	for _, l := range []struct{ id, label string }{{login.ID, "backend"}, {cleanup.ID, "backend"}, {cleanup.ID, "wontfix"}} {
+		if err := store.AddLabel(ctx, l.id, l.label, "test-user"); err != nil {
+			t.Fatalf("AddLabel failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, cleanup.ID, "done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"-status:closed", "login,signup 50% off"},
+		{"priority<=1", "login"},
+		{"type:(bug|task)", "login,signup 50% off"},
+		{"assignee:none", "cleanup,signup 50% off"},
+		{"label:backend -label:wontfix", "login"},
+		{"label:none", "signup 50% off"},
+		{"closed:none", "login,signup 50% off"},
+		{"closed>1h", "cleanup"},
+		{"updated<1h", ""},
+		{"title:LOG", "login"},
+		{`"5_%"`, ""},
+		{"status:open OR label:wontfix", "cleanup,login,signup 50% off"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			expr, err := query.Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.filter, err)
+			}
+			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Expr: expr})
+			if err != nil {
+				t.Fatalf("SearchIssues failed: %v", err)
+			}
+			titles := make([]string, len(issues))
+			for i, issue := range issues {
+				titles[i] = issue.Title
+			}
+			sort.Strings(titles)
+			if got := strings.Join(titles, ","); got != tt.want {
+				t.Errorf("filter %q = [%s], want [%s]", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
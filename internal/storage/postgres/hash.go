@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// GetExportHash retrieves the content hash of the last export for an issue.
+// Returns empty string if no hash is stored (first export).
+func (s *PostgresStorage) GetExportHash(ctx context.Context, issueID string) (string, error) {
+	var hash string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT content_hash FROM export_hashes WHERE issue_id = $1
+	`, issueID).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get export hash for %s: %w", issueID, err)
+	}
+	return hash, nil
+}
+
+// SetExportHash stores the content hash of an issue after successful export.
+func (s *PostgresStorage) SetExportHash(ctx context.Context, issueID, contentHash string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO export_hashes (issue_id, content_hash, exported_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (issue_id) DO UPDATE SET
+			content_hash = excluded.content_hash,
+			exported_at = now()
+	`, issueID, contentHash)
+	if err != nil {
+		return fmt.Errorf("failed to set export hash for %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// ClearAllExportHashes removes all export hashes from the database.
+func (s *PostgresStorage) ClearAllExportHashes(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM export_hashes`); err != nil {
+		return fmt.Errorf("failed to clear export hashes: %w", err)
+	}
+	return nil
+}
+
+// GetJSONLFileHash retrieves the stored hash of the JSONL file (beads-160).
+func (s *PostgresStorage) GetJSONLFileHash(ctx context.Context) (string, error) {
+	hash, err := s.GetMetadata(ctx, "jsonl_file_hash")
+	if err != nil {
+		return "", fmt.Errorf("failed to get jsonl_file_hash: %w", err)
+	}
+	return hash, nil
+}
+
+// SetJSONLFileHash stores the hash of the JSONL file after export (beads-160).
+func (s *PostgresStorage) SetJSONLFileHash(ctx context.Context, fileHash string) error {
+	if err := s.SetMetadata(ctx, "jsonl_file_hash", fileHash); err != nil {
+		return fmt.Errorf("failed to set jsonl_file_hash: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// Adaptive hash ID length bounds; same defaults and config keys as the SQLite backend
+// (max_collision_prob, min_hash_length, max_hash_length)
+const (
+	defaultMaxCollisionProb = 0.25
+	defaultMinHashLength    = 4
+	defaultMaxHashLength    = 8
+)
+
+// generateHashID creates a hash-based ID for a top-level issue.
+// It must produce the same IDs as the SQLite backend so clones can migrate between them.
+func generateHashID(prefix, title, description, creator string, timestamp time.Time, length, nonce int) string {
+	content := fmt.Sprintf("%s|%s|%s|%d|%d", title, description, creator, timestamp.UnixNano(), nonce)
+	hash := sha256.Sum256([]byte(content))
+
+	full := hex.EncodeToString(hash[:4])
+	if length < defaultMinHashLength || length > defaultMaxHashLength {
+		length = 6
+	}
+
+	return fmt.Sprintf("%s-%s", prefix, full[:length])
+}
+
+// collisionProbability calculates P(collision) using the birthday paradox approximation
+func collisionProbability(numIssues int, idLength int) float64 {
+	const base = 36.0
+	totalPossibilities := math.Pow(base, float64(idLength))
+	exponent := -float64(numIssues*numIssues) / (2.0 * totalPossibilities)
+	return 1.0 - math.Exp(exponent)
+}
+
+// computeAdaptiveLength returns the shortest length whose collision probability is acceptable
+func computeAdaptiveLength(numIssues int, maxProb float64, minLength, maxLength int) int {
+	for length := minLength; length <= maxLength; length++ {
+		if collisionProbability(numIssues, length) <= maxProb {
+			return length
+		}
+	}
+	return maxLength
+}
+
+// adaptiveIDLength returns the hash length to start with for the current database size
+func adaptiveIDLength(ctx context.Context, tx *sql.Tx, prefix string) (int, error) {
+	var numIssues int
+	// Count only top-level issues (no dot in ID after prefix)
+	err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM issues
+		WHERE id LIKE $1::text || '-%'
+		  AND strpos(substr(id, length($1::text) + 2), '.') = 0
+	`, prefix).Scan(&numIssues)
+	if err != nil {
+		return 6, err
+	}
+
+	maxProb := defaultMaxCollisionProb
+	minLength := defaultMinHashLength
+	maxLength := defaultMaxHashLength
+
+	readConfig := func(key string) string {
+		var value string
+		_ = tx.QueryRowContext(ctx, `SELECT value FROM config WHERE key = $1`, key).Scan(&value)
+		return value
+	}
+	if v, err := strconv.ParseFloat(readConfig("max_collision_prob"), 64); err == nil {
+		maxProb = v
+	}
+	if v, err := strconv.Atoi(readConfig("min_hash_length")); err == nil {
+		minLength = v
+	}
+	if v, err := strconv.Atoi(readConfig("max_hash_length")); err == nil {
+		maxLength = v
+	}
+
+	return computeAdaptiveLength(numIssues, maxProb, minLength, maxLength), nil
+}
+
+// generateUniqueID finds an unused hash ID for issue, expanding the length on collision.
+// usedIDs holds IDs already claimed by the current batch (may be nil).
+// Callers must hold the ID advisory lock.
+func generateUniqueID(ctx context.Context, tx *sql.Tx, prefix string, issue *types.Issue, actor string, usedIDs map[string]bool) (string, error) {
+	baseLength, err := adaptiveIDLength(ctx, tx, prefix)
+	if err != nil {
+		baseLength = 6
+	}
+	if baseLength > defaultMaxHashLength {
+		baseLength = defaultMaxHashLength
+	}
+
+	for length := baseLength; length <= defaultMaxHashLength; length++ {
+		for nonce := 0; nonce < 10; nonce++ {
+			candidate := generateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+			if usedIDs[candidate] {
+				continue
+			}
+
+			var exists bool
+			err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = $1)`, candidate).Scan(&exists)
+			if err != nil {
+				return "", fmt.Errorf("failed to check for ID collision: %w", err)
+			}
+			if !exists {
+				return candidate, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("failed to generate unique ID after trying lengths %d-%d with 10 nonces each", baseLength, defaultMaxHashLength)
+}
+
+// getNextChildNumber atomically generates the next child number for a parent ID
+func (s *PostgresStorage) getNextChildNumber(ctx context.Context, parentID string) (int, error) {
+	var nextChild int
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO child_counters (parent_id, last_child)
+		VALUES ($1, 1)
+		ON CONFLICT (parent_id) DO UPDATE SET
+			last_child = child_counters.last_child + 1
+		RETURNING last_child
+	`, parentID).Scan(&nextChild)
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate next child number for parent %s: %w", parentID, err)
+	}
+	return nextChild, nil
+}
+
+// GetNextChildID generates the next hierarchical child ID for a given parent
+// Returns formatted ID as parentID.{counter} (e.g., beads-a3f8e9.1 or beads-a3f8e9.1.5)
+// Works at any depth (max 3 levels)
+func (s *PostgresStorage) GetNextChildID(ctx context.Context, parentID string) (string, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = $1)`, parentID).Scan(&exists)
+	if err != nil {
+		return "", fmt.Errorf("failed to check parent existence: %w", err)
+	}
+	if !exists {
+		return "", fmt.Errorf("parent issue %s does not exist", parentID)
+	}
+
+	if depth := strings.Count(parentID, "."); depth >= 3 {
+		return "", fmt.Errorf("maximum hierarchy depth (3) exceeded for parent %s", parentID)
+	}
+
+	nextNum, err := s.getNextChildNumber(ctx, parentID)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s.%d", parentID, nextNum), nil
+}
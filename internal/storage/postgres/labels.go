@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// executeLabelOperation executes a label operation (add or remove) within a transaction
+func (s *PostgresStorage) executeLabelOperation(
+	ctx context.Context,
+	issueID, actor string,
+	labelSQL string,
+	labelSQLArgs []interface{},
+	eventType types.EventType,
+	eventComment string,
+	operationError string,
+) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, labelSQL, labelSQLArgs...); err != nil {
+		return fmt.Errorf("%s: %w", operationError, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES ($1, $2, $3, $4)
+	`, issueID, eventType, actor, eventComment)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, markDirty, issueID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// AddLabel adds a label to an issue
+func (s *PostgresStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	return s.executeLabelOperation(
+		ctx, issueID, actor,
+		`INSERT INTO labels (issue_id, label) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		[]interface{}{issueID, label},
+		types.EventLabelAdded,
+		fmt.Sprintf("Added label: %s", label),
+		"failed to add label",
+	)
+}
+
+// RemoveLabel removes a label from an issue
+func (s *PostgresStorage) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	return s.executeLabelOperation(
+		ctx, issueID, actor,
+		`DELETE FROM labels WHERE issue_id = $1 AND label = $2`,
+		[]interface{}{issueID, label},
+		types.EventLabelRemoved,
+		fmt.Sprintf("Removed label: %s", label),
+		"failed to remove label",
+	)
+}
+
+// GetLabels returns all labels for an issue
+func (s *PostgresStorage) GetLabels(ctx context.Context, issueID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT label FROM labels WHERE issue_id = $1 ORDER BY label
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var labels []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+
+	return labels, rows.Err()
+}
+
+// GetIssuesByLabel returns issues with a specific label
+func (s *PostgresStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.recurrence, i.due_at, i.recurred_at
+		FROM issues i
+		JOIN labels l ON i.id = l.issue_id
+		WHERE l.label = $1
+		ORDER BY i.priority ASC, i.created_at DESC
+	`, label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues by label: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
@@ -0,0 +1,383 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// ClaimReadyWork atomically assigns the highest-ranked unclaimed ready issue to
+// agentID: the issue moves to in_progress with agentID as assignee, and a lease
+// expiring after leaseTTL is recorded. Expired leases are released first, so
+// abandoned work becomes claimable again. Returns nil if nothing is ready.
+func (s *PostgresStorage) ClaimReadyWork(ctx context.Context, filter types.WorkFilter, agentID string, leaseTTL time.Duration) (*types.Claim, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("agent ID is required to claim work")
+	}
+	if leaseTTL <= 0 {
+		return nil, fmt.Errorf("lease TTL must be positive, got %v", leaseTTL)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	if _, err := releaseExpiredLeases(ctx, tx, now, agentID); err != nil {
+		return nil, err
+	}
+
+	// Only open issues are claimable by default; in_progress work already has an owner
+	if filter.Status == "" {
+		filter.Status = types.StatusOpen
+	}
+	filter.Limit = 1
+	q := &queryBuilder{}
+	query := readyWorkQuery(q, filter, "NOT EXISTS (SELECT 1 FROM leases l WHERE l.issue_id = i.id)")
+	// Concurrent claimers skip rows another claim has locked instead of
+	// waiting for it and then taking the same issue
+	issue, err := queryIssueInTx(ctx, tx, query+" FOR UPDATE OF i SKIP LOCKED", q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ready work: %w", err)
+	}
+	if issue == nil {
+		return nil, tx.Commit()
+	}
+
+	if err := types.CurrentWorkflow().CanTransition(issue.Status, types.StatusInProgress); err != nil {
+		return nil, fmt.Errorf("cannot claim %s: %w", issue.ID, err)
+	}
+
+	oldData, err := json.Marshal(issue)
+	if err != nil {
+		oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, issue.ID))
+	}
+	claimed := *issue
+	claimed.Status = types.StatusInProgress
+	claimed.Assignee = agentID
+	claimed.UpdatedAt = now
+	claimed.ContentHash = claimed.ComputeContentHash()
+
+	lease := &types.Lease{
+		IssueID:       issue.ID,
+		Holder:        agentID,
+		ClaimedAt:     now,
+		LastHeartbeat: now,
+		ExpiresAt:     now.Add(leaseTTL),
+	}
+	newData, _ := json.Marshal(map[string]interface{}{
+		"status":   claimed.Status,
+		"assignee": agentID,
+	})
+	comment := fmt.Sprintf("Claimed by %s until %s", agentID, lease.ExpiresAt.Format(time.RFC3339))
+	if err := applyLeaseChange(ctx, tx, &claimed, agentID, string(oldData), string(newData), comment); err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO leases (issue_id, holder, claimed_at, expires_at, last_heartbeat)
+		VALUES ($1, $2, $3, $4, $5)
+	`, lease.IssueID, lease.Holder, lease.ClaimedAt, lease.ExpiresAt, lease.LastHeartbeat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record lease: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit claim: %w", err)
+	}
+
+	labels, err := s.GetLabels(ctx, claimed.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels for issue %s: %w", claimed.ID, err)
+	}
+	claimed.Labels = labels
+	return &types.Claim{Issue: &claimed, Lease: lease}, nil
+}
+
+// GetLeases returns all current leases, soonest to expire first. Expired
+// leases are included until they are released.
+func (s *PostgresStorage) GetLeases(ctx context.Context) ([]*types.Lease, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, holder, claimed_at, expires_at, last_heartbeat
+		FROM leases
+		ORDER BY expires_at ASC, issue_id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leases: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanLeases(rows)
+}
+
+// Heartbeat records that holder is still working on an in_progress issue
+// assigned to it, extending its lease to leaseTTL from now. An issue set
+// in_progress without a claim gets a lease on its first heartbeat.
+func (s *PostgresStorage) Heartbeat(ctx context.Context, issueID, holder string, leaseTTL time.Duration) (*types.Lease, error) {
+	if holder == "" {
+		return nil, fmt.Errorf("holder is required for a heartbeat")
+	}
+	if leaseTTL <= 0 {
+		return nil, fmt.Errorf("lease TTL must be positive, got %v", leaseTTL)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var status string
+	var assignee sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT status, assignee FROM issues WHERE id = $1 FOR UPDATE`, issueID).Scan(&status, &assignee)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	if status != string(types.StatusInProgress) {
+		return nil, fmt.Errorf("issue %s is %s, not in_progress", issueID, status)
+	}
+	if assignee.String != holder {
+		if assignee.String == "" {
+			return nil, fmt.Errorf("issue %s is not assigned (assign it to %s first)", issueID, holder)
+		}
+		return nil, fmt.Errorf("issue %s is assigned to %s, not %s", issueID, assignee.String, holder)
+	}
+
+	// A new holder starts a new lease; the same holder keeps its claim time
+	now := time.Now().UTC()
+	rows, err := tx.QueryContext(ctx, `
+		INSERT INTO leases (issue_id, holder, claimed_at, expires_at, last_heartbeat)
+		VALUES ($1, $2, $3, $4, $3)
+		ON CONFLICT (issue_id) DO UPDATE SET
+			claimed_at = CASE WHEN leases.holder = EXCLUDED.holder THEN leases.claimed_at ELSE EXCLUDED.claimed_at END,
+			holder = EXCLUDED.holder,
+			expires_at = EXCLUDED.expires_at,
+			last_heartbeat = EXCLUDED.last_heartbeat
+		RETURNING issue_id, holder, claimed_at, expires_at, last_heartbeat
+	`, issueID, holder, now, now.Add(leaseTTL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record heartbeat: %w", err)
+	}
+	leases, err := scanLeases(rows)
+	_ = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(leases) != 1 {
+		return nil, fmt.Errorf("lease for %s not found after heartbeat", issueID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit heartbeat: %w", err)
+	}
+	return leases[0], nil
+}
+
+// ReleaseExpiredLeases releases leases that expired at or before the given
+// time. An issue still held by the lease's holder returns to open and
+// unassigned, with an event naming the last holder.
+func (s *PostgresStorage) ReleaseExpiredLeases(ctx context.Context, before time.Time, actor string) ([]*types.Lease, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	released, err := releaseExpiredLeases(ctx, tx, before, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit lease release: %w", err)
+	}
+	return released, nil
+}
+
+// releaseExpiredLeases deletes expired leases inside the caller's transaction
+// and returns their issues to open. Deleting first means a concurrent release
+// waits on the row locks and then finds nothing left to release.
+func releaseExpiredLeases(ctx context.Context, tx *sql.Tx, before time.Time, actor string) ([]*types.Lease, error) {
+	rows, err := tx.QueryContext(ctx, `
+		DELETE FROM leases WHERE expires_at <= $1
+		RETURNING issue_id, holder, claimed_at, expires_at, last_heartbeat
+	`, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired leases: %w", err)
+	}
+	leases, err := scanLeases(rows)
+	_ = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lease := range leases {
+		issue, err := queryIssueInTx(ctx, tx, `SELECT `+issueColumns+` FROM issues WHERE id = $1 FOR UPDATE`, lease.IssueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue %s: %w", lease.IssueID, err)
+		}
+		// Leave issues that were finished, handed off or reassigned alone
+		if issue == nil || issue.Status != types.StatusInProgress || issue.Assignee != lease.Holder {
+			continue
+		}
+		comment := fmt.Sprintf("Lease held by %s expired at %s", lease.Holder, lease.ExpiresAt.Format(time.RFC3339))
+		if err := returnToOpen(ctx, tx, issue, actor, comment); err != nil {
+			return nil, err
+		}
+	}
+	return leases, nil
+}
+
+// ReleaseUnleasedWork returns in_progress issues that have no lease and were
+// last updated at or before the given time to open and unassigned. These were
+// set in_progress without a claim and never sent a heartbeat. Returns the
+// issues as they were before release.
+func (s *PostgresStorage) ReleaseUnleasedWork(ctx context.Context, before time.Time, actor string) ([]*types.Issue, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+issueColumns+` FROM issues i
+		WHERE status = $1 AND updated_at <= $2
+		  AND NOT EXISTS (SELECT 1 FROM leases l WHERE l.issue_id = i.id)
+		ORDER BY updated_at ASC
+		FOR UPDATE
+	`, types.StatusInProgress, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unleased work: %w", err)
+	}
+	var idle []*types.Issue
+	for rows.Next() {
+		var issue types.Issue
+		if err := scanIssueRow(rows, &issue); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		idle = append(idle, &issue)
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unleased work: %w", err)
+	}
+
+	for _, issue := range idle {
+		comment := fmt.Sprintf("In progress without a heartbeat since %s", issue.UpdatedAt.UTC().Format(time.RFC3339))
+		if err := returnToOpen(ctx, tx, issue, actor, comment); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit release: %w", err)
+	}
+	return idle, nil
+}
+
+// returnToOpen moves an in_progress issue back to open and unassigned
+func returnToOpen(ctx context.Context, tx *sql.Tx, issue *types.Issue, actor, comment string) error {
+	oldData, err := json.Marshal(issue)
+	if err != nil {
+		oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, issue.ID))
+	}
+	released := *issue
+	released.Status = types.StatusOpen
+	released.Assignee = ""
+	released.UpdatedAt = time.Now()
+	released.ContentHash = released.ComputeContentHash()
+
+	newData, _ := json.Marshal(map[string]interface{}{
+		"status":   released.Status,
+		"assignee": "",
+	})
+	return applyLeaseChange(ctx, tx, &released, actor, string(oldData), string(newData), comment)
+}
+
+// applyLeaseChange writes a claim or release's status and assignee, records
+// the event and marks the issue dirty
+func applyLeaseChange(ctx context.Context, tx *sql.Tx, issue *types.Issue, actor, oldValue, newValue, comment string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE issues SET status = $1, assignee = $2, updated_at = $3, content_hash = $4
+		WHERE id = $5
+	`, issue.Status, issue.Assignee, issue.UpdatedAt, issue.ContentHash, issue.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue %s: %w", issue.ID, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, issue.ID, types.EventStatusChanged, actor, oldValue, newValue, comment)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, markDirty, issue.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// dropLeaseOnUpdate releases an issue's lease when an update takes it away
+// from the holder: a status other than in_progress, or a different assignee
+func dropLeaseOnUpdate(ctx context.Context, tx *sql.Tx, id string, updates map[string]interface{}) error {
+	if statusVal, ok := updates["status"]; ok {
+		if status, ok := statusFromValue(statusVal); ok && status != types.StatusInProgress {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM leases WHERE issue_id = $1`, id); err != nil {
+				return fmt.Errorf("failed to release lease: %w", err)
+			}
+			return nil
+		}
+	}
+	if assigneeVal, ok := updates["assignee"]; ok {
+		assignee, _ := assigneeVal.(string)
+		if _, err := tx.ExecContext(ctx, `DELETE FROM leases WHERE issue_id = $1 AND holder <> $2`, id, assignee); err != nil {
+			return fmt.Errorf("failed to release lease: %w", err)
+		}
+	}
+	return nil
+}
+
+// queryIssueInTx returns the first issue selected by a query using
+// issueColumns, or nil if there is none. Labels are not loaded.
+func queryIssueInTx(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (*types.Issue, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	var issue types.Issue
+	if err := scanIssueRow(rows, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+func scanLeases(rows *sql.Rows) ([]*types.Lease, error) {
+	var leases []*types.Lease
+	for rows.Next() {
+		var lease types.Lease
+		var lastHeartbeat sql.NullTime
+		if err := rows.Scan(&lease.IssueID, &lease.Holder, &lease.ClaimedAt, &lease.ExpiresAt, &lastHeartbeat); err != nil {
+			return nil, fmt.Errorf("failed to scan lease: %w", err)
+		}
+		lease.LastHeartbeat = lease.ClaimedAt
+		if lastHeartbeat.Valid {
+			lease.LastHeartbeat = lastHeartbeat.Time
+		}
+		leases = append(leases, &lease)
+	}
+	return leases, rows.Err()
+}
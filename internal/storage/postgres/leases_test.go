@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestClaimHeartbeatAndRelease(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	createTestIssue(t, store, "Claim me", types.TypeTask)
+
+	claim, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-1", time.Minute)
+	if err != nil || claim == nil {
+		t.Fatalf("ClaimReadyWork failed: %v", err)
+	}
+	if claim.Issue.Status != types.StatusInProgress || claim.Issue.Assignee != "agent-1" {
+		t.Errorf("unexpected claimed issue: %+v", claim.Issue)
+	}
+	if again, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-2", time.Minute); err != nil || again != nil {
+		t.Fatalf("expected nothing left to claim, got %+v, %v", again, err)
+	}
+
+	lease, err := store.Heartbeat(ctx, claim.Issue.ID, "agent-1", time.Hour)
+	if err != nil {
+		t.Fatalf("Heartbeat failed: %v", err)
+	}
+	if lease.ExpiresAt.Before(time.Now().Add(59 * time.Minute)) {
+		t.Errorf("heartbeat did not renew the lease: %+v", lease)
+	}
+	if _, err := store.Heartbeat(ctx, claim.Issue.ID, "agent-2", time.Hour); err == nil {
+		t.Error("expected a heartbeat from another agent to be rejected")
+	}
+
+	released, err := store.ReleaseExpiredLeases(ctx, time.Now().Add(2*time.Minute), "reaper")
+	if err != nil || len(released) != 0 {
+		t.Fatalf("expected the renewed lease to survive, got %+v, %v", released, err)
+	}
+	released, err = store.ReleaseExpiredLeases(ctx, time.Now().Add(2*time.Hour), "reaper")
+	if err != nil {
+		t.Fatalf("ReleaseExpiredLeases failed: %v", err)
+	}
+	if len(released) != 1 || released[0].Holder != "agent-1" {
+		t.Fatalf("expected agent-1's lease released, got %+v", released)
+	}
+
+	got, err := store.GetIssue(ctx, claim.Issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("expired issue not returned to open: status=%s assignee=%s", got.Status, got.Assignee)
+	}
+	if leases, err := store.GetLeases(ctx); err != nil || len(leases) != 0 {
+		t.Errorf("expected no leases left, got %+v, %v", leases, err)
+	}
+}
+
+func TestReleaseUnleasedWork(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	issue := createTestIssue(t, store, "Started by hand", types.TypeTask)
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{
+		"status":   string(types.StatusInProgress),
+		"assignee": "human",
+	}, "human"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	released, err := store.ReleaseUnleasedWork(ctx, time.Now().Add(-time.Minute), "reaper")
+	if err != nil || len(released) != 0 {
+		t.Fatalf("expected recently updated work kept, got %+v, %v", released, err)
+	}
+	released, err = store.ReleaseUnleasedWork(ctx, time.Now().Add(time.Minute), "reaper")
+	if err != nil {
+		t.Fatalf("ReleaseUnleasedWork failed: %v", err)
+	}
+	if len(released) != 1 || released[0].ID != issue.ID || released[0].Assignee != "human" {
+		t.Fatalf("expected %s released, got %+v", issue.ID, released)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusOpen || got.Assignee != "" {
+		t.Errorf("idle issue not returned to open: status=%s assignee=%s", got.Status, got.Assignee)
+	}
+}
@@ -0,0 +1,1080 @@
+// Package postgres implements the storage interface using PostgreSQL.
+//
+// It lets a team share one tracker across many clones: every clone points its
+// .beads/config.json at the same database instead of a local SQLite file.
+// The schema and query semantics mirror internal/storage/sqlite.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	// Import Postgres driver
+	_ "github.com/lib/pq"
+	"github.com/shaneholloman/beads/internal/recur"
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// Advisory lock keys used to serialize work across clones sharing a database
+const (
+	schemaLockKey = 0x6265616473 // "beads"
+	idLockKey     = schemaLockKey + 1
+)
+
+// PostgresStorage implements the Storage interface using PostgreSQL
+type PostgresStorage struct {
+	db     *sql.DB
+	path   string
+	closed atomic.Bool // Tracks whether Close() has been called
+}
+
+// New creates a new Postgres storage backend and initializes the schema.
+// cfg.Path is reported by Path() so daemons and clients bind to the same
+// local .beads database location; when empty a password-free DSN is used instead.
+func New(cfg storage.Config) (*PostgresStorage, error) {
+	path := cfg.Path
+	if path == "" {
+		redacted := cfg
+		redacted.Password = ""
+		path = ConnString(redacted)
+	}
+	return open(ConnString(cfg), path)
+}
+
+// open connects to dsn, initializes the schema and syncs workflow statuses
+func open(dsn, path string) (*PostgresStorage, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	if err := initSchema(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	// Register custom workflow statuses from .beads/config.yaml
+	if err := syncStatusCategories(db, types.CurrentWorkflow()); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to sync workflow statuses: %w", err)
+	}
+
+	return &PostgresStorage{
+		db:   db,
+		path: path,
+	}, nil
+}
+
+// initSchema creates tables and views while holding an advisory lock, so clones
+// opening the same fresh database at once don't race on CREATE TABLE.
+func initSchema(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1)`, schemaLockKey); err != nil {
+		return fmt.Errorf("failed to acquire schema lock: %w", err)
+	}
+	if _, err := tx.Exec(schema); err != nil {
+		return fmt.Errorf("failed to initialize schema: %w", err)
+	}
+	if _, err := tx.Exec(views); err != nil {
+		return fmt.Errorf("failed to initialize views: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ConnString builds a postgres:// URL from the storage configuration.
+// Unset fields are left out of the URL, so the driver falls back to the
+// PGHOST, PGPORT, ... environment variables and then its own defaults.
+func ConnString(cfg storage.Config) string {
+	u := url.URL{Scheme: "postgres", Path: "/" + cfg.Database}
+
+	host := cfg.Host
+	if cfg.Port > 0 {
+		host += ":" + strconv.Itoa(cfg.Port)
+	}
+	u.Host = host
+
+	if cfg.User != "" {
+		if cfg.Password != "" {
+			u.User = url.UserPassword(cfg.User, cfg.Password)
+		} else {
+			u.User = url.User(cfg.User)
+		}
+	}
+
+	if cfg.SSLMode != "" {
+		u.RawQuery = url.Values{"sslmode": {cfg.SSLMode}}.Encode()
+	}
+
+	return u.String()
+}
+
+// issueColumns is the column list scanned by scanIssues
+const issueColumns = `id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref, recurrence, due_at, recurred_at`
+
+// markDirty is the upsert used to flag an issue for incremental export
+const markDirty = `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES ($1, $2)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`
+
+// getPrefix reads the configured issue prefix inside a transaction
+func getPrefix(ctx context.Context, tx *sql.Tx) (string, error) {
+	var prefix string
+	err := tx.QueryRowContext(ctx, `SELECT value FROM config WHERE key = $1`, "issue_prefix").Scan(&prefix)
+	if err == sql.ErrNoRows || (err == nil && prefix == "") {
+		// CRITICAL: Reject operation if issue_prefix config is missing (beads-166)
+		return "", fmt.Errorf("database not initialized: issue_prefix config is missing (run 'beads init --prefix <prefix>' first)")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get config: %w", err)
+	}
+	return prefix, nil
+}
+
+// CreateIssue creates a new issue
+func (s *PostgresStorage) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	if err := issue.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	now := time.Now()
+	issue.CreatedAt = now
+	issue.UpdatedAt = now
+
+	// Compute content hash (beads-95)
+	if issue.ContentHash == "" {
+		issue.ContentHash = issue.ComputeContentHash()
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Serialize ID generation across all clients of this database
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, idLockKey); err != nil {
+		return fmt.Errorf("failed to acquire ID lock: %w", err)
+	}
+
+	prefix, err := getPrefix(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if issue.ID == "" {
+		id, err := generateUniqueID(ctx, tx, prefix, issue, actor, nil)
+		if err != nil {
+			return err
+		}
+		issue.ID = id
+	} else if err := validateExplicitID(ctx, tx, issue.ID, prefix); err != nil {
+		return err
+	}
+
+	if err := insertIssue(ctx, tx, issue); err != nil {
+		return fmt.Errorf("failed to insert issue: %w", err)
+	}
+	if err := recordCreatedEvent(ctx, tx, issue, actor); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, markDirty, issue.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// validateExplicitID checks that a caller-supplied ID uses the configured prefix
+// and, for hierarchical IDs, that the parent exists (beads-177)
+func validateExplicitID(ctx context.Context, tx *sql.Tx, id, prefix string) error {
+	if !strings.HasPrefix(id, prefix+"-") {
+		return fmt.Errorf("issue ID '%s' does not match configured prefix '%s'", id, prefix)
+	}
+
+	if lastDot := strings.LastIndex(id, "."); lastDot >= 0 {
+		parentID := id[:lastDot]
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = $1)`, parentID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check parent existence: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("parent issue %s does not exist", parentID)
+		}
+	}
+	return nil
+}
+
+func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO issues (
+			id, content_hash, title, description, design, acceptance_criteria, notes,
+			status, priority, issue_type, assignee, estimated_minutes,
+			created_at, updated_at, closed_at, external_ref, recurrence, due_at, recurred_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+	`,
+		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
+		issue.AcceptanceCriteria, issue.Notes, issue.Status,
+		issue.Priority, issue.IssueType, issue.Assignee,
+		issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
+		issue.ClosedAt, issue.ExternalRef, issue.Recurrence, issue.DueAt, issue.RecurredAt,
+	)
+	return err
+}
+
+func recordCreatedEvent(ctx context.Context, tx *sql.Tx, issue *types.Issue, actor string) error {
+	eventData, err := json.Marshal(issue)
+	if err != nil {
+		// Fall back to minimal description if marshaling fails
+		eventData = []byte(fmt.Sprintf(`{"id":"%s","title":"%s"}`, issue.ID, issue.Title))
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, new_value)
+		VALUES ($1, $2, $3, $4)
+	`, issue.ID, types.EventCreated, actor, string(eventData))
+	if err != nil {
+		return fmt.Errorf("failed to record event for %s: %w", issue.ID, err)
+	}
+	return nil
+}
+
+// CreateIssues creates multiple issues atomically in a single transaction.
+// Semantics match the SQLite backend: all issues are validated first, missing IDs
+// are generated, explicit IDs must use the configured prefix, and any error rolls
+// back the whole batch.
+func (s *PostgresStorage) CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for i, issue := range issues {
+		if issue == nil {
+			return fmt.Errorf("issue %d is nil", i)
+		}
+		if issue.CreatedAt.IsZero() {
+			issue.CreatedAt = now
+		}
+		if issue.UpdatedAt.IsZero() {
+			issue.UpdatedAt = now
+		}
+		if err := issue.Validate(); err != nil {
+			return fmt.Errorf("validation failed for issue %d: %w", i, err)
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, idLockKey); err != nil {
+		return fmt.Errorf("failed to acquire ID lock: %w", err)
+	}
+
+	prefix, err := getPrefix(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	usedIDs := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.ID != "" {
+			if !strings.HasPrefix(issue.ID, prefix+"-") {
+				return fmt.Errorf("issue ID '%s' does not match configured prefix '%s'", issue.ID, prefix)
+			}
+			usedIDs[issue.ID] = true
+		}
+	}
+
+	for i, issue := range issues {
+		if issue.ID == "" {
+			id, err := generateUniqueID(ctx, tx, prefix, issue, actor, usedIDs)
+			if err != nil {
+				return fmt.Errorf("issue %d: %w", i, err)
+			}
+			issue.ID = id
+			usedIDs[id] = true
+		}
+		if issue.ContentHash == "" {
+			issue.ContentHash = issue.ComputeContentHash()
+		}
+	}
+
+	dirtyTime := time.Now()
+	for _, issue := range issues {
+		if err := insertIssue(ctx, tx, issue); err != nil {
+			return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
+		}
+		if err := recordCreatedEvent(ctx, tx, issue, actor); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, markDirty, issue.ID, dirtyTime); err != nil {
+			return fmt.Errorf("failed to mark dirty %s: %w", issue.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetIssue retrieves an issue by ID
+func (s *PostgresStorage) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	var issue types.Issue
+	var closedAt sql.NullTime
+	var estimatedMinutes sql.NullInt64
+	var assignee sql.NullString
+	var externalRef sql.NullString
+	var dueAt sql.NullTime
+	var recurredAt sql.NullTime
+	var compactionLevel sql.NullInt64
+	var compactedAt sql.NullTime
+	var originalSize sql.NullInt64
+	var contentHash sql.NullString
+	var compactedAtCommit sql.NullString
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT `+issueColumns+`,
+		       compaction_level, compacted_at, compacted_at_commit, original_size
+		FROM issues
+		WHERE id = $1
+	`, id).Scan(
+		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
+		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
+		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
+		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &issue.Recurrence, &dueAt, &recurredAt,
+		&compactionLevel, &compactedAt, &compactedAtCommit, &originalSize,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	if contentHash.Valid {
+		issue.ContentHash = contentHash.String
+	}
+	if closedAt.Valid {
+		issue.ClosedAt = &closedAt.Time
+	}
+	if estimatedMinutes.Valid {
+		mins := int(estimatedMinutes.Int64)
+		issue.EstimatedMinutes = &mins
+	}
+	if assignee.Valid {
+		issue.Assignee = assignee.String
+	}
+	if externalRef.Valid {
+		issue.ExternalRef = &externalRef.String
+	}
+	if dueAt.Valid {
+		issue.DueAt = &dueAt.Time
+	}
+	if recurredAt.Valid {
+		issue.RecurredAt = &recurredAt.Time
+	}
+	if compactionLevel.Valid {
+		issue.CompactionLevel = int(compactionLevel.Int64)
+	}
+	if compactedAt.Valid {
+		issue.CompactedAt = &compactedAt.Time
+	}
+	if compactedAtCommit.Valid {
+		issue.CompactedAtCommit = &compactedAtCommit.String
+	}
+	if originalSize.Valid {
+		issue.OriginalSize = int(originalSize.Int64)
+	}
+
+	labels, err := s.GetLabels(ctx, issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	issue.Labels = labels
+
+	return &issue, nil
+}
+
+// Allowed fields for update to prevent SQL injection
+var allowedUpdateFields = map[string]bool{
+	"status":              true,
+	"priority":            true,
+	"title":               true,
+	"assignee":            true,
+	"description":         true,
+	"design":              true,
+	"acceptance_criteria": true,
+	"notes":               true,
+	"issue_type":          true,
+	"estimated_minutes":   true,
+	"external_ref":        true,
+	"recurrence":          true,
+	"due_at":              true,
+	"recurred_at":         true,
+}
+
+// validateFieldUpdate validates a field update value
+func validateFieldUpdate(key string, value interface{}) error {
+	switch key {
+	case "priority":
+		if priority, ok := value.(int); ok && (priority < 0 || priority > 4) {
+			return fmt.Errorf("priority must be between 0 and 4 (got %d)", priority)
+		}
+	case "status":
+		if status, ok := value.(string); ok && !types.Status(status).IsValid() {
+			return fmt.Errorf("invalid status: %s", status)
+		}
+	case "issue_type":
+		if issueType, ok := value.(string); ok && !types.IssueType(issueType).IsValid() {
+			return fmt.Errorf("invalid issue type: %s", issueType)
+		}
+	case "title":
+		if title, ok := value.(string); ok && (len(title) == 0 || len(title) > 500) {
+			return fmt.Errorf("title must be 1-500 characters")
+		}
+	case "estimated_minutes":
+		if mins, ok := value.(int); ok && mins < 0 {
+			return fmt.Errorf("estimated_minutes cannot be negative")
+		}
+	case "recurrence":
+		if rule, ok := value.(string); ok && rule != "" {
+			if _, err := recur.Parse(rule); err != nil {
+				return err
+			}
+		}
+	case "due_at", "recurred_at":
+		switch value.(type) {
+		case time.Time, *time.Time, nil:
+		default:
+			return fmt.Errorf("invalid %s: %v", key, value)
+		}
+	}
+	return nil
+}
+
+// dueAtFromValue converts a validated due_at update value to a field value
+func dueAtFromValue(value interface{}) *time.Time {
+	switch v := value.(type) {
+	case time.Time:
+		return &v
+	case *time.Time:
+		return v
+	}
+	return nil
+}
+
+// statusFromValue extracts a status from an update value (string or types.Status)
+func statusFromValue(value interface{}) (types.Status, bool) {
+	switch v := value.(type) {
+	case string:
+		return types.Status(v), true
+	case types.Status:
+		return v, true
+	}
+	return "", false
+}
+
+// determineEventType determines the event type for an update based on old and new status
+func determineEventType(oldIssue *types.Issue, updates map[string]interface{}) types.EventType {
+	statusVal, hasStatus := updates["status"]
+	if !hasStatus {
+		return types.EventUpdated
+	}
+
+	newStatus, ok := statusFromValue(statusVal)
+	if !ok {
+		return types.EventUpdated
+	}
+
+	if newStatus == types.StatusClosed {
+		return types.EventClosed
+	}
+	if oldIssue.Status == types.StatusClosed {
+		return types.EventReopened
+	}
+	return types.EventStatusChanged
+}
+
+// applyUpdates returns a copy of issue with the content fields from updates applied
+func applyUpdates(issue types.Issue, updates map[string]interface{}) types.Issue {
+	for key, value := range updates {
+		switch key {
+		case "title":
+			issue.Title = value.(string)
+		case "description":
+			issue.Description = value.(string)
+		case "design":
+			issue.Design = value.(string)
+		case "acceptance_criteria":
+			issue.AcceptanceCriteria = value.(string)
+		case "notes":
+			issue.Notes = value.(string)
+		case "status":
+			if status, ok := statusFromValue(value); ok {
+				issue.Status = status
+			}
+		case "priority":
+			issue.Priority = value.(int)
+		case "issue_type":
+			if t, ok := value.(types.IssueType); ok {
+				issue.IssueType = t
+			} else {
+				issue.IssueType = types.IssueType(value.(string))
+			}
+		case "assignee":
+			if value == nil {
+				issue.Assignee = ""
+			} else {
+				issue.Assignee = value.(string)
+			}
+		case "external_ref":
+			if value == nil {
+				issue.ExternalRef = nil
+			} else {
+				str := value.(string)
+				issue.ExternalRef = &str
+			}
+		case "recurrence":
+			issue.Recurrence = value.(string)
+		case "due_at":
+			issue.DueAt = dueAtFromValue(value)
+		}
+	}
+	return issue
+}
+
+// contentFields are the update keys that change an issue's content hash (beads-95)
+var contentFields = []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "recurrence", "due_at"}
+
+// UpdateIssue updates fields on an issue
+func (s *PostgresStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	oldIssue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if oldIssue == nil {
+		return fmt.Errorf("issue %s not found", id)
+	}
+
+	// Enforce workflow transition rules from .beads/config.yaml
+	if statusVal, hasStatus := updates["status"]; hasStatus {
+		if newStatus, ok := statusFromValue(statusVal); ok {
+			if err := types.CurrentWorkflow().CanTransition(oldIssue.Status, newStatus); err != nil {
+				return fmt.Errorf("cannot update %s: %w", id, err)
+			}
+		}
+	}
+
+	q := &queryBuilder{}
+	setClauses := []string{"updated_at = " + q.arg(time.Now())}
+
+	for key, value := range updates {
+		// Prevent SQL injection by validating field names
+		if !allowedUpdateFields[key] {
+			return fmt.Errorf("invalid field for update: %s", key)
+		}
+		if err := validateFieldUpdate(key, value); err != nil {
+			return err
+		}
+		setClauses = append(setClauses, key+" = "+q.arg(value))
+	}
+
+	// Auto-manage closed_at when status changes (enforce invariant).
+	// Only the built-in closed status carries closed_at.
+	if statusVal, hasStatus := updates["status"]; hasStatus {
+		if newStatus, ok := statusFromValue(statusVal); ok {
+			if newStatus == types.StatusClosed {
+				if _, hasClosedAt := updates["closed_at"]; !hasClosedAt {
+					now := time.Now()
+					updates["closed_at"] = now
+					setClauses = append(setClauses, "closed_at = "+q.arg(now))
+				}
+			} else if oldIssue.Status == types.StatusClosed {
+				updates["closed_at"] = nil
+				setClauses = append(setClauses, "closed_at = NULL")
+			}
+		}
+	}
+
+	// Recompute content_hash if any content fields changed (beads-95)
+	for _, field := range contentFields {
+		if _, exists := updates[field]; exists {
+			updated := applyUpdates(*oldIssue, updates)
+			setClauses = append(setClauses, "content_hash = "+q.arg(updated.ComputeContentHash()))
+			break
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// #nosec G202 - column names are validated against allowedUpdateFields
+	query := "UPDATE issues SET " + strings.Join(setClauses, ", ") + " WHERE id = " + q.arg(id)
+	if _, err := tx.ExecContext(ctx, query, q.args...); err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	if err := dropLeaseOnUpdate(ctx, tx, id, updates); err != nil {
+		return err
+	}
+
+	oldData, err := json.Marshal(oldIssue)
+	if err != nil {
+		oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, id))
+	}
+	newData, err := json.Marshal(updates)
+	if err != nil {
+		newData = []byte(`{}`)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+		VALUES ($1, $2, $3, $4, $5)
+	`, id, determineEventType(oldIssue, updates), actor, string(oldData), string(newData))
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, markDirty, id, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UpdateIssueID updates an issue ID and all its text fields in a single transaction.
+// Foreign keys are declared ON UPDATE CASCADE, so related rows follow the new ID.
+func (s *PostgresStorage) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE issues
+		SET id = $1, title = $2, description = $3, design = $4, acceptance_criteria = $5, notes = $6, updated_at = $7
+		WHERE id = $8
+	`, newID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes, time.Now(), oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue ID: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, markDirty, newID, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
+		VALUES ($1, 'renamed', $2, $3, $4)
+	`, newID, actor, oldID, newID)
+	if err != nil {
+		return fmt.Errorf("failed to record rename event: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// RenameDependencyPrefix is a no-op; dependency rows follow UpdateIssueID via ON UPDATE CASCADE
+func (s *PostgresStorage) RenameDependencyPrefix(ctx context.Context, oldPrefix, newPrefix string) error {
+	return nil
+}
+
+// RenameCounterPrefix is a no-op with hash-based IDs (beads-8e05)
+func (s *PostgresStorage) RenameCounterPrefix(ctx context.Context, oldPrefix, newPrefix string) error {
+	return nil
+}
+
+// CloseIssue closes an issue with a reason
+func (s *PostgresStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
+	now := time.Now()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Enforce workflow transition rules from .beads/config.yaml
+	var oldStatus string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM issues WHERE id = $1 FOR UPDATE`, id).Scan(&oldStatus)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get issue status: %w", err)
+	}
+	if err == nil {
+		if err := types.CurrentWorkflow().CanTransition(types.Status(oldStatus), types.StatusClosed); err != nil {
+			return fmt.Errorf("cannot close %s: %w", id, err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE issues SET status = $1, closed_at = $2, updated_at = $3
+		WHERE id = $4
+	`, types.StatusClosed, now, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+
+	// A closed issue is no longer held by anyone
+	if _, err := tx.ExecContext(ctx, `DELETE FROM leases WHERE issue_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES ($1, $2, $3, $4)
+	`, id, types.EventClosed, actor, reason)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, markDirty, id, time.Now()); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// SearchIssues finds issues matching query and filters
+func (s *PostgresStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	q := &queryBuilder{}
+	whereClauses := []string{}
+
+	if query != "" {
+		p := q.arg("%" + query + "%")
+		whereClauses = append(whereClauses, fmt.Sprintf("(title ILIKE %s OR description ILIKE %s OR id ILIKE %s)", p, p, p))
+	}
+
+	filterClauses, err := issueFilterClauses(q, filter, "")
+	if err != nil {
+		return nil, err
+	}
+	whereClauses = append(whereClauses, filterClauses...)
+
+	whereSQL := ""
+	if len(whereClauses) > 0 {
+		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = "LIMIT " + q.arg(filter.Limit)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	querySQL := fmt.Sprintf(`
+		SELECT %s
+		FROM issues
+		%s
+		ORDER BY priority ASC, created_at DESC
+		%s
+	`, issueColumns, whereSQL, limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, querySQL, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
+// issueFilterClauses translates an IssueFilter (except Limit) into WHERE clauses.
+// col is prepended to every column name, e.g. "i." when issues is aliased in a join.
+func issueFilterClauses(q *queryBuilder, filter types.IssueFilter, col string) ([]string, error) {
+	whereClauses := []string{}
+
+	if filter.TitleSearch != "" {
+		whereClauses = append(whereClauses, col+"title ILIKE "+q.arg("%"+filter.TitleSearch+"%"))
+	}
+
+	if filter.Status != nil {
+		whereClauses = append(whereClauses, col+"status = "+q.arg(*filter.Status))
+	}
+
+	if filter.Priority != nil {
+		whereClauses = append(whereClauses, col+"priority = "+q.arg(*filter.Priority))
+	}
+
+	if filter.IssueType != nil {
+		whereClauses = append(whereClauses, col+"issue_type = "+q.arg(*filter.IssueType))
+	}
+
+	if filter.Assignee != nil {
+		whereClauses = append(whereClauses, col+"assignee = "+q.arg(*filter.Assignee))
+	}
+
+	// Label filtering: issue must have ALL specified labels
+	for _, label := range filter.Labels {
+		whereClauses = append(whereClauses, col+"id IN (SELECT issue_id FROM labels WHERE label = "+q.arg(label)+")")
+	}
+
+	// Label filtering (OR): issue must have AT LEAST ONE of these labels
+	if len(filter.LabelsAny) > 0 {
+		whereClauses = append(whereClauses, col+"id IN (SELECT issue_id FROM labels WHERE label IN ("+q.list(filter.LabelsAny)+"))")
+	}
+
+	// ID filtering: match specific issue IDs
+	if len(filter.IDs) > 0 {
+		whereClauses = append(whereClauses, col+"id IN ("+q.list(filter.IDs)+")")
+	}
+
+	if filter.Expr != nil {
+		clause, err := compileFilterExpr(q, filter.Expr, col)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		whereClauses = append(whereClauses, clause)
+	}
+
+	return whereClauses, nil
+}
+
+// scanIssues scans rows selected with issueColumns and attaches labels
+func (s *PostgresStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	for rows.Next() {
+		var issue types.Issue
+		if err := scanIssueRow(rows, &issue); err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, &issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// Close before fetching labels so the connection is released
+	_ = rows.Close()
+
+	for _, issue := range issues {
+		labels, err := s.GetLabels(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for issue %s: %w", issue.ID, err)
+		}
+		issue.Labels = labels
+	}
+
+	return issues, nil
+}
+
+// scanIssueRow scans the issueColumns into issue, followed by any extra
+// destinations for columns selected after them
+func scanIssueRow(rows *sql.Rows, issue *types.Issue, extra ...interface{}) error {
+	var contentHash sql.NullString
+	var closedAt sql.NullTime
+	var estimatedMinutes sql.NullInt64
+	var assignee sql.NullString
+	var externalRef sql.NullString
+	var dueAt sql.NullTime
+	var recurredAt sql.NullTime
+
+	dest := []interface{}{
+		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
+		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
+		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
+		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &issue.Recurrence, &dueAt, &recurredAt,
+	}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return fmt.Errorf("failed to scan issue: %w", err)
+	}
+
+	if contentHash.Valid {
+		issue.ContentHash = contentHash.String
+	}
+	if closedAt.Valid {
+		issue.ClosedAt = &closedAt.Time
+	}
+	if estimatedMinutes.Valid {
+		mins := int(estimatedMinutes.Int64)
+		issue.EstimatedMinutes = &mins
+	}
+	if assignee.Valid {
+		issue.Assignee = assignee.String
+	}
+	if externalRef.Valid {
+		issue.ExternalRef = &externalRef.String
+	}
+	if dueAt.Valid {
+		issue.DueAt = &dueAt.Time
+	}
+	if recurredAt.Valid {
+		issue.RecurredAt = &recurredAt.Time
+	}
+	return nil
+}
+
+// SetConfig sets a configuration value
+func (s *PostgresStorage) SetConfig(ctx context.Context, key, value string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO config (key, value) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value
+	`, key, value)
+	return err
+}
+
+// GetConfig gets a configuration value
+func (s *PostgresStorage) GetConfig(ctx context.Context, key string) (string, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM config WHERE key = $1`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return value, err
+}
+
+// GetAllConfig gets all configuration key-value pairs
+func (s *PostgresStorage) GetAllConfig(ctx context.Context) (map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM config ORDER BY key`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	config := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		config[key] = value
+	}
+	return config, rows.Err()
+}
+
+// DeleteConfig deletes a configuration value
+func (s *PostgresStorage) DeleteConfig(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM config WHERE key = $1`, key)
+	return err
+}
+
+// SetMetadata sets a metadata value (for internal state like import hashes)
+func (s *PostgresStorage) SetMetadata(ctx context.Context, key, value string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO metadata (key, value) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value
+	`, key, value)
+	return err
+}
+
+// GetMetadata gets a metadata value (for internal state like import hashes)
+func (s *PostgresStorage) GetMetadata(ctx context.Context, key string) (string, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM metadata WHERE key = $1`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return value, err
+}
+
+// AddIssueComment adds a comment to an issue
+func (s *PostgresStorage) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = $1)`, issueID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+
+	comment := &types.Comment{}
+	err = s.db.QueryRowContext(ctx, `
+		INSERT INTO comments (issue_id, author, text, created_at)
+		VALUES ($1, $2, $3, now())
+		RETURNING id, issue_id, author, text, created_at
+	`, issueID, author, text).Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert comment: %w", err)
+	}
+
+	if err := s.MarkIssueDirty(ctx, issueID); err != nil {
+		return nil, fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return comment, nil
+}
+
+// GetIssueComments retrieves all comments for an issue
+func (s *PostgresStorage) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, issue_id, author, text, created_at
+		FROM comments
+		WHERE issue_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var comments []*types.Comment
+	for rows.Next() {
+		comment := &types.Comment{}
+		if err := rows.Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// Close closes the database connection
+func (s *PostgresStorage) Close() error {
+	s.closed.Store(true)
+	return s.db.Close()
+}
+
+// Path returns the local database path this store was opened for
+// (or a password-free connection string when none was given)
+func (s *PostgresStorage) Path() string {
+	return s.path
+}
+
+// IsClosed returns true if Close() has been called on this storage
+func (s *PostgresStorage) IsClosed() bool {
+	return s.closed.Load()
+}
+
+// UnderlyingDB returns the underlying *sql.DB connection for extensions.
+// The same safety rules as the SQLite backend apply: never Close it and never
+// change pool settings. Extension SQL must use Postgres syntax ($1 placeholders).
+func (s *PostgresStorage) UnderlyingDB() *sql.DB {
+	return s.db
+}
+
+// UnderlyingConn returns a single connection from the pool for scoped use.
+// The caller MUST close the connection when done.
+func (s *PostgresStorage) UnderlyingConn(ctx context.Context) (*sql.Conn, error) {
+	return s.db.Conn(ctx)
+}
@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// SQL fragments selecting statuses by category from the status_categories table.
+// Use "status NOT IN " + doneStatusesSQL for "still blocks dependents" so that
+// statuses unknown to this database keep blocking.
+const (
+	doneStatusesSQL   = `(SELECT status FROM status_categories WHERE category = 'done')`
+	activeStatusesSQL = `(SELECT status FROM status_categories WHERE category = 'active')`
+)
+
+// GetReadyWork returns issues with no open blockers
+// By default, shows all statuses in the active category (beads-165)
+func (s *PostgresStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	q := &queryBuilder{}
+	query := readyWorkQuery(q, filter)
+
+	rows, err := s.db.QueryContext(ctx, query, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ready work: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
+// readyWorkQuery builds the ready work query for a filter, adding its
+// arguments to q. Extra conditions on the issues table (aliased i) are ANDed
+// into the WHERE clause.
+func readyWorkQuery(q *queryBuilder, filter types.WorkFilter, extraWhere ...string) string {
+	whereClauses := []string{}
+
+	if filter.Status == "" {
+		whereClauses = append(whereClauses, "i.status IN "+activeStatusesSQL)
+	} else {
+		whereClauses = append(whereClauses, "i.status = "+q.arg(filter.Status))
+	}
+
+	if filter.Priority != nil {
+		whereClauses = append(whereClauses, "i.priority = "+q.arg(*filter.Priority))
+	}
+
+	if filter.Assignee != nil {
+		whereClauses = append(whereClauses, "i.assignee = "+q.arg(*filter.Assignee))
+	}
+
+	// Recurring issues are templates; their occurrences are the work
+	whereClauses = append(whereClauses, "i.recurrence = ''")
+
+	whereClauses = append(whereClauses, extraWhere...)
+
+	whereSQL := strings.Join(whereClauses, " AND ")
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = "LIMIT " + q.arg(filter.Limit)
+	}
+
+	sortPolicy := filter.SortPolicy
+	if sortPolicy == "" {
+		sortPolicy = types.SortPolicyHybrid
+	}
+	orderBySQL := buildOrderByClause(sortPolicy)
+
+	// Same algorithm as the SQLite backend:
+	// 1. Find issues directly blocked by 'blocks' dependencies
+	// 2. Recursively propagate blockage to all descendants via 'parent-child' links
+	// 3. Exclude all blocked issues (both direct and transitive) from ready work
+	// #nosec G201 - safe SQL with controlled formatting
+	return fmt.Sprintf(`
+		WITH RECURSIVE
+		  blocked_directly AS (
+		    SELECT DISTINCT d.issue_id
+		    FROM dependencies d
+		    JOIN issues blocker ON d.depends_on_id = blocker.id
+		    WHERE d.type = 'blocks'
+		      AND blocker.status NOT IN %s
+		  ),
+
+		  blocked_transitively AS (
+		    SELECT issue_id, 0 as depth
+		    FROM blocked_directly
+
+		    UNION ALL
+
+		    SELECT d.issue_id, bt.depth + 1
+		    FROM blocked_transitively bt
+		    JOIN dependencies d ON d.depends_on_id = bt.issue_id
+		    WHERE d.type = 'parent-child'
+		      AND bt.depth < 50
+		  )
+
+		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		i.created_at, i.updated_at, i.closed_at, i.external_ref, i.recurrence, i.due_at, i.recurred_at
+		FROM issues i
+		WHERE %s
+		AND NOT EXISTS (
+		SELECT 1 FROM blocked_transitively WHERE issue_id = i.id
+		)
+		%s
+		%s
+	`, doneStatusesSQL, whereSQL, orderBySQL, limitSQL)
+}
+
+// GetBlockedIssues returns issues that are blocked by dependencies
+func (s *PostgresStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
+	// Use string_agg to get all blocker IDs in a single query (no N+1)
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT
+		    i.id, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		    i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		    i.created_at, i.updated_at, i.closed_at, i.external_ref,
+		    COUNT(d.depends_on_id) as blocked_by_count,
+		    string_agg(d.depends_on_id, ',' ORDER BY d.depends_on_id) as blocker_ids
+		FROM issues i
+		JOIN dependencies d ON i.id = d.issue_id
+		JOIN issues blocker ON d.depends_on_id = blocker.id
+		WHERE i.status NOT IN `+doneStatusesSQL+`
+		  AND d.type = 'blocks'
+		  AND blocker.status NOT IN `+doneStatusesSQL+`
+		GROUP BY i.id
+		ORDER BY i.priority ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var blocked []*types.BlockedIssue
+	for rows.Next() {
+		var issue types.BlockedIssue
+		var closedAt sql.NullTime
+		var estimatedMinutes sql.NullInt64
+		var assignee sql.NullString
+		var externalRef sql.NullString
+		var blockerIDsStr string
+
+		err := rows.Scan(
+			&issue.ID, &issue.Title, &issue.Description, &issue.Design,
+			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
+			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
+			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &issue.BlockedByCount,
+			&blockerIDsStr,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blocked issue: %w", err)
+		}
+
+		if closedAt.Valid {
+			issue.ClosedAt = &closedAt.Time
+		}
+		if estimatedMinutes.Valid {
+			mins := int(estimatedMinutes.Int64)
+			issue.EstimatedMinutes = &mins
+		}
+		if assignee.Valid {
+			issue.Assignee = assignee.String
+		}
+		if externalRef.Valid {
+			issue.ExternalRef = &externalRef.String
+		}
+
+		if blockerIDsStr != "" {
+			issue.BlockedBy = strings.Split(blockerIDsStr, ",")
+		}
+
+		blocked = append(blocked, &issue)
+	}
+
+	return blocked, rows.Err()
+}
+
+// buildOrderByClause generates the ORDER BY clause based on sort policy
+func buildOrderByClause(policy types.SortPolicy) string {
+	switch policy {
+	case types.SortPolicyPriority:
+		return `ORDER BY i.priority ASC, i.created_at ASC`
+
+	case types.SortPolicyOldest:
+		return `ORDER BY i.created_at ASC`
+
+	case types.SortPolicyDeadline:
+		// Raise priority by types.Issue.DeadlineWeight, then soonest due first
+		return `ORDER BY
+			i.priority - CASE
+				WHEN i.due_at IS NULL THEN 0
+				WHEN i.due_at <= now() + interval '1 day' THEN 2
+				WHEN i.due_at <= now() + interval '7 days' THEN 1
+				ELSE 0
+			END ASC,
+			i.due_at ASC NULLS LAST,
+			i.created_at ASC`
+
+	case types.SortPolicyHybrid:
+		fallthrough
+	default:
+		// Recent issues (last 48h) first by priority, then older issues by age
+		return `ORDER BY
+			CASE
+				WHEN i.created_at >= now() - interval '48 hours' THEN 0
+				ELSE 1
+			END ASC,
+			CASE
+				WHEN i.created_at >= now() - interval '48 hours' THEN i.priority
+				ELSE NULL
+			END ASC,
+			CASE
+				WHEN i.created_at < now() - interval '48 hours' THEN i.created_at
+				ELSE NULL
+			END ASC,
+			i.created_at ASC`
+	}
+}
@@ -0,0 +1,238 @@
+package postgres
+
+// schema mirrors the SQLite schema (internal/storage/sqlite/schema.go) using
+// Postgres types. Every statement is idempotent so New can run it on each open.
+const schema = `
+-- Issues table
+CREATE TABLE IF NOT EXISTS issues (
+    id TEXT PRIMARY KEY,
+    content_hash TEXT,
+    title TEXT NOT NULL CHECK(length(title) <= 500),
+    description TEXT NOT NULL DEFAULT '',
+    design TEXT NOT NULL DEFAULT '',
+    acceptance_criteria TEXT NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'open',
+    priority INTEGER NOT NULL DEFAULT 2 CHECK(priority >= 0 AND priority <= 4),
+    issue_type TEXT NOT NULL DEFAULT 'task',
+    assignee TEXT,
+    estimated_minutes INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    closed_at TIMESTAMPTZ,
+    external_ref TEXT,
+    compaction_level INTEGER DEFAULT 0,
+    compacted_at TIMESTAMPTZ,
+    compacted_at_commit TEXT,
+    original_size INTEGER,
+    recurrence TEXT NOT NULL DEFAULT '',
+    due_at TIMESTAMPTZ,
+    recurred_at TIMESTAMPTZ,
+    CHECK ((status = 'closed') = (closed_at IS NOT NULL))
+);
+
+-- Columns added after the table was first created
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS recurrence TEXT NOT NULL DEFAULT '';
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS due_at TIMESTAMPTZ;
+ALTER TABLE issues ADD COLUMN IF NOT EXISTS recurred_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_issues_status ON issues(status);
+CREATE INDEX IF NOT EXISTS idx_issues_priority ON issues(priority);
+CREATE INDEX IF NOT EXISTS idx_issues_assignee ON issues(assignee);
+CREATE INDEX IF NOT EXISTS idx_issues_created_at ON issues(created_at);
+
+-- Dependencies table
+CREATE TABLE IF NOT EXISTS dependencies (
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    depends_on_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    type TEXT NOT NULL DEFAULT 'blocks',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL,
+    PRIMARY KEY (issue_id, depends_on_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dependencies_issue ON dependencies(issue_id);
+CREATE INDEX IF NOT EXISTS idx_dependencies_depends_on ON dependencies(depends_on_id);
+CREATE INDEX IF NOT EXISTS idx_dependencies_depends_on_type ON dependencies(depends_on_id, type);
+
+-- Labels table
+CREATE TABLE IF NOT EXISTS labels (
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    label TEXT NOT NULL,
+    PRIMARY KEY (issue_id, label)
+);
+
+CREATE INDEX IF NOT EXISTS idx_labels_label ON labels(label);
+
+-- Comments table
+CREATE TABLE IF NOT EXISTS comments (
+    id BIGSERIAL PRIMARY KEY,
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    author TEXT NOT NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_comments_issue ON comments(issue_id);
+CREATE INDEX IF NOT EXISTS idx_comments_created_at ON comments(created_at);
+
+-- Events table (audit trail)
+CREATE TABLE IF NOT EXISTS events (
+    id BIGSERIAL PRIMARY KEY,
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    event_type TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    comment TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_issue ON events(issue_id);
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
+
+-- Config table (for storing settings like issue prefix)
+CREATE TABLE IF NOT EXISTS config (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
+
+-- Default compaction configuration
+INSERT INTO config (key, value) VALUES
+    ('compaction_enabled', 'false'),
+    ('compact_tier1_days', '30'),
+    ('compact_tier1_dep_levels', '2'),
+    ('compact_tier2_days', '90'),
+    ('compact_tier2_dep_levels', '5'),
+    ('compact_tier2_commits', '100'),
+    ('compact_model', 'claude-3-5-haiku-20241022'),
+    ('compact_batch_size', '50'),
+    ('compact_parallel_workers', '5'),
+    ('auto_compact_enabled', 'false')
+ON CONFLICT (key) DO NOTHING;
+
+-- Metadata table (for storing internal state like import hashes)
+CREATE TABLE IF NOT EXISTS metadata (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
+
+-- Dirty issues table (for incremental JSONL export)
+CREATE TABLE IF NOT EXISTS dirty_issues (
+    issue_id TEXT PRIMARY KEY REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    marked_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    content_hash TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_dirty_issues_marked_at ON dirty_issues(marked_at);
+
+-- Tracks content hash of last export for each issue (for timestamp-only dedup, beads-164)
+CREATE TABLE IF NOT EXISTS export_hashes (
+    issue_id TEXT PRIMARY KEY REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    content_hash TEXT NOT NULL,
+    exported_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Child counters table (for hierarchical ID generation)
+CREATE TABLE IF NOT EXISTS child_counters (
+    parent_id TEXT PRIMARY KEY REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    last_child INTEGER NOT NULL DEFAULT 0
+);
+
+-- Issue snapshots table (for compaction)
+CREATE TABLE IF NOT EXISTS issue_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    snapshot_time TIMESTAMPTZ NOT NULL,
+    compaction_level INTEGER NOT NULL,
+    original_size INTEGER NOT NULL,
+    compressed_size INTEGER NOT NULL,
+    original_content TEXT NOT NULL,
+    archived_events TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_snapshots_issue ON issue_snapshots(issue_id);
+CREATE INDEX IF NOT EXISTS idx_snapshots_level ON issue_snapshots(compaction_level);
+
+-- Compaction snapshots table (for restoration)
+CREATE TABLE IF NOT EXISTS compaction_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    issue_id TEXT NOT NULL REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    compaction_level INTEGER NOT NULL,
+    snapshot_json BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_comp_snap_issue_level_created ON compaction_snapshots(issue_id, compaction_level, created_at DESC);
+
+-- Leases table (atomic work claims, see ClaimReadyWork)
+CREATE TABLE IF NOT EXISTS leases (
+    issue_id TEXT PRIMARY KEY REFERENCES issues(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    holder TEXT NOT NULL,
+    claimed_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    last_heartbeat TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_leases_expires_at ON leases(expires_at);
+
+-- Status categories table (for custom workflow statuses)
+CREATE TABLE IF NOT EXISTS status_categories (
+    status TEXT PRIMARY KEY,
+    category TEXT NOT NULL CHECK(category IN ('active', 'blocked', 'done'))
+);
+
+INSERT INTO status_categories (status, category) VALUES
+    ('open', 'active'),
+    ('in_progress', 'active'),
+    ('blocked', 'blocked'),
+    ('closed', 'done')
+ON CONFLICT (status) DO NOTHING;
+`
+
+// views are recreated on every open so their i.* column lists track the issues table.
+// CREATE OR REPLACE VIEW can't insert columns before blocked_by_count, so drop first.
+const views = `
+DROP VIEW IF EXISTS ready_issues;
+DROP VIEW IF EXISTS blocked_issues;
+
+-- Ready work view (with hierarchical blocking)
+CREATE VIEW ready_issues AS
+WITH RECURSIVE
+  blocked_directly AS (
+    SELECT DISTINCT d.issue_id
+    FROM dependencies d
+    JOIN issues blocker ON d.depends_on_id = blocker.id
+    WHERE d.type = 'blocks'
+      AND blocker.status NOT IN (SELECT status FROM status_categories WHERE category = 'done')
+  ),
+  blocked_transitively AS (
+    SELECT issue_id, 0 as depth
+    FROM blocked_directly
+    UNION ALL
+    SELECT d.issue_id, bt.depth + 1
+    FROM blocked_transitively bt
+    JOIN dependencies d ON d.depends_on_id = bt.issue_id
+    WHERE d.type = 'parent-child'
+      AND bt.depth < 50
+  )
+SELECT i.*
+FROM issues i
+WHERE i.status = 'open'
+  AND NOT EXISTS (
+    SELECT 1 FROM blocked_transitively WHERE issue_id = i.id
+  );
+
+-- Blocked issues view
+CREATE VIEW blocked_issues AS
+SELECT
+    i.*,
+    COUNT(d.depends_on_id) as blocked_by_count
+FROM issues i
+JOIN dependencies d ON i.id = d.issue_id
+JOIN issues blocker ON d.depends_on_id = blocker.id
+WHERE i.status NOT IN (SELECT status FROM status_categories WHERE category = 'done')
+  AND d.type = 'blocks'
+  AND blocker.status NOT IN (SELECT status FROM status_categories WHERE category = 'done')
+GROUP BY i.id;
+`
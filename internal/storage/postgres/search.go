@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// searchDocuments builds a weighted tsvector per issue, matching storage.SearchFields:
+// title (A), description (B), design/acceptance criteria/notes (C) and comments (D).
+// Vectors are computed at query time, so no extra index has to be kept in sync.
+const searchDocuments = `
+	SELECT i.id,
+		setweight(to_tsvector('english', i.title), 'A') ||
+		setweight(to_tsvector('english', i.description), 'B') ||
+		setweight(to_tsvector('english', concat_ws(' ', i.design, i.acceptance_criteria, i.notes)), 'C') ||
+		setweight(to_tsvector('english', COALESCE(c.text, '')), 'D') AS doc,
+		concat_ws(E'\n', i.title, i.description, i.design, i.acceptance_criteria, i.notes, c.text) AS body
+	FROM issues i
+	LEFT JOIN (
+		SELECT issue_id, string_agg(text, E'\n' ORDER BY id) AS text
+		FROM comments
+		GROUP BY issue_id
+	) c ON c.issue_id = i.id`
+
+// rankWeights are the ts_rank weights for {D, C, B, A}, scaled from storage.SearchFields
+// so a title hit ranks like it does in the SQLite index
+const rankWeights = `'{0.1, 0.2, 0.5, 1.0}'`
+
+// tsQueryExpr renders a parsed query as a tsquery expression, adding each term as
+// a parameter. Terms are split into plain words first, so user input can never
+// inject tsquery operators.
+func tsQueryExpr(q *queryBuilder, parsed storage.SearchQuery) string {
+	clauses := make([]string, 0, len(parsed.Clauses))
+	for _, clause := range parsed.Clauses {
+		alternatives := make([]string, 0, len(clause))
+		for _, term := range clause {
+			words := strings.FieldsFunc(term.Text, func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			})
+			if term.Prefix {
+				alternatives = append(alternatives, "to_tsquery('english', "+q.arg("'"+strings.Join(words, "' <-> '")+"':*")+")")
+			} else {
+				alternatives = append(alternatives, "phraseto_tsquery('english', "+q.arg(strings.Join(words, " "))+")")
+			}
+		}
+		clauses = append(clauses, "("+strings.Join(alternatives, " || ")+")")
+	}
+	return strings.Join(clauses, " && ")
+}
+
+// SearchIssuesRanked runs a full-text search over issue text and comments,
+// returning the best matches first with a highlighted snippet for each
+func (s *PostgresStorage) SearchIssuesRanked(ctx context.Context, query string, filter types.IssueFilter) ([]*types.SearchResult, error) {
+	parsed := storage.ParseSearchQuery(query)
+	if parsed.Empty() {
+		return nil, fmt.Errorf("search query has no searchable terms")
+	}
+
+	q := &queryBuilder{}
+	headlineOpts := q.arg(fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=16, MinWords=8, MaxFragments=1, FragmentDelimiter=" … "`,
+		storage.HighlightStart, storage.HighlightEnd))
+	tsQuery := tsQueryExpr(q, parsed)
+
+	whereClauses := []string{"d.doc @@ query.q"}
+	filterClauses, err := issueFilterClauses(q, filter, "i.")
+	if err != nil {
+		return nil, err
+	}
+	whereClauses = append(whereClauses, filterClauses...)
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = "LIMIT " + q.arg(filter.Limit)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	querySQL := fmt.Sprintf(`
+		WITH docs AS (%s)
+		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.recurrence, i.due_at, i.recurred_at,
+		       ts_rank(%s, d.doc, query.q)::float8 AS score,
+		       ts_headline('english', d.body, query.q, %s) AS snippet
+		FROM issues i
+		JOIN docs d ON d.id = i.id
+		CROSS JOIN (SELECT %s AS q) query
+		WHERE %s
+		ORDER BY score DESC, i.priority ASC, i.created_at DESC
+		%s
+	`, searchDocuments, rankWeights, headlineOpts, tsQuery, strings.Join(whereClauses, " AND "), limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, querySQL, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	var results []*types.SearchResult
+	for rows.Next() {
+		var result types.SearchResult
+		if err := scanIssueRow(rows, &result.Issue, &result.Score, &result.Snippet); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		results = append(results, &result)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	for _, result := range results {
+		labels, err := s.GetLabels(ctx, result.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for issue %s: %w", result.ID, err)
+		}
+		result.Labels = labels
+	}
+
+	return results, nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestTSQueryExpr(t *testing.T) {
+	q := &queryBuilder{}
+	got := tsQueryExpr(q, storage.ParseSearchQuery(`login "session token" OR auth* beads-a3f8`))
+	want := "(phraseto_tsquery('english', $1)) && " +
+		"(phraseto_tsquery('english', $2) || to_tsquery('english', $3)) && " +
+		"(phraseto_tsquery('english', $4))"
+	if got != want {
+		t.Errorf("tsQueryExpr() =\n  %s\nwant\n  %s", got, want)
+	}
+	wantArgs := []interface{}{"login", "session token", "'auth':*", "beads a3f8"}
+	if len(q.args) != len(wantArgs) {
+		t.Fatalf("args = %v, want %v", q.args, wantArgs)
+	}
+	for i := range wantArgs {
+		if q.args[i] != wantArgs[i] {
+			t.Errorf("args[%d] = %v, want %v", i, q.args[i], wantArgs[i])
+		}
+	}
+}
+
+func TestSearchIssuesRanked(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	inDesc := createTestIssue(t, store, "Refactor session handling", types.TypeTask)
+	if err := store.UpdateIssue(ctx, inDesc.ID, map[string]interface{}{"description": "Login crashes when the token expires"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	inTitle := createTestIssue(t, store, "Login page broken", types.TypeBug)
+	inComment := createTestIssue(t, store, "Flaky CI", types.TypeTask)
+	if _, err := store.AddIssueComment(ctx, inComment.ID, "alice", "Started after the login change"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	results, err := store.SearchIssuesRanked(ctx, "login", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].ID != inTitle.ID || results[1].ID != inDesc.ID || results[2].ID != inComment.ID {
+		t.Errorf("unexpected ranking: %s, %s, %s", results[0].ID, results[1].ID, results[2].ID)
+	}
+	if !strings.Contains(results[1].Snippet, "**Login**") {
+		t.Errorf("expected highlighted snippet, got %q", results[1].Snippet)
+	}
+
+	// Stemming, and filters apply on top of the match
+	bug := types.TypeBug
+	results, err = store.SearchIssuesRanked(ctx, "crash OR page", types.IssueFilter{IssueType: &bug})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != inTitle.ID {
+		t.Errorf("expected only %s, got %+v", inTitle.ID, results)
+	}
+}
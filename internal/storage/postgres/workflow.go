@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// syncStatusCategories makes the status_categories table match the workflow:
+// custom statuses are inserted or re-categorized and stale custom rows are removed.
+// Built-in rows are never touched. Writes only happen when something changed.
+func syncStatusCategories(db *sql.DB, wf *types.Workflow) error {
+	rows, err := db.Query(`SELECT status, category FROM status_categories`)
+	if err != nil {
+		return fmt.Errorf("failed to read status categories: %w", err)
+	}
+	existing := make(map[types.Status]types.StatusCategory)
+	for rows.Next() {
+		var status, category string
+		if err := rows.Scan(&status, &category); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan status category: %w", err)
+		}
+		existing[types.Status(status)] = types.StatusCategory(category)
+	}
+	_ = rows.Close()
+
+	var upserts, deletes []types.Status
+	for _, status := range wf.CustomStatuses() {
+		category, _ := wf.Category(status)
+		if existing[status] != category {
+			upserts = append(upserts, status)
+		}
+	}
+	for status := range existing {
+		if _, ok := wf.Category(status); !ok && !status.IsBuiltin() {
+			deletes = append(deletes, status)
+		}
+	}
+	if len(upserts) == 0 && len(deletes) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, status := range upserts {
+		category, _ := wf.Category(status)
+		if _, err := tx.Exec(`
+			INSERT INTO status_categories (status, category) VALUES ($1, $2)
+			ON CONFLICT (status) DO UPDATE SET category = excluded.category
+		`, status, category); err != nil {
+			return fmt.Errorf("failed to save status category for %s: %w", status, err)
+		}
+	}
+	for _, status := range deletes {
+		if _, err := tx.Exec(`DELETE FROM status_categories WHERE status = $1`, status); err != nil {
+			return fmt.Errorf("failed to remove status category for %s: %w", status, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SyncWorkflow refreshes the status_categories table from the current workflow.
+// New calls this automatically; call it again after types.SetWorkflow on an open store.
+func (s *PostgresStorage) SyncWorkflow() error {
+	return syncStatusCategories(s.db, types.CurrentWorkflow())
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Markers wrapped around matched terms in full-text search snippets
+const (
+	HighlightStart = "**"
+	HighlightEnd   = "**"
+)
+
+// SearchField is an issue field covered by full-text search and its relative ranking weight.
+// A match in the title counts more than the same match in a comment.
+type SearchField struct {
+	Name   string
+	Weight float64
+}
+
+// SearchFields lists the indexed fields in index column order, shared by all backends
+var SearchFields = []SearchField{
+	{Name: "title", Weight: 10},
+	{Name: "description", Weight: 5},
+	{Name: "design", Weight: 2},
+	{Name: "acceptance_criteria", Weight: 2},
+	{Name: "notes", Weight: 2},
+	{Name: "comments", Weight: 1},
+}
+
+// SearchTerm is a single word or quoted phrase in a full-text query
+type SearchTerm struct {
+	Text   string // Lowercased word or phrase
+	Phrase bool   // Came from "double quotes"; words must appear together
+	Prefix bool   // Ended in *; matches any word starting with Text
+}
+
+// SearchQuery is a parsed full-text query. Every clause must match, and a clause
+// matches when any of its terms does: `login "session token" OR cookie*` is
+// login AND ("session token" OR cookie*).
+type SearchQuery struct {
+	Clauses [][]SearchTerm
+}
+
+// ParseSearchQuery parses the user-facing search syntax: whitespace separated
+// terms (all required), "quoted phrases", an OR keyword between alternatives and
+// a trailing * for prefix matches. Punctuation inside terms is left to each
+// backend's tokenizer, so IDs like beads-a3f8 can be searched as-is.
+func ParseSearchQuery(query string) SearchQuery {
+	var q SearchQuery
+	pendingOr := false
+
+	for _, tok := range tokenizeSearchQuery(query) {
+		if tok.Text == "OR" && !tok.Phrase {
+			pendingOr = len(q.Clauses) > 0
+			continue
+		}
+
+		term := SearchTerm{Phrase: tok.Phrase}
+		term.Text = strings.ToLower(tok.Text)
+		if strings.HasSuffix(term.Text, "*") {
+			term.Prefix = true
+			term.Text = strings.TrimRight(term.Text, "*")
+		}
+		if strings.IndexFunc(term.Text, isWordRune) < 0 {
+			// Nothing searchable (e.g. a lone "*" or "-"); dropping it also drops a pending OR
+			pendingOr = false
+			continue
+		}
+
+		if pendingOr {
+			last := len(q.Clauses) - 1
+			q.Clauses[last] = append(q.Clauses[last], term)
+		} else {
+			q.Clauses = append(q.Clauses, []SearchTerm{term})
+		}
+		pendingOr = false
+	}
+
+	return q
+}
+
+// Empty reports whether the query has no searchable terms
+func (q SearchQuery) Empty() bool {
+	return len(q.Clauses) == 0
+}
+
+// Terms returns every term in the query, in order
+func (q SearchQuery) Terms() []SearchTerm {
+	var terms []SearchTerm
+	for _, clause := range q.Clauses {
+		terms = append(terms, clause...)
+	}
+	return terms
+}
+
+type searchToken struct {
+	Text   string
+	Phrase bool
+}
+
+// tokenizeSearchQuery splits on whitespace, keeping "quoted phrases" together.
+// A * directly after a closing quote stays attached to the phrase.
+func tokenizeSearchQuery(query string) []searchToken {
+	var tokens []searchToken
+	var cur strings.Builder
+	inQuote := false
+
+	flush := func(phrase bool) {
+		text := strings.TrimSpace(cur.String())
+		cur.Reset()
+		if text != "" {
+			tokens = append(tokens, searchToken{Text: text, Phrase: phrase})
+		}
+	}
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '"' && inQuote:
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				cur.WriteRune('*')
+				i++
+			}
+			flush(true)
+			inQuote = false
+		case r == '"':
+			flush(false)
+			inQuote = true
+		case unicode.IsSpace(r) && !inQuote:
+			flush(false)
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush(inQuote)
+
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  [][]SearchTerm
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"Login", [][]SearchTerm{{{Text: "login"}}}},
+		{"login timeout", [][]SearchTerm{{{Text: "login"}}, {{Text: "timeout"}}}},
+		{`"Session Token" expired`, [][]SearchTerm{{{Text: "session token", Phrase: true}}, {{Text: "expired"}}}},
+		{"login OR signin", [][]SearchTerm{{{Text: "login"}, {Text: "signin"}}}},
+		{"bug login OR signin OR sso", [][]SearchTerm{{{Text: "bug"}}, {{Text: "login"}, {Text: "signin"}, {Text: "sso"}}}},
+		{"auth*", [][]SearchTerm{{{Text: "auth", Prefix: true}}}},
+		{`"rate lim"*`, [][]SearchTerm{{{Text: "rate lim", Phrase: true, Prefix: true}}}},
+		{"beads-a3f8", [][]SearchTerm{{{Text: "beads-a3f8"}}}},
+		// A leading OR has nothing to join and is dropped
+		{"OR login", [][]SearchTerm{{{Text: "login"}}}},
+		// Lowercase "or" is an ordinary word
+		{"this or that", [][]SearchTerm{{{Text: "this"}}, {{Text: "or"}}, {{Text: "that"}}}},
+		// Terms without letters or digits are ignored
+		{`* - "" login`, [][]SearchTerm{{{Text: "login"}}}},
+		// An unterminated quote runs to the end of the query
+		{`"open phrase`, [][]SearchTerm{{{Text: "open phrase", Phrase: true}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := ParseSearchQuery(tt.query)
+			if !reflect.DeepEqual(got.Clauses, tt.want) {
+				t.Errorf("ParseSearchQuery(%q) = %+v, want %+v", tt.query, got.Clauses, tt.want)
+			}
+			if got.Empty() != (len(tt.want) == 0) {
+				t.Errorf("Empty() = %v for %q", got.Empty(), tt.query)
+			}
+		})
+	}
+}
+
+func TestSearchQueryTerms(t *testing.T) {
+	q := ParseSearchQuery("a b OR c")
+	terms := q.Terms()
+	if len(terms) != 3 || terms[0].Text != "a" || terms[2].Text != "c" {
+		t.Errorf("Terms() = %+v, want a, b, c", terms)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"time"
 
 	"github.com/shaneholloman/beads/internal/types"
 )
@@ -119,6 +120,13 @@ func compareIssues(existing, incoming *types.Issue) []string {
 		(existing.ExternalRef != nil && incoming.ExternalRef != nil && *existing.ExternalRef != *incoming.ExternalRef) {
 		conflicts = append(conflicts, "external_ref")
 	}
+	if existing.Recurrence != incoming.Recurrence {
+		conflicts = append(conflicts, "recurrence")
+	}
+	if (existing.DueAt == nil) != (incoming.DueAt == nil) ||
+		(existing.DueAt != nil && !existing.DueAt.Equal(*incoming.DueAt)) {
+		conflicts = append(conflicts, "due_at")
+	}
 
 	return conflicts
 }
@@ -138,5 +146,11 @@ func hashIssueContent(issue *types.Issue) string {
 	if issue.ExternalRef != nil {
 		fmt.Fprintf(h, "external_ref:%s\n", *issue.ExternalRef)
 	}
+	if issue.Recurrence != "" {
+		fmt.Fprintf(h, "recurrence:%s\n", issue.Recurrence)
+	}
+	if issue.DueAt != nil {
+		fmt.Fprintf(h, "due_at:%s\n", issue.DueAt.UTC().Format(time.RFC3339Nano))
+	}
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
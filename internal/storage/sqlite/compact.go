@@ -16,6 +16,8 @@ type CompactionCandidate struct {
 	OriginalSize   int
 	EstimatedSize  int
 	DependentCount int
+	// CompactedAtCommit is the git commit of the Tier 1 compaction (Tier 2 candidates only)
+	CompactedAtCommit string
 }
 
 // GetTier1Candidates returns issues eligible for Tier 1 compaction.
@@ -24,6 +26,7 @@ type CompactionCandidate struct {
 // - Closed for at least compact_tier1_days
 // - No open dependents within compact_tier1_dep_levels depth
 // - Not already compacted (compaction_level = 0)
+// - No summary awaiting review, or rejected for the current content
 func (s *SQLiteStorage) GetTier1Candidates(ctx context.Context) ([]*CompactionCandidate, error) {
 	// Get configuration
 	daysStr, err := s.GetConfig(ctx, "compact_tier1_days")
@@ -78,13 +81,14 @@ func (s *SQLiteStorage) GetTier1Candidates(ctx context.Context) ([]*CompactionCa
 		  COUNT(DISTINCT dt.dependent_id) as dependent_count
 		FROM issues i
 		LEFT JOIN dependent_tree dt ON i.id = dt.issue_id 
-		  AND dt.dependent_status IN ('open', 'in_progress', 'blocked')
+		  AND dt.dependent_status NOT IN ` + doneStatusesSQL + `
 		  AND dt.depth <= ?
 		WHERE i.status = 'closed'
 		  AND i.closed_at IS NOT NULL
 		  AND i.closed_at <= datetime('now', '-' || CAST(? AS INTEGER) || ' days')
 		  AND COALESCE(i.compaction_level, 0) = 0
 		  AND dt.dependent_id IS NULL  -- No open dependents
+		  AND ` + pendingExclusionSQL + `
 		GROUP BY i.id
 		ORDER BY i.closed_at ASC
 	`
@@ -117,7 +121,10 @@ func (s *SQLiteStorage) GetTier1Candidates(ctx context.Context) ([]*CompactionCa
 // - Closed for at least compact_tier2_days
 // - No open dependents within compact_tier2_dep_levels depth
 // - Already at compaction_level = 1
-// - Either has many commits (compact_tier2_commits) or many dependent issues
+// - No summary awaiting review, or rejected for the current content
+//
+// The compact_tier2_commits threshold (commits since the Tier 1 compaction)
+// needs git, so the compact package applies it using CompactedAtCommit.
 func (s *SQLiteStorage) GetTier2Candidates(ctx context.Context) ([]*CompactionCandidate, error) {
 	// Get configuration
 	daysStr, err := s.GetConfig(ctx, "compact_tier2_days")
@@ -128,45 +135,61 @@ func (s *SQLiteStorage) GetTier2Candidates(ctx context.Context) ([]*CompactionCa
 		daysStr = "90"
 	}
 
-	commitsStr, err := s.GetConfig(ctx, "compact_tier2_commits")
+	depthStr, err := s.GetConfig(ctx, "compact_tier2_dep_levels")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get compact_tier2_commits: %w", err)
+		return nil, fmt.Errorf("failed to get compact_tier2_dep_levels: %w", err)
 	}
-	if commitsStr == "" {
-		commitsStr = "100"
+	if depthStr == "" {
+		depthStr = "5"
 	}
 
 	query := `
-		WITH event_counts AS (
-		  SELECT issue_id, COUNT(*) as event_count
-		  FROM events
-		  GROUP BY issue_id
-		)
+		WITH RECURSIVE
+		  dependent_tree AS (
+		    SELECT 
+		      d.depends_on_id as issue_id,
+		      i.id as dependent_id,
+		      i.status as dependent_status,
+		      0 as depth
+		    FROM dependencies d
+		    JOIN issues i ON d.issue_id = i.id
+		    WHERE d.type = 'blocks'
+		    
+		    UNION ALL
+		    
+		    SELECT 
+		      dt.issue_id,
+		      i.id as dependent_id,
+		      i.status as dependent_status,
+		      dt.depth + 1
+		    FROM dependent_tree dt
+		    JOIN dependencies d ON d.depends_on_id = dt.dependent_id
+		    JOIN issues i ON d.issue_id = i.id
+		    WHERE d.type = 'parent-child'
+		      AND dt.depth < ?
+		  )
 		SELECT 
 		  i.id,
 		  i.closed_at,
-		  i.original_size,
-		  0 as estimated_size,
-		  COALESCE(ec.event_count, 0) as dependent_count
+		  COALESCE(i.original_size, 0) as original_size,
+		  LENGTH(i.description) + LENGTH(i.design) + LENGTH(i.notes) + LENGTH(i.acceptance_criteria) as estimated_size,
+		  0 as dependent_count,
+		  COALESCE(i.compacted_at_commit, '')
 		FROM issues i
-		LEFT JOIN event_counts ec ON i.id = ec.issue_id
+		LEFT JOIN dependent_tree dt ON i.id = dt.issue_id 
+		  AND dt.dependent_status NOT IN ` + doneStatusesSQL + `
+		  AND dt.depth <= ?
 		WHERE i.status = 'closed'
 		  AND i.closed_at IS NOT NULL
 		  AND i.closed_at <= datetime('now', '-' || CAST(? AS INTEGER) || ' days')
-		  AND i.compaction_level = 1
-		  AND COALESCE(ec.event_count, 0) >= CAST(? AS INTEGER)
-		  AND NOT EXISTS (
-		    -- Check for open dependents
-		    SELECT 1 FROM dependencies d
-		    JOIN issues dep ON d.issue_id = dep.id
-		    WHERE d.depends_on_id = i.id
-		      AND d.type = 'blocks'
-		      AND dep.status IN ('open', 'in_progress', 'blocked')
-		  )
+		  AND COALESCE(i.compaction_level, 0) = 1
+		  AND dt.dependent_id IS NULL  -- No open dependents
+		  AND ` + pendingExclusionSQL + `
+		GROUP BY i.id
 		ORDER BY i.closed_at ASC
 	`
 
-	rows, err := s.db.QueryContext(ctx, query, daysStr, commitsStr)
+	rows, err := s.db.QueryContext(ctx, query, depthStr, depthStr, daysStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tier2 candidates: %w", err)
 	}
@@ -175,7 +198,7 @@ func (s *SQLiteStorage) GetTier2Candidates(ctx context.Context) ([]*CompactionCa
 	var candidates []*CompactionCandidate
 	for rows.Next() {
 		var c CompactionCandidate
-		if err := rows.Scan(&c.IssueID, &c.ClosedAt, &c.OriginalSize, &c.EstimatedSize, &c.DependentCount); err != nil {
+		if err := rows.Scan(&c.IssueID, &c.ClosedAt, &c.OriginalSize, &c.EstimatedSize, &c.DependentCount, &c.CompactedAtCommit); err != nil {
 			return nil, fmt.Errorf("failed to scan candidate: %w", err)
 		}
 		candidates = append(candidates, &c)
@@ -219,6 +242,17 @@ func (s *SQLiteStorage) CheckEligibility(ctx context.Context, issueID string, ti
 		return false, "issue has no closed_at timestamp", nil
 	}
 
+	pending, rejected, err := s.compactionReviewState(ctx, issueID)
+	if err != nil {
+		return false, "", err
+	}
+	if pending {
+		return false, "a compaction summary is pending review", nil
+	}
+	if rejected {
+		return false, "its compaction summary was rejected and the issue hasn't changed since", nil
+	}
+
 	switch tier {
 	case 1:
 		if compactionLevel != 0 {
@@ -256,7 +290,7 @@ func (s *SQLiteStorage) CheckEligibility(ctx context.Context, issueID string, ti
 			}
 		}
 
-		return false, "issue has open dependents, not closed long enough, or insufficient events", nil
+		return false, "issue has open dependents or not closed long enough", nil
 	}
 
 	return false, fmt.Sprintf("invalid tier: %d", tier), nil
@@ -292,6 +326,19 @@ func (s *SQLiteStorage) ApplyCompaction(ctx context.Context, issueID string, lev
 		return fmt.Errorf("failed to apply compaction metadata: %w", err)
 	}
 
+	if err := recordCompactionEvent(ctx, tx, issueID, level, originalSize, compressedSize); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// recordCompactionEvent adds the compacted audit event with the size savings.
+func recordCompactionEvent(ctx context.Context, tx *sql.Tx, issueID string, level, originalSize, compressedSize int) error {
 	reductionPct := 0.0
 	if originalSize > 0 {
 		reductionPct = (1.0 - float64(compressedSize)/float64(originalSize)) * 100
@@ -300,18 +347,12 @@ func (s *SQLiteStorage) ApplyCompaction(ctx context.Context, issueID string, lev
 	eventData := fmt.Sprintf(`{"tier":%d,"original_size":%d,"compressed_size":%d,"reduction_pct":%.1f}`,
 		level, originalSize, compressedSize, reductionPct)
 
-	_, err = tx.ExecContext(ctx, `
+	_, err := tx.ExecContext(ctx, `
 		INSERT INTO events (issue_id, event_type, actor, comment)
 		VALUES (?, ?, 'compactor', ?)
 	`, issueID, types.EventCompacted, eventData)
-
 	if err != nil {
 		return fmt.Errorf("failed to record compaction event: %w", err)
 	}
-
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return nil
 }
@@ -103,53 +103,51 @@ func TestGetTier2Candidates(t *testing.T) {
 	defer cleanup()
 	ctx := context.Background()
 
-	// Create old tier1 compacted issue with many events
-	issue1 := &types.Issue{
-		ID:          "beads-1",
-		Title:       "Tier1 compacted with events",
-		Description: "Summary",
-		Status:      "closed",
-		Priority:    2,
-		IssueType:   "task",
-		ClosedAt:    timePtr(time.Now().Add(-100 * 24 * time.Hour)),
-	}
-	if err := store.CreateIssue(ctx, issue1, "test"); err != nil {
-		t.Fatalf("Failed to create issue1: %v", err)
+	create := func(id string, closedDaysAgo int) {
+		issue := &types.Issue{
+			ID:          id,
+			Title:       "Tier1 compacted " + id,
+			Description: "Summary",
+			Status:      "closed",
+			Priority:    2,
+			IssueType:   "task",
+			ClosedAt:    timePtr(time.Now().Add(-time.Duration(closedDaysAgo) * 24 * time.Hour)),
+		}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create %s: %v", id, err)
+		}
+		if err := store.ApplyCompaction(ctx, id, 1, 1000, 100, "abc123"); err != nil {
+			t.Fatalf("Failed to set compaction level: %v", err)
+		}
 	}
 
-	// Set compaction level to 1
-	_, err := store.db.ExecContext(ctx, `
-		UPDATE issues 
-		SET compaction_level = 1, 
-		    compacted_at = datetime('now', '-95 days'),
-		    original_size = 1000
-		WHERE id = ?
-	`, "beads-1")
-	if err != nil {
-		t.Fatalf("Failed to set compaction level: %v", err)
-	}
+	create(testIssueBeads1, 100) // Eligible
+	create("beads-2", 30)        // Not closed long enough
+	create("beads-3", 100)       // Has an open dependent
 
-	// Add many events (simulate high activity)
-	for i := 0; i < 120; i++ {
-		if err := store.AddComment(ctx, "beads-1", "test", "comment"); err != nil {
-			t.Fatalf("Failed to add event: %v", err)
-		}
+	open := &types.Issue{ID: "beads-4", Title: "Open", Status: "open", Priority: 2, IssueType: "task"}
+	if err := store.CreateIssue(ctx, open, "test"); err != nil {
+		t.Fatalf("Failed to create open issue: %v", err)
+	}
+	dep := &types.Dependency{IssueID: "beads-4", DependsOnID: "beads-3", Type: "blocks"}
+	if err := store.AddDependency(ctx, dep, "test"); err != nil {
+		t.Fatalf("Failed to add dependency: %v", err)
 	}
 
-	// Get tier2 candidates
 	candidates, err := store.GetTier2Candidates(ctx)
 	if err != nil {
 		t.Fatalf("GetTier2Candidates failed: %v", err)
 	}
 
-	// Should return beads-1
 	if len(candidates) != 1 {
-		t.Errorf("Expected 1 candidate, got %d", len(candidates))
+		t.Fatalf("Expected 1 candidate, got %d", len(candidates))
 	}
-
-	if len(candidates) > 0 && candidates[0].IssueID != testIssueBeads1 {
+	if candidates[0].IssueID != testIssueBeads1 {
 		t.Errorf("Expected candidate beads-1, got %s", candidates[0].IssueID)
 	}
+	if candidates[0].CompactedAtCommit != "abc123" {
+		t.Errorf("Expected CompactedAtCommit abc123, got %q", candidates[0].CompactedAtCommit)
+	}
 }
 
 func TestCheckEligibilityTier1(t *testing.T) {
@@ -24,6 +24,11 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		return fmt.Errorf("invalid dependency type: %s (must be blocks, related, parent-child, or discovered-from)", dep.Type)
 	}
 
+	// Dependencies on other repositories (api:bd-a3f8) are kept separately
+	if types.IsQualifiedID(dep.DependsOnID) {
+		return s.addExternalDependency(ctx, dep, actor)
+	}
+
 	// Validate that both issues exist
 	issueExists, err := s.GetIssue(ctx, dep.IssueID)
 	if err != nil {
@@ -160,6 +165,10 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 
 // RemoveDependency removes a dependency
 func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	if types.IsQualifiedID(dependsOnID) {
+		return s.removeExternalDependency(ctx, issueID, dependsOnID, actor)
+	}
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -204,7 +213,7 @@ func (s *SQLiteStorage) GetDependencies(ctx context.Context, issueID string) ([]
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.recurrence, i.due_at, i.recurred_at
 		FROM issues i
 		JOIN dependencies d ON i.id = d.depends_on_id
 		WHERE d.issue_id = ?
@@ -223,7 +232,7 @@ func (s *SQLiteStorage) GetDependents(ctx context.Context, issueID string) ([]*t
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.recurrence, i.due_at, i.recurred_at
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		WHERE d.depends_on_id = ?
@@ -237,14 +246,19 @@ func (s *SQLiteStorage) GetDependents(ctx context.Context, issueID string) ([]*t
 	return s.scanIssues(ctx, rows)
 }
 
-// GetDependencyRecords returns raw dependency records for an issue
+// GetDependencyRecords returns raw dependency records for an issue, including
+// dependencies on other repositories
 func (s *SQLiteStorage) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT issue_id, depends_on_id, type, created_at, created_by
 		FROM dependencies
 		WHERE issue_id = ?
+		UNION ALL
+		SELECT issue_id, depends_on_id, type, created_at, created_by
+		FROM external_dependencies
+		WHERE issue_id = ?
 		ORDER BY created_at ASC
-	`, issueID)
+	`, issueID, issueID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dependency records: %w", err)
 	}
@@ -275,6 +289,9 @@ func (s *SQLiteStorage) GetAllDependencyRecords(ctx context.Context) (map[string
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT issue_id, depends_on_id, type, created_at, created_by
 		FROM dependencies
+		UNION ALL
+		SELECT issue_id, depends_on_id, type, created_at, created_by
+		FROM external_dependencies
 		ORDER BY issue_id, created_at ASC
 	`)
 	if err != nil {
@@ -554,37 +571,8 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 	var issues []*types.Issue
 	for rows.Next() {
 		var issue types.Issue
-		var contentHash sql.NullString
-		var closedAt sql.NullTime
-		var estimatedMinutes sql.NullInt64
-		var assignee sql.NullString
-		var externalRef sql.NullString
-
-		err := rows.Scan(
-			&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
-			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
-			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan issue: %w", err)
-		}
-
-		if contentHash.Valid {
-			issue.ContentHash = contentHash.String
-		}
-		if closedAt.Valid {
-			issue.ClosedAt = &closedAt.Time
-		}
-		if estimatedMinutes.Valid {
-			mins := int(estimatedMinutes.Int64)
-			issue.EstimatedMinutes = &mins
-		}
-		if assignee.Valid {
-			issue.Assignee = assignee.String
-		}
-		if externalRef.Valid {
-			issue.ExternalRef = &externalRef.String
+		if err := scanIssueRow(rows, &issue); err != nil {
+			return nil, err
 		}
 
 		// Fetch labels for this issue
@@ -599,3 +587,49 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 
 	return issues, nil
 }
+
+// scanIssueRow scans the standard issue columns (id through recurred_at) into issue,
+// followed by any extra destinations for columns selected after them
+func scanIssueRow(rows *sql.Rows, issue *types.Issue, extra ...interface{}) error {
+	var contentHash sql.NullString
+	var closedAt sql.NullTime
+	var estimatedMinutes sql.NullInt64
+	var assignee sql.NullString
+	var externalRef sql.NullString
+	var dueAt sql.NullTime
+	var recurredAt sql.NullTime
+
+	dest := []interface{}{
+		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
+		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
+		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
+		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &issue.Recurrence, &dueAt, &recurredAt,
+	}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return fmt.Errorf("failed to scan issue: %w", err)
+	}
+
+	if contentHash.Valid {
+		issue.ContentHash = contentHash.String
+	}
+	if closedAt.Valid {
+		issue.ClosedAt = &closedAt.Time
+	}
+	if estimatedMinutes.Valid {
+		mins := int(estimatedMinutes.Int64)
+		issue.EstimatedMinutes = &mins
+	}
+	if assignee.Valid {
+		issue.Assignee = assignee.String
+	}
+	if externalRef.Valid {
+		issue.ExternalRef = &externalRef.String
+	}
+	if dueAt.Valid {
+		issue.DueAt = &dueAt.Time
+	}
+	if recurredAt.Valid {
+		issue.RecurredAt = &recurredAt.Time
+	}
+	return nil
+}
@@ -23,7 +23,7 @@ func (s *SQLiteStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*type
 			SELECT 
 				epic_id,
 				COUNT(*) AS total_children,
-				SUM(CASE WHEN child_status = 'closed' THEN 1 ELSE 0 END) AS closed_children
+				SUM(CASE WHEN child_status IN ` + doneStatusesSQL + ` THEN 1 ELSE 0 END) AS closed_children
 			FROM epic_children
 			GROUP BY epic_id
 		)
@@ -36,7 +36,7 @@ func (s *SQLiteStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*type
 		FROM issues i
 		LEFT JOIN epic_stats es ON es.epic_id = i.id
 		WHERE i.issue_type = 'epic'
-		  AND i.status != 'closed'
+		  AND i.status NOT IN ` + doneStatusesSQL + `
 		ORDER BY i.priority ASC, i.created_at ASC
 	`
 
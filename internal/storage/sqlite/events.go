@@ -111,10 +111,11 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 		SELECT
 			COUNT(*) as total,
 			COALESCE(SUM(CASE WHEN status = 'open' THEN 1 ELSE 0 END), 0) as open,
-			COALESCE(SUM(CASE WHEN status = 'in_progress' THEN 1 ELSE 0 END), 0) as in_progress,
-			COALESCE(SUM(CASE WHEN status = 'closed' THEN 1 ELSE 0 END), 0) as closed
+			COALESCE(SUM(CASE WHEN status != 'open' AND status IN `+activeStatusesSQL+` THEN 1 ELSE 0 END), 0) as in_progress,
+			COALESCE(SUM(CASE WHEN status IN `+doneStatusesSQL+` THEN 1 ELSE 0 END), 0) as closed,
+			COALESCE(SUM(CASE WHEN status NOT IN `+doneStatusesSQL+` AND julianday(due_at) < julianday('now') THEN 1 ELSE 0 END), 0) as overdue
 		FROM issues
-	`).Scan(&stats.TotalIssues, &stats.OpenIssues, &stats.InProgressIssues, &stats.ClosedIssues)
+	`).Scan(&stats.TotalIssues, &stats.OpenIssues, &stats.InProgressIssues, &stats.ClosedIssues, &stats.OverdueIssues)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issue counts: %w", err)
 	}
@@ -125,9 +126,9 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		JOIN issues blocker ON d.depends_on_id = blocker.id
-		WHERE i.status IN ('open', 'in_progress', 'blocked')
+		WHERE i.status NOT IN `+doneStatusesSQL+`
 		  AND d.type = 'blocks'
-		  AND blocker.status IN ('open', 'in_progress', 'blocked')
+		  AND blocker.status NOT IN `+doneStatusesSQL+`
 	`).Scan(&stats.BlockedIssues)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get blocked count: %w", err)
@@ -143,14 +144,15 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 		    JOIN issues blocked ON d.depends_on_id = blocked.id
 		    WHERE d.issue_id = i.id
 		      AND d.type = 'blocks'
-		      AND blocked.status IN ('open', 'in_progress', 'blocked')
+		      AND blocked.status NOT IN `+doneStatusesSQL+`
 		  )
 	`).Scan(&stats.ReadyIssues)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ready count: %w", err)
 	}
 
-	// Get average lead time (hours from created to closed)
+	// Get average lead time (hours from created to closed). Custom done
+	// statuses have no closed_at (see manageClosedAt), so only closed issues count.
 	var avgLeadTime sql.NullFloat64
 	err = s.db.QueryRowContext(ctx, `
 		SELECT AVG(
@@ -180,7 +182,7 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 			SELECT 
 				epic_id,
 				COUNT(*) AS total_children,
-				SUM(CASE WHEN child_status = 'closed' THEN 1 ELSE 0 END) AS closed_children
+				SUM(CASE WHEN child_status IN `+doneStatusesSQL+` THEN 1 ELSE 0 END) AS closed_children
 			FROM epic_children
 			GROUP BY epic_id
 		)
@@ -188,7 +190,7 @@ func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, e
 		FROM issues i
 		JOIN epic_stats es ON es.epic_id = i.id
 		WHERE i.issue_type = 'epic'
-		  AND i.status != 'closed'
+		  AND i.status NOT IN `+doneStatusesSQL+`
 		  AND es.total_children > 0
 		  AND es.closed_children = es.total_children
 	`).Scan(&stats.EpicsEligibleForClosure)
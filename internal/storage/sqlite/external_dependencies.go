@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// addExternalDependency records a dependency on an issue in another
+// repository. The target can't be checked here, so there is no existence or
+// cycle check; callers that can reach the other database should verify it.
+func (s *SQLiteStorage) addExternalDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	if dep.Type == types.DepParentChild {
+		return fmt.Errorf("parent-child dependencies can't cross repositories (%s → %s)", dep.IssueID, dep.DependsOnID)
+	}
+
+	issue, err := s.GetIssue(ctx, dep.IssueID)
+	if err != nil {
+		return fmt.Errorf("failed to check issue %s: %w", dep.IssueID, err)
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s not found", dep.IssueID)
+	}
+
+	if dep.CreatedAt.IsZero() {
+		dep.CreatedAt = time.Now()
+	}
+	if dep.CreatedBy == "" {
+		dep.CreatedBy = actor
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO external_dependencies (issue_id, depends_on_id, type, created_at, created_by)
+		VALUES (?, ?, ?, ?, ?)
+	`, dep.IssueID, dep.DependsOnID, dep.Type, dep.CreatedAt, dep.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to add dependency: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, dep.IssueID, types.EventDependencyAdded, actor,
+		fmt.Sprintf("Added dependency: %s %s %s", dep.IssueID, dep.Type, dep.DependsOnID))
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	// Only the local issue is exported from this database
+	if err := markIssuesDirtyTx(ctx, tx, []string{dep.IssueID}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// removeExternalDependency removes a dependency on an issue in another repository
+func (s *SQLiteStorage) removeExternalDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM external_dependencies WHERE issue_id = ? AND depends_on_id = ?
+	`, issueID, dependsOnID)
+	if err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("dependency from %s to %s does not exist", issueID, dependsOnID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, types.EventDependencyRemoved, actor,
+		fmt.Sprintf("Removed dependency on %s", dependsOnID))
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	if err := markIssuesDirtyTx(ctx, tx, []string{issueID}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetExternalBlockers returns the cross-repository 'blocks' dependencies of
+// issues in active statuses, as qualified IDs grouped by issue ID. Whether
+// they still block depends on the other repositories (see package federation).
+func (s *SQLiteStorage) GetExternalBlockers(ctx context.Context) (map[string][]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT e.issue_id, e.depends_on_id
+		FROM external_dependencies e
+		JOIN issues i ON i.id = e.issue_id
+		WHERE e.type = 'blocks'
+		  AND i.status IN `+activeStatusesSQL+`
+		ORDER BY e.issue_id, e.created_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external blockers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	blockers := make(map[string][]string)
+	for rows.Next() {
+		var issueID, dependsOnID string
+		if err := rows.Scan(&issueID, &dependsOnID); err != nil {
+			return nil, fmt.Errorf("failed to scan external blocker: %w", err)
+		}
+		blockers[issueID] = append(blockers[issueID], dependsOnID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return blockers, nil
+}
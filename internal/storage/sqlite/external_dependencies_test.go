@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestExternalDependencies(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{ID: "beads-ext1", Title: "Needs the API change", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	local := &types.Issue{ID: "beads-ext2", Title: "Local blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, i := range []*types.Issue{issue, local} {
+		if err := store.CreateIssue(ctx, i, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	// The target lives in another database, so it isn't looked up here
+	ext := &types.Dependency{IssueID: issue.ID, DependsOnID: "api:api-a3f8", Type: types.DepBlocks}
+	if err := store.AddDependency(ctx, ext, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: issue.ID, DependsOnID: local.ID, Type: types.DepRelated}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	err := store.AddDependency(ctx, &types.Dependency{IssueID: issue.ID, DependsOnID: "api:api-b001", Type: types.DepParentChild}, "test")
+	if err == nil || !strings.Contains(err.Error(), "can't cross repositories") {
+		t.Errorf("expected parent-child across repositories to fail, got %v", err)
+	}
+
+	records, err := store.GetDependencyRecords(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected local and external records, got %d", len(records))
+	}
+	all, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		t.Fatalf("GetAllDependencyRecords failed: %v", err)
+	}
+	if len(all[issue.ID]) != 2 {
+		t.Errorf("expected the external dependency to be exported, got %+v", all[issue.ID])
+	}
+
+	blockers, err := store.GetExternalBlockers(ctx)
+	if err != nil {
+		t.Fatalf("GetExternalBlockers failed: %v", err)
+	}
+	if len(blockers) != 1 || len(blockers[issue.ID]) != 1 || blockers[issue.ID][0] != "api:api-a3f8" {
+		t.Errorf("unexpected external blockers: %v", blockers)
+	}
+
+	// Renaming the issue carries its external dependencies along
+	issue.ID = "beads-ext9"
+	if err := store.UpdateIssueID(ctx, "beads-ext1", issue.ID, issue, "test"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+	if blockers, _ := store.GetExternalBlockers(ctx); len(blockers[issue.ID]) != 1 {
+		t.Errorf("expected the external dependency to follow the rename, got %v", blockers)
+	}
+
+	if err := store.RemoveDependency(ctx, issue.ID, "api:api-a3f8", "test"); err != nil {
+		t.Fatalf("RemoveDependency failed: %v", err)
+	}
+	if err := store.RemoveDependency(ctx, issue.ID, "api:api-a3f8", "test"); err == nil {
+		t.Error("expected removing a missing external dependency to fail")
+	}
+	if blockers, _ := store.GetExternalBlockers(ctx); len(blockers) != 0 {
+		t.Errorf("expected no external blockers, got %v", blockers)
+	}
+}
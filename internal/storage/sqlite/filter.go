@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/query"
+)
+
+// filterColumns maps filter fields to issue columns
+var filterColumns = map[query.Field]string{
+	query.FieldID:          "id",
+	query.FieldTitle:       "title",
+	query.FieldDescription: "description",
+	query.FieldStatus:      "status",
+	query.FieldPriority:    "priority",
+	query.FieldType:        "issue_type",
+	query.FieldAssignee:    "assignee",
+	query.FieldCreated:     "created_at",
+	query.FieldUpdated:     "updated_at",
+	query.FieldClosed:      "closed_at",
+	query.FieldDue:         "due_at",
+}
+
+// compileFilterExpr compiles a structured filter into a WHERE clause fragment.
+// col is the issues table qualifier ("" or "i.").
+func compileFilterExpr(expr query.Expr, col string) (string, []interface{}, error) {
+	switch e := expr.(type) {
+	case query.And:
+		return compileFilterList(e.Exprs, " AND ", col)
+	case query.Or:
+		return compileFilterList(e.Exprs, " OR ", col)
+	case query.Not:
+		clause, args, err := compileFilterExpr(e.Expr, col)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT (" + clause + ")", args, nil
+	case query.Cond:
+		return compileFilterCond(e, col)
+	}
+	return "", nil, fmt.Errorf("unsupported filter expression %T", expr)
+}
+
+func compileFilterList(exprs []query.Expr, sep, col string) (string, []interface{}, error) {
+	clauses := make([]string, 0, len(exprs))
+	var args []interface{}
+	for _, child := range exprs {
+		clause, childArgs, err := compileFilterExpr(child, col)
+		if err != nil {
+			return "", nil, err
+		}
+		clauses = append(clauses, clause)
+		args = append(args, childArgs...)
+	}
+	return "(" + strings.Join(clauses, sep) + ")", args, nil
+}
+
+// compileFilterCond compiles a single condition. Every clause evaluates to true
+// or false, never NULL, so negation behaves as expected on unset columns.
+func compileFilterCond(c query.Cond, col string) (string, []interface{}, error) {
+	if len(c.Values) == 0 {
+		return "", nil, fmt.Errorf("filter on %s has no values", c.Field)
+	}
+
+	switch c.Field {
+	case query.FieldLabel:
+		var alternatives []string
+		var args []interface{}
+		var labels []string
+		for _, v := range c.Values {
+			if v.None {
+				alternatives = append(alternatives, col+"id NOT IN (SELECT issue_id FROM labels)")
+			} else {
+				labels = append(labels, v.Str)
+			}
+		}
+		if len(labels) > 0 {
+			alternatives = append(alternatives, fmt.Sprintf("%sid IN (SELECT issue_id FROM labels WHERE label IN (%s))", col, placeholderList(len(labels))))
+			for _, label := range labels {
+				args = append(args, label)
+			}
+		}
+		return "(" + strings.Join(alternatives, " OR ") + ")", args, nil
+
+	case query.FieldText:
+		var alternatives []string
+		var args []interface{}
+		for _, v := range c.Values {
+			alternatives = append(alternatives, fmt.Sprintf(`(%[1]stitle LIKE ? ESCAPE '\' OR %[1]sdescription LIKE ? ESCAPE '\' OR %[1]sid LIKE ? ESCAPE '\')`, col))
+			pattern := likePattern(v.Str)
+			args = append(args, pattern, pattern, pattern)
+		}
+		return "(" + strings.Join(alternatives, " OR ") + ")", args, nil
+	}
+
+	column, ok := filterColumns[c.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported filter field %q", c.Field)
+	}
+	column = col + column
+
+	switch c.Field.Kind() {
+	case query.KindText:
+		var alternatives []string
+		var args []interface{}
+		for _, v := range c.Values {
+			if v.None {
+				alternatives = append(alternatives, fmt.Sprintf("COALESCE(%s, '') = ''", column))
+			} else {
+				alternatives = append(alternatives, column+` LIKE ? ESCAPE '\'`)
+				args = append(args, likePattern(v.Str))
+			}
+		}
+		return "(" + strings.Join(alternatives, " OR ") + ")", args, nil
+
+	case query.KindKeyword:
+		// none is stored as NULL or '', so compare against ''
+		args := make([]interface{}, len(c.Values))
+		for i, v := range c.Values {
+			args[i] = v.Str
+		}
+		return fmt.Sprintf("COALESCE(%s, '') IN (%s)", column, placeholderList(len(args))), args, nil
+
+	case query.KindNumber:
+		if c.Op != query.OpEq {
+			return fmt.Sprintf("%s %s ?", column, c.Op), []interface{}{c.Values[0].Int}, nil
+		}
+		args := make([]interface{}, len(c.Values))
+		for i, v := range c.Values {
+			args[i] = v.Int
+		}
+		return fmt.Sprintf("%s IN (%s)", column, placeholderList(len(args))), args, nil
+
+	case query.KindTime:
+		if c.Op == query.OpEq {
+			// The parser only allows none with ':' on time fields
+			return fmt.Sprintf("(%s IS NULL)", column), nil, nil
+		}
+		// julianday() normalizes timezone offsets, which plain string comparison would not
+		return fmt.Sprintf("(%[1]s IS NOT NULL AND julianday(%[1]s) %[2]s julianday(?))", column, c.Op),
+			[]interface{}{c.Values[0].Time.UTC().Format(time.RFC3339Nano)}, nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported filter field %q", c.Field)
+}
+
+// likePattern builds a LIKE substring pattern, escaping wildcards in s
+func likePattern(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return "%" + s + "%"
+}
+
+// placeholderList returns n comma-separated ? placeholders
+func placeholderList(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/query"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// setupFilterFixture creates issues covering every filterable field:
+//
+//	login:   P0 bug, alice, [backend], updated in early 2025 (stored with a +02:00 offset)
+//	signup:  P1 task, unassigned, no labels, description mentions 50%_off
+//	cleanup: P3 chore, bob, [backend, wontfix], closed
+func setupFilterFixture(t *testing.T) *SQLiteStorage {
+	t.Helper()
+	store, cleanup := setupTestDB(t)
+	t.Cleanup(cleanup)
+	ctx := context.Background()
+
+	create := func(title, description string, priority int, issueType types.IssueType, assignee string, labels ...string) *types.Issue {
+		issue := &types.Issue{Title: title, Description: description, Status: types.StatusOpen, Priority: priority, IssueType: issueType, Assignee: assignee}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		return issue
+	}
+
+	login := create("login", "Users cannot sign in", 0, types.TypeBug, "alice", "backend")
+	create("signup", "Promo code 50%_off is rejected", 1, types.TypeTask, "")
+	cleanupIssue := create("cleanup", "Remove dead code", 3, types.TypeChore, "bob", "backend", "wontfix")
+
+	if err := store.CloseIssue(ctx, cleanupIssue.ID, "done", "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	// 2025-01-01T08:00:00Z written with an offset, as another machine might
+	if _, err := store.db.ExecContext(ctx, `UPDATE issues SET updated_at = '2025-01-01T10:00:00+02:00', created_at = '2025-01-01T10:00:00+02:00' WHERE id = ?`, login.ID); err != nil {
+		t.Fatalf("failed to backdate issue: %v", err)
+	}
+
+	return store
+}
+
+func filterTitles(t *testing.T, store *SQLiteStorage, filterText string) string {
+	t.Helper()
+	expr, err := query.Parse(filterText)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", filterText, err)
+	}
+	issues, err := store.SearchIssues(context.Background(), "", types.IssueFilter{Expr: expr})
+	if err != nil {
+		t.Fatalf("SearchIssues(%q) failed: %v", filterText, err)
+	}
+	titles := make([]string, len(issues))
+	for i, issue := range issues {
+		titles[i] = issue.Title
+	}
+	sort.Strings(titles)
+	return strings.Join(titles, ",")
+}
+
+func TestSearchIssuesFilterExpr(t *testing.T) {
+	store := setupFilterFixture(t)
+
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"status:open", "login,signup"},
+		{"-status:closed", "login,signup"},
+		{"status:(open|closed)", "cleanup,login,signup"},
+		{"priority<=1", "login,signup"},
+		{"priority>1", "cleanup"},
+		{"priority:(0|3)", "cleanup,login"},
+		{"type:(bug|task)", "login,signup"},
+		{"assignee:none", "signup"},
+		{"-assignee:none", "cleanup,login"},
+		{"assignee:(alice|none)", "login,signup"},
+		{"label:backend", "cleanup,login"},
+		{"label:backend -label:wontfix", "login"},
+		{"label:none", "signup"},
+		{"label:(wontfix|none)", "cleanup,signup"},
+		{"closed:none", "login,signup"},
+		{"-closed:none", "cleanup"},
+		{"closed>1h", "cleanup"},
+		{"-closed>1h", "login,signup"},
+		{"updated<2025-01-01T08:30:00Z", "login"},
+		{"updated>2025-01-01T07:30:00Z updated<2025-01-01T08:30:00Z", "login"},
+		{"created>1d", "cleanup,signup"},
+		{"title:LOG", "login"},
+		{"description:cannot", "login"},
+		{"desc:none", ""},
+		{"sign", "login,signup"},
+		{`"50%_off"`, "signup"},
+		{`"50%"`, "signup"},
+		{`"5_%"`, ""}, // wildcards are literal, so this does not match "50%"
+		{"status:open OR label:wontfix", "cleanup,login,signup"},
+		{"(priority:0 OR priority:3) label:backend", "cleanup,login"},
+		{"-(label:backend assignee:alice)", "cleanup,signup"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			if got := filterTitles(t, store, tt.filter); got != tt.want {
+				t.Errorf("filter %q = [%s], want [%s]", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchIssuesFilterExprWithFields(t *testing.T) {
+	store := setupFilterFixture(t)
+	ctx := context.Background()
+
+	// Expr is ANDed with the other IssueFilter fields
+	expr, err := query.Parse("label:backend")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	status := types.StatusOpen
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Status: &status, Expr: expr})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "login" {
+		t.Errorf("expected only login, got %v", issues)
+	}
+
+	// And applies to ranked search too
+	results, err := store.SearchIssuesRanked(ctx, "code", types.IssueFilter{Expr: expr})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "cleanup" {
+		t.Errorf("expected only cleanup, got %v", results)
+	}
+}
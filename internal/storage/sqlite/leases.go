@@ -0,0 +1,458 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// ClaimReadyWork atomically assigns the highest-ranked unclaimed ready issue to
+// agentID: the issue moves to in_progress with agentID as assignee, and a lease
+// expiring after leaseTTL is recorded. Expired leases are released first, so
+// abandoned work becomes claimable again. Returns nil if nothing is ready.
+func (s *SQLiteStorage) ClaimReadyWork(ctx context.Context, filter types.WorkFilter, agentID string, leaseTTL time.Duration) (*types.Claim, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("agent ID is required to claim work")
+	}
+	if leaseTTL <= 0 {
+		return nil, fmt.Errorf("lease TTL must be positive, got %v", leaseTTL)
+	}
+
+	// Claims run in an IMMEDIATE transaction on a dedicated connection (see
+	// CreateIssue) so concurrent claimers serialize and never pick the same issue
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return nil, fmt.Errorf("failed to begin immediate transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}()
+
+	now := time.Now().UTC()
+	if _, err := releaseExpiredLeases(ctx, conn, now, agentID); err != nil {
+		return nil, err
+	}
+
+	// Only open issues are claimable by default; in_progress work already has an owner
+	if filter.Status == "" {
+		filter.Status = types.StatusOpen
+	}
+	filter.Limit = 1
+	query, args := readyWorkQuery(filter, "NOT EXISTS (SELECT 1 FROM leases l WHERE l.issue_id = i.id)")
+	issue, err := queryIssueOnConn(ctx, conn, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ready work: %w", err)
+	}
+	if issue == nil {
+		// Keep any expired leases released above
+		if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+			return nil, fmt.Errorf("failed to commit lease release: %w", err)
+		}
+		committed = true
+		return nil, nil
+	}
+
+	if err := types.CurrentWorkflow().CanTransition(issue.Status, types.StatusInProgress); err != nil {
+		return nil, fmt.Errorf("cannot claim %s: %w", issue.ID, err)
+	}
+
+	oldData, err := json.Marshal(issue)
+	if err != nil {
+		oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, issue.ID))
+	}
+	claimed := *issue
+	claimed.Status = types.StatusInProgress
+	claimed.Assignee = agentID
+	claimed.UpdatedAt = time.Now()
+	claimed.ContentHash = claimed.ComputeContentHash()
+
+	lease := &types.Lease{
+		IssueID:       issue.ID,
+		Holder:        agentID,
+		ClaimedAt:     now,
+		LastHeartbeat: now,
+		ExpiresAt:     now.Add(leaseTTL),
+	}
+	newData, _ := json.Marshal(map[string]interface{}{
+		"status":   claimed.Status,
+		"assignee": agentID,
+	})
+	comment := fmt.Sprintf("Claimed by %s until %s", agentID, lease.ExpiresAt.Format(time.RFC3339))
+	if err := applyLeaseChange(ctx, conn, &claimed, agentID, string(oldData), string(newData), comment); err != nil {
+		return nil, err
+	}
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO leases (issue_id, holder, claimed_at, expires_at, last_heartbeat)
+		VALUES (?, ?, ?, ?, ?)
+	`, lease.IssueID, lease.Holder, lease.ClaimedAt, lease.ExpiresAt, lease.LastHeartbeat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record lease: %w", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return nil, fmt.Errorf("failed to commit claim: %w", err)
+	}
+	committed = true
+
+	labels, err := s.GetLabels(ctx, claimed.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels for issue %s: %w", claimed.ID, err)
+	}
+	claimed.Labels = labels
+	return &types.Claim{Issue: &claimed, Lease: lease}, nil
+}
+
+// GetLeases returns all current leases, soonest to expire first. Expired
+// leases are included until they are released.
+func (s *SQLiteStorage) GetLeases(ctx context.Context) ([]*types.Lease, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, holder, claimed_at, expires_at, last_heartbeat
+		FROM leases
+		ORDER BY expires_at ASC, issue_id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leases: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanLeases(rows)
+}
+
+// Heartbeat records that holder is still working on an in_progress issue
+// assigned to it, extending its lease to leaseTTL from now. An issue set
+// in_progress without a claim gets a lease on its first heartbeat.
+func (s *SQLiteStorage) Heartbeat(ctx context.Context, issueID, holder string, leaseTTL time.Duration) (*types.Lease, error) {
+	if holder == "" {
+		return nil, fmt.Errorf("holder is required for a heartbeat")
+	}
+	if leaseTTL <= 0 {
+		return nil, fmt.Errorf("lease TTL must be positive, got %v", leaseTTL)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var status string
+	var assignee sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT status, assignee FROM issues WHERE id = ?`, issueID).Scan(&status, &assignee)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	if err := checkHeartbeat(issueID, types.Status(status), assignee.String, holder); err != nil {
+		return nil, err
+	}
+
+	// A new holder starts a new lease; the same holder keeps its claim time
+	now := time.Now().UTC()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO leases (issue_id, holder, claimed_at, expires_at, last_heartbeat)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET
+			claimed_at = CASE WHEN leases.holder = excluded.holder THEN leases.claimed_at ELSE excluded.claimed_at END,
+			holder = excluded.holder,
+			expires_at = excluded.expires_at,
+			last_heartbeat = excluded.last_heartbeat
+	`, issueID, holder, now, now.Add(leaseTTL), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record heartbeat: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT issue_id, holder, claimed_at, expires_at, last_heartbeat FROM leases WHERE issue_id = ?`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lease: %w", err)
+	}
+	leases, err := scanLeases(rows)
+	_ = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(leases) != 1 {
+		return nil, fmt.Errorf("lease for %s not found after heartbeat", issueID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit heartbeat: %w", err)
+	}
+	return leases[0], nil
+}
+
+// checkHeartbeat rejects heartbeats for issues the holder is not working on
+func checkHeartbeat(issueID string, status types.Status, assignee, holder string) error {
+	if status != types.StatusInProgress {
+		return fmt.Errorf("issue %s is %s, not in_progress", issueID, status)
+	}
+	if assignee != holder {
+		if assignee == "" {
+			return fmt.Errorf("issue %s is not assigned (assign it to %s first)", issueID, holder)
+		}
+		return fmt.Errorf("issue %s is assigned to %s, not %s", issueID, assignee, holder)
+	}
+	return nil
+}
+
+// ReleaseExpiredLeases releases leases that expired at or before the given
+// time. An issue still held by the lease's holder returns to open and
+// unassigned, with an event naming the last holder.
+func (s *SQLiteStorage) ReleaseExpiredLeases(ctx context.Context, before time.Time, actor string) ([]*types.Lease, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return nil, fmt.Errorf("failed to begin immediate transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}()
+
+	released, err := releaseExpiredLeases(ctx, conn, before, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return nil, fmt.Errorf("failed to commit lease release: %w", err)
+	}
+	committed = true
+	return released, nil
+}
+
+// releaseExpiredLeases releases expired leases inside the caller's transaction
+func releaseExpiredLeases(ctx context.Context, conn *sql.Conn, before time.Time, actor string) ([]*types.Lease, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT issue_id, holder, claimed_at, expires_at, last_heartbeat FROM leases ORDER BY expires_at ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leases: %w", err)
+	}
+	leases, err := scanLeases(rows)
+	_ = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var released []*types.Lease
+	for _, lease := range leases {
+		if !lease.Expired(before) {
+			continue
+		}
+		if err := releaseLease(ctx, conn, lease, actor); err != nil {
+			return nil, err
+		}
+		released = append(released, lease)
+	}
+	return released, nil
+}
+
+// releaseLease deletes a lease, returning its issue to open if the holder
+// still has it in progress
+func releaseLease(ctx context.Context, conn *sql.Conn, lease *types.Lease, actor string) error {
+	if _, err := conn.ExecContext(ctx, `DELETE FROM leases WHERE issue_id = ?`, lease.IssueID); err != nil {
+		return fmt.Errorf("failed to delete lease on %s: %w", lease.IssueID, err)
+	}
+
+	issue, err := queryIssueOnConn(ctx, conn, `
+		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref, recurrence, due_at, recurred_at
+		FROM issues
+		WHERE id = ?
+	`, lease.IssueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", lease.IssueID, err)
+	}
+	// Leave issues that were finished, handed off or reassigned alone
+	if issue == nil || issue.Status != types.StatusInProgress || issue.Assignee != lease.Holder {
+		return nil
+	}
+	comment := fmt.Sprintf("Lease held by %s expired at %s", lease.Holder, lease.ExpiresAt.Format(time.RFC3339))
+	return returnToOpen(ctx, conn, issue, actor, comment)
+}
+
+// ReleaseUnleasedWork returns in_progress issues that have no lease and were
+// last updated at or before the given time to open and unassigned. These were
+// set in_progress without a claim and never sent a heartbeat. Returns the
+// issues as they were before release.
+func (s *SQLiteStorage) ReleaseUnleasedWork(ctx context.Context, before time.Time, actor string) ([]*types.Issue, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return nil, fmt.Errorf("failed to begin immediate transaction: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}()
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref, recurrence, due_at, recurred_at
+		FROM issues i
+		WHERE status = ? AND NOT EXISTS (SELECT 1 FROM leases l WHERE l.issue_id = i.id)
+		ORDER BY updated_at ASC
+	`, types.StatusInProgress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unleased work: %w", err)
+	}
+	var idle []*types.Issue
+	for rows.Next() {
+		var issue types.Issue
+		if err := scanIssueRow(rows, &issue); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		if !issue.UpdatedAt.After(before) {
+			idle = append(idle, &issue)
+		}
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unleased work: %w", err)
+	}
+
+	for _, issue := range idle {
+		comment := fmt.Sprintf("In progress without a heartbeat since %s", issue.UpdatedAt.UTC().Format(time.RFC3339))
+		if err := returnToOpen(ctx, conn, issue, actor, comment); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return nil, fmt.Errorf("failed to commit release: %w", err)
+	}
+	committed = true
+	return idle, nil
+}
+
+// returnToOpen moves an in_progress issue back to open and unassigned
+func returnToOpen(ctx context.Context, conn *sql.Conn, issue *types.Issue, actor, comment string) error {
+	oldData, err := json.Marshal(issue)
+	if err != nil {
+		oldData = []byte(fmt.Sprintf(`{"id":"%s"}`, issue.ID))
+	}
+	released := *issue
+	released.Status = types.StatusOpen
+	released.Assignee = ""
+	released.UpdatedAt = time.Now()
+	released.ContentHash = released.ComputeContentHash()
+
+	newData, _ := json.Marshal(map[string]interface{}{
+		"status":   released.Status,
+		"assignee": "",
+	})
+	return applyLeaseChange(ctx, conn, &released, actor, string(oldData), string(newData), comment)
+}
+
+// applyLeaseChange writes a claim or release's status and assignee, records
+// the event and marks the issue dirty
+func applyLeaseChange(ctx context.Context, conn *sql.Conn, issue *types.Issue, actor, oldValue, newValue, comment string) error {
+	_, err := conn.ExecContext(ctx, `
+		UPDATE issues SET status = ?, assignee = ?, updated_at = ?, content_hash = ?
+		WHERE id = ?
+	`, issue.Status, issue.Assignee, issue.UpdatedAt, issue.ContentHash, issue.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue %s: %w", issue.ID, err)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, issue.ID, types.EventStatusChanged, actor, oldValue, newValue, comment)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, issue.ID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// dropLeaseOnUpdate releases an issue's lease when an update takes it away
+// from the holder: a status other than in_progress, or a different assignee
+func dropLeaseOnUpdate(ctx context.Context, tx *sql.Tx, id string, updates map[string]interface{}) error {
+	if statusVal, ok := updates["status"]; ok {
+		if status, ok := statusFromValue(statusVal); ok && status != types.StatusInProgress {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM leases WHERE issue_id = ?`, id); err != nil {
+				return fmt.Errorf("failed to release lease: %w", err)
+			}
+			return nil
+		}
+	}
+	if assigneeVal, ok := updates["assignee"]; ok {
+		assignee, _ := assigneeVal.(string)
+		if _, err := tx.ExecContext(ctx, `DELETE FROM leases WHERE issue_id = ? AND holder != ?`, id, assignee); err != nil {
+			return fmt.Errorf("failed to release lease: %w", err)
+		}
+	}
+	return nil
+}
+
+// queryIssueOnConn returns the first issue selected by a query using the
+// standard issue columns, or nil if there is none. Labels are not loaded.
+func queryIssueOnConn(ctx context.Context, conn *sql.Conn, query string, args ...interface{}) (*types.Issue, error) {
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	var issue types.Issue
+	if err := scanIssueRow(rows, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+func scanLeases(rows *sql.Rows) ([]*types.Lease, error) {
+	var leases []*types.Lease
+	for rows.Next() {
+		var lease types.Lease
+		var lastHeartbeat sql.NullTime
+		if err := rows.Scan(&lease.IssueID, &lease.Holder, &lease.ClaimedAt, &lease.ExpiresAt, &lastHeartbeat); err != nil {
+			return nil, fmt.Errorf("failed to scan lease: %w", err)
+		}
+		lease.LastHeartbeat = lease.ClaimedAt
+		if lastHeartbeat.Valid {
+			lease.LastHeartbeat = lastHeartbeat.Time
+		}
+		leases = append(leases, &lease)
+	}
+	return leases, rows.Err()
+}
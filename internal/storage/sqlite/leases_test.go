@@ -0,0 +1,329 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func createLeaseTestIssues(t *testing.T, store *SQLiteStorage, n int) []*types.Issue {
+	t.Helper()
+	ctx := context.Background()
+	issues := make([]*types.Issue, n)
+	for i := range issues {
+		issues[i] = &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: i % 4, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issues[i], "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	return issues
+}
+
+// latestEvent returns the most recently recorded event; events recorded within
+// the same second share a created_at, so order by ID
+func latestEvent(t *testing.T, store *SQLiteStorage, issueID string) *types.Event {
+	t.Helper()
+	events, err := store.GetEvents(context.Background(), issueID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var last *types.Event
+	for _, event := range events {
+		if last == nil || event.ID > last.ID {
+			last = event
+		}
+	}
+	if last == nil {
+		t.Fatalf("no events for %s", issueID)
+	}
+	return last
+}
+
+func TestClaimReadyWork(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	blocked := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, blocked} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	claim, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-1", time.Hour)
+	if err != nil {
+		t.Fatalf("ClaimReadyWork failed: %v", err)
+	}
+	if claim == nil || claim.Issue.ID != blocker.ID {
+		t.Fatalf("expected to claim the unblocked issue %s, got %+v", blocker.ID, claim)
+	}
+	if claim.Issue.Status != types.StatusInProgress || claim.Issue.Assignee != "agent-1" {
+		t.Errorf("claimed issue not in progress for agent-1: %+v", claim.Issue)
+	}
+
+	stored, err := store.GetIssue(ctx, blocker.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if stored.Status != types.StatusInProgress || stored.Assignee != "agent-1" {
+		t.Errorf("stored issue not claimed: status=%s assignee=%s", stored.Status, stored.Assignee)
+	}
+	if stored.ContentHash != stored.ComputeContentHash() {
+		t.Error("content hash not updated by claim")
+	}
+
+	// The only other issue is blocked, so nothing is left to claim
+	claim, err = store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-2", time.Hour)
+	if err != nil {
+		t.Fatalf("ClaimReadyWork failed: %v", err)
+	}
+	if claim != nil {
+		t.Errorf("expected nothing to claim, got %s", claim.Issue.ID)
+	}
+
+	last := latestEvent(t, store, blocker.ID)
+	if last.EventType != types.EventStatusChanged || last.Actor != "agent-1" ||
+		last.Comment == nil || !strings.HasPrefix(*last.Comment, "Claimed by agent-1") {
+		t.Errorf("unexpected claim event: %+v", last)
+	}
+}
+
+func TestClaimReadyWorkConcurrent(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	const numIssues, numAgents = 5, 10
+	createLeaseTestIssues(t, store, numIssues)
+
+	var mu sync.Mutex
+	claimedBy := make(map[string]string)
+	var wg sync.WaitGroup
+	for i := 0; i < numAgents; i++ {
+		wg.Add(1)
+		go func(agent string) {
+			defer wg.Done()
+			claim, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, agent, time.Hour)
+			if err != nil {
+				t.Errorf("ClaimReadyWork(%s) failed: %v", agent, err)
+				return
+			}
+			if claim == nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if other, ok := claimedBy[claim.Issue.ID]; ok {
+				t.Errorf("%s claimed by both %s and %s", claim.Issue.ID, other, agent)
+			}
+			claimedBy[claim.Issue.ID] = agent
+		}(string(rune('a' + i)))
+	}
+	wg.Wait()
+
+	if len(claimedBy) != numIssues {
+		t.Errorf("expected all %d issues claimed, got %d", numIssues, len(claimedBy))
+	}
+	leases, err := store.GetLeases(ctx)
+	if err != nil {
+		t.Fatalf("GetLeases failed: %v", err)
+	}
+	if len(leases) != numIssues {
+		t.Errorf("expected %d leases, got %d", numIssues, len(leases))
+	}
+}
+
+func TestReleaseExpiredLeases(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issues := createLeaseTestIssues(t, store, 1)
+	claim, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-1", time.Minute)
+	if err != nil || claim == nil {
+		t.Fatalf("ClaimReadyWork failed: %v", err)
+	}
+
+	released, err := store.ReleaseExpiredLeases(ctx, time.Now(), "reaper")
+	if err != nil {
+		t.Fatalf("ReleaseExpiredLeases failed: %v", err)
+	}
+	if len(released) != 0 {
+		t.Fatalf("released %d leases before expiry", len(released))
+	}
+
+	released, err = store.ReleaseExpiredLeases(ctx, time.Now().Add(2*time.Minute), "reaper")
+	if err != nil {
+		t.Fatalf("ReleaseExpiredLeases failed: %v", err)
+	}
+	if len(released) != 1 || released[0].Holder != "agent-1" {
+		t.Fatalf("expected agent-1's lease released, got %+v", released)
+	}
+
+	issue, err := store.GetIssue(ctx, issues[0].ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if issue.Status != types.StatusOpen || issue.Assignee != "" {
+		t.Errorf("expired issue not returned to ready: status=%s assignee=%s", issue.Status, issue.Assignee)
+	}
+	if last := latestEvent(t, store, issue.ID); last.Actor != "reaper" || last.Comment == nil || !strings.Contains(*last.Comment, "agent-1") {
+		t.Errorf("release event does not name the last holder: %+v", last)
+	}
+
+	// The issue can be claimed again
+	claim, err = store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-2", time.Minute)
+	if err != nil || claim == nil || claim.Issue.ID != issue.ID {
+		t.Fatalf("expected agent-2 to claim %s, got %+v (err %v)", issue.ID, claim, err)
+	}
+}
+
+func TestLeaseEndsWithIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	createLeaseTestIssues(t, store, 2)
+	first, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-1", time.Hour)
+	if err != nil || first == nil {
+		t.Fatalf("ClaimReadyWork failed: %v", err)
+	}
+	second, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-1", time.Hour)
+	if err != nil || second == nil {
+		t.Fatalf("ClaimReadyWork failed: %v", err)
+	}
+
+	if err := store.CloseIssue(ctx, first.Issue.ID, "Done", "agent-1"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, second.Issue.ID, map[string]interface{}{"assignee": "human"}, "human"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	leases, err := store.GetLeases(ctx)
+	if err != nil {
+		t.Fatalf("GetLeases failed: %v", err)
+	}
+	if len(leases) != 0 {
+		t.Errorf("expected closing and reassigning to end leases, still have %+v", leases)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issues := createLeaseTestIssues(t, store, 2)
+	claim, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-1", time.Minute)
+	if err != nil || claim == nil {
+		t.Fatalf("ClaimReadyWork failed: %v", err)
+	}
+
+	lease, err := store.Heartbeat(ctx, claim.Issue.ID, "agent-1", time.Hour)
+	if err != nil {
+		t.Fatalf("Heartbeat failed: %v", err)
+	}
+	if !lease.ClaimedAt.Equal(claim.Lease.ClaimedAt) {
+		t.Errorf("heartbeat changed the claim time: %v -> %v", claim.Lease.ClaimedAt, lease.ClaimedAt)
+	}
+	if lease.ExpiresAt.Before(time.Now().Add(59*time.Minute)) || lease.LastHeartbeat.Before(claim.Lease.ClaimedAt) {
+		t.Errorf("heartbeat did not renew the lease: %+v", lease)
+	}
+
+	// A renewed lease survives past the original expiry
+	released, err := store.ReleaseExpiredLeases(ctx, time.Now().Add(2*time.Minute), "reaper")
+	if err != nil {
+		t.Fatalf("ReleaseExpiredLeases failed: %v", err)
+	}
+	if len(released) != 0 {
+		t.Errorf("released a renewed lease: %+v", released)
+	}
+
+	if _, err := store.Heartbeat(ctx, claim.Issue.ID, "agent-2", time.Hour); err == nil {
+		t.Error("expected a heartbeat from another agent to be rejected")
+	}
+	other := issues[0]
+	if other.ID == claim.Issue.ID {
+		other = issues[1]
+	}
+	if _, err := store.Heartbeat(ctx, other.ID, "agent-1", time.Hour); err == nil {
+		t.Error("expected a heartbeat for an open issue to be rejected")
+	}
+
+	// In-progress work that was never claimed gets a lease on its first heartbeat
+	if err := store.UpdateIssue(ctx, other.ID, map[string]interface{}{"status": string(types.StatusInProgress), "assignee": "human"}, "human"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if _, err := store.Heartbeat(ctx, other.ID, "human", time.Hour); err != nil {
+		t.Fatalf("Heartbeat failed: %v", err)
+	}
+	leases, err := store.GetLeases(ctx)
+	if err != nil {
+		t.Fatalf("GetLeases failed: %v", err)
+	}
+	if len(leases) != 2 {
+		t.Errorf("expected 2 leases, got %+v", leases)
+	}
+}
+
+func TestReleaseUnleasedWork(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issues := createLeaseTestIssues(t, store, 2)
+	// One issue is set in_progress by hand, the other is claimed
+	if err := store.UpdateIssue(ctx, issues[0].ID, map[string]interface{}{"status": string(types.StatusInProgress), "assignee": "human"}, "human"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	claim, err := store.ClaimReadyWork(ctx, types.WorkFilter{}, "agent-1", time.Hour)
+	if err != nil || claim == nil || claim.Issue.ID != issues[1].ID {
+		t.Fatalf("expected agent-1 to claim %s, got %+v (err %v)", issues[1].ID, claim, err)
+	}
+
+	released, err := store.ReleaseUnleasedWork(ctx, time.Now().Add(-time.Minute), "reaper")
+	if err != nil {
+		t.Fatalf("ReleaseUnleasedWork failed: %v", err)
+	}
+	if len(released) != 0 {
+		t.Fatalf("released recently updated work: %+v", released)
+	}
+
+	released, err = store.ReleaseUnleasedWork(ctx, time.Now().Add(time.Minute), "reaper")
+	if err != nil {
+		t.Fatalf("ReleaseUnleasedWork failed: %v", err)
+	}
+	if len(released) != 1 || released[0].ID != issues[0].ID || released[0].Assignee != "human" {
+		t.Fatalf("expected only %s released, got %+v", issues[0].ID, released)
+	}
+
+	issue, err := store.GetIssue(ctx, issues[0].ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if issue.Status != types.StatusOpen || issue.Assignee != "" {
+		t.Errorf("idle issue not returned to open: status=%s assignee=%s", issue.Status, issue.Assignee)
+	}
+	if last := latestEvent(t, store, issue.ID); last.Actor != "reaper" {
+		t.Errorf("release event not recorded: %+v", last)
+	}
+
+	// The claimed issue keeps its lease
+	issue, err = store.GetIssue(ctx, issues[1].ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if issue.Status != types.StatusInProgress || issue.Assignee != "agent-1" {
+		t.Errorf("claimed issue was released: status=%s assignee=%s", issue.Status, issue.Assignee)
+	}
+}
@@ -0,0 +1,175 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PendingCompaction is a compaction summary staged for human review when
+// compact_mode is pending_review.
+type PendingCompaction struct {
+	IssueID      string     `json:"issue_id"`
+	Tier         int        `json:"tier"`
+	Summary      string     `json:"summary"`
+	OriginalSize int        `json:"original_size"`
+	ContentHash  string     `json:"content_hash"`           // Issue content hash when the summary was staged
+	MissingRefs  []string   `json:"missing_refs,omitempty"` // References in the original the summary dropped
+	StagedAt     time.Time  `json:"staged_at"`
+	RejectedAt   *time.Time `json:"rejected_at,omitempty"`
+}
+
+// pendingExclusionSQL keeps issues with a summary awaiting review, or whose
+// summary was rejected and which haven't changed since, out of the candidate
+// queries. It expects the issues table aliased as i.
+const pendingExclusionSQL = `
+	NOT EXISTS (
+	  SELECT 1 FROM pending_compactions p
+	  WHERE p.issue_id = i.id
+	    AND (p.rejected_at IS NULL OR p.content_hash = COALESCE(i.content_hash, ''))
+	)
+`
+
+// StagePendingCompaction saves a summary for review, replacing any earlier
+// summary staged or rejected for the issue.
+func (s *SQLiteStorage) StagePendingCompaction(ctx context.Context, pc *PendingCompaction) error {
+	stagedAt := pc.StagedAt
+	if stagedAt.IsZero() {
+		stagedAt = time.Now().UTC()
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO pending_compactions (issue_id, tier, summary, original_size, content_hash, missing_refs, staged_at, rejected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, NULL)
+		ON CONFLICT(issue_id) DO UPDATE SET
+			tier = excluded.tier,
+			summary = excluded.summary,
+			original_size = excluded.original_size,
+			content_hash = excluded.content_hash,
+			missing_refs = excluded.missing_refs,
+			staged_at = excluded.staged_at,
+			rejected_at = NULL
+	`, pc.IssueID, pc.Tier, pc.Summary, pc.OriginalSize, pc.ContentHash, strings.Join(pc.MissingRefs, "\n"), stagedAt)
+	if err != nil {
+		return fmt.Errorf("failed to stage compaction for %s: %w", pc.IssueID, err)
+	}
+	return nil
+}
+
+// GetPendingCompaction returns the summary awaiting review for an issue, or
+// nil if there is none.
+func (s *SQLiteStorage) GetPendingCompaction(ctx context.Context, issueID string) (*PendingCompaction, error) {
+	rows, err := s.queryPendingCompactions(ctx, `WHERE issue_id = ? AND rejected_at IS NULL`, issueID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return rows[0], nil
+}
+
+// ListPendingCompactions returns the summaries awaiting review, oldest first.
+func (s *SQLiteStorage) ListPendingCompactions(ctx context.Context) ([]*PendingCompaction, error) {
+	return s.queryPendingCompactions(ctx, `WHERE rejected_at IS NULL ORDER BY staged_at ASC, issue_id ASC`)
+}
+
+// RejectPendingCompaction discards a staged summary. The rejection is
+// remembered so the issue isn't summarized again until its content changes.
+func (s *SQLiteStorage) RejectPendingCompaction(ctx context.Context, issueID string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE pending_compactions SET rejected_at = ?
+		WHERE issue_id = ? AND rejected_at IS NULL
+	`, time.Now().UTC(), issueID)
+	if err != nil {
+		return fmt.Errorf("failed to reject compaction for %s: %w", issueID, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("no compaction pending review for %s", issueID)
+	}
+	return nil
+}
+
+// RecordRejectedCompaction saves a summary the compactor refused as already
+// rejected, so the issue isn't summarized again until its content changes.
+// It replaces any earlier summary staged or rejected for the issue.
+func (s *SQLiteStorage) RecordRejectedCompaction(ctx context.Context, pc *PendingCompaction) error {
+	now := time.Now().UTC()
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO pending_compactions (issue_id, tier, summary, original_size, content_hash, missing_refs, staged_at, rejected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(issue_id) DO UPDATE SET
+			tier = excluded.tier,
+			summary = excluded.summary,
+			original_size = excluded.original_size,
+			content_hash = excluded.content_hash,
+			missing_refs = excluded.missing_refs,
+			staged_at = excluded.staged_at,
+			rejected_at = excluded.rejected_at
+	`, pc.IssueID, pc.Tier, pc.Summary, pc.OriginalSize, pc.ContentHash, strings.Join(pc.MissingRefs, "\n"), now, now)
+	if err != nil {
+		return fmt.Errorf("failed to record rejected compaction for %s: %w", pc.IssueID, err)
+	}
+	return nil
+}
+
+// DeletePendingCompaction removes any staged or rejected summary for an issue.
+func (s *SQLiteStorage) DeletePendingCompaction(ctx context.Context, issueID string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM pending_compactions WHERE issue_id = ?`, issueID); err != nil {
+		return fmt.Errorf("failed to delete pending compaction for %s: %w", issueID, err)
+	}
+	return nil
+}
+
+// compactionReviewState reports whether an issue has a summary awaiting
+// review, or a rejected one matching its current content.
+func (s *SQLiteStorage) compactionReviewState(ctx context.Context, issueID string) (pending, rejected bool, err error) {
+	var rejectedAt sql.NullTime
+	var sameContent bool
+	err = s.db.QueryRowContext(ctx, `
+		SELECT p.rejected_at, p.content_hash = COALESCE(i.content_hash, '')
+		FROM pending_compactions p
+		JOIN issues i ON i.id = p.issue_id
+		WHERE p.issue_id = ?
+	`, issueID).Scan(&rejectedAt, &sameContent)
+	if err == sql.ErrNoRows {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get pending compaction: %w", err)
+	}
+	return !rejectedAt.Valid, rejectedAt.Valid && sameContent, nil
+}
+
+func (s *SQLiteStorage) queryPendingCompactions(ctx context.Context, where string, args ...interface{}) ([]*PendingCompaction, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT issue_id, tier, summary, original_size, content_hash, missing_refs, staged_at, rejected_at
+		FROM pending_compactions
+	`+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending compactions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []*PendingCompaction
+	for rows.Next() {
+		var pc PendingCompaction
+		var missing string
+		var rejectedAt sql.NullTime
+		if err := rows.Scan(&pc.IssueID, &pc.Tier, &pc.Summary, &pc.OriginalSize, &pc.ContentHash, &missing, &pc.StagedAt, &rejectedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pending compaction: %w", err)
+		}
+		if missing != "" {
+			pc.MissingRefs = strings.Split(missing, "\n")
+		}
+		if rejectedAt.Valid {
+			pc.RejectedAt = &rejectedAt.Time
+		}
+		result = append(result, &pc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return result, nil
+}
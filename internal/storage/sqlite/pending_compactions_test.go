@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestPendingCompactions(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, "compact_tier1_days", "0"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	issue := &types.Issue{
+		ID:          testIssueBeads1,
+		Title:       "Closed issue",
+		Description: "A long description mentioning bd-a3f8 and main.go.",
+		Status:      "closed",
+		Priority:    2,
+		IssueType:   "task",
+		ClosedAt:    timePtr(time.Now().Add(-time.Hour)),
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	stored, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	err = store.StagePendingCompaction(ctx, &PendingCompaction{
+		IssueID:      issue.ID,
+		Tier:         1,
+		Summary:      "Short summary.",
+		OriginalSize: len(issue.Description),
+		ContentHash:  stored.ContentHash,
+		MissingRefs:  []string{"bd-a3f8", "main.go"},
+	})
+	if err != nil {
+		t.Fatalf("StagePendingCompaction failed: %v", err)
+	}
+
+	pending, err := store.ListPendingCompactions(ctx)
+	if err != nil {
+		t.Fatalf("ListPendingCompactions failed: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Summary != "Short summary." || len(pending[0].MissingRefs) != 2 {
+		t.Fatalf("unexpected pending compactions: %+v", pending)
+	}
+	if candidates, _ := store.GetTier1Candidates(ctx); len(candidates) != 0 {
+		t.Errorf("expected a staged issue not to be a candidate")
+	}
+	if eligible, reason, _ := store.CheckEligibility(ctx, issue.ID, 1); eligible || reason != "a compaction summary is pending review" {
+		t.Errorf("unexpected eligibility: %v, %q", eligible, reason)
+	}
+
+	// Renaming the issue carries the staged summary along
+	renamed := *stored
+	renamed.ID = "beads-renamed"
+	if err := store.UpdateIssueID(ctx, issue.ID, renamed.ID, &renamed, "test"); err != nil {
+		t.Fatalf("UpdateIssueID failed: %v", err)
+	}
+	pc, err := store.GetPendingCompaction(ctx, renamed.ID)
+	if err != nil || pc == nil {
+		t.Fatalf("expected the pending compaction to follow the rename, got %v, %v", pc, err)
+	}
+
+	if err := store.RejectPendingCompaction(ctx, renamed.ID); err != nil {
+		t.Fatalf("RejectPendingCompaction failed: %v", err)
+	}
+	if err := store.RejectPendingCompaction(ctx, renamed.ID); err == nil {
+		t.Error("expected an error rejecting twice")
+	}
+	if pc, _ := store.GetPendingCompaction(ctx, renamed.ID); pc != nil {
+		t.Errorf("expected no pending compaction after rejecting, got %+v", pc)
+	}
+	if candidates, _ := store.GetTier1Candidates(ctx); len(candidates) != 0 {
+		t.Errorf("expected a rejected, unchanged issue not to be a candidate")
+	}
+
+	if err := store.DeletePendingCompaction(ctx, renamed.ID); err != nil {
+		t.Fatalf("DeletePendingCompaction failed: %v", err)
+	}
+	if candidates, _ := store.GetTier1Candidates(ctx); len(candidates) != 1 {
+		t.Errorf("expected the issue to be a candidate again, got %d", len(candidates))
+	}
+}
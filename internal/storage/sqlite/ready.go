@@ -10,15 +10,28 @@ import (
 )
 
 // GetReadyWork returns issues with no open blockers
-// By default, shows both 'open' and 'in_progress' issues so epics/tasks
-// ready to close are visible (beads-165)
+// By default, shows all statuses in the active category ('open', 'in_progress' and
+// any custom active statuses) so epics/tasks ready to close are visible (beads-165)
 func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	query, args := readyWorkQuery(filter)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ready work: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
+// readyWorkQuery builds the ready work query for a filter. Extra conditions on
+// the issues table (aliased i) are ANDed into the WHERE clause.
+func readyWorkQuery(filter types.WorkFilter, extraWhere ...string) (string, []interface{}) {
 	whereClauses := []string{}
 	args := []interface{}{}
 
-	// Default to open OR in_progress if not specified (beads-165)
+	// Default to active statuses if not specified (beads-165)
 	if filter.Status == "" {
-		whereClauses = append(whereClauses, "i.status IN ('open', 'in_progress')")
+		whereClauses = append(whereClauses, "i.status IN "+activeStatusesSQL)
 	} else {
 		whereClauses = append(whereClauses, "i.status = ?")
 		args = append(args, filter.Status)
@@ -34,6 +47,11 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		args = append(args, *filter.Assignee)
 	}
 
+	// Recurring issues are templates; their occurrences are the work
+	whereClauses = append(whereClauses, "i.recurrence = ''")
+
+	whereClauses = append(whereClauses, extraWhere...)
+
 	// Build WHERE clause properly
 	whereSQL := strings.Join(whereClauses, " AND ")
 
@@ -57,7 +75,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	// 2. Recursively propagate blockage to all descendants via 'parent-child' links
 	// 3. Exclude all blocked issues (both direct and transitive) from ready work
 	// #nosec G201 - safe SQL with controlled formatting
-	query := fmt.Sprintf(`
+	return fmt.Sprintf(`
 		WITH RECURSIVE
 		  -- Step 1: Find issues blocked directly by dependencies
 		  blocked_directly AS (
@@ -65,7 +83,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		    FROM dependencies d
 		    JOIN issues blocker ON d.depends_on_id = blocker.id
 		    WHERE d.type = 'blocks'
-		      AND blocker.status IN ('open', 'in_progress', 'blocked')
+		      AND blocker.status NOT IN %s
 		  ),
 
 		  -- Step 2: Propagate blockage to all descendants via parent-child
@@ -87,7 +105,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		-- Step 3: Select ready issues (excluding all blocked)
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		i.created_at, i.updated_at, i.closed_at, i.external_ref
+		i.created_at, i.updated_at, i.closed_at, i.external_ref, i.recurrence, i.due_at, i.recurred_at
 		FROM issues i
 		WHERE %s
 		AND NOT EXISTS (
@@ -95,15 +113,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		)
 		%s
 		%s
-	`, whereSQL, orderBySQL, limitSQL)
-
-	rows, err := s.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get ready work: %w", err)
-	}
-	defer func() { _ = rows.Close() }()
-
-	return s.scanIssues(ctx, rows)
+	`, doneStatusesSQL, whereSQL, orderBySQL, limitSQL), args
 }
 
 // GetBlockedIssues returns issues that are blocked by dependencies
@@ -119,9 +129,9 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		JOIN issues blocker ON d.depends_on_id = blocker.id
-		WHERE i.status IN ('open', 'in_progress', 'blocked')
+		WHERE i.status NOT IN `+doneStatusesSQL+`
 		  AND d.type = 'blocks'
-		  AND blocker.status IN ('open', 'in_progress', 'blocked')
+		  AND blocker.status NOT IN `+doneStatusesSQL+`
 		GROUP BY i.id
 		ORDER BY i.priority ASC
 	`)
@@ -184,6 +194,19 @@ func buildOrderByClause(policy types.SortPolicy) string {
 	case types.SortPolicyOldest:
 		return `ORDER BY i.created_at ASC`
 
+	case types.SortPolicyDeadline:
+		// Raise priority by types.Issue.DeadlineWeight, then soonest due first
+		return `ORDER BY
+			i.priority - CASE
+				WHEN i.due_at IS NULL THEN 0
+				WHEN julianday(i.due_at) <= julianday('now', '+1 day') THEN 2
+				WHEN julianday(i.due_at) <= julianday('now', '+7 days') THEN 1
+				ELSE 0
+			END ASC,
+			i.due_at IS NULL ASC,
+			julianday(i.due_at) ASC,
+			i.created_at ASC`
+
 	case types.SortPolicyHybrid:
 		fallthrough
 	default:
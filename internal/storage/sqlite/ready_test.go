@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/shaneholloman/beads/internal/types"
 )
@@ -1017,6 +1018,51 @@ func TestSortPolicyOldest(t *testing.T) {
 	}
 }
 
+// TestSortPolicyDeadline tests that approaching due dates raise an issue's priority
+func TestSortPolicyDeadline(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now()
+	due := func(d time.Duration) *time.Time {
+		at := now.Add(d)
+		return &at
+	}
+
+	issues := []*types.Issue{
+		{Title: "P1-no-due", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{Title: "P3-due-30d", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask, DueAt: due(30 * 24 * time.Hour)},
+		{Title: "P0-no-due", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask},
+		{Title: "P1-due-3d", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DueAt: due(3 * 24 * time.Hour)},
+		{Title: "P2-due-12h", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, DueAt: due(12 * time.Hour)},
+	}
+	for _, issue := range issues {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{
+		Status:     types.StatusOpen,
+		SortPolicy: types.SortPolicyDeadline,
+	})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 5 {
+		t.Fatalf("Expected 5 ready issues, got %d", len(ready))
+	}
+
+	// Effective priority 0: due in 12h (P2-2), due in 3d (P1-1) and P0, soonest due first
+	expectedTitles := []string{"P2-due-12h", "P1-due-3d", "P0-no-due", "P1-no-due", "P3-due-30d"}
+	for i, expected := range expectedTitles {
+		if ready[i].Title != expected {
+			t.Errorf("Position %d: expected %s, got %s", i, expected, ready[i].Title)
+		}
+	}
+}
+
 // TestSortPolicyHybrid tests hybrid sort (default behavior)
 func TestSortPolicyHybrid(t *testing.T) {
 	store, cleanup := setupTestDB(t)
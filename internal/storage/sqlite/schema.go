@@ -23,6 +23,9 @@ CREATE TABLE IF NOT EXISTS issues (
     compacted_at DATETIME,
     compacted_at_commit TEXT,
     original_size INTEGER,
+    recurrence TEXT NOT NULL DEFAULT '',
+    due_at DATETIME,
+    recurred_at DATETIME,
     CHECK ((status = 'closed') = (closed_at IS NOT NULL))
 );
 
@@ -47,6 +50,18 @@ CREATE INDEX IF NOT EXISTS idx_dependencies_issue ON dependencies(issue_id);
 CREATE INDEX IF NOT EXISTS idx_dependencies_depends_on ON dependencies(depends_on_id);
 CREATE INDEX IF NOT EXISTS idx_dependencies_depends_on_type ON dependencies(depends_on_id, type);
 
+-- Cross-repository dependencies (depends_on_id is a qualified ID such as api:bd-a3f8)
+-- The target lives in another database, so only issue_id has a foreign key
+CREATE TABLE IF NOT EXISTS external_dependencies (
+    issue_id TEXT NOT NULL,
+    depends_on_id TEXT NOT NULL,
+    type TEXT NOT NULL DEFAULT 'blocks',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by TEXT NOT NULL,
+    PRIMARY KEY (issue_id, depends_on_id),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
 -- Labels table
 CREATE TABLE IF NOT EXISTS labels (
     issue_id TEXT NOT NULL,
@@ -165,6 +180,53 @@ CREATE TABLE IF NOT EXISTS compaction_snapshots (
 
 CREATE INDEX IF NOT EXISTS idx_comp_snap_issue_level_created ON compaction_snapshots(issue_id, compaction_level, created_at DESC);
 
+-- Compaction summaries awaiting review (compact_mode = pending_review)
+-- A rejected summary is kept so the issue isn't summarized again until it changes
+CREATE TABLE IF NOT EXISTS pending_compactions (
+    issue_id TEXT PRIMARY KEY,
+    tier INTEGER NOT NULL,
+    summary TEXT NOT NULL,
+    original_size INTEGER NOT NULL,
+    content_hash TEXT NOT NULL,
+    missing_refs TEXT NOT NULL DEFAULT '',
+    staged_at DATETIME NOT NULL,
+    rejected_at DATETIME,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Leases table (atomic work claims, see ClaimReadyWork)
+-- A lease gives one agent an in_progress issue until expires_at; heartbeats extend it
+CREATE TABLE IF NOT EXISTS leases (
+    issue_id TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    claimed_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    last_heartbeat DATETIME,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_leases_expires_at ON leases(expires_at);
+
+-- Status categories table (for custom workflow statuses)
+-- Maps each status to active/blocked/done so ready work and blocking queries
+-- keep working when .beads/config.yaml defines extra statuses
+CREATE TABLE IF NOT EXISTS status_categories (
+    status TEXT PRIMARY KEY,
+    category TEXT NOT NULL CHECK(category IN ('active', 'blocked', 'done'))
+);
+
+INSERT OR IGNORE INTO status_categories (status, category) VALUES
+    ('open', 'active'),
+    ('in_progress', 'active'),
+    ('blocked', 'blocked'),
+    ('closed', 'done');
+`
+
+// views is executed after migrations so views created by older versions can be
+// dropped and recreated (see migrateStatusCategoryViews).
+// Statuses in the 'done' category never block; anything else (including statuses
+// missing from status_categories) does.
+const views = `
 -- Ready work view (with hierarchical blocking)
 -- Uses recursive CTE to propagate blocking through parent-child hierarchy
 CREATE VIEW IF NOT EXISTS ready_issues AS
@@ -175,7 +237,7 @@ WITH RECURSIVE
     FROM dependencies d
     JOIN issues blocker ON d.depends_on_id = blocker.id
     WHERE d.type = 'blocks'
-      AND blocker.status IN ('open', 'in_progress', 'blocked')
+      AND blocker.status NOT IN (SELECT status FROM status_categories WHERE category = 'done')
   ),
   -- Propagate blockage to all descendants via parent-child
   blocked_transitively AS (
@@ -205,8 +267,8 @@ SELECT
 FROM issues i
 JOIN dependencies d ON i.id = d.issue_id
 JOIN issues blocker ON d.depends_on_id = blocker.id
-WHERE i.status IN ('open', 'in_progress', 'blocked')
+WHERE i.status NOT IN (SELECT status FROM status_categories WHERE category = 'done')
   AND d.type = 'blocks'
-  AND blocker.status IN ('open', 'in_progress', 'blocked')
+  AND blocker.status NOT IN (SELECT status FROM status_categories WHERE category = 'done')
 GROUP BY i.id;
 `
@@ -0,0 +1,203 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// ftsTable is the FTS5 index over issue text and comments. Column order after
+// issue_id must match storage.SearchFields so bm25 weights line up.
+const ftsTable = `
+CREATE VIRTUAL TABLE issues_fts USING fts5(
+    issue_id UNINDEXED,
+    title,
+    description,
+    design,
+    acceptance_criteria,
+    notes,
+    comments,
+    tokenize = 'porter unicode61'
+)`
+
+// ftsComments is the comments column value for issue_id = %s
+const ftsComments = `COALESCE((SELECT group_concat(text, char(10)) FROM comments WHERE issue_id = %s), '')`
+
+// ftsTriggers keep issues_fts in sync with issues and comments. Issue updates only
+// reindex when indexed text (or the ID, on prefix rename) changes.
+var ftsTriggers = `
+CREATE TRIGGER IF NOT EXISTS issues_fts_insert AFTER INSERT ON issues BEGIN
+    INSERT INTO issues_fts (issue_id, title, description, design, acceptance_criteria, notes, comments)
+    VALUES (new.id, new.title, new.description, new.design, new.acceptance_criteria, new.notes,
+            ` + fmt.Sprintf(ftsComments, "new.id") + `);
+END;
+
+CREATE TRIGGER IF NOT EXISTS issues_fts_update
+AFTER UPDATE OF id, title, description, design, acceptance_criteria, notes ON issues BEGIN
+    DELETE FROM issues_fts WHERE issue_id = old.id;
+    INSERT INTO issues_fts (issue_id, title, description, design, acceptance_criteria, notes, comments)
+    VALUES (new.id, new.title, new.description, new.design, new.acceptance_criteria, new.notes,
+            ` + fmt.Sprintf(ftsComments, "new.id") + `);
+END;
+
+CREATE TRIGGER IF NOT EXISTS issues_fts_delete AFTER DELETE ON issues BEGIN
+    DELETE FROM issues_fts WHERE issue_id = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS comments_fts_insert AFTER INSERT ON comments BEGIN
+    UPDATE issues_fts SET comments = ` + fmt.Sprintf(ftsComments, "new.issue_id") + `
+    WHERE issue_id = new.issue_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS comments_fts_update AFTER UPDATE ON comments BEGIN
+    UPDATE issues_fts SET comments = ` + fmt.Sprintf(ftsComments, "old.issue_id") + `
+    WHERE issue_id = old.issue_id;
+    UPDATE issues_fts SET comments = ` + fmt.Sprintf(ftsComments, "new.issue_id") + `
+    WHERE issue_id = new.issue_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS comments_fts_delete AFTER DELETE ON comments BEGIN
+    UPDATE issues_fts SET comments = ` + fmt.Sprintf(ftsComments, "old.issue_id") + `
+    WHERE issue_id = old.issue_id;
+END;
+`
+
+// migrateFullTextSearch creates the FTS5 index if missing, backfills it from
+// existing issues and comments, and installs the sync triggers.
+func migrateFullTextSearch(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var name string
+	err = tx.QueryRow(`SELECT name FROM sqlite_master WHERE type = 'table' AND name = 'issues_fts'`).Scan(&name)
+	if err == sql.ErrNoRows {
+		if _, err := tx.Exec(ftsTable); err != nil {
+			return fmt.Errorf("failed to create issues_fts table: %w", err)
+		}
+		// #nosec G201 - fixed SQL fragment
+		_, err := tx.Exec(`
+			INSERT INTO issues_fts (issue_id, title, description, design, acceptance_criteria, notes, comments)
+			SELECT id, title, description, design, acceptance_criteria, notes, ` + fmt.Sprintf(ftsComments, "issues.id") + `
+			FROM issues
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to backfill issues_fts: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to check issues_fts table: %w", err)
+	}
+
+	if _, err := tx.Exec(ftsTriggers); err != nil {
+		return fmt.Errorf("failed to create issues_fts triggers: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ftsMatchExpr renders a parsed query as an FTS5 MATCH expression. Every term
+// is quoted, so user input can never be parsed as FTS5 operators or columns.
+func ftsMatchExpr(q storage.SearchQuery) string {
+	clauses := make([]string, 0, len(q.Clauses))
+	for _, clause := range q.Clauses {
+		alternatives := make([]string, 0, len(clause))
+		for _, term := range clause {
+			expr := `"` + strings.ReplaceAll(term.Text, `"`, `""`) + `"`
+			if term.Prefix {
+				expr += "*"
+			}
+			alternatives = append(alternatives, expr)
+		}
+		if len(alternatives) == 1 {
+			clauses = append(clauses, alternatives[0])
+		} else {
+			clauses = append(clauses, "("+strings.Join(alternatives, " OR ")+")")
+		}
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// bm25Weights returns the bm25() column weight arguments, issue_id first
+func bm25Weights() string {
+	weights := []string{"0"}
+	for _, field := range storage.SearchFields {
+		weights = append(weights, strconv.FormatFloat(field.Weight, 'f', -1, 64))
+	}
+	return strings.Join(weights, ", ")
+}
+
+// SearchIssuesRanked runs a full-text search over issue text and comments,
+// returning the best matches first with a highlighted snippet for each
+func (s *SQLiteStorage) SearchIssuesRanked(ctx context.Context, query string, filter types.IssueFilter) ([]*types.SearchResult, error) {
+	parsed := storage.ParseSearchQuery(query)
+	if parsed.Empty() {
+		return nil, fmt.Errorf("search query has no searchable terms")
+	}
+
+	whereClauses := []string{"issues_fts MATCH ?"}
+	args := []interface{}{ftsMatchExpr(parsed)}
+
+	filterClauses, filterArgs, err := issueFilterClauses(filter, "i.")
+	if err != nil {
+		return nil, err
+	}
+	whereClauses = append(whereClauses, filterClauses...)
+	args = append(args, filterArgs...)
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = "LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	// bm25() is lower for better matches, so negate it for a "higher is better" score
+	// #nosec G201 - safe SQL with controlled formatting
+	querySQL := fmt.Sprintf(`
+		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
+		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.recurrence, i.due_at, i.recurred_at,
+		       -bm25(issues_fts, %s) AS score,
+		       snippet(issues_fts, -1, '%s', '%s', '…', 16) AS snippet
+		FROM issues_fts
+		JOIN issues i ON i.id = issues_fts.issue_id
+		WHERE %s
+		ORDER BY score DESC, i.priority ASC, i.created_at DESC
+		%s
+	`, bm25Weights(), storage.HighlightStart, storage.HighlightEnd, strings.Join(whereClauses, " AND "), limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	var results []*types.SearchResult
+	for rows.Next() {
+		var result types.SearchResult
+		if err := scanIssueRow(rows, &result.Issue, &result.Score, &result.Snippet); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		results = append(results, &result)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	for _, result := range results {
+		labels, err := s.GetLabels(ctx, result.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for issue %s: %w", result.ID, err)
+		}
+		result.Labels = labels
+	}
+
+	return results, nil
+}
@@ -0,0 +1,228 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func createSearchIssue(t *testing.T, store *SQLiteStorage, title, description string, priority int) *types.Issue {
+	t.Helper()
+	issue := &types.Issue{
+		Title:       title,
+		Description: description,
+		Status:      types.StatusOpen,
+		Priority:    priority,
+		IssueType:   types.TypeTask,
+	}
+	if err := store.CreateIssue(context.Background(), issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	return issue
+}
+
+func searchIDs(t *testing.T, store *SQLiteStorage, query string, filter types.IssueFilter) []string {
+	t.Helper()
+	results, err := store.SearchIssuesRanked(context.Background(), query, filter)
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked(%q) failed: %v", query, err)
+	}
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func TestSearchIssuesRanked_TitleOutranksDescription(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	inDesc := createSearchIssue(t, store, "Refactor session handling", "Login fails when the token expires", 2)
+	inTitle := createSearchIssue(t, store, "Login page broken", "Nothing renders", 2)
+	createSearchIssue(t, store, "Unrelated", "Nothing to see", 2)
+
+	results, err := store.SearchIssuesRanked(context.Background(), "login", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].ID != inTitle.ID || results[1].ID != inDesc.ID {
+		t.Errorf("expected title match first, got %s then %s", results[0].ID, results[1].ID)
+	}
+	if results[0].Score <= results[1].Score {
+		t.Errorf("expected title score %f > description score %f", results[0].Score, results[1].Score)
+	}
+	if !strings.Contains(results[1].Snippet, "**Login**") {
+		t.Errorf("expected highlighted snippet, got %q", results[1].Snippet)
+	}
+}
+
+func TestSearchIssuesRanked_QuerySyntax(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	crash := createSearchIssue(t, store, "App crashes on startup", "Segfault in init", 1)
+	token := createSearchIssue(t, store, "Session token expired", "Users get logged out", 2)
+	auth := createSearchIssue(t, store, "Authentication overhaul", "Replace the token store", 2)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"crash", []string{crash.ID}},           // stemming
+		{`"session token"`, []string{token.ID}}, // phrase
+		{`"token session"`, nil},                // phrase order matters
+		{"crash OR overhaul", []string{crash.ID, auth.ID}},
+		{"token store", []string{auth.ID}},         // AND across fields
+		{"authent*", []string{auth.ID}},            // prefix
+		{`(token) "`, []string{token.ID, auth.ID}}, // FTS5 syntax is not interpreted
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := searchIDs(t, store, tt.query, types.IssueFilter{})
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			want := make(map[string]bool)
+			for _, id := range tt.want {
+				want[id] = true
+			}
+			for _, id := range got {
+				if !want[id] {
+					t.Errorf("unexpected result %s (got %v, want %v)", id, got, tt.want)
+				}
+			}
+		})
+	}
+
+	if _, err := store.SearchIssuesRanked(context.Background(), `"" *`, types.IssueFilter{}); err == nil {
+		t.Error("expected error for query without searchable terms")
+	}
+}
+
+func TestSearchIssuesRanked_IndexStaysInSync(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := createSearchIssue(t, store, "Flaky test", "Fails sometimes", 2)
+
+	// Comments are indexed
+	comment, err := store.AddIssueComment(ctx, issue.ID, "alice", "Reproduced with the websocket reconnect")
+	if err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if got := searchIDs(t, store, "websocket", types.IssueFilter{}); len(got) != 1 || got[0] != issue.ID {
+		t.Errorf("expected comment match, got %v", got)
+	}
+
+	// Comment edits reindex
+	if _, err := store.db.ExecContext(ctx, `UPDATE comments SET text = 'Caused by the scheduler' WHERE id = ?`, comment.ID); err != nil {
+		t.Fatalf("failed to update comment: %v", err)
+	}
+	if got := searchIDs(t, store, "websocket", types.IssueFilter{}); len(got) != 0 {
+		t.Errorf("expected stale comment text to be gone, got %v", got)
+	}
+	if got := searchIDs(t, store, "scheduler", types.IssueFilter{}); len(got) != 1 {
+		t.Errorf("expected edited comment match, got %v", got)
+	}
+
+	// Field updates reindex
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"description": "Deadlock in the worker pool"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got := searchIDs(t, store, "sometimes", types.IssueFilter{}); len(got) != 0 {
+		t.Errorf("expected old description to be gone, got %v", got)
+	}
+	if got := searchIDs(t, store, "deadlock", types.IssueFilter{}); len(got) != 1 {
+		t.Errorf("expected new description match, got %v", got)
+	}
+	// Comments survive a field update
+	if got := searchIDs(t, store, "scheduler", types.IssueFilter{}); len(got) != 1 {
+		t.Errorf("expected comment match after update, got %v", got)
+	}
+
+	// Deletes remove the entry
+	if err := store.DeleteIssue(ctx, issue.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	var count int
+	if err := store.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues_fts`).Scan(&count); err != nil {
+		t.Fatalf("failed to count index rows: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected empty index after delete, got %d rows", count)
+	}
+}
+
+func TestSearchIssuesRanked_Filters(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	p1 := createSearchIssue(t, store, "Cache invalidation bug", "", 1)
+	p3 := createSearchIssue(t, store, "Cache warmup", "", 3)
+	createSearchIssue(t, store, "Cache metrics", "", 3)
+	if err := store.AddLabel(ctx, p3.ID, "perf", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	priority := 1
+	if got := searchIDs(t, store, "cache", types.IssueFilter{Priority: &priority}); len(got) != 1 || got[0] != p1.ID {
+		t.Errorf("priority filter: got %v, want [%s]", got, p1.ID)
+	}
+	if got := searchIDs(t, store, "cache", types.IssueFilter{Labels: []string{"perf"}}); len(got) != 1 || got[0] != p3.ID {
+		t.Errorf("label filter: got %v, want [%s]", got, p3.ID)
+	}
+	if got := searchIDs(t, store, "cache", types.IssueFilter{Limit: 2}); len(got) != 2 {
+		t.Errorf("limit: got %d results, want 2", len(got))
+	}
+
+	results, err := store.SearchIssuesRanked(ctx, "warmup", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssuesRanked failed: %v", err)
+	}
+	if len(results) != 1 || len(results[0].Labels) != 1 || results[0].Labels[0] != "perf" {
+		t.Errorf("expected labels on result, got %+v", results)
+	}
+}
+
+func TestMigrateFullTextSearch_Backfill(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	issue := createSearchIssue(t, store, "Existing issue", "Written before search existed", 2)
+	if _, err := store.AddIssueComment(ctx, issue.ID, "bob", "Mentions kubernetes"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	// Simulate a database from before the index existed
+	for _, stmt := range []string{
+		`DROP TRIGGER issues_fts_insert`, `DROP TRIGGER issues_fts_update`, `DROP TRIGGER issues_fts_delete`,
+		`DROP TRIGGER comments_fts_insert`, `DROP TRIGGER comments_fts_update`, `DROP TRIGGER comments_fts_delete`,
+		`DROP TABLE issues_fts`,
+	} {
+		if _, err := store.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	store.Close()
+
+	store = newTestStore(t, dbPath)
+	defer store.Close()
+
+	if got := searchIDs(t, store, "kubernetes", types.IssueFilter{}); len(got) != 1 || got[0] != issue.ID {
+		t.Errorf("expected backfilled comment match, got %v", got)
+	}
+	if got := searchIDs(t, store, "existed", types.IssueFilter{}); len(got) != 1 {
+		t.Errorf("expected backfilled description match, got %v", got)
+	}
+}
@@ -0,0 +1,359 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// SnapshotContent is the issue text saved in issue_snapshots.original_content
+// before a Tier 2 compaction overwrites it.
+type SnapshotContent struct {
+	Description        string `json:"description"`
+	Design             string `json:"design,omitempty"`
+	AcceptanceCriteria string `json:"acceptance_criteria,omitempty"`
+	Notes              string `json:"notes,omitempty"`
+}
+
+// ArchivedHistory is the audit trail and discussion saved in
+// issue_snapshots.archived_events when Tier 2 compaction prunes them.
+type ArchivedHistory struct {
+	Events   []*types.Event   `json:"events,omitempty"`
+	Comments []*types.Comment `json:"comments,omitempty"`
+}
+
+// IssueSnapshot is a pre-compaction copy of an issue kept for restore.
+type IssueSnapshot struct {
+	ID              int64
+	IssueID         string
+	SnapshotTime    time.Time
+	CompactionLevel int
+	OriginalSize    int
+	CompressedSize  int
+	Content         SnapshotContent
+	Archived        ArchivedHistory
+}
+
+const tier2IssueQuery = `
+	SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+	       status, priority, issue_type, assignee, estimated_minutes,
+	       created_at, updated_at, closed_at, external_ref, recurrence, due_at, recurred_at
+	FROM issues
+	WHERE id = ?
+`
+
+// ApplyTier2Compaction replaces a Tier 1 issue's text with summary, archives
+// its events and comments into a snapshot, and prunes them from the live
+// tables. Everything happens in one transaction so a failure leaves the issue
+// untouched. The snapshot can be undone with RestoreSnapshot.
+func (s *SQLiteStorage) ApplyTier2Compaction(ctx context.Context, issueID, summary, commitHash string) error {
+	now := time.Now().UTC()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	issue, err := queryIssueOnTx(ctx, tx, tier2IssueQuery, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", issueID, err)
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+
+	var level int
+	var originalSize sql.NullInt64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(compaction_level, 0), original_size FROM issues WHERE id = ?
+	`, issueID).Scan(&level, &originalSize); err != nil {
+		return fmt.Errorf("failed to get compaction level: %w", err)
+	}
+	if level != 1 {
+		return fmt.Errorf("issue %s is at compaction level %d, tier 2 requires level 1", issueID, level)
+	}
+
+	archived, err := loadArchivedHistory(ctx, tx, issueID)
+	if err != nil {
+		return err
+	}
+
+	content := SnapshotContent{
+		Description:        issue.Description,
+		Design:             issue.Design,
+		AcceptanceCriteria: issue.AcceptanceCriteria,
+		Notes:              issue.Notes,
+	}
+	contentJSON, err := json.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot content: %w", err)
+	}
+	archivedJSON, err := json.Marshal(archived)
+	if err != nil {
+		return fmt.Errorf("failed to encode archived events: %w", err)
+	}
+
+	currentSize := len(issue.Description) + len(issue.Design) + len(issue.AcceptanceCriteria) + len(issue.Notes)
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO issue_snapshots
+		  (issue_id, snapshot_time, compaction_level, original_size, compressed_size, original_content, archived_events)
+		VALUES (?, ?, 2, ?, ?, ?, ?)
+	`, issueID, now, currentSize, len(summary), string(contentJSON), string(archivedJSON)); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM events WHERE issue_id = ?`, issueID); err != nil {
+		return fmt.Errorf("failed to prune events: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM comments WHERE issue_id = ?`, issueID); err != nil {
+		return fmt.Errorf("failed to prune comments: %w", err)
+	}
+
+	issue.Description = summary
+	issue.Design = ""
+	issue.AcceptanceCriteria = ""
+	issue.Notes = ""
+	issue.ContentHash = issue.ComputeContentHash()
+
+	var commitHashPtr *string
+	if commitHash != "" {
+		commitHashPtr = &commitHash
+	}
+
+	// original_size keeps the pre-Tier 1 size so stats report the total saving
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE issues
+		SET description = ?, design = '', acceptance_criteria = '', notes = '',
+		    content_hash = ?,
+		    compaction_level = 2,
+		    compacted_at = ?,
+		    compacted_at_commit = COALESCE(?, compacted_at_commit),
+		    updated_at = ?
+		WHERE id = ?
+	`, summary, issue.ContentHash, now, commitHashPtr, now, issueID); err != nil {
+		return fmt.Errorf("failed to apply compaction: %w", err)
+	}
+
+	baseSize := currentSize
+	if originalSize.Valid && originalSize.Int64 > 0 {
+		baseSize = int(originalSize.Int64)
+	}
+	if err := recordCompactionEvent(ctx, tx, issueID, 2, baseSize, len(summary)); err != nil {
+		return err
+	}
+	if err := markDirtyOnTx(ctx, tx, issueID, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetLatestSnapshot returns the most recent snapshot for an issue, or nil if
+// it has none.
+func (s *SQLiteStorage) GetLatestSnapshot(ctx context.Context, issueID string) (*IssueSnapshot, error) {
+	var snap IssueSnapshot
+	var contentJSON string
+	var archivedJSON sql.NullString
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, issue_id, snapshot_time, compaction_level, original_size, compressed_size,
+		       original_content, archived_events
+		FROM issue_snapshots
+		WHERE issue_id = ?
+		ORDER BY id DESC
+		LIMIT 1
+	`, issueID).Scan(&snap.ID, &snap.IssueID, &snap.SnapshotTime, &snap.CompactionLevel,
+		&snap.OriginalSize, &snap.CompressedSize, &contentJSON, &archivedJSON)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(contentJSON), &snap.Content); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot content: %w", err)
+	}
+	if archivedJSON.Valid && archivedJSON.String != "" {
+		if err := json.Unmarshal([]byte(archivedJSON.String), &snap.Archived); err != nil {
+			return nil, fmt.Errorf("failed to decode archived events: %w", err)
+		}
+	}
+	return &snap, nil
+}
+
+// RestoreSnapshot undoes the latest Tier 2 compaction of an issue: the text
+// fields, events and comments are put back, the issue returns to compaction
+// level 1 and the snapshot is deleted.
+func (s *SQLiteStorage) RestoreSnapshot(ctx context.Context, issueID string) error {
+	snap, err := s.GetLatestSnapshot(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if snap == nil {
+		return fmt.Errorf("issue %s has no compaction snapshot", issueID)
+	}
+
+	now := time.Now().UTC()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	issue, err := queryIssueOnTx(ctx, tx, tier2IssueQuery, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", issueID, err)
+	}
+	if issue == nil {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+
+	issue.Description = snap.Content.Description
+	issue.Design = snap.Content.Design
+	issue.AcceptanceCriteria = snap.Content.AcceptanceCriteria
+	issue.Notes = snap.Content.Notes
+	issue.ContentHash = issue.ComputeContentHash()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE issues
+		SET description = ?, design = ?, acceptance_criteria = ?, notes = ?,
+		    content_hash = ?,
+		    compaction_level = ?,
+		    updated_at = ?
+		WHERE id = ?
+	`, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
+		issue.ContentHash, snap.CompactionLevel-1, now, issueID); err != nil {
+		return fmt.Errorf("failed to restore issue: %w", err)
+	}
+
+	// Reinsert in chronological order with the original timestamps
+	for i := len(snap.Archived.Events) - 1; i >= 0; i-- {
+		e := snap.Archived.Events[i]
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, issueID, e.EventType, e.Actor, e.OldValue, e.NewValue, e.Comment, e.CreatedAt); err != nil {
+			return fmt.Errorf("failed to restore event: %w", err)
+		}
+	}
+	for _, c := range snap.Archived.Comments {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO comments (issue_id, author, text, created_at)
+			VALUES (?, ?, ?, ?)
+		`, issueID, c.Author, c.Text, c.CreatedAt); err != nil {
+			return fmt.Errorf("failed to restore comment: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM issue_snapshots WHERE id = ?`, snap.ID); err != nil {
+		return fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+	if err := markDirtyOnTx(ctx, tx, issueID, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// loadArchivedHistory reads all events (newest first, as GetEvents returns
+// them) and comments for an issue.
+func loadArchivedHistory(ctx context.Context, tx *sql.Tx, issueID string) (ArchivedHistory, error) {
+	var archived ArchivedHistory
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE issue_id = ?
+		ORDER BY created_at DESC, id DESC
+	`, issueID)
+	if err != nil {
+		return archived, fmt.Errorf("failed to get events: %w", err)
+	}
+	for rows.Next() {
+		var event types.Event
+		var oldValue, newValue, comment sql.NullString
+		if err := rows.Scan(&event.ID, &event.IssueID, &event.EventType, &event.Actor,
+			&oldValue, &newValue, &comment, &event.CreatedAt); err != nil {
+			_ = rows.Close()
+			return archived, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if oldValue.Valid {
+			event.OldValue = &oldValue.String
+		}
+		if newValue.Valid {
+			event.NewValue = &newValue.String
+		}
+		if comment.Valid {
+			event.Comment = &comment.String
+		}
+		archived.Events = append(archived.Events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return archived, fmt.Errorf("failed to read events: %w", err)
+	}
+	_ = rows.Close()
+
+	rows, err = tx.QueryContext(ctx, `
+		SELECT id, issue_id, author, text, created_at
+		FROM comments
+		WHERE issue_id = ?
+		ORDER BY created_at ASC, id ASC
+	`, issueID)
+	if err != nil {
+		return archived, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		comment := &types.Comment{}
+		if err := rows.Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt); err != nil {
+			return archived, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		archived.Comments = append(archived.Comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		return archived, fmt.Errorf("error iterating comments: %w", err)
+	}
+	return archived, nil
+}
+
+// queryIssueOnTx is queryIssueOnConn for a *sql.Tx.
+func queryIssueOnTx(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (*types.Issue, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	var issue types.Issue
+	if err := scanIssueRow(rows, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+func markDirtyOnTx(ctx context.Context, tx *sql.Tx, issueID string, now time.Time) error {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, issueID, now); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
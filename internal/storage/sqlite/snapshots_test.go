@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestApplyTier2CompactionAndRestore(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		ID:          testIssueBeads1,
+		Title:       "Tier 1 compacted",
+		Description: "**Summary:** Fixed the login timeout. Sessions now refresh.",
+		Notes:       "Shipped in v1.2",
+		Status:      "closed",
+		Priority:    2,
+		IssueType:   "task",
+		ClosedAt:    timePtr(time.Now().Add(-100 * 24 * time.Hour)),
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "alice", "looked into it"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, issue.ID, "bob", "LGTM"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	// Tier 2 requires Tier 1 first
+	if err := store.ApplyTier2Compaction(ctx, issue.ID, "Fixed login timeout.", ""); err == nil {
+		t.Fatal("Expected error for an issue at compaction level 0")
+	}
+	if err := store.ApplyCompaction(ctx, issue.ID, 1, 2000, 60, "abc123"); err != nil {
+		t.Fatalf("ApplyCompaction failed: %v", err)
+	}
+
+	eventsBefore, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+
+	if err := store.ApplyTier2Compaction(ctx, issue.ID, "Fixed login timeout.", "def456"); err != nil {
+		t.Fatalf("ApplyTier2Compaction failed: %v", err)
+	}
+
+	compacted, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if compacted.Description != "Fixed login timeout." || compacted.Notes != "" {
+		t.Errorf("Unexpected compacted text: %q / %q", compacted.Description, compacted.Notes)
+	}
+	if compacted.CompactionLevel != 2 {
+		t.Errorf("Expected compaction level 2, got %d", compacted.CompactionLevel)
+	}
+	if compacted.OriginalSize != 2000 {
+		t.Errorf("Expected original_size to stay 2000, got %d", compacted.OriginalSize)
+	}
+	if compacted.ContentHash != compacted.ComputeContentHash() {
+		t.Error("Content hash not recomputed")
+	}
+
+	// Only the Tier 2 compaction event remains
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 1 || events[0].EventType != types.EventCompacted || !strings.Contains(*events[0].Comment, `"tier":2`) {
+		t.Errorf("Expected a single tier 2 compacted event, got %+v", events)
+	}
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 0 {
+		t.Errorf("Expected comments to be pruned, got %d", len(comments))
+	}
+
+	snap, err := store.GetLatestSnapshot(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetLatestSnapshot failed: %v", err)
+	}
+	if snap == nil || snap.CompactionLevel != 2 || snap.Content.Notes != "Shipped in v1.2" {
+		t.Fatalf("Unexpected snapshot: %+v", snap)
+	}
+	if len(snap.Archived.Events) != len(eventsBefore) || len(snap.Archived.Comments) != 1 {
+		t.Errorf("Expected %d events and 1 comment archived, got %d and %d",
+			len(eventsBefore), len(snap.Archived.Events), len(snap.Archived.Comments))
+	}
+
+	if err := store.RestoreSnapshot(ctx, issue.ID); err != nil {
+		t.Fatalf("RestoreSnapshot failed: %v", err)
+	}
+
+	restored, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if restored.Description != issue.Description || restored.Notes != issue.Notes {
+		t.Errorf("Text not restored: %q / %q", restored.Description, restored.Notes)
+	}
+	if restored.CompactionLevel != 1 {
+		t.Errorf("Expected compaction level 1 after restore, got %d", restored.CompactionLevel)
+	}
+
+	// The archived history is back, after the tier 2 event
+	events, err = store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != len(eventsBefore)+1 {
+		t.Errorf("Expected %d events after restore, got %d", len(eventsBefore)+1, len(events))
+	}
+	comments, err = store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Text != "LGTM" {
+		t.Errorf("Expected comment restored, got %+v", comments)
+	}
+
+	if snap, _ := store.GetLatestSnapshot(ctx, issue.ID); snap != nil {
+		t.Error("Expected snapshot to be deleted after restore")
+	}
+	if err := store.RestoreSnapshot(ctx, issue.ID); err == nil {
+		t.Error("Expected error restoring without a snapshot")
+	}
+}
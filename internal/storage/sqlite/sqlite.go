@@ -15,6 +15,7 @@ import (
 	"time"
 
 	// Import SQLite driver
+	"github.com/shaneholloman/beads/internal/recur"
 	"github.com/shaneholloman/beads/internal/types"
 	_ "modernc.org/sqlite"
 )
@@ -123,6 +124,46 @@ func New(path string) (*SQLiteStorage, error) {
 		return nil, fmt.Errorf("failed to migrate content_hash column: %w", err)
 	}
 
+	// Migrate existing databases to recreate views on top of status_categories
+	if err := migrateStatusCategoryViews(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate status category views: %w", err)
+	}
+
+	// Migrate existing databases to add the full-text search index and its triggers
+	if err := migrateFullTextSearch(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate full-text search index: %w", err)
+	}
+
+	// Migrate existing databases to add last_heartbeat to leases
+	if err := migrateLeaseHeartbeatColumn(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate lease heartbeat column: %w", err)
+	}
+
+	// Migrate existing databases to add recurrence to issues
+	if err := migrateRecurrenceColumn(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate recurrence column: %w", err)
+	}
+
+	// Migrate existing databases to add due_at to issues
+	if err := migrateDueAtColumn(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate due_at column: %w", err)
+	}
+
+	// Migrate existing databases to add recurred_at to issues
+	if err := migrateRecurredAtColumn(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate recurred_at column: %w", err)
+	}
+
+	// Create ready/blocked views (after migrations so stale views are replaced)
+	if _, err := db.Exec(views); err != nil {
+		return nil, fmt.Errorf("failed to initialize views: %w", err)
+	}
+
+	// Register custom workflow statuses from .beads/config.yaml
+	if err := syncStatusCategories(db, types.CurrentWorkflow()); err != nil {
+		return nil, fmt.Errorf("failed to sync workflow statuses: %w", err)
+	}
+
 	// Convert to absolute path for consistency
 	absPath, err := filepath.Abs(path)
 	if err != nil {
@@ -135,6 +176,36 @@ func New(path string) (*SQLiteStorage, error) {
 	}, nil
 }
 
+// NewReadOnly opens an existing database for reading only, such as another
+// repository's. Unlike New it neither creates the file nor initializes or
+// migrates the schema, so a database last opened by an older beads may lack
+// columns until beads runs in its repository.
+func NewReadOnly(path string) (*SQLiteStorage, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	if _, err := os.Stat(absPath); err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// mode=ro refuses writes; the other options match New's
+	connStr := "file:" + absPath + "?mode=ro&_pragma=busy_timeout(30000)&_time_format=sqlite"
+	db, err := sql.Open("sqlite", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &SQLiteStorage{
+		db:     db,
+		dbPath: absPath,
+	}, nil
+}
+
 // migrateDirtyIssuesTable checks if the dirty_issues table exists and creates it if missing.
 // This ensures existing databases created before the incremental export feature get migrated automatically.
 func migrateDirtyIssuesTable(db *sql.DB) error {
@@ -427,6 +498,106 @@ func migrateCompactedAtCommitColumn(db *sql.DB) error {
 	return nil
 }
 
+// migrateLeaseHeartbeatColumn adds the last_heartbeat column to the leases table.
+// This migration is idempotent and safe to run multiple times.
+func migrateLeaseHeartbeatColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('leases')
+		WHERE name = 'last_heartbeat'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check last_heartbeat column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE leases ADD COLUMN last_heartbeat DATETIME`)
+	if err != nil {
+		return fmt.Errorf("failed to add last_heartbeat column: %w", err)
+	}
+
+	return nil
+}
+
+// migrateRecurrenceColumn adds the recurrence column to the issues table.
+// This migration is idempotent and safe to run multiple times.
+func migrateRecurrenceColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'recurrence'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check recurrence column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN recurrence TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("failed to add recurrence column: %w", err)
+	}
+
+	return nil
+}
+
+// migrateDueAtColumn adds the due_at column to the issues table.
+// This migration is idempotent and safe to run multiple times.
+func migrateDueAtColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'due_at'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check due_at column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN due_at DATETIME`)
+	if err != nil {
+		return fmt.Errorf("failed to add due_at column: %w", err)
+	}
+
+	return nil
+}
+
+// migrateRecurredAtColumn adds the recurred_at column to the issues table.
+// This migration is idempotent and safe to run multiple times.
+func migrateRecurredAtColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'recurred_at'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check recurred_at column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN recurred_at DATETIME`)
+	if err != nil {
+		return fmt.Errorf("failed to add recurred_at column: %w", err)
+	}
+
+	return nil
+}
+
 // migrateExportHashesTable ensures the export_hashes table exists for timestamp-only dedup (beads-164)
 func migrateExportHashesTable(db *sql.DB) error {
 	// Check if export_hashes table exists
@@ -782,14 +953,14 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		INSERT INTO issues (
 			id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
-			created_at, updated_at, closed_at, external_ref
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			created_at, updated_at, closed_at, external_ref, recurrence, due_at, recurred_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
 		issue.AcceptanceCriteria, issue.Notes, issue.Status,
 		issue.Priority, issue.IssueType, issue.Assignee,
 		issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
-		issue.ClosedAt, issue.ExternalRef,
+		issue.ClosedAt, issue.ExternalRef, issue.Recurrence, issue.DueAt, issue.RecurredAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert issue: %w", err)
@@ -942,8 +1113,8 @@ func bulkInsertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue
 		INSERT INTO issues (
 			id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
-			created_at, updated_at, closed_at, external_ref
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			created_at, updated_at, closed_at, external_ref, recurrence, due_at, recurred_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
@@ -956,7 +1127,7 @@ func bulkInsertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue
 			issue.AcceptanceCriteria, issue.Notes, issue.Status,
 			issue.Priority, issue.IssueType, issue.Assignee,
 			issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
-			issue.ClosedAt, issue.ExternalRef,
+			issue.ClosedAt, issue.ExternalRef, issue.Recurrence, issue.DueAt, issue.RecurredAt,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
@@ -1126,6 +1297,8 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	var estimatedMinutes sql.NullInt64
 	var assignee sql.NullString
 	var externalRef sql.NullString
+	var dueAt sql.NullTime
+	var recurredAt sql.NullTime
 	var compactedAt sql.NullTime
 	var originalSize sql.NullInt64
 
@@ -1134,7 +1307,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	err := s.db.QueryRowContext(ctx, `
 		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, updated_at, closed_at, external_ref,
+		       created_at, updated_at, closed_at, external_ref, recurrence, due_at, recurred_at,
 		       compaction_level, compacted_at, compacted_at_commit, original_size
 		FROM issues
 		WHERE id = ?
@@ -1142,7 +1315,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
 		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
+		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &issue.Recurrence, &dueAt, &recurredAt,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize,
 	)
 
@@ -1169,6 +1342,12 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	if externalRef.Valid {
 		issue.ExternalRef = &externalRef.String
 	}
+	if dueAt.Valid {
+		issue.DueAt = &dueAt.Time
+	}
+	if recurredAt.Valid {
+		issue.RecurredAt = &recurredAt.Time
+	}
 	if compactedAt.Valid {
 		issue.CompactedAt = &compactedAt.Time
 	}
@@ -1202,6 +1381,9 @@ var allowedUpdateFields = map[string]bool{
 	"issue_type":          true,
 	"estimated_minutes":   true,
 	"external_ref":        true,
+	"recurrence":          true,
+	"due_at":              true,
+	"recurred_at":         true,
 }
 
 // validatePriority validates a priority value
@@ -1254,6 +1436,45 @@ func validateEstimatedMinutes(value interface{}) error {
 	return nil
 }
 
+// validateRecurrence validates a recurrence rule; empty stops recurring
+func validateRecurrence(value interface{}) error {
+	if rule, ok := value.(string); ok && rule != "" {
+		if _, err := recur.Parse(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDueAt validates a due_at value: a time, or nil to clear it
+func validateDueAt(value interface{}) error {
+	switch value.(type) {
+	case time.Time, *time.Time, nil:
+		return nil
+	}
+	return fmt.Errorf("invalid due_at: %v", value)
+}
+
+// validateRecurredAt validates a recurred_at value: a time, or nil to clear it
+func validateRecurredAt(value interface{}) error {
+	switch value.(type) {
+	case time.Time, *time.Time, nil:
+		return nil
+	}
+	return fmt.Errorf("invalid recurred_at: %v", value)
+}
+
+// dueAtFromValue converts a validated due_at update value to a field value
+func dueAtFromValue(value interface{}) *time.Time {
+	switch v := value.(type) {
+	case time.Time:
+		return &v
+	case *time.Time:
+		return v
+	}
+	return nil
+}
+
 // fieldValidators maps field names to their validation functions
 var fieldValidators = map[string]func(interface{}) error{
 	"priority":          validatePriority,
@@ -1261,6 +1482,9 @@ var fieldValidators = map[string]func(interface{}) error{
 	"issue_type":        validateIssueType,
 	"title":             validateTitle,
 	"estimated_minutes": validateEstimatedMinutes,
+	"recurrence":        validateRecurrence,
+	"due_at":            validateDueAt,
+	"recurred_at":       validateRecurredAt,
 }
 
 // validateFieldUpdate validates a field update value
@@ -1299,18 +1523,15 @@ func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 		return setClauses, args
 	}
 
-	// Handle both string and types.Status
-	var newStatus string
-	switch v := statusVal.(type) {
-	case string:
-		newStatus = v
-	case types.Status:
-		newStatus = string(v)
-	default:
+	newStatus, ok := statusFromValue(statusVal)
+	if !ok {
 		return setClauses, args
 	}
 
-	if newStatus == string(types.StatusClosed) {
+	// Only the built-in closed status carries closed_at (see the CHECK constraint);
+	// custom statuses in the done category are terminal without a closed_at timestamp,
+	// which keeps them out of lead-time stats and compaction (documented in docs/config.md).
+	if newStatus == types.StatusClosed {
 		// Changing to closed: ensure closed_at is set
 		if _, hasClosedAt := updates["closed_at"]; !hasClosedAt {
 			now := time.Now()
@@ -1339,6 +1560,15 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return fmt.Errorf("issue %s not found", id)
 	}
 
+	// Enforce workflow transition rules from .beads/config.yaml
+	if statusVal, hasStatus := updates["status"]; hasStatus {
+		if newStatus, ok := statusFromValue(statusVal); ok {
+			if err := types.CurrentWorkflow().CanTransition(oldIssue.Status, newStatus); err != nil {
+				return fmt.Errorf("cannot update %s: %w", id, err)
+			}
+		}
+	}
+
 	// Build update query with validated field names
 	setClauses := []string{"updated_at = ?"}
 	args := []interface{}{time.Now()}
@@ -1363,7 +1593,7 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 
 	// Recompute content_hash if any content fields changed (beads-95)
 	contentChanged := false
-	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref"}
+	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "recurrence", "due_at"}
 	for _, field := range contentFields {
 		if _, exists := updates[field]; exists {
 			contentChanged = true
@@ -1414,6 +1644,10 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 					str := value.(string)
 					updatedIssue.ExternalRef = &str
 				}
+			case "recurrence":
+				updatedIssue.Recurrence = value.(string)
+			case "due_at":
+				updatedIssue.DueAt = dueAtFromValue(value)
 			}
 		}
 		newHash := updatedIssue.ComputeContentHash()
@@ -1437,6 +1671,10 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return fmt.Errorf("failed to update issue: %w", err)
 	}
 
+	if err := dropLeaseOnUpdate(ctx, tx, id, updates); err != nil {
+		return err
+	}
+
 	// Record event
 	oldData, err := json.Marshal(oldIssue)
 	if err != nil {
@@ -1514,6 +1752,11 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update depends_on_id in dependencies: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `UPDATE external_dependencies SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update external_dependencies: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `UPDATE events SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
 	if err != nil {
 		return fmt.Errorf("failed to update events: %w", err)
@@ -1546,6 +1789,16 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update compaction_snapshots: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `UPDATE leases SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update leases: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE pending_compactions SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update pending_compactions: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
@@ -1596,6 +1849,18 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	// Enforce workflow transition rules from .beads/config.yaml
+	var oldStatus string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM issues WHERE id = ?`, id).Scan(&oldStatus)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get issue status: %w", err)
+	}
+	if err == nil {
+		if err := types.CurrentWorkflow().CanTransition(types.Status(oldStatus), types.StatusClosed); err != nil {
+			return fmt.Errorf("cannot close %s: %w", id, err)
+		}
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?
 		WHERE id = ?
@@ -1604,6 +1869,12 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 		return fmt.Errorf("failed to close issue: %w", err)
 	}
 
+	// A closed issue is no longer held by anyone
+	_, err = tx.ExecContext(ctx, `DELETE FROM leases WHERE issue_id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO events (issue_id, event_type, actor, comment)
 		VALUES (?, ?, ?, ?)
@@ -1954,36 +2225,80 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		args = append(args, pattern, pattern, pattern)
 	}
 
+	filterClauses, filterArgs, err := issueFilterClauses(filter, "")
+	if err != nil {
+		return nil, err
+	}
+	whereClauses = append(whereClauses, filterClauses...)
+	args = append(args, filterArgs...)
+
+	whereSQL := ""
+	if len(whereClauses) > 0 {
+		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	limitSQL := ""
+	if filter.Limit > 0 {
+		limitSQL = " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	querySQL := fmt.Sprintf(`
+		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref, recurrence, due_at, recurred_at
+		FROM issues
+		%s
+		ORDER BY priority ASC, created_at DESC
+		%s
+	`, whereSQL, limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
+// issueFilterClauses translates an IssueFilter (except Limit) into WHERE clauses.
+// col is prepended to every column name, e.g. "i." when issues is aliased in a join.
+func issueFilterClauses(filter types.IssueFilter, col string) ([]string, []interface{}, error) {
+	whereClauses := []string{}
+	args := []interface{}{}
+
 	if filter.TitleSearch != "" {
-		whereClauses = append(whereClauses, "title LIKE ?")
+		whereClauses = append(whereClauses, col+"title LIKE ?")
 		pattern := "%" + filter.TitleSearch + "%"
 		args = append(args, pattern)
 	}
 
 	if filter.Status != nil {
-		whereClauses = append(whereClauses, "status = ?")
+		whereClauses = append(whereClauses, col+"status = ?")
 		args = append(args, *filter.Status)
 	}
 
 	if filter.Priority != nil {
-		whereClauses = append(whereClauses, "priority = ?")
+		whereClauses = append(whereClauses, col+"priority = ?")
 		args = append(args, *filter.Priority)
 	}
 
 	if filter.IssueType != nil {
-		whereClauses = append(whereClauses, "issue_type = ?")
+		whereClauses = append(whereClauses, col+"issue_type = ?")
 		args = append(args, *filter.IssueType)
 	}
 
 	if filter.Assignee != nil {
-		whereClauses = append(whereClauses, "assignee = ?")
+		whereClauses = append(whereClauses, col+"assignee = ?")
 		args = append(args, *filter.Assignee)
 	}
 
 	// Label filtering: issue must have ALL specified labels
 	if len(filter.Labels) > 0 {
 		for _, label := range filter.Labels {
-			whereClauses = append(whereClauses, "id IN (SELECT issue_id FROM labels WHERE label = ?)")
+			whereClauses = append(whereClauses, col+"id IN (SELECT issue_id FROM labels WHERE label = ?)")
 			args = append(args, label)
 		}
 	}
@@ -1995,7 +2310,7 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 			placeholders[i] = "?"
 			args = append(args, label)
 		}
-		whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM labels WHERE label IN (%s))", strings.Join(placeholders, ", ")))
+		whereClauses = append(whereClauses, fmt.Sprintf("%sid IN (SELECT issue_id FROM labels WHERE label IN (%s))", col, strings.Join(placeholders, ", ")))
 	}
 
 	// ID filtering: match specific issue IDs
@@ -2005,38 +2320,19 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 			placeholders[i] = "?"
 			args = append(args, id)
 		}
-		whereClauses = append(whereClauses, fmt.Sprintf("id IN (%s)", strings.Join(placeholders, ", ")))
+		whereClauses = append(whereClauses, fmt.Sprintf("%sid IN (%s)", col, strings.Join(placeholders, ", ")))
 	}
 
-	whereSQL := ""
-	if len(whereClauses) > 0 {
-		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
-	}
-
-	limitSQL := ""
-	if filter.Limit > 0 {
-		limitSQL = " LIMIT ?"
-		args = append(args, filter.Limit)
-	}
-
-	// #nosec G201 - safe SQL with controlled formatting
-	querySQL := fmt.Sprintf(`
-		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
-		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, updated_at, closed_at, external_ref
-		FROM issues
-		%s
-		ORDER BY priority ASC, created_at DESC
-		%s
-	`, whereSQL, limitSQL)
-
-	rows, err := s.db.QueryContext(ctx, querySQL, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search issues: %w", err)
+	if filter.Expr != nil {
+		clause, exprArgs, err := compileFilterExpr(filter.Expr, col)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		whereClauses = append(whereClauses, clause)
+		args = append(args, exprArgs...)
 	}
-	defer func() { _ = rows.Close() }()
 
-	return s.scanIssues(ctx, rows)
+	return whereClauses, args, nil
 }
 
 // SetConfig sets a configuration value
@@ -1039,6 +1039,38 @@ func TestGetStatistics(t *testing.T) {
 	}
 }
 
+func TestGetStatisticsOverdue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	pastDue := time.Now().Add(-time.Hour)
+	futureDue := time.Now().Add(time.Hour)
+	issues := []*types.Issue{
+		{Title: "Late", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DueAt: &pastDue},
+		{Title: "Late and started", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeTask, DueAt: &pastDue},
+		{Title: "On time", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DueAt: &futureDue},
+		{Title: "Late but closed", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, DueAt: &pastDue},
+		{Title: "No deadline", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	for _, issue := range issues {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, issues[3].ID, "Done", "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	stats, err := store.GetStatistics(ctx)
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	if stats.OverdueIssues != 2 {
+		t.Errorf("Expected 2 overdue issues (closed issues are never overdue), got %d", stats.OverdueIssues)
+	}
+}
+
 // Note: High-concurrency stress tests were removed as the pure Go SQLite driver
 // (modernc.org/sqlite) can experience "database is locked" errors under extreme
 // parallel load (100+ simultaneous operations). This is a known limitation and
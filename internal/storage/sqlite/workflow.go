@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// SQL fragments selecting statuses by category from the status_categories table.
+// Use "status NOT IN " + doneStatusesSQL for "still blocks dependents" so that
+// statuses unknown to this database keep blocking.
+const (
+	doneStatusesSQL   = `(SELECT status FROM status_categories WHERE category = 'done')`
+	activeStatusesSQL = `(SELECT status FROM status_categories WHERE category = 'active')`
+)
+
+// migrateStatusCategoryViews drops the ready_issues and blocked_issues views if they were
+// created before the status_categories table existed, so they are recreated from views.
+func migrateStatusCategoryViews(db *sql.DB) error {
+	for _, name := range []string{"ready_issues", "blocked_issues"} {
+		var viewSQL string
+		err := db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'view' AND name = ?`, name).Scan(&viewSQL)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s view: %w", name, err)
+		}
+		if strings.Contains(viewSQL, "status_categories") {
+			continue
+		}
+		// #nosec G202 - view name comes from a fixed list
+		if _, err := db.Exec("DROP VIEW IF EXISTS " + name); err != nil {
+			return fmt.Errorf("failed to drop %s view: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// syncStatusCategories makes the status_categories table match the workflow:
+// custom statuses are inserted or re-categorized and stale custom rows are removed.
+// Built-in rows are never touched. Writes only happen when something changed.
+func syncStatusCategories(db *sql.DB, wf *types.Workflow) error {
+	rows, err := db.Query(`SELECT status, category FROM status_categories`)
+	if err != nil {
+		return fmt.Errorf("failed to read status categories: %w", err)
+	}
+	existing := make(map[types.Status]types.StatusCategory)
+	for rows.Next() {
+		var status, category string
+		if err := rows.Scan(&status, &category); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan status category: %w", err)
+		}
+		existing[types.Status(status)] = types.StatusCategory(category)
+	}
+	_ = rows.Close()
+
+	var upserts, deletes []types.Status
+	for _, status := range wf.CustomStatuses() {
+		category, _ := wf.Category(status)
+		if existing[status] != category {
+			upserts = append(upserts, status)
+		}
+	}
+	for status := range existing {
+		if _, ok := wf.Category(status); !ok && !status.IsBuiltin() {
+			deletes = append(deletes, status)
+		}
+	}
+	if len(upserts) == 0 && len(deletes) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, status := range upserts {
+		category, _ := wf.Category(status)
+		if _, err := tx.Exec(`
+			INSERT INTO status_categories (status, category) VALUES (?, ?)
+			ON CONFLICT (status) DO UPDATE SET category = excluded.category
+		`, status, category); err != nil {
+			return fmt.Errorf("failed to save status category for %s: %w", status, err)
+		}
+	}
+	for _, status := range deletes {
+		if _, err := tx.Exec(`DELETE FROM status_categories WHERE status = ?`, status); err != nil {
+			return fmt.Errorf("failed to remove status category for %s: %w", status, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SyncWorkflow refreshes the status_categories table from the current workflow.
+// New calls this automatically; call it again after types.SetWorkflow on an open store.
+func (s *SQLiteStorage) SyncWorkflow() error {
+	return syncStatusCategories(s.db, types.CurrentWorkflow())
+}
+
+// statusFromValue extracts a status from an update value (string or types.Status)
+func statusFromValue(value interface{}) (types.Status, bool) {
+	switch v := value.(type) {
+	case string:
+		return types.Status(v), true
+	case types.Status:
+		return v, true
+	}
+	return "", false
+}
@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// setupWorkflowTestDB installs a workflow with custom statuses and opens a fresh store
+func setupWorkflowTestDB(t *testing.T) *SQLiteStorage {
+	t.Helper()
+
+	wf := types.DefaultWorkflow()
+	if err := wf.AddStatus("in_review", types.CategoryActive); err != nil {
+		t.Fatal(err)
+	}
+	if err := wf.AddStatus("deferred", types.CategoryBlocked); err != nil {
+		t.Fatal(err)
+	}
+	if err := wf.AddStatus("wontfix", types.CategoryDone); err != nil {
+		t.Fatal(err)
+	}
+	if err := wf.SetTransitions(types.StatusClosed, []types.Status{types.StatusOpen}); err != nil {
+		t.Fatal(err)
+	}
+	types.SetWorkflow(wf)
+	t.Cleanup(func() { types.SetWorkflow(nil) })
+
+	store := newTestStore(t, filepath.Join(t.TempDir(), "test.db"))
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestCustomStatusReadyAndBlocked(t *testing.T) {
+	store := setupWorkflowTestDB(t)
+	ctx := context.Background()
+
+	review := &types.Issue{Title: "In review", Status: "in_review", Priority: 1, IssueType: types.TypeTask}
+	deferred := &types.Issue{Title: "Deferred", Status: "deferred", Priority: 1, IssueType: types.TypeTask}
+	wontfix := &types.Issue{Title: "Won't fix", Status: "wontfix", Priority: 1, IssueType: types.TypeTask}
+	blockedByDeferred := &types.Issue{Title: "Waits on deferred", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blockedByWontfix := &types.Issue{Title: "Waits on wontfix", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+
+	for _, issue := range []*types.Issue{review, deferred, wontfix, blockedByDeferred, blockedByWontfix} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", issue.Title, err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blockedByDeferred.ID, DependsOnID: deferred.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: blockedByWontfix.ID, DependsOnID: wontfix.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatal(err)
+	}
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	readyIDs := make(map[string]bool)
+	for _, issue := range ready {
+		readyIDs[issue.ID] = true
+	}
+	if !readyIDs[review.ID] {
+		t.Errorf("active custom status %s should be in ready work", review.ID)
+	}
+	if readyIDs[deferred.ID] {
+		t.Errorf("blocked-category status %s should not be in ready work", deferred.ID)
+	}
+	if readyIDs[blockedByDeferred.ID] {
+		t.Errorf("%s is blocked by a deferred issue and should not be ready", blockedByDeferred.ID)
+	}
+	if !readyIDs[blockedByWontfix.ID] {
+		t.Errorf("%s depends on a done-category issue and should be ready", blockedByWontfix.ID)
+	}
+
+	blocked, err := store.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(blocked) != 1 || blocked[0].ID != blockedByDeferred.ID {
+		t.Errorf("expected only %s to be blocked, got %d blocked issues", blockedByDeferred.ID, len(blocked))
+	}
+
+	stats, err := store.GetStatistics(ctx)
+	if err != nil {
+		t.Fatalf("GetStatistics failed: %v", err)
+	}
+	if stats.InProgressIssues != 1 {
+		t.Errorf("InProgressIssues = %d, want 1 (in_review)", stats.InProgressIssues)
+	}
+	if stats.ClosedIssues != 1 {
+		t.Errorf("ClosedIssues = %d, want 1 (wontfix)", stats.ClosedIssues)
+	}
+	if stats.BlockedIssues != 1 {
+		t.Errorf("BlockedIssues = %d, want 1", stats.BlockedIssues)
+	}
+}
+
+func TestCustomStatusTransitions(t *testing.T) {
+	store := setupWorkflowTestDB(t)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Transition me", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": "in_review"}, "test-user"); err != nil {
+		t.Fatalf("open -> in_review should be allowed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": "bogus"}, "test-user"); err == nil {
+		t.Error("unknown status should be rejected")
+	}
+	if err := store.CloseIssue(ctx, issue.ID, "done", "test-user"); err != nil {
+		t.Fatalf("in_review -> closed should be allowed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": "in_review"}, "test-user"); err == nil {
+		t.Error("closed -> in_review should be rejected by workflow")
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusOpen)}, "test-user"); err != nil {
+		t.Fatalf("closed -> open should be allowed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != types.StatusOpen || got.ClosedAt != nil {
+		t.Errorf("expected reopened issue with no closed_at, got status=%s closed_at=%v", got.Status, got.ClosedAt)
+	}
+}
+
+func TestSyncStatusCategoriesRemovesStaleStatuses(t *testing.T) {
+	store := setupWorkflowTestDB(t)
+
+	// Drop the custom statuses and resync
+	types.SetWorkflow(nil)
+	if err := store.SyncWorkflow(); err != nil {
+		t.Fatalf("SyncWorkflow failed: %v", err)
+	}
+
+	var count int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM status_categories`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 {
+		t.Errorf("expected only the 4 built-in statuses after resync, got %d", count)
+	}
+}
+
+func TestMigrateStatusCategoryViews(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store := newTestStore(t, dbPath)
+
+	// Simulate a view created by an older version
+	if _, err := store.db.Exec(`DROP VIEW blocked_issues`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.db.Exec(`CREATE VIEW blocked_issues AS SELECT * FROM issues WHERE status IN ('open', 'in_progress', 'blocked')`); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	store = newTestStore(t, dbPath)
+	defer store.Close()
+
+	var viewSQL string
+	if err := store.db.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'view' AND name = 'blocked_issues'`).Scan(&viewSQL); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(viewSQL, "status_categories") {
+		t.Errorf("blocked_issues view was not recreated: %s", viewSQL)
+	}
+}
@@ -4,6 +4,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/shaneholloman/beads/internal/types"
 )
@@ -17,6 +18,7 @@ type Storage interface {
 	UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error
 	CloseIssue(ctx context.Context, id string, reason string, actor string) error
 	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	SearchIssuesRanked(ctx context.Context, query string, filter types.IssueFilter) ([]*types.SearchResult, error) // Full-text, best match first
 
 	// Dependencies
 	AddDependency(ctx context.Context, dep *types.Dependency, actor string) error
@@ -39,6 +41,13 @@ type Storage interface {
 	GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error)
 	GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error)
 
+	// Leases (atomic work assignment for agents)
+	ClaimReadyWork(ctx context.Context, filter types.WorkFilter, agentID string, leaseTTL time.Duration) (*types.Claim, error) // nil if nothing is ready
+	GetLeases(ctx context.Context) ([]*types.Lease, error)
+	ReleaseExpiredLeases(ctx context.Context, before time.Time, actor string) ([]*types.Lease, error)    // Returns the released leases
+	ReleaseUnleasedWork(ctx context.Context, before time.Time, actor string) ([]*types.Issue, error)     // in_progress with no lease, not updated since before
+	Heartbeat(ctx context.Context, issueID, holder string, leaseTTL time.Duration) (*types.Lease, error) // Renews (or starts) the holder's lease
+
 	// Events
 	AddComment(ctx context.Context, issueID, actor, comment string) error
 	GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error)
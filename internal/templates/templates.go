@@ -0,0 +1,332 @@
+// Package templates loads the issue templates used by 'beads create --template'.
+// Templates live in .beads/templates/<name>.yaml and prefill an issue's fields,
+// optionally with child issues and dependencies between them.
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/shaneholloman/beads/internal/types"
+	"github.com/spf13/viper"
+)
+
+// Dir is the templates directory, relative to .beads/
+const Dir = "templates"
+
+// Issue holds the fields a template fills in. Unset fields keep the usual
+// 'beads create' defaults.
+type Issue struct {
+	Title       string   `mapstructure:"title" json:"title,omitempty"`
+	Description string   `mapstructure:"description" json:"description,omitempty"`
+	Design      string   `mapstructure:"design" json:"design,omitempty"`
+	Acceptance  string   `mapstructure:"acceptance" json:"acceptance,omitempty"`
+	Type        string   `mapstructure:"type" json:"type,omitempty"`
+	Priority    *int     `mapstructure:"priority" json:"priority,omitempty"`
+	Assignee    string   `mapstructure:"assignee" json:"assignee,omitempty"`
+	Labels      []string `mapstructure:"labels" json:"labels,omitempty"`
+	Estimate    *int     `mapstructure:"estimate" json:"estimate,omitempty"` // Minutes
+}
+
+// Child is an issue created under the templated one
+type Child struct {
+	Issue     `mapstructure:",squash"`
+	Key       string   `mapstructure:"key" json:"key,omitempty"`               // Names the child in other children's depends_on
+	DependsOn []string `mapstructure:"depends_on" json:"depends_on,omitempty"` // Keys of sibling children that block this one
+}
+
+// Template is a named issue template
+type Template struct {
+	Issue    `mapstructure:",squash"`
+	Name     string            `mapstructure:"-" json:"name"`
+	Summary  string            `mapstructure:"summary" json:"summary,omitempty"` // What the template is for
+	Vars     map[string]string `mapstructure:"vars" json:"vars,omitempty"`       // Variables and their defaults; empty means required
+	Children []Child           `mapstructure:"children" json:"children,omitempty"`
+}
+
+var (
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+	varPattern  = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+)
+
+// Load reads the template called name from beadsDir/templates.
+//
+// Example (.beads/templates/release-checklist.yaml):
+//
+//	summary: Steps for cutting a release
+//	vars:
+//	  version:              # Required: beads create --template release-checklist --var version=1.4
+//	  branch: main
+//	title: Release {{version}}
+//	type: epic
+//	labels: [release]
+//	acceptance: v{{version}} is tagged and published
+//	children:
+//	  - key: changelog
+//	    title: Update CHANGELOG for {{version}}
+//	  - key: tag
+//	    title: Tag v{{version}} on {{branch}}
+//	    depends_on: [changelog]
+//
+// Text fields may use {{var}} placeholders for the declared variables.
+// Children become parent-child dependents of the created issue.
+func Load(beadsDir, name string) (*Template, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid template name %q", name)
+	}
+	dir := filepath.Join(beadsDir, Dir)
+	for _, ext := range []string{".yaml", ".yml"} {
+		path := filepath.Join(dir, name+ext)
+		data, err := os.ReadFile(path) // #nosec G304 - path built from a validated name
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		t, err := Parse(name, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return t, nil
+	}
+
+	names, _ := listNames(dir)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("template %q not found (no templates in %s)", name, dir)
+	}
+	return nil, fmt.Errorf("template %q not found (available: %s)", name, strings.Join(names, ", "))
+}
+
+// List loads every template in beadsDir/templates, sorted by name. A missing
+// directory yields no templates.
+func List(beadsDir string) ([]*Template, error) {
+	names, err := listNames(filepath.Join(beadsDir, Dir))
+	if err != nil {
+		return nil, err
+	}
+	templates := make([]*Template, 0, len(names))
+	for _, name := range names {
+		t, err := Load(beadsDir, name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
+
+func listNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+	var names []string
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		name := strings.TrimSuffix(entry.Name(), ext)
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") || !namePattern.MatchString(name) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Parse parses and validates a YAML template
+func Parse(name string, data []byte) (*Template, error) {
+	cfg := viper.New()
+	cfg.SetConfigType("yaml")
+	if err := cfg.ReadConfig(strings.NewReader(string(data))); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	var t Template
+	rejectUnknown := func(c *mapstructure.DecoderConfig) { c.ErrorUnused = true }
+	if err := cfg.Unmarshal(&t, rejectUnknown); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	// Variables declared without a default decode to nothing; keep them as required
+	for v := range cfg.GetStringMap("vars") {
+		if _, ok := t.Vars[v]; !ok {
+			if t.Vars == nil {
+				t.Vars = make(map[string]string)
+			}
+			t.Vars[v] = ""
+		}
+	}
+	t.Name = name
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (t *Template) validate() error {
+	if err := t.Issue.validate(t.Vars); err != nil {
+		return err
+	}
+	keys := make(map[string]bool)
+	for i, c := range t.Children {
+		if c.Title == "" {
+			return fmt.Errorf("children[%d]: title is required", i)
+		}
+		if err := c.Issue.validate(t.Vars); err != nil {
+			return fmt.Errorf("children[%d]: %w", i, err)
+		}
+		if c.Key == "" {
+			continue
+		}
+		if keys[c.Key] {
+			return fmt.Errorf("children[%d]: duplicate key %q", i, c.Key)
+		}
+		keys[c.Key] = true
+	}
+	for i, c := range t.Children {
+		for _, key := range c.DependsOn {
+			if !keys[key] {
+				return fmt.Errorf("children[%d]: depends_on unknown key %q", i, key)
+			}
+			if key == c.Key {
+				return fmt.Errorf("children[%d]: %q depends on itself", i, key)
+			}
+		}
+	}
+	_, err := t.childOrder()
+	return err
+}
+
+func (i *Issue) validate(vars map[string]string) error {
+	if i.Type != "" && !types.IssueType(i.Type).IsValid() {
+		return fmt.Errorf("invalid type %q", i.Type)
+	}
+	if i.Priority != nil && (*i.Priority < 0 || *i.Priority > 4) {
+		return fmt.Errorf("priority must be between 0 and 4 (got %d)", *i.Priority)
+	}
+	if i.Estimate != nil && *i.Estimate < 0 {
+		return fmt.Errorf("estimate cannot be negative")
+	}
+	for _, text := range i.texts() {
+		for _, m := range varPattern.FindAllStringSubmatch(*text, -1) {
+			if _, ok := vars[strings.ToLower(m[1])]; !ok {
+				return fmt.Errorf("undeclared variable %q (declare it under vars)", m[1])
+			}
+		}
+	}
+	return nil
+}
+
+// texts returns the fields that may hold {{var}} placeholders
+func (i *Issue) texts() []*string {
+	texts := []*string{&i.Title, &i.Description, &i.Design, &i.Acceptance, &i.Assignee}
+	for j := range i.Labels {
+		texts = append(texts, &i.Labels[j])
+	}
+	return texts
+}
+
+// childOrder returns the indexes of the children with each one after the
+// siblings it depends on, otherwise keeping file order
+func (t *Template) childOrder() ([]int, error) {
+	index := make(map[string]int)
+	for i, c := range t.Children {
+		if c.Key != "" {
+			index[c.Key] = i
+		}
+	}
+	order := make([]int, 0, len(t.Children))
+	placed := make([]bool, len(t.Children))
+	// Repeatedly take the first child whose dependencies are all placed
+	for len(order) < len(t.Children) {
+		next := -1
+		for i, c := range t.Children {
+			if !placed[i] && !slices.ContainsFunc(c.DependsOn, func(key string) bool { return !placed[index[key]] }) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf("children have a dependency cycle")
+		}
+		placed[next] = true
+		order = append(order, next)
+	}
+	return order, nil
+}
+
+// ParseVars parses --var flags of the form key=value
+func ParseVars(specs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		key, value, ok := strings.Cut(spec, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid variable %q (expected key=value)", spec)
+		}
+		vars[strings.ToLower(key)] = value
+	}
+	return vars, nil
+}
+
+// Render fills in the template's variables from vars over the declared
+// defaults. The result's children are ordered so each comes after the
+// siblings it depends on. Variable names are case-insensitive.
+func (t *Template) Render(vars map[string]string) (*Template, error) {
+	values := make(map[string]string, len(t.Vars))
+	for name, def := range t.Vars {
+		values[name] = def
+	}
+	for name, value := range vars {
+		name = strings.ToLower(name)
+		if _, ok := t.Vars[name]; !ok {
+			return nil, fmt.Errorf("template %q has no variable %q", t.Name, name)
+		}
+		values[name] = value
+	}
+	var missing []string
+	for name, value := range values {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("template %q needs --var for: %s", t.Name, strings.Join(missing, ", "))
+	}
+
+	order, err := t.childOrder()
+	if err != nil {
+		return nil, err
+	}
+	out := *t
+	out.Vars = values
+	out.Issue = t.Issue.render(values)
+	out.Children = make([]Child, 0, len(t.Children))
+	for _, i := range order {
+		c := t.Children[i]
+		c.Issue = c.Issue.render(values)
+		out.Children = append(out.Children, c)
+	}
+	return &out, nil
+}
+
+func (i Issue) render(values map[string]string) Issue {
+	i.Labels = append([]string(nil), i.Labels...)
+	for _, text := range i.texts() {
+		*text = varPattern.ReplaceAllStringFunc(*text, func(m string) string {
+			return values[strings.ToLower(varPattern.FindStringSubmatch(m)[1])]
+		})
+	}
+	return i
+}
@@ -0,0 +1,142 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const releaseTemplate = `
+summary: Steps for cutting a release
+vars:
+  version:
+  Branch: main
+title: Release {{version}}
+type: epic
+priority: 1
+labels: [release, "v{{ version }}"]
+acceptance: v{{version}} is published
+children:
+  - key: publish
+    title: Publish v{{version}}
+    depends_on: [tag]
+  - key: changelog
+    title: Update CHANGELOG for {{version}}
+    estimate: 30
+  - key: tag
+    title: Tag v{{version}} on {{branch}}
+    type: chore
+    depends_on: [changelog]
+  - title: Announce {{version}}
+`
+
+func writeTemplate(t *testing.T, beadsDir, file, content string) {
+	t.Helper()
+	dir := filepath.Join(beadsDir, Dir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("failed to create templates dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+}
+
+func TestLoadAndRender(t *testing.T) {
+	beadsDir := t.TempDir()
+	writeTemplate(t, beadsDir, "release-checklist.yaml", releaseTemplate)
+	writeTemplate(t, beadsDir, "bug.yml", "summary: Bug report\ntype: bug\nlabels: [bug]\n")
+	writeTemplate(t, beadsDir, "notes.txt", "not a template")
+
+	tmpl, err := Load(beadsDir, "release-checklist")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if tmpl.Name != "release-checklist" || tmpl.Summary != "Steps for cutting a release" || *tmpl.Priority != 1 {
+		t.Errorf("unexpected template: %+v", tmpl)
+	}
+
+	if _, err := tmpl.Render(nil); err == nil || !strings.Contains(err.Error(), "--var for: version") {
+		t.Errorf("expected a missing variable error, got %v", err)
+	}
+	if _, err := tmpl.Render(map[string]string{"version": "1.4", "colour": "red"}); err == nil || !strings.Contains(err.Error(), `no variable "colour"`) {
+		t.Errorf("expected an unknown variable error, got %v", err)
+	}
+
+	r, err := tmpl.Render(map[string]string{"VERSION": "1.4"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if r.Title != "Release 1.4" || r.Acceptance != "v1.4 is published" || strings.Join(r.Labels, ",") != "release,v1.4" {
+		t.Errorf("unexpected rendered issue: %+v", r.Issue)
+	}
+	if tmpl.Labels[1] != "v{{ version }}" {
+		t.Errorf("Render modified the template: %v", tmpl.Labels)
+	}
+	var titles []string
+	for _, c := range r.Children {
+		titles = append(titles, c.Title)
+	}
+	want := "Update CHANGELOG for 1.4|Tag v1.4 on main|Publish v1.4|Announce 1.4"
+	if got := strings.Join(titles, "|"); got != want {
+		t.Errorf("children = %s, want %s", got, want)
+	}
+	if *r.Children[0].Estimate != 30 || r.Children[1].Type != "chore" {
+		t.Errorf("unexpected children: %+v", r.Children)
+	}
+
+	all, err := List(beadsDir)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(all) != 2 || all[0].Name != "bug" || all[1].Name != "release-checklist" {
+		t.Errorf("unexpected templates: %+v", all)
+	}
+
+	if _, err := Load(beadsDir, "spike"); err == nil || !strings.Contains(err.Error(), "available: bug, release-checklist") {
+		t.Errorf("expected a not found error listing templates, got %v", err)
+	}
+	if _, err := Load(beadsDir, "../config"); err == nil || !strings.Contains(err.Error(), "invalid template name") {
+		t.Errorf("expected an invalid name error, got %v", err)
+	}
+	if all, err := List(t.TempDir()); err != nil || len(all) != 0 {
+		t.Errorf("expected no templates without a directory, got %v, %v", all, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		yaml string
+		want string
+	}{
+		{"title: [unclosed", "failed to parse"},
+		{"titel: Typo", "titel"},
+		{"type: story", `invalid type "story"`},
+		{"priority: 7", "priority must be between 0 and 4"},
+		{"title: Release {{version}}", `undeclared variable "version"`},
+		{"children:\n  - key: a\n", "children[0]: title is required"},
+		{"children:\n  - {key: a, title: A}\n  - {key: a, title: B}\n", `duplicate key "a"`},
+		{"children:\n  - {title: A, depends_on: [b]}\n", `unknown key "b"`},
+		{"children:\n  - {key: a, title: A, depends_on: [a]}\n", "depends on itself"},
+		{"children:\n  - {key: a, title: A, depends_on: [b]}\n  - {key: b, title: B, depends_on: [a]}\n", "cycle"},
+	} {
+		if _, err := Parse("test", []byte(tc.yaml)); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%q: expected an error about %q, got %v", tc.yaml, tc.want, err)
+		}
+	}
+}
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"version=1.4", "Note=a=b", "empty="})
+	if err != nil {
+		t.Fatalf("ParseVars failed: %v", err)
+	}
+	if vars["version"] != "1.4" || vars["note"] != "a=b" || vars["empty"] != "" || len(vars) != 3 {
+		t.Errorf("unexpected vars: %v", vars)
+	}
+	for _, spec := range []string{"version", "=1.4"} {
+		if _, err := ParseVars([]string{spec}); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
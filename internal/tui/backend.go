@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/shaneholloman/beads/internal/query"
+	"github.com/shaneholloman/beads/internal/rpc"
+	"github.com/shaneholloman/beads/internal/storage"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// maxTreeDepth bounds the dependency trees shown in the navigator
+const maxTreeDepth = 50
+
+// Details is an issue with everything the detail pane shows
+type Details struct {
+	*types.Issue
+	Labels       []string         `json:"labels,omitempty"`
+	Dependencies []*types.Issue   `json:"dependencies,omitempty"`
+	Dependents   []*types.Issue   `json:"dependents,omitempty"`
+	Comments     []*types.Comment `json:"comments,omitempty"`
+}
+
+// Backend is how the TUI reads and edits issues, either through the daemon
+// or directly against the database
+type Backend interface {
+	// List returns the issues matching a filter expression (see query.Parse)
+	List(filter string) ([]*types.Issue, error)
+	Get(id string) (*Details, error)
+	SetStatus(id string, status types.Status) error
+	Close(id, reason string) error
+	SetPriority(id string, priority int) error
+	AddLabel(id, label string) error
+	RemoveLabel(id, label string) error
+	// DependencyTree returns what id depends on, or with reverse what depends on it
+	DependencyTree(id string, reverse bool) ([]*types.TreeNode, error)
+}
+
+// rpcBackend talks to the daemon. Requests from the TUI's commands run
+// concurrently, so they are serialized over the client's single connection.
+type rpcBackend struct {
+	mu     sync.Mutex
+	client *rpc.Client
+}
+
+// NewRPCBackend returns a Backend that goes through the daemon
+func NewRPCBackend(client *rpc.Client) Backend {
+	return &rpcBackend{client: client}
+}
+
+func (b *rpcBackend) execute(call func() (*rpc.Response, error), result interface{}) error {
+	b.mu.Lock()
+	resp, err := call()
+	b.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Data, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+func (b *rpcBackend) List(filter string) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	err := b.execute(func() (*rpc.Response, error) {
+		return b.client.List(&rpc.ListArgs{Filter: filter})
+	}, &issues)
+	return issues, err
+}
+
+func (b *rpcBackend) Get(id string) (*Details, error) {
+	var details Details
+	if err := b.execute(func() (*rpc.Response, error) {
+		return b.client.Show(&rpc.ShowArgs{ID: id})
+	}, &details); err != nil {
+		return nil, err
+	}
+	if details.Issue == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+	if err := b.execute(func() (*rpc.Response, error) {
+		return b.client.ListComments(&rpc.CommentListArgs{ID: id})
+	}, &details.Comments); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+func (b *rpcBackend) SetStatus(id string, status types.Status) error {
+	s := string(status)
+	return b.execute(func() (*rpc.Response, error) {
+		return b.client.Update(&rpc.UpdateArgs{ID: id, Status: &s})
+	}, nil)
+}
+
+func (b *rpcBackend) Close(id, reason string) error {
+	return b.execute(func() (*rpc.Response, error) {
+		return b.client.CloseIssue(&rpc.CloseArgs{ID: id, Reason: reason})
+	}, nil)
+}
+
+func (b *rpcBackend) SetPriority(id string, priority int) error {
+	return b.execute(func() (*rpc.Response, error) {
+		return b.client.Update(&rpc.UpdateArgs{ID: id, Priority: &priority})
+	}, nil)
+}
+
+func (b *rpcBackend) AddLabel(id, label string) error {
+	return b.execute(func() (*rpc.Response, error) {
+		return b.client.AddLabel(&rpc.LabelAddArgs{ID: id, Label: label})
+	}, nil)
+}
+
+func (b *rpcBackend) RemoveLabel(id, label string) error {
+	return b.execute(func() (*rpc.Response, error) {
+		return b.client.RemoveLabel(&rpc.LabelRemoveArgs{ID: id, Label: label})
+	}, nil)
+}
+
+func (b *rpcBackend) DependencyTree(id string, reverse bool) ([]*types.TreeNode, error) {
+	var tree []*types.TreeNode
+	err := b.execute(func() (*rpc.Response, error) {
+		return b.client.DepTree(&rpc.DepTreeArgs{ID: id, MaxDepth: maxTreeDepth, Reverse: reverse})
+	}, &tree)
+	return tree, err
+}
+
+// storeBackend works directly against the database
+type storeBackend struct {
+	store    storage.Storage
+	actor    string
+	onChange func()
+}
+
+// NewStoreBackend returns a Backend that uses store directly, recording
+// changes as actor. onChange, if set, is called after every successful edit
+// so the caller can schedule a JSONL export.
+func NewStoreBackend(store storage.Storage, actor string, onChange func()) Backend {
+	return &storeBackend{store: store, actor: actor, onChange: onChange}
+}
+
+func (b *storeBackend) changed() {
+	if b.onChange != nil {
+		b.onChange()
+	}
+}
+
+func (b *storeBackend) List(filter string) ([]*types.Issue, error) {
+	expr, err := query.Parse(filter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	return b.store.SearchIssues(context.Background(), "", types.IssueFilter{Expr: expr})
+}
+
+func (b *storeBackend) Get(id string) (*Details, error) {
+	ctx := context.Background()
+	issue, err := b.store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", id, err)
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+
+	details := &Details{Issue: issue}
+	if details.Labels, err = b.store.GetLabels(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	if details.Dependencies, err = b.store.GetDependencies(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	if details.Dependents, err = b.store.GetDependents(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get dependents: %w", err)
+	}
+	if details.Comments, err = b.store.GetIssueComments(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	return details, nil
+}
+
+func (b *storeBackend) update(id string, updates map[string]interface{}) error {
+	if err := b.store.UpdateIssue(context.Background(), id, updates, b.actor); err != nil {
+		return err
+	}
+	b.changed()
+	return nil
+}
+
+func (b *storeBackend) SetStatus(id string, status types.Status) error {
+	return b.update(id, map[string]interface{}{"status": string(status)})
+}
+
+func (b *storeBackend) Close(id, reason string) error {
+	if err := b.store.CloseIssue(context.Background(), id, reason, b.actor); err != nil {
+		return err
+	}
+	b.changed()
+	return nil
+}
+
+func (b *storeBackend) SetPriority(id string, priority int) error {
+	return b.update(id, map[string]interface{}{"priority": priority})
+}
+
+func (b *storeBackend) AddLabel(id, label string) error {
+	if err := b.store.AddLabel(context.Background(), id, label, b.actor); err != nil {
+		return err
+	}
+	b.changed()
+	return nil
+}
+
+func (b *storeBackend) RemoveLabel(id, label string) error {
+	if err := b.store.RemoveLabel(context.Background(), id, label, b.actor); err != nil {
+		return err
+	}
+	b.changed()
+	return nil
+}
+
+func (b *storeBackend) DependencyTree(id string, reverse bool) ([]*types.TreeNode, error) {
+	return b.store.GetDependencyTree(context.Background(), id, maxTreeDepth, false, reverse)
+}
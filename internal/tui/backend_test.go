@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shaneholloman/beads/internal/storage/sqlite"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+func TestStoreBackend(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlite.New(t.TempDir() + "/test.db")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+
+	feature := &types.Issue{Title: "Feature", Description: "Build it", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeFeature}
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{feature, blocker} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: feature.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, feature.ID, "alice", "Looks good"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	changes := 0
+	backend := NewStoreBackend(store, "tester", func() { changes++ })
+
+	if err := backend.AddLabel(feature.ID, "ui"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := backend.SetPriority(feature.ID, 0); err != nil {
+		t.Fatalf("SetPriority failed: %v", err)
+	}
+	if err := backend.SetStatus(feature.ID, types.StatusInProgress); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+	if changes != 3 {
+		t.Errorf("expected 3 change notifications, got %d", changes)
+	}
+
+	details, err := backend.Get(feature.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if details.Status != types.StatusInProgress || details.Priority != 0 || details.Description != "Build it" {
+		t.Errorf("unexpected issue: %+v", details.Issue)
+	}
+	if len(details.Labels) != 1 || details.Labels[0] != "ui" {
+		t.Errorf("expected label ui, got %v", details.Labels)
+	}
+	if len(details.Dependencies) != 1 || details.Dependencies[0].ID != blocker.ID {
+		t.Errorf("expected a dependency on %s, got %+v", blocker.ID, details.Dependencies)
+	}
+	if len(details.Comments) != 1 || details.Comments[0].Text != "Looks good" {
+		t.Errorf("expected alice's comment, got %+v", details.Comments)
+	}
+
+	if err := backend.Close(blocker.ID, "Done"); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	issues, err := backend.List(DefaultFilter)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != feature.ID {
+		t.Errorf("expected only %s open, got %d issues", feature.ID, len(issues))
+	}
+	if _, err := backend.List("priority<<"); err == nil {
+		t.Error("expected an invalid filter to be rejected")
+	}
+
+	tree, err := backend.DependencyTree(blocker.ID, true)
+	if err != nil {
+		t.Fatalf("DependencyTree failed: %v", err)
+	}
+	if rows := treeRows(tree, blocker.ID); len(rows) != 2 || rows[1].ID != feature.ID {
+		t.Errorf("expected %s to depend on %s, got %+v", feature.ID, blocker.ID, rows)
+	}
+
+	if _, err := backend.Get("test-missing"); err == nil {
+		t.Error("expected a missing issue to be reported")
+	}
+}
@@ -0,0 +1,429 @@
+// Package tui is a full-screen terminal interface for browsing and triaging
+// issues, built on Bubble Tea.
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// DefaultFilter hides closed issues
+const DefaultFilter = "-status:closed"
+
+// closeReason is recorded when an issue is closed from the TUI, as with 'beads close'
+const closeReason = "Closed"
+
+// promptKind is what a line of text typed at the bottom of the screen is for
+type promptKind int
+
+const (
+	promptNone promptKind = iota
+	promptFilter
+	promptStatus
+	promptAddLabel
+	promptRemoveLabel
+)
+
+// Messages delivered by the commands that call the backend
+type (
+	issuesMsg struct {
+		issues []*types.Issue
+		err    error
+	}
+	detailsMsg struct {
+		id      string
+		details *Details
+		err     error
+	}
+	treeMsg struct {
+		root    string
+		reverse bool
+		tree    []*types.TreeNode
+		err     error
+	}
+	editedMsg struct {
+		id      string
+		message string
+		err     error
+	}
+)
+
+// Model is the TUI's state
+type Model struct {
+	backend Backend
+	width   int
+	height  int
+
+	filter string
+	issues []*types.Issue
+	cursor int // Selected issue in the list
+	offset int // First issue shown in the list
+
+	// The detail pane shows detailID: the selected issue, or one reached from
+	// the dependency tree that the filter hides (pinned)
+	detailID     string
+	pinned       bool
+	details      *Details
+	detailScroll int
+
+	// Dependency tree navigator, shown in place of the detail pane
+	tree        []*types.TreeNode
+	treeRoot    string
+	treeReverse bool
+	treeCursor  int
+	showTree    bool
+
+	showHelp bool
+	prompt   promptKind
+	input    string
+	message  string
+	err      error
+}
+
+// New returns a model listing the issues that match filter
+func New(backend Backend, filter string) Model {
+	return Model{backend: backend, filter: filter}
+}
+
+// Init loads the issue list
+func (m Model) Init() tea.Cmd {
+	return m.loadIssues()
+}
+
+func (m Model) loadIssues() tea.Cmd {
+	backend, filter := m.backend, m.filter
+	return func() tea.Msg {
+		issues, err := backend.List(filter)
+		return issuesMsg{issues: issues, err: err}
+	}
+}
+
+func (m Model) loadDetails(id string) tea.Cmd {
+	backend := m.backend
+	return func() tea.Msg {
+		details, err := backend.Get(id)
+		return detailsMsg{id: id, details: details, err: err}
+	}
+}
+
+func (m Model) loadTree(id string, reverse bool) tea.Cmd {
+	backend := m.backend
+	return func() tea.Msg {
+		tree, err := backend.DependencyTree(id, reverse)
+		return treeMsg{root: id, reverse: reverse, tree: tree, err: err}
+	}
+}
+
+// edit runs a change to the issue in the detail pane
+func (m Model) edit(message string, apply func(b Backend, id string) error) tea.Cmd {
+	if m.detailID == "" {
+		return nil
+	}
+	backend, id := m.backend, m.detailID
+	return func() tea.Msg {
+		return editedMsg{id: id, message: message, err: apply(backend, id)}
+	}
+}
+
+func (m Model) setStatus(status types.Status) tea.Cmd {
+	if status == types.StatusClosed {
+		return m.edit(fmt.Sprintf("Closed %s", m.detailID), func(b Backend, id string) error {
+			return b.Close(id, closeReason)
+		})
+	}
+	return m.edit(fmt.Sprintf("Set %s to %s", m.detailID, status), func(b Backend, id string) error {
+		return b.SetStatus(id, status)
+	})
+}
+
+func (m Model) setPriority(priority int) tea.Cmd {
+	return m.edit(fmt.Sprintf("Set %s to P%d", m.detailID, priority), func(b Backend, id string) error {
+		return b.SetPriority(id, priority)
+	})
+}
+
+// Update handles a message
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollList()
+		return m, nil
+
+	case issuesMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.issues = msg.issues
+		return m, m.syncSelection()
+
+	case detailsMsg:
+		if msg.id != m.detailID {
+			return m, nil // The selection moved on while this was loading
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.details = msg.details
+		return m, nil
+
+	case treeMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.tree = treeRows(msg.tree, msg.root)
+		m.treeRoot, m.treeReverse = msg.root, msg.reverse
+		m.treeCursor = 0
+		m.showTree = true
+		return m, nil
+
+	case editedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.message = msg.message
+		cmds := []tea.Cmd{m.loadIssues()}
+		if msg.id == m.detailID {
+			cmds = append(cmds, m.loadDetails(msg.id))
+		}
+		return m, tea.Batch(cmds...)
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.prompt != promptNone {
+			return m.updatePrompt(msg)
+		}
+		m.err, m.message = nil, ""
+		if m.showTree {
+			return m.updateTree(msg)
+		}
+		return m.updateList(msg)
+	}
+	return m, nil
+}
+
+// syncSelection keeps the detail pane on the same issue after the list
+// reloads, or follows the cursor if that issue left the list
+func (m *Model) syncSelection() tea.Cmd {
+	for i, issue := range m.issues {
+		if issue.ID == m.detailID {
+			m.cursor, m.pinned = i, false
+			m.scrollList()
+			return nil
+		}
+	}
+	if m.pinned {
+		return nil
+	}
+	return m.selectIssue(m.cursor)
+}
+
+// selectIssue moves the cursor to the issue at index i and shows it
+func (m *Model) selectIssue(i int) tea.Cmd {
+	if len(m.issues) == 0 {
+		m.cursor, m.offset = 0, 0
+		m.detailID, m.details, m.pinned = "", nil, false
+		return nil
+	}
+	m.cursor = max(0, min(i, len(m.issues)-1))
+	m.scrollList()
+	m.pinned = false
+	id := m.issues[m.cursor].ID
+	if id == m.detailID && m.details != nil {
+		return nil
+	}
+	return m.showIssue(id)
+}
+
+// showIssue shows id in the detail pane
+func (m *Model) showIssue(id string) tea.Cmd {
+	m.detailID, m.details, m.detailScroll = id, nil, 0
+	return m.loadDetails(id)
+}
+
+func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key := msg.String(); key {
+	case "q", "esc":
+		if m.showHelp {
+			m.showHelp = false
+			return m, nil
+		}
+		if key == "q" {
+			return m, tea.Quit
+		}
+	case "?":
+		m.showHelp = !m.showHelp
+	case "j", "down":
+		return m, m.selectIssue(m.cursor + 1)
+	case "k", "up":
+		return m, m.selectIssue(m.cursor - 1)
+	case "pgdown", "ctrl+f":
+		return m, m.selectIssue(m.cursor + m.listHeight())
+	case "pgup", "ctrl+b":
+		return m, m.selectIssue(m.cursor - m.listHeight())
+	case "g", "home":
+		return m, m.selectIssue(0)
+	case "G", "end":
+		return m, m.selectIssue(len(m.issues) - 1)
+	case "J", "ctrl+d":
+		m.detailScroll++
+	case "K", "ctrl+u":
+		m.detailScroll = max(0, m.detailScroll-1)
+	case "r":
+		cmds := []tea.Cmd{m.loadIssues()}
+		if m.detailID != "" {
+			cmds = append(cmds, m.loadDetails(m.detailID))
+		}
+		return m, tea.Batch(cmds...)
+	case "/":
+		m.prompt, m.input = promptFilter, m.filter
+	case "s":
+		if m.detailID != "" {
+			m.prompt, m.input = promptStatus, ""
+		}
+	case "l":
+		if m.detailID != "" {
+			m.prompt, m.input = promptAddLabel, ""
+		}
+	case "L":
+		if m.detailID != "" {
+			m.prompt, m.input = promptRemoveLabel, ""
+		}
+	case "o":
+		return m, m.setStatus(types.StatusOpen)
+	case "i":
+		return m, m.setStatus(types.StatusInProgress)
+	case "b":
+		return m, m.setStatus(types.StatusBlocked)
+	case "c":
+		return m, m.setStatus(types.StatusClosed)
+	case "0", "1", "2", "3", "4":
+		return m, m.setPriority(int(key[0] - '0'))
+	case "t", "T":
+		if m.detailID != "" {
+			return m, m.loadTree(m.detailID, key == "T")
+		}
+	}
+	return m, nil
+}
+
+func (m Model) updateTree(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc", "t", "T":
+		m.showTree = false
+	case "j", "down":
+		m.treeCursor = min(m.treeCursor+1, len(m.tree)-1)
+	case "k", "up":
+		m.treeCursor = max(m.treeCursor-1, 0)
+	case "g", "home":
+		m.treeCursor = 0
+	case "G", "end":
+		m.treeCursor = len(m.tree) - 1
+	case "enter":
+		if len(m.tree) == 0 {
+			return m, nil
+		}
+		id := m.tree[m.treeCursor].ID
+		m.showTree = false
+		for i, issue := range m.issues {
+			if issue.ID == id {
+				return m, m.selectIssue(i)
+			}
+		}
+		m.pinned = true
+		return m, m.showIssue(id)
+	}
+	return m, nil
+}
+
+func (m Model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.prompt, m.input = promptNone, ""
+		return m, nil
+	case tea.KeyEnter:
+		kind, value := m.prompt, strings.TrimSpace(m.input)
+		m.prompt, m.input = promptNone, ""
+		return m.submitPrompt(kind, value)
+	case tea.KeyBackspace:
+		if runes := []rune(m.input); len(runes) > 0 {
+			m.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeyCtrlU:
+		m.input = ""
+	case tea.KeySpace:
+		m.input += " "
+	case tea.KeyRunes:
+		m.input += string(msg.Runes)
+	}
+	return m, nil
+}
+
+func (m Model) submitPrompt(kind promptKind, value string) (tea.Model, tea.Cmd) {
+	switch kind {
+	case promptFilter:
+		m.filter = value
+		return m, m.loadIssues()
+	case promptStatus:
+		if value == "" {
+			return m, nil
+		}
+		status := types.Status(value)
+		if _, ok := types.CurrentWorkflow().Category(status); !ok {
+			m.err = fmt.Errorf("invalid status: %s", value)
+			return m, nil
+		}
+		return m, m.setStatus(status)
+	case promptAddLabel:
+		if value == "" {
+			return m, nil
+		}
+		return m, m.edit(fmt.Sprintf("Added label %s to %s", value, m.detailID), func(b Backend, id string) error {
+			return b.AddLabel(id, value)
+		})
+	case promptRemoveLabel:
+		if value == "" {
+			return m, nil
+		}
+		return m, m.edit(fmt.Sprintf("Removed label %s from %s", value, m.detailID), func(b Backend, id string) error {
+			return b.RemoveLabel(id, value)
+		})
+	}
+	return m, nil
+}
+
+// treeRows orders a dependency tree depth-first from its root, so each issue
+// is listed under the one it was reached from
+func treeRows(tree []*types.TreeNode, root string) []*types.TreeNode {
+	children := make(map[string][]*types.TreeNode)
+	var rows []*types.TreeNode
+	for _, node := range tree {
+		if node.ID == root {
+			rows = append(rows, node)
+			continue
+		}
+		children[node.ParentID] = append(children[node.ParentID], node)
+	}
+
+	var walk func(id string)
+	walk = func(id string) {
+		for _, child := range children[id] {
+			rows = append(rows, child)
+			walk(child.ID)
+		}
+	}
+	walk(root)
+	return rows
+}
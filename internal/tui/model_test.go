@@ -0,0 +1,323 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+// fakeBackend keeps issues in memory. The default filter hides closed
+// issues; any other filter lists everything.
+type fakeBackend struct {
+	issues   map[string]*types.Issue
+	labels   map[string][]string
+	comments map[string][]*types.Comment
+	deps     map[string][]string // Issue ID -> IDs it depends on
+}
+
+func newFakeBackend() *fakeBackend {
+	b := &fakeBackend{
+		issues:   map[string]*types.Issue{},
+		labels:   map[string][]string{},
+		comments: map[string][]*types.Comment{},
+		deps:     map[string][]string{},
+	}
+	for i, title := range []string{"Login page", "Session store", "Password reset", "Old bug"} {
+		id := fmt.Sprintf("bd-%d", i+1)
+		b.issues[id] = &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	}
+	b.issues["bd-1"].Description = "Users sign in here."
+	b.issues["bd-1"].AcceptanceCriteria = "Errors are shown inline."
+	b.issues["bd-4"].Status = types.StatusClosed
+	b.comments["bd-1"] = []*types.Comment{{IssueID: "bd-1", Author: "alice", Text: "Started on the form"}}
+	b.deps["bd-1"] = []string{"bd-2", "bd-4"}
+	b.deps["bd-2"] = []string{"bd-3"}
+	return b
+}
+
+func (b *fakeBackend) List(filter string) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	for _, issue := range b.issues {
+		if filter == DefaultFilter && issue.Status == types.StatusClosed {
+			continue
+		}
+		copied := *issue
+		issues = append(issues, &copied)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+	return issues, nil
+}
+
+func (b *fakeBackend) Get(id string) (*Details, error) {
+	issue, ok := b.issues[id]
+	if !ok {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+	copied := *issue
+	details := &Details{Issue: &copied, Labels: b.labels[id], Comments: b.comments[id]}
+	for _, dep := range b.deps[id] {
+		details.Dependencies = append(details.Dependencies, b.issues[dep])
+	}
+	return details, nil
+}
+
+func (b *fakeBackend) SetStatus(id string, status types.Status) error {
+	b.issues[id].Status = status
+	return nil
+}
+
+func (b *fakeBackend) Close(id, reason string) error {
+	b.issues[id].Status = types.StatusClosed
+	return nil
+}
+
+func (b *fakeBackend) SetPriority(id string, priority int) error {
+	b.issues[id].Priority = priority
+	return nil
+}
+
+func (b *fakeBackend) AddLabel(id, label string) error {
+	b.labels[id] = append(b.labels[id], label)
+	return nil
+}
+
+func (b *fakeBackend) RemoveLabel(id, label string) error {
+	var kept []string
+	for _, l := range b.labels[id] {
+		if l != label {
+			kept = append(kept, l)
+		}
+	}
+	b.labels[id] = kept
+	return nil
+}
+
+// DependencyTree returns nodes by depth, like the storage layer
+func (b *fakeBackend) DependencyTree(id string, reverse bool) ([]*types.TreeNode, error) {
+	if reverse {
+		return nil, fmt.Errorf("reverse trees not supported")
+	}
+	tree := []*types.TreeNode{{Issue: *b.issues[id], ParentID: id}}
+	for i := 0; i < len(tree); i++ {
+		for _, dep := range b.deps[tree[i].ID] {
+			tree = append(tree, &types.TreeNode{Issue: *b.issues[dep], Depth: tree[i].Depth + 1, ParentID: tree[i].ID})
+		}
+	}
+	return tree, nil
+}
+
+// send delivers msg and then the results of the commands it triggers, as
+// the Bubble Tea runtime would
+func send(t *testing.T, m Model, msg tea.Msg) Model {
+	t.Helper()
+	queue := []tea.Msg{msg}
+	for len(queue) > 0 {
+		msg, queue = queue[0], queue[1:]
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, cmd := range batch {
+				if cmd != nil {
+					queue = append(queue, cmd())
+				}
+			}
+			continue
+		}
+		if _, ok := msg.(tea.QuitMsg); ok {
+			continue
+		}
+		next, cmd := m.Update(msg)
+		m = next.(Model)
+		if cmd != nil {
+			queue = append(queue, cmd())
+		}
+	}
+	return m
+}
+
+// press sends keys: named keys like "enter" and "esc", or runes typed one at a time
+func press(t *testing.T, m Model, keys ...string) Model {
+	t.Helper()
+	for _, key := range keys {
+		switch key {
+		case "enter":
+			m = send(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+		case "esc":
+			m = send(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+		case "ctrl+u":
+			m = send(t, m, tea.KeyMsg{Type: tea.KeyCtrlU})
+		default:
+			for _, r := range key {
+				m = send(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			}
+		}
+	}
+	return m
+}
+
+func start(t *testing.T, backend Backend) Model {
+	t.Helper()
+	m := New(backend, DefaultFilter)
+	m = send(t, m, tea.WindowSizeMsg{Width: 120, Height: 30})
+	return send(t, m, m.Init()())
+}
+
+func TestNavigation(t *testing.T) {
+	m := start(t, newFakeBackend())
+
+	if len(m.issues) != 3 {
+		t.Fatalf("expected the 3 open issues, got %d", len(m.issues))
+	}
+	if m.detailID != "bd-1" || m.details == nil || m.details.Title != "Login page" {
+		t.Fatalf("expected bd-1's details, got %s %+v", m.detailID, m.details)
+	}
+
+	m = press(t, m, "j")
+	if m.cursor != 1 || m.details == nil || m.details.ID != "bd-2" {
+		t.Errorf("expected j to select bd-2, got cursor %d and %+v", m.cursor, m.details)
+	}
+	m = press(t, m, "G")
+	if m.detailID != "bd-3" {
+		t.Errorf("expected G to select the last issue, got %s", m.detailID)
+	}
+	m = press(t, m, "j", "g", "k")
+	if m.cursor != 0 || m.detailID != "bd-1" {
+		t.Errorf("expected the cursor to stay within the list, got %d (%s)", m.cursor, m.detailID)
+	}
+}
+
+func TestEdits(t *testing.T) {
+	backend := newFakeBackend()
+	m := start(t, backend)
+
+	m = press(t, m, "i", "1")
+	if issue := backend.issues["bd-1"]; issue.Status != types.StatusInProgress || issue.Priority != 1 {
+		t.Errorf("expected bd-1 in_progress at P1, got %s P%d", issue.Status, issue.Priority)
+	}
+	if m.details.Status != types.StatusInProgress || m.issues[0].Priority != 1 {
+		t.Error("expected the list and details to reload after edits")
+	}
+	if m.message != "Set bd-1 to P1" {
+		t.Errorf("unexpected message %q", m.message)
+	}
+
+	m = press(t, m, "l", "frontend", "enter", "l", "ui", "enter", "L", "frontend", "enter")
+	if got := strings.Join(m.details.Labels, ","); got != "ui" {
+		t.Errorf("expected labels [ui], got %v", m.details.Labels)
+	}
+
+	m = press(t, m, "s", "nope", "enter")
+	if m.err == nil || !strings.Contains(m.err.Error(), "invalid status") {
+		t.Errorf("expected an invalid status error, got %v", m.err)
+	}
+	m = press(t, m, "s", "blocked", "enter")
+	if backend.issues["bd-1"].Status != types.StatusBlocked {
+		t.Errorf("expected bd-1 blocked, got %s", backend.issues["bd-1"].Status)
+	}
+
+	// Closing hides the issue; the next one takes its place
+	m = press(t, m, "c")
+	if backend.issues["bd-1"].Status != types.StatusClosed {
+		t.Fatalf("expected bd-1 closed, got %s", backend.issues["bd-1"].Status)
+	}
+	if len(m.issues) != 2 || m.detailID != "bd-2" || m.details == nil || m.details.ID != "bd-2" {
+		t.Errorf("expected bd-2 selected after closing bd-1, got %s with %d issues", m.detailID, len(m.issues))
+	}
+}
+
+func TestFilterPrompt(t *testing.T) {
+	m := start(t, newFakeBackend())
+
+	m = press(t, m, "/", "ctrl+u", "enter")
+	if m.filter != "" || len(m.issues) != 4 {
+		t.Errorf("expected all 4 issues with no filter, got %d with %q", len(m.issues), m.filter)
+	}
+
+	// Escape leaves the filter alone
+	m = press(t, m, "/", "status:open", "esc")
+	if m.filter != "" || m.prompt != promptNone {
+		t.Errorf("expected esc to cancel, got filter %q", m.filter)
+	}
+
+	// Keys typed at a prompt are not commands
+	m = press(t, m, "l", "q", "enter")
+	if m.details == nil || len(m.details.Labels) != 1 || m.details.Labels[0] != "q" {
+		t.Errorf("expected label q, got %+v", m.details)
+	}
+}
+
+func TestTreeNavigator(t *testing.T) {
+	m := start(t, newFakeBackend())
+
+	m = press(t, m, "t")
+	if !m.showTree {
+		t.Fatalf("expected the tree to be shown (err: %v)", m.err)
+	}
+	var ids []string
+	for _, node := range m.tree {
+		ids = append(ids, fmt.Sprintf("%s@%d", node.ID, node.Depth))
+	}
+	// Depth-first: bd-3 is listed under bd-2, not after bd-4
+	if got, want := strings.Join(ids, " "), "bd-1@0 bd-2@1 bd-3@2 bd-4@1"; got != want {
+		t.Errorf("expected tree %s, got %s", want, got)
+	}
+
+	m = press(t, m, "j", "j", "enter")
+	if m.showTree || m.detailID != "bd-3" || m.cursor != 2 || m.pinned {
+		t.Errorf("expected to jump to bd-3 in the list, got %s at %d (pinned %v)", m.detailID, m.cursor, m.pinned)
+	}
+
+	// bd-4 is closed, so the filter hides it; it is shown without moving the cursor
+	m = press(t, m, "g", "t", "G", "enter")
+	if m.detailID != "bd-4" || !m.pinned || m.cursor != 0 || m.details == nil || m.details.ID != "bd-4" {
+		t.Errorf("expected bd-4 pinned, got %s (pinned %v, cursor %d)", m.detailID, m.pinned, m.cursor)
+	}
+	m = press(t, m, "j")
+	if m.detailID != "bd-2" || m.pinned {
+		t.Errorf("expected moving the cursor to unpin, got %s", m.detailID)
+	}
+
+	m = press(t, m, "T")
+	if m.showTree || m.err == nil {
+		t.Errorf("expected the backend error to be reported, got %v", m.err)
+	}
+	m = press(t, m, "t", "esc")
+	if m.showTree {
+		t.Error("expected esc to close the tree")
+	}
+}
+
+func TestView(t *testing.T) {
+	m := start(t, newFakeBackend())
+
+	view := m.View()
+	for _, want := range []string{
+		"3 issues", "-status:closed",
+		"bd-1: Login page", "Description", "Users sign in here.",
+		"Acceptance Criteria", "Errors are shown inline.",
+		"Depends on (2)", "bd-2: Session store [open]",
+		"Comments (1)", "Started on the form",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected view to contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Design") {
+		t.Error("expected empty sections to be left out")
+	}
+	if lines := strings.Split(view, "\n"); len(lines) != 30 {
+		t.Errorf("expected the view to fill 30 rows, got %d", len(lines))
+	}
+
+	m = press(t, m, "?")
+	if view := m.View(); !strings.Contains(view, "dependent tree") {
+		t.Errorf("expected help in the view:\n%s", view)
+	}
+
+	m = press(t, m, "?", "s")
+	if view := m.View(); !strings.Contains(view, "Status (open, in_progress, blocked, closed): ") {
+		t.Errorf("expected the status prompt in the view:\n%s", view)
+	}
+}
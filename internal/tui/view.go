@@ -0,0 +1,291 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shaneholloman/beads/internal/types"
+)
+
+var (
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8"))
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	headingStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("6"))
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	messageStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	priorityStyle = map[int]lipgloss.Style{
+		0: lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true),
+		1: lipgloss.NewStyle().Foreground(lipgloss.Color("3")),
+	}
+)
+
+const helpText = `Keys
+
+  j/k, ↑/↓      move through the list
+  pgup/pgdn     move a page
+  g/G           first/last issue
+  J/K           scroll the detail pane
+  /             edit the filter (e.g. status:open priority<=1 label:ui)
+  r             reload
+
+  o/i/b/c       set status to open, in_progress, blocked or closed
+  s             set any status, including custom workflow statuses
+  0-4           set priority
+  l/L           add/remove a label
+
+  t             dependency tree: what the issue depends on
+  T             dependent tree: what depends on the issue
+                (j/k to move, enter to show an issue, esc to go back)
+
+  ?             toggle this help
+  q, ctrl+c     quit`
+
+// listWidth is the width of the list pane, borders included
+func (m Model) listWidth() int {
+	return max(30, m.width*2/5)
+}
+
+// listHeight is the number of rows inside the panes
+func (m Model) listHeight() int {
+	return max(1, m.height-4) // Header, footer and the panes' borders
+}
+
+// scrollList keeps the cursor on screen
+func (m *Model) scrollList() {
+	h := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+h {
+		m.offset = m.cursor - h + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.issues)-h))
+}
+
+// View renders the screen
+func (m Model) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	h := m.listHeight()
+	listInner := m.listWidth() - 2
+	detailInner := max(10, m.width-m.listWidth()-2)
+
+	var right string
+	switch {
+	case m.showHelp:
+		right = m.renderLines(strings.Split(helpText, "\n"), 0, detailInner, h)
+	case m.showTree:
+		right = m.renderTree(detailInner, h)
+	default:
+		right = m.renderDetails(detailInner, h)
+	}
+
+	panes := lipgloss.JoinHorizontal(lipgloss.Top,
+		paneStyle.Width(listInner).Height(h).Render(m.renderList(listInner, h)),
+		paneStyle.Width(detailInner).Height(h).Render(right),
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, m.renderHeader(), panes, m.renderFooter())
+}
+
+func (m Model) renderHeader() string {
+	filter := m.filter
+	if filter == "" {
+		filter = "(all issues)"
+	}
+	count := fmt.Sprintf("%d issues", len(m.issues))
+	if len(m.issues) == 1 {
+		count = "1 issue"
+	}
+	line := fmt.Sprintf("%s  filter: %s  %s", titleStyle.Render("beads"), filter, dimStyle.Render(count))
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(line)
+}
+
+func (m Model) renderFooter() string {
+	var line string
+	switch {
+	case m.prompt != promptNone:
+		line = promptLabel(m.prompt) + m.input + "█"
+	case m.err != nil:
+		line = errorStyle.Render("Error: " + m.err.Error())
+	case m.message != "":
+		line = messageStyle.Render(m.message)
+	case m.showTree:
+		line = dimStyle.Render("j/k move  enter show issue  esc back  q quit")
+	default:
+		line = dimStyle.Render("/ filter  o/i/b/c status  0-4 priority  l/L label  t/T deps  ? help  q quit")
+	}
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(line)
+}
+
+func promptLabel(kind promptKind) string {
+	switch kind {
+	case promptFilter:
+		return "Filter: "
+	case promptStatus:
+		var names []string
+		for _, s := range workflowStatuses() {
+			names = append(names, string(s))
+		}
+		return fmt.Sprintf("Status (%s): ", strings.Join(names, ", "))
+	case promptAddLabel:
+		return "Add label: "
+	case promptRemoveLabel:
+		return "Remove label: "
+	}
+	return ""
+}
+
+// workflowStatuses lists the built-in statuses followed by custom ones
+func workflowStatuses() []types.Status {
+	statuses := []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusClosed}
+	return append(statuses, types.CurrentWorkflow().CustomStatuses()...)
+}
+
+func (m Model) renderList(width, height int) string {
+	if len(m.issues) == 0 {
+		return dimStyle.Render("No issues match the filter")
+	}
+
+	idWidth := 0
+	for _, issue := range m.issues {
+		idWidth = max(idWidth, len(issue.ID))
+	}
+
+	row := lipgloss.NewStyle().MaxWidth(width)
+	var lines []string
+	for i := m.offset; i < len(m.issues) && i < m.offset+height; i++ {
+		issue := m.issues[i]
+		if i == m.cursor {
+			text := fmt.Sprintf("%-*s P%d %-11s %s", idWidth, issue.ID, issue.Priority, issue.Status, issue.Title)
+			lines = append(lines, row.Render(selectedStyle.Render(padRight(text, width))))
+			continue
+		}
+		priority := fmt.Sprintf("P%d", issue.Priority)
+		if style, ok := priorityStyle[issue.Priority]; ok {
+			priority = style.Render(priority)
+		}
+		status := fmt.Sprintf("%-11s", issue.Status)
+		if issue.Status.Category() == types.CategoryDone {
+			status = dimStyle.Render(status)
+		}
+		lines = append(lines, row.Render(fmt.Sprintf("%-*s %s %s %s", idWidth, issue.ID, priority, status, issue.Title)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// detailLines renders the detail pane's content wrapped to width
+func (m Model) detailLines(width int) []string {
+	d := m.details
+	if d == nil {
+		if m.detailID == "" {
+			return []string{dimStyle.Render("No issue selected")}
+		}
+		return []string{dimStyle.Render("Loading " + m.detailID + "...")}
+	}
+
+	wrap := lipgloss.NewStyle().Width(width)
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(d.ID+": "+d.Title) + "\n")
+	if m.pinned {
+		b.WriteString(dimStyle.Render("(not in the filtered list)") + "\n")
+	}
+	b.WriteString(fmt.Sprintf("Status: %s  Priority: P%d  Type: %s\n", d.Status, d.Priority, d.IssueType))
+	if d.Assignee != "" {
+		b.WriteString(fmt.Sprintf("Assignee: %s\n", d.Assignee))
+	}
+	if d.EstimatedMinutes != nil {
+		b.WriteString(fmt.Sprintf("Estimate: %d minutes\n", *d.EstimatedMinutes))
+	}
+	if len(d.Labels) > 0 {
+		b.WriteString(fmt.Sprintf("Labels: %s\n", strings.Join(d.Labels, ", ")))
+	}
+	b.WriteString(dimStyle.Render(fmt.Sprintf("Created %s  Updated %s",
+		d.CreatedAt.Local().Format("2006-01-02 15:04"), d.UpdatedAt.Local().Format("2006-01-02 15:04"))) + "\n")
+
+	section := func(heading, text string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		b.WriteString("\n" + headingStyle.Render(heading) + "\n" + text + "\n")
+	}
+	section("Description", d.Description)
+	section("Design", d.Design)
+	section("Acceptance Criteria", d.AcceptanceCriteria)
+	section("Notes", d.Notes)
+	section(fmt.Sprintf("Depends on (%d)", len(d.Dependencies)), issueLines(d.Dependencies))
+	section(fmt.Sprintf("Blocks (%d)", len(d.Dependents)), issueLines(d.Dependents))
+
+	var comments []string
+	for _, c := range d.Comments {
+		comments = append(comments, dimStyle.Render(fmt.Sprintf("%s, %s", c.Author, c.CreatedAt.Local().Format("2006-01-02 15:04")))+"\n"+c.Text)
+	}
+	section(fmt.Sprintf("Comments (%d)", len(d.Comments)), strings.Join(comments, "\n\n"))
+
+	return strings.Split(wrap.Render(strings.TrimRight(b.String(), "\n")), "\n")
+}
+
+func issueLines(issues []*types.Issue) string {
+	var lines []string
+	for _, issue := range issues {
+		lines = append(lines, fmt.Sprintf("  %s: %s [%s]", issue.ID, issue.Title, issue.Status))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) renderDetails(width, height int) string {
+	lines := m.detailLines(width)
+	scroll := max(0, min(m.detailScroll, len(lines)-height))
+	return m.renderLines(lines, scroll, width, height)
+}
+
+func (m Model) renderLines(lines []string, scroll, width, height int) string {
+	end := min(len(lines), scroll+height)
+	row := lipgloss.NewStyle().MaxWidth(width)
+	var out []string
+	for _, line := range lines[scroll:end] {
+		out = append(out, row.Render(line))
+	}
+	return strings.Join(out, "\n")
+}
+
+func (m Model) renderTree(width, height int) string {
+	heading := "Dependency tree for " + m.treeRoot
+	if m.treeReverse {
+		heading = "Dependent tree for " + m.treeRoot
+	}
+	lines := []string{headingStyle.Render(heading), ""}
+	if len(m.tree) <= 1 {
+		if m.treeReverse {
+			lines = append(lines, dimStyle.Render(m.treeRoot+" has no dependents"))
+		} else {
+			lines = append(lines, dimStyle.Render(m.treeRoot+" has no dependencies"))
+		}
+	}
+
+	rows := height - len(lines)
+	start := max(0, min(m.treeCursor-rows/2, len(m.tree)-rows))
+	for i := start; i < len(m.tree) && i < start+rows; i++ {
+		node := m.tree[i]
+		text := fmt.Sprintf("%s→ %s: %s [P%d] (%s)", strings.Repeat("  ", node.Depth), node.ID, node.Title, node.Priority, node.Status)
+		if node.Truncated {
+			text += " … [truncated]"
+		}
+		if i == m.treeCursor {
+			text = selectedStyle.Render(padRight(text, width))
+		}
+		lines = append(lines, text)
+	}
+	return m.renderLines(lines, 0, width, height)
+}
+
+// padRight pads s with spaces to width columns
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+// repoAliasRe matches a repository alias: lowercase letters, digits, '-' and '_',
+// starting with a letter or digit
+var repoAliasRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// IsValidRepoAlias reports whether alias can name a repository in a qualified ID
+func IsValidRepoAlias(alias string) bool {
+	return repoAliasRe.MatchString(alias)
+}
+
+// ParseQualifiedID splits a cross-repository issue reference such as
+// "api:bd-a3f8" into the repository alias and the issue ID in that repository.
+// The alias is lowercased. ok is false for local IDs and malformed references.
+func ParseQualifiedID(id string) (repo, localID string, ok bool) {
+	repo, localID, found := strings.Cut(id, ":")
+	if !found || localID == "" || strings.Contains(localID, ":") {
+		return "", "", false
+	}
+	repo = strings.ToLower(repo)
+	if !IsValidRepoAlias(repo) {
+		return "", "", false
+	}
+	return repo, localID, true
+}
+
+// IsQualifiedID reports whether id refers to an issue in another repository
+func IsQualifiedID(id string) bool {
+	_, _, ok := ParseQualifiedID(id)
+	return ok
+}
+
+// QualifiedID builds the reference to issue id in the repository with the given alias
+func QualifiedID(repo, id string) string {
+	return repo + ":" + id
+}
@@ -0,0 +1,37 @@
+package types
+
+import "testing"
+
+func TestParseQualifiedID(t *testing.T) {
+	tests := []struct {
+		id      string
+		repo    string
+		localID string
+		ok      bool
+	}{
+		{"api:bd-a3f8", "api", "bd-a3f8", true},
+		{"API:bd-a3f8.1", "api", "bd-a3f8.1", true},
+		{"web_ui-2:web-42", "web_ui-2", "web-42", true},
+		{"bd-a3f8", "", "", false},
+		{"api:", "", "", false},
+		{":bd-a3f8", "", "", false},
+		{"a b:bd-a3f8", "", "", false},
+		{"api:bd:a3f8", "", "", false},
+		{"-api:bd-a3f8", "", "", false},
+	}
+
+	for _, tt := range tests {
+		repo, localID, ok := ParseQualifiedID(tt.id)
+		if repo != tt.repo || localID != tt.localID || ok != tt.ok {
+			t.Errorf("ParseQualifiedID(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.id, repo, localID, ok, tt.repo, tt.localID, tt.ok)
+		}
+		if IsQualifiedID(tt.id) != tt.ok {
+			t.Errorf("IsQualifiedID(%q) = %v, want %v", tt.id, !tt.ok, tt.ok)
+		}
+	}
+
+	if got := QualifiedID("api", "bd-a3f8"); got != "api:bd-a3f8" {
+		t.Errorf("QualifiedID() = %q", got)
+	}
+}
@@ -4,7 +4,10 @@ package types
 import (
 	"crypto/sha256"
 	"fmt"
+	"sort"
 	"time"
+
+	"github.com/shaneholloman/beads/internal/query"
 )
 
 // Issue represents a trackable work item
@@ -24,7 +27,10 @@ type Issue struct {
 	CreatedAt          time.Time     `json:"created_at"`
 	UpdatedAt          time.Time     `json:"updated_at"`
 	ClosedAt           *time.Time    `json:"closed_at,omitempty"`
+	DueAt              *time.Time    `json:"due_at,omitempty"`       // Deadline; see SortPolicyDeadline
 	ExternalRef        *string       `json:"external_ref,omitempty"` // e.g., "gh-9", "jira-ABC"
+	Recurrence         string        `json:"recurrence,omitempty"`   // Cron rule for materializing copies (see internal/recur)
+	RecurredAt         *time.Time    `json:"recurred_at,omitempty"`  // Latest scheduled time already materialized (or skipped)
 	CompactionLevel    int           `json:"compaction_level,omitempty"`
 	CompactedAt        *time.Time    `json:"compacted_at,omitempty"`
 	CompactedAtCommit  *string       `json:"compacted_at_commit,omitempty"` // Git commit hash when compacted
@@ -63,6 +69,15 @@ func (i *Issue) ComputeContentHash() string {
 	if i.ExternalRef != nil {
 		h.Write([]byte(*i.ExternalRef))
 	}
+	// Appended only when set so hashes of non-recurring issues don't change
+	if i.Recurrence != "" {
+		h.Write([]byte{0})
+		h.Write([]byte(i.Recurrence))
+	}
+	if i.DueAt != nil {
+		h.Write([]byte{0})
+		h.Write([]byte(i.DueAt.UTC().Format(time.RFC3339Nano)))
+	}
 
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
@@ -109,12 +124,10 @@ const (
 )
 
 // IsValid checks if the status value is valid
+// Custom statuses are valid once registered in the current workflow (see workflow.go)
 func (s Status) IsValid() bool {
-	switch s {
-	case StatusOpen, StatusInProgress, StatusBlocked, StatusClosed:
-		return true
-	}
-	return false
+	_, ok := CurrentWorkflow().Category(s)
+	return ok
 }
 
 // IssueType categorizes the kind of work
@@ -219,6 +232,13 @@ type BlockedIssue struct {
 	BlockedBy      []string `json:"blocked_by"`
 }
 
+// SearchResult extends Issue with full-text search relevance
+type SearchResult struct {
+	Issue
+	Score   float64 `json:"score"`   // Higher is more relevant; only comparable within one search
+	Snippet string  `json:"snippet"` // Best matching excerpt with matched terms highlighted
+}
+
 // TreeNode represents a node in a dependency tree
 type TreeNode struct {
 	Issue
@@ -237,6 +257,10 @@ type Statistics struct {
 	ReadyIssues             int     `json:"ready_issues"`
 	EpicsEligibleForClosure int     `json:"epics_eligible_for_closure"`
 	AverageLeadTime         float64 `json:"average_lead_time_hours"`
+	OverdueIssues           int     `json:"overdue_issues"`
+
+	// Filled in by the CLI from the sla config, not by storage
+	SLABreaches []*SLABreach `json:"sla_breaches,omitempty"`
 }
 
 // IssueFilter is used to filter issue queries
@@ -248,7 +272,8 @@ type IssueFilter struct {
 	Labels      []string // AND semantics: issue must have ALL these labels
 	LabelsAny   []string // OR semantics: issue must have AT LEAST ONE of these labels
 	TitleSearch string
-	IDs         []string // Filter by specific issue IDs
+	IDs         []string   // Filter by specific issue IDs
+	Expr        query.Expr // Structured filter (see query.Parse), ANDed with the fields above
 	Limit       int
 }
 
@@ -269,12 +294,82 @@ const (
 	// SortPolicyOldest always sorts by creation date (oldest first)
 	// Use for backlog clearing, preventing issue starvation
 	SortPolicyOldest SortPolicy = "oldest"
+
+	// SortPolicyDeadline sorts by priority raised for approaching deadlines
+	// (see Issue.DeadlineWeight), then by due date, then creation date
+	// Use when due dates matter more than strict priority
+	SortPolicyDeadline SortPolicy = "deadline"
 )
 
+// Deadline windows used by SortPolicyDeadline. The SQL backends spell these
+// out as '+1 day' and '+7 days'.
+const (
+	DeadlineImminent = 24 * time.Hour
+	DeadlineNear     = 7 * 24 * time.Hour
+)
+
+// DeadlineWeight is how many priority levels an issue's deadline raises it
+// under SortPolicyDeadline: 2 when overdue or due within DeadlineImminent,
+// 1 when due within DeadlineNear, otherwise 0
+func (i *Issue) DeadlineWeight(now time.Time) int {
+	switch {
+	case i.DueAt == nil:
+		return 0
+	case !i.DueAt.After(now.Add(DeadlineImminent)):
+		return 2
+	case !i.DueAt.After(now.Add(DeadlineNear)):
+		return 1
+	}
+	return 0
+}
+
+// IsOverdue reports whether the issue is past its due date and not done
+func (i *Issue) IsOverdue(now time.Time) bool {
+	return i.DueAt != nil && i.DueAt.Before(now) && i.Status.Category() != CategoryDone
+}
+
+// SLABreach is an issue open longer than its priority's SLA window allows
+type SLABreach struct {
+	IssueID     string  `json:"issue_id"`
+	Title       string  `json:"title"`
+	Priority    int     `json:"priority"`
+	OpenHours   float64 `json:"open_hours"`
+	WindowHours float64 `json:"sla_hours"`
+}
+
+// SLAPolicy maps priorities to how long an issue of that priority may stay
+// open. Priorities without an entry have no SLA.
+type SLAPolicy map[int]time.Duration
+
+// Breaches returns the issues that are not done and were created longer ago
+// than their priority's window, longest overdue first
+func (p SLAPolicy) Breaches(issues []*Issue, now time.Time) []*SLABreach {
+	var breaches []*SLABreach
+	for _, issue := range issues {
+		window, ok := p[issue.Priority]
+		if !ok || issue.Status.Category() == CategoryDone {
+			continue
+		}
+		if openFor := now.Sub(issue.CreatedAt); openFor > window {
+			breaches = append(breaches, &SLABreach{
+				IssueID:     issue.ID,
+				Title:       issue.Title,
+				Priority:    issue.Priority,
+				OpenHours:   openFor.Hours(),
+				WindowHours: window.Hours(),
+			})
+		}
+	}
+	sort.Slice(breaches, func(i, j int) bool {
+		return breaches[i].OpenHours-breaches[i].WindowHours > breaches[j].OpenHours-breaches[j].WindowHours
+	})
+	return breaches
+}
+
 // IsValid checks if the sort policy value is valid
 func (s SortPolicy) IsValid() bool {
 	switch s {
-	case SortPolicyHybrid, SortPolicyPriority, SortPolicyOldest, "":
+	case SortPolicyHybrid, SortPolicyPriority, SortPolicyOldest, SortPolicyDeadline, "":
 		return true
 	}
 	return false
@@ -289,6 +384,32 @@ type WorkFilter struct {
 	SortPolicy SortPolicy
 }
 
+// DefaultLeaseTTL is how long a claim or heartbeat lasts when no TTL is given
+const DefaultLeaseTTL = 30 * time.Minute
+
+// Lease is an agent's time-limited claim on an issue. A claimed issue is
+// in_progress and assigned to the holder until the lease is released (by closing
+// or reassigning the issue) or expires, which returns the issue to open.
+// Each heartbeat from the holder extends the lease.
+type Lease struct {
+	IssueID       string    `json:"issue_id"`
+	Holder        string    `json:"holder"`
+	ClaimedAt     time.Time `json:"claimed_at"`
+	LastHeartbeat time.Time `json:"last_heartbeat"` // ClaimedAt until the first heartbeat
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the lease has lapsed by now
+func (l *Lease) Expired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// Claim is an issue claimed from ready work, with its lease
+type Claim struct {
+	Issue *Issue `json:"issue"`
+	Lease *Lease `json:"lease"`
+}
+
 // EpicStatus represents an epic with its completion status
 type EpicStatus struct {
 	Epic             *Issue `json:"epic"`
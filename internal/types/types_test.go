@@ -354,3 +354,60 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestDeadlineWeight(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		due := now.Add(d)
+		return &due
+	}
+
+	tests := []struct {
+		name        string
+		issue       Issue
+		wantWeight  int
+		wantOverdue bool
+	}{
+		{"no due date", Issue{Status: StatusOpen}, 0, false},
+		{"due in a month", Issue{Status: StatusOpen, DueAt: at(30 * 24 * time.Hour)}, 0, false},
+		{"due in 3 days", Issue{Status: StatusOpen, DueAt: at(3 * 24 * time.Hour)}, 1, false},
+		{"due in 12 hours", Issue{Status: StatusOpen, DueAt: at(12 * time.Hour)}, 2, false},
+		{"overdue", Issue{Status: StatusInProgress, DueAt: at(-time.Hour)}, 2, true},
+		{"closed past due", Issue{Status: StatusClosed, DueAt: at(-time.Hour)}, 2, false}, // Only ready work is weighed
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.issue.DeadlineWeight(now); got != tt.wantWeight {
+				t.Errorf("DeadlineWeight() = %d, want %d", got, tt.wantWeight)
+			}
+			if got := tt.issue.IsOverdue(now); got != tt.wantOverdue {
+				t.Errorf("IsOverdue() = %v, want %v", got, tt.wantOverdue)
+			}
+		})
+	}
+}
+
+func TestSLAPolicyBreaches(t *testing.T) {
+	now := time.Now()
+	policy := SLAPolicy{0: 24 * time.Hour, 1: 7 * 24 * time.Hour}
+	issues := []*Issue{
+		{ID: "bd-1", Priority: 0, Status: StatusOpen, CreatedAt: now.Add(-30 * time.Hour)},
+		{ID: "bd-2", Priority: 0, Status: StatusOpen, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "bd-3", Priority: 1, Status: StatusInProgress, CreatedAt: now.Add(-10 * 24 * time.Hour)},
+		{ID: "bd-4", Priority: 0, Status: StatusClosed, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "bd-5", Priority: 2, Status: StatusOpen, CreatedAt: now.Add(-365 * 24 * time.Hour)},
+	}
+
+	breaches := policy.Breaches(issues, now)
+	if len(breaches) != 2 {
+		t.Fatalf("expected 2 breaches, got %d: %+v", len(breaches), breaches)
+	}
+	// bd-3 is 3 days over its window, bd-1 only 6 hours
+	if breaches[0].IssueID != "bd-3" || breaches[1].IssueID != "bd-1" {
+		t.Errorf("expected breaches most overdue first (bd-3, bd-1), got %s, %s", breaches[0].IssueID, breaches[1].IssueID)
+	}
+	if breaches[1].WindowHours != 24 || breaches[1].OpenHours < 29.9 || breaches[1].OpenHours > 30.1 {
+		t.Errorf("unexpected hours for bd-1: %+v", breaches[1])
+	}
+}
@@ -0,0 +1,176 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+)
+
+// StatusCategory groups statuses by how they affect ready work and blocking
+type StatusCategory string
+
+// Status category constants
+const (
+	// CategoryActive statuses are workable: they appear in ready work and block dependents
+	CategoryActive StatusCategory = "active"
+
+	// CategoryBlocked statuses are parked: they never appear in ready work but still block dependents
+	CategoryBlocked StatusCategory = "blocked"
+
+	// CategoryDone statuses are terminal: they no longer block dependents
+	CategoryDone StatusCategory = "done"
+)
+
+// IsValid checks if the status category value is valid
+func (c StatusCategory) IsValid() bool {
+	switch c {
+	case CategoryActive, CategoryBlocked, CategoryDone:
+		return true
+	}
+	return false
+}
+
+// builtinStatuses maps the built-in statuses to their fixed categories
+var builtinStatuses = map[Status]StatusCategory{
+	StatusOpen:       CategoryActive,
+	StatusInProgress: CategoryActive,
+	StatusBlocked:    CategoryBlocked,
+	StatusClosed:     CategoryDone,
+}
+
+// IsBuiltin reports whether the status is one of the four built-in statuses
+func (s Status) IsBuiltin() bool {
+	_, ok := builtinStatuses[s]
+	return ok
+}
+
+// Workflow describes the statuses an issue may take and the allowed transitions between them.
+// The built-in statuses are always present; custom statuses extend them.
+type Workflow struct {
+	// Statuses maps every known status (built-in and custom) to its category
+	Statuses map[Status]StatusCategory
+
+	// Transitions maps a status to the statuses it may move to.
+	// Statuses without an entry may move to any status.
+	Transitions map[Status][]Status
+}
+
+// DefaultWorkflow returns the built-in workflow with no custom statuses and no transition rules
+func DefaultWorkflow() *Workflow {
+	statuses := make(map[Status]StatusCategory, len(builtinStatuses))
+	for s, c := range builtinStatuses {
+		statuses[s] = c
+	}
+	return &Workflow{
+		Statuses:    statuses,
+		Transitions: map[Status][]Status{},
+	}
+}
+
+// AddStatus registers a custom status with the given category
+func (w *Workflow) AddStatus(status Status, category StatusCategory) error {
+	if status == "" {
+		return fmt.Errorf("status name cannot be empty")
+	}
+	if !category.IsValid() {
+		return fmt.Errorf("invalid category %q for status %s (must be active, blocked, or done)", category, status)
+	}
+	if builtin, ok := builtinStatuses[status]; ok {
+		if builtin != category {
+			return fmt.Errorf("cannot change category of built-in status %s (always %s)", status, builtin)
+		}
+		return nil
+	}
+	w.Statuses[status] = category
+	return nil
+}
+
+// SetTransitions restricts the statuses that from may move to
+func (w *Workflow) SetTransitions(from Status, to []Status) error {
+	if _, ok := w.Statuses[from]; !ok {
+		return fmt.Errorf("transition rule references unknown status: %s", from)
+	}
+	for _, target := range to {
+		if _, ok := w.Statuses[target]; !ok {
+			return fmt.Errorf("transition rule %s -> %s references unknown status: %s", from, target, target)
+		}
+	}
+	w.Transitions[from] = to
+	return nil
+}
+
+// Category returns the category of a status and whether the status is known
+func (w *Workflow) Category(status Status) (StatusCategory, bool) {
+	c, ok := w.Statuses[status]
+	return c, ok
+}
+
+// CanTransition returns an error if moving from one status to another is not allowed.
+// Staying in the same status is always allowed.
+func (w *Workflow) CanTransition(from, to Status) error {
+	if _, ok := w.Statuses[to]; !ok {
+		return fmt.Errorf("invalid status: %s", to)
+	}
+	if from == to {
+		return nil
+	}
+	allowed, restricted := w.Transitions[from]
+	if !restricted {
+		return nil
+	}
+	for _, s := range allowed {
+		if s == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("status transition %s -> %s is not allowed by workflow", from, to)
+}
+
+// StatusesIn returns all statuses in the given category, sorted by name
+func (w *Workflow) StatusesIn(category StatusCategory) []Status {
+	var result []Status
+	for s, c := range w.Statuses {
+		if c == category {
+			result = append(result, s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// CustomStatuses returns the non-built-in statuses, sorted by name
+func (w *Workflow) CustomStatuses() []Status {
+	var result []Status
+	for s := range w.Statuses {
+		if !s.IsBuiltin() {
+			result = append(result, s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// currentWorkflow holds the process-wide workflow (loaded from .beads/config.yaml at startup)
+var currentWorkflow atomic.Pointer[Workflow]
+
+// SetWorkflow installs the process-wide workflow. Passing nil restores the default.
+func SetWorkflow(w *Workflow) {
+	currentWorkflow.Store(w)
+}
+
+// CurrentWorkflow returns the process-wide workflow
+func CurrentWorkflow() *Workflow {
+	if w := currentWorkflow.Load(); w != nil {
+		return w
+	}
+	return DefaultWorkflow()
+}
+
+// Category returns the category of the status under the current workflow.
+// Unknown statuses are treated as active so they keep blocking dependents.
+func (s Status) Category() StatusCategory {
+	if c, ok := CurrentWorkflow().Category(s); ok {
+		return c
+	}
+	return CategoryActive
+}
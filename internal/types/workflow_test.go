@@ -0,0 +1,125 @@
+package types
+
+import "testing"
+
+func TestDefaultWorkflowCategories(t *testing.T) {
+	wf := DefaultWorkflow()
+
+	tests := []struct {
+		status   Status
+		expected StatusCategory
+	}{
+		{StatusOpen, CategoryActive},
+		{StatusInProgress, CategoryActive},
+		{StatusBlocked, CategoryBlocked},
+		{StatusClosed, CategoryDone},
+	}
+
+	for _, tt := range tests {
+		got, ok := wf.Category(tt.status)
+		if !ok {
+			t.Errorf("Category(%s) not found", tt.status)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("Category(%s) = %s, want %s", tt.status, got, tt.expected)
+		}
+	}
+
+	if len(wf.CustomStatuses()) != 0 {
+		t.Errorf("default workflow should have no custom statuses, got %v", wf.CustomStatuses())
+	}
+}
+
+func TestWorkflowAddStatus(t *testing.T) {
+	wf := DefaultWorkflow()
+
+	if err := wf.AddStatus("in_review", CategoryActive); err != nil {
+		t.Fatalf("AddStatus(in_review) failed: %v", err)
+	}
+	if err := wf.AddStatus("deferred", CategoryBlocked); err != nil {
+		t.Fatalf("AddStatus(deferred) failed: %v", err)
+	}
+
+	if err := wf.AddStatus("bogus", "sideways"); err == nil {
+		t.Error("expected error for invalid category")
+	}
+	if err := wf.AddStatus("", CategoryActive); err == nil {
+		t.Error("expected error for empty status name")
+	}
+	if err := wf.AddStatus(StatusClosed, CategoryActive); err == nil {
+		t.Error("expected error when re-categorizing built-in status")
+	}
+	if err := wf.AddStatus(StatusClosed, CategoryDone); err != nil {
+		t.Errorf("re-declaring built-in status with its own category should be allowed: %v", err)
+	}
+
+	custom := wf.CustomStatuses()
+	if len(custom) != 2 || custom[0] != "deferred" || custom[1] != "in_review" {
+		t.Errorf("CustomStatuses() = %v, want [deferred in_review]", custom)
+	}
+
+	active := wf.StatusesIn(CategoryActive)
+	if len(active) != 3 {
+		t.Errorf("StatusesIn(active) = %v, want 3 statuses", active)
+	}
+}
+
+func TestWorkflowTransitions(t *testing.T) {
+	wf := DefaultWorkflow()
+	if err := wf.AddStatus("in_review", CategoryActive); err != nil {
+		t.Fatal(err)
+	}
+	if err := wf.SetTransitions(StatusClosed, []Status{StatusOpen}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := wf.CanTransition(StatusClosed, "in_review"); err == nil {
+		t.Error("closed -> in_review should be rejected")
+	}
+	if err := wf.CanTransition(StatusClosed, StatusOpen); err != nil {
+		t.Errorf("closed -> open should be allowed: %v", err)
+	}
+	if err := wf.CanTransition(StatusClosed, StatusClosed); err != nil {
+		t.Errorf("staying in the same status should be allowed: %v", err)
+	}
+	if err := wf.CanTransition(StatusOpen, "in_review"); err != nil {
+		t.Errorf("unrestricted status should allow any transition: %v", err)
+	}
+	if err := wf.CanTransition(StatusOpen, "unknown"); err == nil {
+		t.Error("transition to unknown status should be rejected")
+	}
+
+	if err := wf.SetTransitions("unknown", []Status{StatusOpen}); err == nil {
+		t.Error("SetTransitions should reject unknown source status")
+	}
+	if err := wf.SetTransitions(StatusOpen, []Status{"unknown"}); err == nil {
+		t.Error("SetTransitions should reject unknown target status")
+	}
+}
+
+func TestSetWorkflowAffectsStatusValidation(t *testing.T) {
+	defer SetWorkflow(nil)
+
+	if Status("in_review").IsValid() {
+		t.Fatal("in_review should not be valid before it is registered")
+	}
+
+	wf := DefaultWorkflow()
+	if err := wf.AddStatus("in_review", CategoryActive); err != nil {
+		t.Fatal(err)
+	}
+	SetWorkflow(wf)
+
+	if !Status("in_review").IsValid() {
+		t.Error("in_review should be valid after SetWorkflow")
+	}
+	if Status("in_review").Category() != CategoryActive {
+		t.Errorf("in_review category = %s, want active", Status("in_review").Category())
+	}
+
+	SetWorkflow(nil)
+	if Status("in_review").IsValid() {
+		t.Error("in_review should be invalid after restoring default workflow")
+	}
+}
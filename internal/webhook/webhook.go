@@ -0,0 +1,182 @@
+// Package webhook delivers daemon mutation events to HTTP endpoints.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/rpc"
+)
+
+// Hook is an endpoint that receives mutation events
+type Hook struct {
+	URL    string   `mapstructure:"url" json:"url"`
+	Events []string `mapstructure:"events" json:"events,omitempty"` // Event types to send (default: all)
+	Secret string   `mapstructure:"secret" json:"-"`                // Signs payloads with HMAC-SHA256
+}
+
+// Validate checks the hook's URL and event types
+func (h Hook) Validate() error {
+	u, err := url.Parse(h.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q (must be http or https)", h.URL)
+	}
+	for _, event := range h.Events {
+		if !slices.Contains(rpc.EventTypes, event) {
+			return fmt.Errorf("webhook %s: unknown event type %q (valid: %v)", h.URL, event, rpc.EventTypes)
+		}
+	}
+	return nil
+}
+
+// Wants reports whether the hook subscribes to an event type
+func (h Hook) Wants(eventType string) bool {
+	return len(h.Events) == 0 || slices.Contains(h.Events, eventType)
+}
+
+// Signature returns the X-Beads-Signature-256 header value for a payload
+func Signature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher posts events to hooks. Each hook has its own queue, so a slow
+// endpoint delays only its own deliveries; events beyond the queue size are
+// dropped and logged.
+type Dispatcher struct {
+	Hooks      []Hook
+	HTTPClient *http.Client
+	Attempts   int           // Delivery attempts per event
+	Backoff    time.Duration // Delay before the first retry, doubled for each retry
+	QueueSize  int
+	Logf       func(format string, args ...interface{})
+}
+
+// NewDispatcher returns a dispatcher with default retry settings
+func NewDispatcher(hooks []Hook, logf func(format string, args ...interface{})) *Dispatcher {
+	return &Dispatcher{
+		Hooks:      hooks,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		Attempts:   3,
+		Backoff:    time.Second,
+		QueueSize:  100,
+		Logf:       logf,
+	}
+}
+
+// Run delivers events until the channel closes or ctx is canceled
+func (d *Dispatcher) Run(ctx context.Context, events <-chan rpc.MutationEvent) {
+	queues := make([]chan rpc.MutationEvent, len(d.Hooks))
+	done := make(chan struct{}, len(d.Hooks))
+	for i, hook := range d.Hooks {
+		queues[i] = make(chan rpc.MutationEvent, d.QueueSize)
+		go func(hook Hook, queue <-chan rpc.MutationEvent) {
+			defer func() { done <- struct{}{} }()
+			for event := range queue {
+				if err := d.Deliver(ctx, hook, event); err != nil && ctx.Err() == nil {
+					d.logf("webhook %s: %v", hook.URL, err)
+				}
+			}
+		}(hook, queues[i])
+	}
+	defer func() {
+		for _, queue := range queues {
+			close(queue)
+		}
+		for range d.Hooks {
+			<-done
+		}
+	}()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			for i, hook := range d.Hooks {
+				if !hook.Wants(event.Type) {
+					continue
+				}
+				select {
+				case queues[i] <- event:
+				default:
+					d.logf("webhook %s: queue full, dropped %s event for %s", hook.URL, event.Type, event.IssueID)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Deliver posts one event to a hook, retrying on network errors and 5xx or
+// 429 responses
+func (d *Dispatcher) Deliver(ctx context.Context, hook Hook, event rpc.MutationEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	attempts := max(d.Attempts, 1)
+	backoff := d.Backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := d.post(ctx, hook, event.Type, payload)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == attempts {
+			return err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// post makes one delivery attempt, reporting whether a failure is worth retrying
+func (d *Dispatcher) post(ctx context.Context, hook Hook, eventType string, payload []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "beads-webhook")
+	req.Header.Set("X-Beads-Event", eventType)
+	if hook.Secret != "" {
+		req.Header.Set("X-Beads-Signature-256", Signature(hook.Secret, payload))
+	}
+
+	client := d.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("delivery failed: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("endpoint returned %s", resp.Status)
+}
+
+func (d *Dispatcher) logf(format string, args ...interface{}) {
+	if d.Logf != nil {
+		d.Logf(format, args...)
+	}
+}
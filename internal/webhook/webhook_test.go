@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shaneholloman/beads/internal/rpc"
+)
+
+// recorder is an endpoint that fails the first failures requests with status
+type recorder struct {
+	mu       sync.Mutex
+	status   int
+	failures int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(r.status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *recorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func testDispatcher(hooks ...Hook) *Dispatcher {
+	d := NewDispatcher(hooks, nil)
+	d.Backoff = time.Millisecond
+	return d
+}
+
+func TestDeliverSignsPayload(t *testing.T) {
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	hook := Hook{URL: srv.URL, Secret: "s3cret"}
+	event := rpc.MutationEvent{Type: rpc.EventClose, IssueID: "bd-1", Actor: "alice", Timestamp: time.Now()}
+	if err := testDispatcher(hook).Deliver(context.Background(), hook, event); err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+
+	req, body := rec.requests[0], rec.bodies[0]
+	if req.Header.Get("X-Beads-Event") != "close" || req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected headers: %v", req.Header)
+	}
+	if got := req.Header.Get("X-Beads-Signature-256"); got != Signature("s3cret", body) {
+		t.Errorf("signature = %q, want %q", got, Signature("s3cret", body))
+	}
+	var got rpc.MutationEvent
+	if err := json.Unmarshal(body, &got); err != nil || got.IssueID != "bd-1" || got.Actor != "alice" {
+		t.Errorf("payload = %s (%v)", body, err)
+	}
+}
+
+func TestDeliverRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		failures int
+		wantErr  bool
+		wantReqs int
+	}{
+		{"recovers after server errors", http.StatusBadGateway, 2, false, 3},
+		{"gives up after attempts", http.StatusServiceUnavailable, 5, true, 3},
+		{"rate limited", http.StatusTooManyRequests, 1, false, 2},
+		{"client errors are not retried", http.StatusBadRequest, 1, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{status: tt.status, failures: tt.failures}
+			srv := httptest.NewServer(rec)
+			defer srv.Close()
+
+			hook := Hook{URL: srv.URL}
+			err := testDispatcher(hook).Deliver(context.Background(), hook, rpc.MutationEvent{Type: rpc.EventCreate, IssueID: "bd-1"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Deliver error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if rec.count() != tt.wantReqs {
+				t.Errorf("requests = %d, want %d", rec.count(), tt.wantReqs)
+			}
+		})
+	}
+}
+
+func TestRunFiltersEvents(t *testing.T) {
+	closes, everything := &recorder{}, &recorder{}
+	closesSrv, everythingSrv := httptest.NewServer(closes), httptest.NewServer(everything)
+	defer closesSrv.Close()
+	defer everythingSrv.Close()
+
+	d := testDispatcher(
+		Hook{URL: closesSrv.URL, Events: []string{rpc.EventClose}},
+		Hook{URL: everythingSrv.URL},
+	)
+	events := make(chan rpc.MutationEvent, 3)
+	events <- rpc.MutationEvent{Type: rpc.EventCreate, IssueID: "bd-1"}
+	events <- rpc.MutationEvent{Type: rpc.EventClose, IssueID: "bd-1"}
+	events <- rpc.MutationEvent{Type: rpc.EventDepAdd, IssueID: "bd-2", DependsOnID: "bd-1"}
+	close(events)
+
+	// Run drains the queues before returning once the channel closes
+	d.Run(context.Background(), events)
+
+	if closes.count() != 1 || closes.requests[0].Header.Get("X-Beads-Event") != "close" {
+		t.Errorf("close hook got %d requests", closes.count())
+	}
+	if everything.count() != 3 {
+		t.Errorf("catch-all hook got %d requests, want 3", everything.count())
+	}
+}
+
+func TestHookValidate(t *testing.T) {
+	valid := []Hook{{URL: "https://example.com/hook"}, {URL: "http://localhost:9000", Events: []string{"dep_remove"}}}
+	for _, h := range valid {
+		if err := h.Validate(); err != nil {
+			t.Errorf("Validate(%+v) = %v", h, err)
+		}
+	}
+	invalid := []Hook{{URL: ""}, {URL: "example.com"}, {URL: "https://example.com", Events: []string{"moved"}}}
+	for _, h := range invalid {
+		if err := h.Validate(); err == nil {
+			t.Errorf("Validate(%+v) succeeded, want error", h)
+		}
+	}
+}